@@ -10,7 +10,13 @@ import (
 	_ "be-sagara-hackathon/docs"
 	"be-sagara-hackathon/src/middlewares"
 	routerAuth "be-sagara-hackathon/src/modules/auth/router"
+	routerCertificate "be-sagara-hackathon/src/modules/certificate/router"
 	routerEvent "be-sagara-hackathon/src/modules/event/router"
+	routerAudit "be-sagara-hackathon/src/modules/general/audit/router"
+	routerExport "be-sagara-hackathon/src/modules/general/export/router"
+	routerImport "be-sagara-hackathon/src/modules/general/importer/router"
+	routerOutbox "be-sagara-hackathon/src/modules/general/outbox/router"
+	routerTrash "be-sagara-hackathon/src/modules/general/trash/router"
 	routerUpload "be-sagara-hackathon/src/modules/general/upload/router"
 	routerHome "be-sagara-hackathon/src/modules/home/router"
 	routerOccupation "be-sagara-hackathon/src/modules/master-data/occupation/router"
@@ -20,6 +26,7 @@ import (
 	routerTechnology "be-sagara-hackathon/src/modules/master-data/technology/router"
 	routerPayment "be-sagara-hackathon/src/modules/payment/router"
 	routerProject "be-sagara-hackathon/src/modules/project/router"
+	routerPromotion "be-sagara-hackathon/src/modules/promotion/router"
 	routerSchedule "be-sagara-hackathon/src/modules/schedule/router"
 	routerTeam "be-sagara-hackathon/src/modules/team/router"
 	routerUser "be-sagara-hackathon/src/modules/user/router"
@@ -55,6 +62,26 @@ func SetupRoutes(app *gin.Engine) {
 		routerRegion.RegionRouter(region)
 	}
 
+	paymentWebhook := app.Group("/api/v1/payments/webhook")
+	{
+		routerPayment.PaymentWebhookRouter(paymentWebhook)
+	}
+
+	invoiceVerification := app.Group("/api/v1/invoices/verify")
+	{
+		routerPayment.InvoiceVerificationRouter(invoiceVerification)
+	}
+
+	certificateVerification := app.Group("/api/v1/certificates/verify")
+	{
+		routerCertificate.CertificateVerificationRouter(certificateVerification)
+	}
+
+	pricing := app.Group("/api/v1/promotions/price")
+	{
+		routerPromotion.PricingRouter(pricing)
+	}
+
 	v1 := app.Group("/api/v1")
 	{
 		v1.Use(middlewares.JwtAuthMiddleware())
@@ -64,6 +91,7 @@ func SetupRoutes(app *gin.Engine) {
 		routerSkill.SkillRouter(v1.Group("/skills"))
 		routerTechnology.TechnologyRouter(v1.Group("/technologies"))
 		routerUser.UserRouter(v1.Group("/users"))
+		routerUser.RoleRouter(v1.Group("/roles"))
 		routerEvent.EventRouter(v1.Group("/events"))
 		routerPayment.PaymentMethodRouter(v1.Group("/payment-methods"))
 		routerPayment.InvoiceRouter(v1.Group("/invoices"))
@@ -72,5 +100,12 @@ func SetupRoutes(app *gin.Engine) {
 		routerProject.ProjectRouter(v1.Group("/projects"))
 		routerSchedule.ScheduleRouter(v1.Group("/schedules"))
 		routerUpload.UploadRouter(v1.Group("/upload"))
+		routerOutbox.EmailOutboxRouter(v1.Group("/emails"))
+		routerAudit.AuditLogRouter(v1.Group("/audit-logs"))
+		routerTrash.TrashRouter(v1.Group("/trash"))
+		routerImport.ImportRouter(v1.Group("/imports"))
+		routerExport.ExportRouter(v1.Group("/exports"))
+		routerCertificate.CertificateRouter(v1.Group("/certificates"))
+		routerPromotion.PromotionRouter(v1.Group("/promotions"))
 	}
 }
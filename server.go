@@ -4,7 +4,13 @@ import (
 	"be-sagara-hackathon/src/cores/database"
 	"be-sagara-hackathon/src/middlewares"
 	"be-sagara-hackathon/src/modules/auth"
+	"be-sagara-hackathon/src/modules/certificate"
 	"be-sagara-hackathon/src/modules/event"
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/export"
+	"be-sagara-hackathon/src/modules/general/importer"
+	"be-sagara-hackathon/src/modules/general/outbox"
+	"be-sagara-hackathon/src/modules/general/trash"
 	"be-sagara-hackathon/src/modules/general/upload"
 	"be-sagara-hackathon/src/modules/home"
 	"be-sagara-hackathon/src/modules/master-data/occupation"
@@ -14,9 +20,16 @@ import (
 	"be-sagara-hackathon/src/modules/master-data/technology"
 	"be-sagara-hackathon/src/modules/payment"
 	"be-sagara-hackathon/src/modules/project"
+	"be-sagara-hackathon/src/modules/promotion"
 	"be-sagara-hackathon/src/modules/schedule"
 	"be-sagara-hackathon/src/modules/team"
+	"context"
 	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	//"be-sagara-hackathon/src/modules/team"
 	"be-sagara-hackathon/src/modules/user"
@@ -32,6 +45,9 @@ func InitApp() {
 	//seeder.RunSeeder(db)
 
 	// initialize modules/apps
+	audit.New(db).InitModule()
+	outbox.New(db).InitModule()
+	promotion.New(db).InitModule()
 	auth.New(db).InitModule()
 	home.New(db).InitModule()
 	event.New(db).InitModule()
@@ -46,6 +62,10 @@ func InitApp() {
 	schedule.New(db).InitModule()
 	project.New(db).InitModule()
 	upload.New().InitModule()
+	trash.New(db).InitModule()
+	importer.New(db).InitModule()
+	export.New(db).InitModule()
+	certificate.New(db).InitModule()
 
 	// Get Gin Mode from ENV
 	mode := os.Getenv("GIN_MODE")
@@ -75,8 +95,24 @@ func InitApp() {
 	SetupRoutes(app)
 
 	// Run App at 3000
-	err := app.Run(fmt.Sprintf(":%s", os.Getenv("API_PORT")))
-	if err != nil {
-		return
+	server := &http.Server{Addr: fmt.Sprintf(":%s", os.Getenv("API_PORT")), Handler: app}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server: %v", err)
+		}
+	}()
+
+	// Wait for a stop signal, then finish the requests and the background work that is in progress
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("server: shutdown: %v", err)
 	}
+	// the scheduler queues reminders, so it stops before the email worker
+	payment.StopScheduler()
+	outbox.StopWorker()
 }
@@ -2,20 +2,31 @@ package database
 
 import (
 	aum "be-sagara-hackathon/src/modules/auth/model"
+	cem "be-sagara-hackathon/src/modules/certificate/model"
 	evm "be-sagara-hackathon/src/modules/event/model"
+	adm "be-sagara-hackathon/src/modules/general/audit/model"
+	obm "be-sagara-hackathon/src/modules/general/outbox/model"
 	regm "be-sagara-hackathon/src/modules/master-data/region/model"
 	skm "be-sagara-hackathon/src/modules/master-data/skill/model"
 	tecm "be-sagara-hackathon/src/modules/master-data/technology/model"
 	pym "be-sagara-hackathon/src/modules/payment/model"
 	prom "be-sagara-hackathon/src/modules/project/model"
+	prm "be-sagara-hackathon/src/modules/promotion/model"
 	scm "be-sagara-hackathon/src/modules/schedule/model"
 	tm "be-sagara-hackathon/src/modules/team/model"
 	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/seeder"
 	"gorm.io/gorm"
+	"log"
 )
 
 func MigrateDb(db *gorm.DB) {
 	var err error
+	defer func() {
+		if err != nil {
+			log.Fatalf("Running migration failed with error: %s", err)
+		}
+	}()
 
 	err = db.AutoMigrate(&regm.RegProvince{})
 	if err != nil {
@@ -63,11 +74,55 @@ func MigrateDb(db *gorm.DB) {
 		return
 	}
 
+	// codes used to be saved without an expiry, they get the lifetime of an email verification code
+	if db.Migrator().HasTable(&aum.VerificationCode{}) {
+		db.Exec("UPDATE verification_codes SET expire_at = DATE_ADD(created_at, INTERVAL 1 DAY) WHERE expire_at IS NULL")
+	}
 	err = db.AutoMigrate(&aum.VerificationCode{})
 	if err != nil {
 		return
 	}
 
+	err = db.AutoMigrate(&um.Permission{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&um.RolePermission{})
+	if err != nil {
+		return
+	}
+
+	// permissions added since the roles were seeded are granted to the roles that get them by default
+	err = seeder.SeederPermission(db)
+	if err != nil {
+		return
+	}
+	err = seeder.SeederRolePermission(db)
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&um.UserSession{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&aum.RefreshToken{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&aum.TwoFactor{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&aum.RecoveryCode{})
+	if err != nil {
+		return
+	}
+
 	err = db.AutoMigrate(&evm.Event{})
 	if err != nil {
 		return
@@ -80,6 +135,12 @@ func MigrateDb(db *gorm.DB) {
 	if err != nil {
 		return
 	}
+	// staff who existed before event_staffs are copied in once, a removed staff member stays removed
+	backfillStaffs := !db.Migrator().HasTable(&evm.EventStaff{})
+	err = db.AutoMigrate(&evm.EventStaff{})
+	if err != nil {
+		return
+	}
 	err = db.AutoMigrate(&evm.EventCompany{})
 	if err != nil {
 		return
@@ -105,6 +166,16 @@ func MigrateDb(db *gorm.DB) {
 		return
 	}
 
+	err = db.AutoMigrate(&prm.FeeTier{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&prm.Voucher{})
+	if err != nil {
+		return
+	}
+
 	err = db.AutoMigrate(&pym.Invoice{})
 	if err != nil {
 		return
@@ -118,6 +189,16 @@ func MigrateDb(db *gorm.DB) {
 		return
 	}
 
+	err = db.AutoMigrate(&pym.InvoiceEntry{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&pym.InvoiceReminder{})
+	if err != nil {
+		return
+	}
+
 	err = db.AutoMigrate(&tm.Team{})
 	if err != nil {
 		return
@@ -155,10 +236,31 @@ func MigrateDb(db *gorm.DB) {
 	if err != nil {
 		return
 	}
+	// a judge could score the same criteria of a project more than once before the unique index,
+	// only their latest score is kept. Rows that aren't deleted win over deleted ones.
+	if db.Migrator().HasTable(&prom.ProjectAssessment{}) &&
+		!db.Migrator().HasIndex(&prom.ProjectAssessment{}, "idx_project_assessments_judge_project_criteria") {
+		err = db.Exec("DELETE pa FROM project_assessments pa JOIN project_assessments newer ON newer.judge_id = pa.judge_id AND newer.project_id = pa.project_id AND newer.criteria_id = pa.criteria_id AND newer.id <> pa.id AND ((newer.deleted_at IS NULL AND pa.deleted_at IS NOT NULL) OR ((newer.deleted_at IS NULL) = (pa.deleted_at IS NULL) AND (newer.updated_at > pa.updated_at OR (newer.updated_at = pa.updated_at AND newer.id > pa.id))))").Error
+		if err != nil {
+			return
+		}
+	}
 	err = db.AutoMigrate(&prom.ProjectAssessment{})
 	if err != nil {
 		return
 	}
+	err = db.AutoMigrate(&prom.ProjectResult{})
+	if err != nil {
+		return
+	}
+	err = db.AutoMigrate(&prom.ProjectJudge{})
+	if err != nil {
+		return
+	}
+	err = db.AutoMigrate(&prom.JudgeConflict{})
+	if err != nil {
+		return
+	}
 
 	err = db.AutoMigrate(&scm.Schedule{})
 	if err != nil {
@@ -169,6 +271,25 @@ func MigrateDb(db *gorm.DB) {
 		return
 	}
 
+	err = db.AutoMigrate(&cem.CertificateTemplate{})
+	if err != nil {
+		return
+	}
+	err = db.AutoMigrate(&cem.Certificate{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&obm.EmailOutbox{})
+	if err != nil {
+		return
+	}
+
+	err = db.AutoMigrate(&adm.AuditLog{})
+	if err != nil {
+		return
+	}
+
 	db.Exec("ALTER TABLE specialities ADD CONSTRAINT idx_unique_speciality_name UNIQUE KEY(`name`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
 	db.Exec("ALTER TABLE skills ADD CONSTRAINT idx_unique_skill_name UNIQUE KEY(`name`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
 	db.Exec("ALTER TABLE occupations ADD CONSTRAINT idx_unique_occupation_name UNIQUE KEY(`name`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
@@ -182,4 +303,19 @@ func MigrateDb(db *gorm.DB) {
 	db.Exec("ALTER TABLE event_companies ADD CONSTRAINT idx_unique_company_phone UNIQUE KEY(`phone_number`, `event_id`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
 	db.Exec("ALTER TABLE teams ADD CONSTRAINT idx_unique_team_code UNIQUE KEY(`code`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
 	db.Exec("ALTER TABLE teams ADD CONSTRAINT idx_unique_team_name UNIQUE KEY(`name`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
+
+	db.Exec("ALTER TABLE vouchers ADD CONSTRAINT idx_unique_voucher_code UNIQUE KEY(`code`, `event_id`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
+	db.Exec("UPDATE invoices SET base_amount = amount WHERE base_amount = 0 AND voucher_id IS NULL;")
+
+	// opening ledger entries for invoices that were paid before the ledger existed
+	db.Exec("INSERT INTO invoice_entries (invoice_id, type, amount, reason, created_at, updated_at, created_by, updated_by) SELECT id, 'payment', paid_amount, 'opening balance', NOW(), NOW(), 'system', 'system' FROM invoices WHERE paid_amount > 0 AND NOT EXISTS (SELECT 1 FROM invoice_entries ie WHERE ie.invoice_id = invoices.id);")
+
+	db.Exec("ALTER TABLE event_staffs ADD CONSTRAINT idx_unique_event_staff UNIQUE KEY(`event_id`, `user_id`, `role`, (coalesce(`deleted_at`, '1900-01-01 12:50:18.262000000')));")
+
+	// event staff memberships for the event creators and the mentors and judges assigned before they existed
+	if backfillStaffs {
+		db.Exec("INSERT INTO event_staffs (event_id, user_id, role, created_at, updated_at, created_by, updated_by) SELECT id, user_id, 'organizer', NOW(), NOW(), 'system', 'system' FROM events WHERE deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM event_staffs es WHERE es.event_id = events.id AND es.user_id = events.user_id AND es.role = 'organizer');")
+		db.Exec("INSERT INTO event_staffs (event_id, user_id, role, created_at, updated_at, created_by, updated_by) SELECT event_id, mentor_id, 'mentor', NOW(), NOW(), 'system', 'system' FROM event_mentors em WHERE em.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM event_staffs es WHERE es.event_id = em.event_id AND es.user_id = em.mentor_id AND es.role = 'mentor');")
+		db.Exec("INSERT INTO event_staffs (event_id, user_id, role, created_at, updated_at, created_by, updated_by) SELECT event_id, judge_id, 'judge', NOW(), NOW(), 'system', 'system' FROM event_judges ej WHERE ej.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM event_staffs es WHERE es.event_id = ej.event_id AND es.user_id = ej.judge_id AND es.role = 'judge');")
+	}
 }
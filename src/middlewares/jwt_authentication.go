@@ -14,6 +14,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// The repositories are looked up on every request, the user module is initialised after the routes are declared
+var (
+	userRepository    = um.GetUserRepository
+	sessionRepository = um.GetUserSessionRepository
+)
+
 // JwtAuthMiddleware Token Authentication
 func JwtAuthMiddleware() gin.HandlerFunc {
 	return func(context *gin.Context) {
@@ -65,13 +71,42 @@ func JwtAuthMiddleware() gin.HandlerFunc {
 		}
 
 		email := fmt.Sprintf("%v", claims["email"])
-		user, err := um.GetUserRepository().FindByEmail(email)
+		user, err := userRepository().FindByEmail(email)
 		if err != nil {
 			common.SendError(context, http.StatusUnauthorized, "Unauthorized", []string{"User not found"})
 			context.Abort()
 			return
 		}
 
+		// Tokens issued before a password change, deactivation or logout everywhere are revoked
+		issuedAt, _ := claims["iat"].(float64)
+		if user.TokensRevokedAt != nil && int64(issuedAt) < user.TokensRevokedAt.Unix() {
+			common.SendError(context, http.StatusUnauthorized, "Unauthorized", []string{"Token revoked"})
+			context.Abort()
+			return
+		}
+
+		// Every access token belongs to a session, the ones issued before sessions existed can't be revoked
+		// so they are refused. Access tokens of a session that has been logged out stop working right away.
+		sessionID, _ := claims["sid"].(float64)
+		if sessionID <= 0 {
+			common.SendError(context, http.StatusUnauthorized, "Unauthorized", []string{"Invalid token"})
+			context.Abort()
+			return
+		}
+
+		session, err := sessionRepository().FindByID(uint(sessionID))
+		if err != nil || session.UserID != user.ID || session.RevokedAt != nil {
+			common.SendError(context, http.StatusUnauthorized, "Unauthorized", []string{"Session revoked"})
+			context.Abort()
+			return
+		}
+
+		if time.Since(session.LastSeenAt) > time.Minute {
+			_ = sessionRepository().Touch(session.ID, time.Now())
+		}
+		context.Set("sessionID", session.ID)
+
 		// Next
 		context.Set("userID", user.ID)
 		context.Set("user", user)
@@ -0,0 +1,153 @@
+package middlewares
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils"
+	e "be-sagara-hackathon/src/utils/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	user model.User
+}
+
+func (repository fakeUserRepository) FindByEmail(email string) (model.User, error) {
+	if email != repository.user.Email {
+		return model.User{}, e.ErrEmailNotRegistered
+	}
+	return repository.user, nil
+}
+
+type fakeUserSessionRepository struct {
+	repository.UserSessionRepository
+	sessions map[uint]model.UserSession
+}
+
+func (repository fakeUserSessionRepository) FindByID(sessionID uint) (model.UserSession, error) {
+	session, ok := repository.sessions[sessionID]
+	if !ok {
+		return model.UserSession{}, e.ErrDataNotFound
+	}
+	return session, nil
+}
+
+func (repository fakeUserSessionRepository) Touch(uint, time.Time) error {
+	return nil
+}
+
+// useAuthRepositories makes the JWT authentication find user and the sessions until the test ends
+func useAuthRepositories(t *testing.T, user model.User, sessions ...model.UserSession) {
+	t.Setenv("API_JWT_SECRET", "test")
+
+	byID := map[uint]model.UserSession{}
+	for _, session := range sessions {
+		byID[session.ID] = session
+	}
+	users, userSessions := userRepository, sessionRepository
+	userRepository = func() repository.UserRepository { return fakeUserRepository{user: user} }
+	sessionRepository = func() repository.UserSessionRepository { return fakeUserSessionRepository{sessions: byID} }
+	t.Cleanup(func() {
+		userRepository, sessionRepository = users, userSessions
+	})
+}
+
+// authenticate sends token through the JWT authentication and returns the response status
+func authenticate(token string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", JwtAuthMiddleware(), func(context *gin.Context) {
+		context.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func authUser() model.User {
+	user := model.User{Email: "jane@example.com", IsActive: true, UserRole: &model.UserRole{}}
+	user.ID = 3
+	return user
+}
+
+func authSession(user model.User) model.UserSession {
+	session := model.UserSession{UserID: user.ID, LastSeenAt: time.Now()}
+	session.ID = 7
+	return session
+}
+
+func TestJwtAuthTokensRevoked(t *testing.T) {
+	tests := []struct {
+		name      string
+		revokedAt time.Duration
+		want      int
+	}{
+		{name: "issued before the revocation", revokedAt: time.Hour, want: http.StatusUnauthorized},
+		{name: "issued after the revocation", revokedAt: -time.Hour, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := authUser()
+			revokedAt := time.Now().Add(tt.revokedAt)
+			user.TokensRevokedAt = &revokedAt
+			session := authSession(user)
+			useAuthRepositories(t, user, session)
+
+			token, _, err := utils.GenerateToken(user, session.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := authenticate(token); got != tt.want {
+				t.Errorf("token %s got status %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJwtAuthSession(t *testing.T) {
+	user := authUser()
+	active := authSession(user)
+	revoked := authSession(user)
+	revoked.ID = 8
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+	other := authSession(user)
+	other.ID = 9
+	other.UserID = 4
+
+	tests := []struct {
+		name      string
+		sessionID uint
+		want      int
+	}{
+		{name: "active session", sessionID: active.ID, want: http.StatusOK},
+		{name: "no session", sessionID: 0, want: http.StatusUnauthorized},
+		{name: "unknown session", sessionID: 10, want: http.StatusUnauthorized},
+		{name: "revoked session", sessionID: revoked.ID, want: http.StatusUnauthorized},
+		{name: "session of another user", sessionID: other.ID, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useAuthRepositories(t, user, active, revoked, other)
+
+			token, _, err := utils.GenerateToken(user, tt.sessionID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := authenticate(token); got != tt.want {
+				t.Errorf("token of %s got status %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package middlewares
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/helper"
+	"be-sagara-hackathon/src/utils/ratelimit"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitStore holds the buckets and lockouts, replace it before the routes are set up to share them between nodes
+var RateLimitStore = ratelimit.NewStore()
+
+// AccountFunc returns the account a request targets, or an empty string when there is none
+type AccountFunc func(context *gin.Context) string
+
+// AccountFromBody reads the account from a field of the JSON body and puts the body back for the handler
+func AccountFromBody(field string) AccountFunc {
+	return func(context *gin.Context) string {
+		body, err := context.GetRawData()
+		context.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]interface{}
+		if err = json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		value, _ := fields[field].(string)
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
+
+// AccountFromUser reads the account from the authenticated user, it needs the JWT authentication before it
+func AccountFromUser(context *gin.Context) string {
+	if user, ok := context.Value("user").(model.User); ok {
+		return strings.ToLower(user.Email)
+	}
+	return ""
+}
+
+// RateLimit limits the requests to an endpoint per client IP and, when account is given, per targeted account
+func RateLimit(name string, perIP, perAccount ratelimit.Rule, account AccountFunc) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if !allowRequest(context, fmt.Sprintf("rl:%s:ip:%s", name, context.ClientIP()), perIP) {
+			return
+		}
+
+		if account != nil {
+			if acc := account(context); acc != "" &&
+				!allowRequest(context, fmt.Sprintf("rl:%s:account:%s", name, acc), perAccount) {
+				return
+			}
+		}
+
+		context.Next()
+	}
+}
+
+// LoginLockout locks an account out after repeated failed logins, the lock gets longer with every further failure.
+// A 401 response counts as a failed login and a 200 response clears the failures.
+func LoginLockout(name string, account AccountFunc) gin.HandlerFunc {
+	lockout := ratelimit.Lockout{
+		Store:       RateLimitStore,
+		MaxFailures: helper.GetEnvInt("LOGIN_MAX_FAILURES", 5),
+		Duration:    helper.GetEnvDuration("LOGIN_LOCKOUT_DURATION", time.Minute),
+		MaxDuration: helper.GetEnvDuration("LOGIN_MAX_LOCKOUT_DURATION", time.Hour),
+		Window:      helper.GetEnvDuration("LOGIN_FAILURE_WINDOW", 24*time.Hour),
+	}
+
+	return func(context *gin.Context) {
+		acc := account(context)
+		if acc == "" {
+			context.Next()
+			return
+		}
+
+		key := fmt.Sprintf("%s:%s", name, acc)
+		locked, retryAfter, err := lockout.Check(key)
+		if err != nil {
+			log.Printf("failed to check login lockout of %s: %v", acc, err)
+		}
+		if locked {
+			sendTooManyRequests(context, retryAfter)
+			return
+		}
+
+		context.Next()
+
+		switch context.Writer.Status() {
+		case http.StatusUnauthorized:
+			err = lockout.Fail(key)
+		case http.StatusOK:
+			err = lockout.Reset(key)
+		}
+		if err != nil {
+			log.Printf("failed to record login attempt of %s: %v", acc, err)
+		}
+	}
+}
+
+// allowRequest counts the request in the bucket of key and answers 429 when the bucket is full.
+// The request is let through when the store fails, an outage of the store should not lock everyone out.
+func allowRequest(context *gin.Context, key string, rule ratelimit.Rule) bool {
+	allowed, retryAfter, err := ratelimit.Allow(RateLimitStore, key, rule)
+	if err != nil {
+		log.Printf("failed to check rate limit %s: %v", key, err)
+		return true
+	}
+	if !allowed {
+		sendTooManyRequests(context, retryAfter)
+		return false
+	}
+	return true
+}
+
+func sendTooManyRequests(context *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	context.Header("Retry-After", fmt.Sprint(seconds))
+	common.SendError(context, http.StatusTooManyRequests, "Too Many Requests",
+		[]string{fmt.Sprintf("too many attempts, try again in %d seconds", seconds)})
+	context.Abort()
+}
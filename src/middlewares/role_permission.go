@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	um "be-sagara-hackathon/src/modules/user"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils/common"
 	"be-sagara-hackathon/src/utils/helper"
@@ -24,3 +25,31 @@ func RolePermission(roles ...string) gin.HandlerFunc {
 		context.Next()
 	}
 }
+
+// permissionRepository is looked up on every request like the repositories of the JWT authentication
+var permissionRepository = um.GetPermissionRepository
+
+// Permission lets the request through when the role of the user has any of the permissions.
+// The grants are read on every request so edits to a role apply right away.
+func Permission(permissions ...string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+
+		userExtract, _ := context.Get("user")
+		user := userExtract.(model.User)
+
+		allowed, err := permissionRepository().HasAny(user.UserRoleID, permissions)
+		if err != nil {
+			common.SendError(context, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+			context.Abort()
+			return
+		}
+
+		if !allowed {
+			common.SendError(context, http.StatusForbidden, "Forbidden", []string{"Forbidden"})
+			context.Abort()
+			return
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/helper"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakePermissionRepository grants the permissions by role id, err fails every lookup
+type fakePermissionRepository struct {
+	repository.PermissionRepository
+	grants map[uint][]string
+	err    error
+}
+
+func (repository fakePermissionRepository) HasAny(roleID uint, names []string) (bool, error) {
+	if repository.err != nil {
+		return false, repository.err
+	}
+	for _, name := range names {
+		if helper.StringInSlice(name, repository.grants[roleID]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestPermission(t *testing.T) {
+	grants := map[uint][]string{
+		1: {constants.PermissionRoleManage, constants.PermissionEventManageAll},
+		2: {constants.PermissionEventManageAll},
+	}
+	tests := []struct {
+		name   string
+		roleID uint
+		err    error
+		want   int
+	}{
+		{name: "role with one of the permissions", roleID: 2, want: http.StatusOK},
+		{name: "role with all of the permissions", roleID: 1, want: http.StatusOK},
+		{name: "role without the permissions", roleID: 3, want: http.StatusForbidden},
+		{name: "failed lookup", roleID: 1, err: errors.New("connection lost"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositories := permissionRepository
+			permissionRepository = func() repository.PermissionRepository {
+				return fakePermissionRepository{grants: grants, err: tt.err}
+			}
+			t.Cleanup(func() { permissionRepository = repositories })
+
+			user := model.User{UserRoleID: tt.roleID}
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/", func(context *gin.Context) {
+				context.Set("user", user)
+			}, Permission(constants.PermissionRoleManage, constants.PermissionEventManageAll), func(context *gin.Context) {
+				context.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+			if recorder.Code != tt.want {
+				t.Errorf("%s got status %d, want %d", tt.name, recorder.Code, tt.want)
+			}
+		})
+	}
+}
@@ -50,9 +50,9 @@ func (controller *AdminAuthControllerImpl) Login(ctx *gin.Context) {
 		return
 	}
 
-	response, err := controller.Service.Login(request)
+	response, err := controller.Service.Login(request, newClient(ctx))
 	if err != nil {
-		if err == errors.ErrWrongLoginCredential {
+		if err == errors.ErrWrongLoginCredential || err == errors.ErrUserIsNotActivated {
 			common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
 			return
 		}
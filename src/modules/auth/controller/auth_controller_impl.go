@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,11 +22,16 @@ type AuthController interface {
 	Login(ctx *gin.Context)
 	RegisterByGoogle(ctx *gin.Context)
 	LoginByGoogle(ctx *gin.Context)
-	GoogleOauth(ctx *gin.Context)
+	RegisterByOauth(ctx *gin.Context)
+	LoginByOauth(ctx *gin.Context)
+	OauthCallback(ctx *gin.Context)
 	VerifyEmail(ctx *gin.Context)
 	SendVerificationCode(ctx *gin.Context)
 	ValidateVerificationCode(ctx *gin.Context)
 	ForgotPassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
+	RefreshToken(ctx *gin.Context)
+	Logout(ctx *gin.Context)
 }
 
 // AuthControllerImpl Binding Services to Controller
@@ -73,7 +79,11 @@ func (controller *AuthControllerImpl) Register(ctx *gin.Context) {
 	if err != nil {
 		if err == e.ErrEmailAlreadyExists ||
 			err == e.ErrPhoneNumberAlreadyExists ||
-			err == e.ErrConfirmPasswordNotSame {
+			err == e.ErrConfirmPasswordNotSame ||
+			err == e.ErrOutsideTimelinePhase ||
+			err == e.ErrVoucherInvalid ||
+			err == e.ErrVoucherUsedUp ||
+			utils.IsPasswordPolicyError(err) {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -113,7 +123,7 @@ func (controller *AuthControllerImpl) Login(ctx *gin.Context) {
 		return
 	}
 
-	response, err := controller.Service.Login(request)
+	response, err := controller.Service.Login(request, newClient(ctx))
 	if err != nil {
 		if err == e.ErrWrongLoginCredential || err == e.ErrUserIsNotActivated {
 			common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
@@ -155,7 +165,7 @@ func (controller *AuthControllerImpl) RegisterByGoogle(ctx *gin.Context) {
 		return
 	}
 
-	response, err := controller.Service.RegisterByGoogle(request)
+	response, err := controller.Service.RegisterByGoogle(request, newClient(ctx))
 	if err != nil {
 		// When Id Token Expired or User Not Found
 		if err.Error() == "idtoken: token expired" {
@@ -198,7 +208,7 @@ func (controller *AuthControllerImpl) LoginByGoogle(ctx *gin.Context) {
 		return
 	}
 
-	response, err := controller.Service.LoginByGoogle(request)
+	response, err := controller.Service.LoginByGoogle(request, newClient(ctx))
 	if err != nil {
 		// When Id Token Expired
 		if (err.Error() == "idtoken: token expired") || (err.Error() == "user not found") {
@@ -213,8 +223,103 @@ func (controller *AuthControllerImpl) LoginByGoogle(ctx *gin.Context) {
 	common.SendSuccess(ctx, http.StatusOK, "Login Success", &response)
 }
 
-func (controller *AuthControllerImpl) GoogleOauth(ctx *gin.Context) {
-	redirectUrlPath := os.Getenv("OAUTH_GOOGLE_REDIRECT_URL")
+// RegisterByOauth Register By GitHub or LinkedIn godoc
+// @Tags Authentication
+// @Summary Register By GitHub or LinkedIn
+// @Description Register a participant with the authorization code the provider redirected back with. The profile link is prefilled from the account.
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "github or linkedin"
+// @Param body body model.RegisterByOauthRequest true "Body Request"
+// @Success 200 {object} src.AuthSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/register/{provider} [post]
+func (controller *AuthControllerImpl) RegisterByOauth(ctx *gin.Context) {
+	var request model.RegisterByOauthRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	response, err := controller.Service.RegisterByOauth(ctx.Param("provider"), request, newClient(ctx))
+	if err != nil {
+		sendOauthError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Register Success", &response)
+}
+
+// LoginByOauth Login By GitHub or LinkedIn godoc
+// @Tags Authentication
+// @Summary Login By GitHub or LinkedIn
+// @Description Login with the authorization code the provider redirected back with
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "github or linkedin"
+// @Param body body model.LoginByOauthRequest true "Body Request"
+// @Success 200 {object} src.AuthSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/login/{provider} [post]
+func (controller *AuthControllerImpl) LoginByOauth(ctx *gin.Context) {
+	var request model.LoginByOauthRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	response, err := controller.Service.LoginByOauth(ctx.Param("provider"), request, newClient(ctx))
+	if err != nil {
+		sendOauthError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Login Success", &response)
+}
+
+func sendOauthError(ctx *gin.Context, err error) {
+	switch err {
+	case oauth.ErrRetrieveToken, oauth.ErrRetrieveUser, e.ErrEmailNotRegistered, e.ErrUserIsNotActivated:
+		common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
+	case e.ErrForbidden:
+		common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+	case e.ErrUnknownOauthProvider, e.ErrOauthEmailNotVerified, e.ErrWrongAuthMethod, e.ErrEmailAlreadyExists,
+		e.ErrPhoneNumberAlreadyExists, e.ErrEventNotRunning, e.ErrOutsideTimelinePhase, e.ErrVoucherInvalid, e.ErrVoucherUsedUp:
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+	default:
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+	}
+}
+
+// OauthCallback is where Google, GitHub and LinkedIn redirect to. The result is handed to the frontend in cookies
+// on the page set in OAUTH_<PROVIDER>_REDIRECT_URL.
+func (controller *AuthControllerImpl) OauthCallback(ctx *gin.Context) {
+	provider := ctx.Param("provider")
+	redirectUrlPath := os.Getenv(fmt.Sprintf("OAUTH_%s_REDIRECT_URL", strings.ToUpper(provider)))
 	redirectUrl := fmt.Sprintf("%s%s", os.Getenv("BASE_FE_URL"), redirectUrlPath)
 
 	code := ctx.Query("code")
@@ -226,29 +331,26 @@ func (controller *AuthControllerImpl) GoogleOauth(ctx *gin.Context) {
 		return
 	}
 
-	// Use the code to get the id and access tokens
-	tokenRes, err := oauth.GetGoogleOauthToken(code)
+	response, err := controller.Service.OauthCallback(provider, code, newClient(ctx))
 	if err != nil {
 		ctx.SetCookie("is_authenticated", "false", 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, false)
 		ctx.SetCookie("error", err.Error(), 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), true, true)
 		ctx.Redirect(http.StatusTemporaryRedirect, redirectUrl)
-		//common.SendError(ctx, http.StatusBadGateway, "Bad Gateway", []string{err.Error()})
 		return
 	}
 
-	googleUser, err := oauth.GetGoogleUser(tokenRes.AccessToken, tokenRes.IdToken)
-	if err != nil {
-		ctx.SetCookie("is_authenticated", "false", 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, false)
-		ctx.SetCookie("error", err.Error(), 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), true, true)
-		ctx.Redirect(http.StatusTemporaryRedirect, redirectUrl)
-		//common.SendError(ctx, http.StatusBadGateway, "Bad Gateway", []string{err.Error()})
-		return
-	}
+	// the login isn't done yet, the frontend sends the challenge with the code to /auth/login/2fa
+	if response.TwoFactor != nil {
+		challenge, err := json.Marshal(response.TwoFactor)
+		if err != nil {
+			ctx.SetCookie("is_authenticated", "false", 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, false)
+			ctx.SetCookie("error", err.Error(), 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), true, true)
+			ctx.Redirect(http.StatusTemporaryRedirect, redirectUrl)
+			return
+		}
 
-	response, err := controller.Service.GoogleOauth(*googleUser)
-	if err != nil {
+		ctx.SetCookie("two_factor_challenge", string(challenge), 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, true)
 		ctx.SetCookie("is_authenticated", "false", 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, false)
-		ctx.SetCookie("error", err.Error(), 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), true, true)
 		ctx.Redirect(http.StatusTemporaryRedirect, redirectUrl)
 		return
 	}
@@ -262,6 +364,7 @@ func (controller *AuthControllerImpl) GoogleOauth(ctx *gin.Context) {
 	}
 
 	ctx.SetCookie("access_token", response.Token, 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, true)
+	ctx.SetCookie("refresh_token", response.RefreshToken, 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, true)
 	ctx.SetCookie("user", string(userData), 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, true)
 	ctx.SetCookie("is_authenticated", "true", 1000*60, redirectUrlPath, os.Getenv("BASE_FE_URL"), false, false)
 	ctx.Redirect(http.StatusTemporaryRedirect, redirectUrl)
@@ -299,7 +402,8 @@ func (controller *AuthControllerImpl) VerifyEmail(ctx *gin.Context) {
 	if err != nil {
 		if err == e.ErrEmailNotRegistered ||
 			err == e.ErrEmailAlreadyVerified ||
-			err == e.ErrInvalidVerificationCode {
+			err == e.ErrInvalidVerificationCode ||
+			err == e.ErrVerificationCodeExpired {
 			common.SendError(ctx, http.StatusBadRequest, "Invalid request", []string{err.Error()})
 			return
 		}
@@ -341,7 +445,7 @@ func (controller *AuthControllerImpl) SendVerificationCode(ctx *gin.Context) {
 
 	err := controller.Service.SendVerificationCode(request)
 	if err != nil {
-		if err == e.ErrEmailNotRegistered {
+		if err == e.ErrEmailNotRegistered || err == e.ErrInvalidVerificationCodeType {
 			common.SendError(ctx, http.StatusBadRequest, "Invalid request", []string{err.Error()})
 			return
 		}
@@ -383,8 +487,9 @@ func (controller *AuthControllerImpl) ValidateVerificationCode(ctx *gin.Context)
 
 	err := controller.Service.ValidateVerificationCode(request)
 	if err != nil {
-		if err == e.ErrInvalidVerificationCode {
+		if err == e.ErrInvalidVerificationCode || err == e.ErrVerificationCodeExpired {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
 		}
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
@@ -423,12 +528,143 @@ func (controller *AuthControllerImpl) ForgotPassword(ctx *gin.Context) {
 
 	err := controller.Service.ForgotPassword(request)
 	if err != nil {
-		if err == e.ErrInvalidVerificationCode {
-			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		sendResetPasswordError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Forgot Password Success", nil)
+}
+
+// ResetPassword Reset Password godoc
+// @Tags Authentication
+// @Summary Reset Password
+// @Description Set a new password with the code from the reset password email, the code works once
+// @Accept  json
+// @Produce  json
+// @Param body body model.ResetPasswordRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /auth/reset-password [post]
+func (controller *AuthControllerImpl) ResetPassword(ctx *gin.Context) {
+	var request model.ResetPasswordRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	err := controller.Service.ResetPassword(request)
+	if err != nil {
+		sendResetPasswordError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Reset Password Success", nil)
+}
+
+func sendResetPasswordError(ctx *gin.Context, err error) {
+	if err == e.ErrInvalidVerificationCode ||
+		err == e.ErrVerificationCodeExpired ||
+		err == e.ErrConfirmPasswordNotSame ||
+		utils.IsPasswordPolicyError(err) {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+	common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+}
+
+// RefreshToken godoc
+// @Tags Authentication
+// @Summary Refresh Token
+// @Description Exchange a refresh token for a new access token and refresh token
+// @Accept  json
+// @Produce  json
+// @Param body body model.RefreshTokenRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/refresh [post]
+func (controller *AuthControllerImpl) RefreshToken(ctx *gin.Context) {
+	var request model.RefreshTokenRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
 		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	response, err := controller.Service.RefreshToken(request, newClient(ctx))
+	if err != nil {
+		if err == e.ErrInvalidRefreshToken || err == e.ErrUserIsNotActivated {
+			common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
 
-	common.SendSuccess(ctx, http.StatusOK, "Forgot Password Success", nil)
+	common.SendSuccess(ctx, http.StatusOK, "Refresh Token Success", response)
+}
+
+// Logout godoc
+// @Tags Authentication
+// @Summary Logout
+// @Description Revoke the refresh token and every token rotated from the same login
+// @Accept  json
+// @Produce  json
+// @Param body body model.RefreshTokenRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/logout [post]
+func (controller *AuthControllerImpl) Logout(ctx *gin.Context) {
+	var request model.RefreshTokenRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	err := controller.Service.Logout(request)
+	if err != nil {
+		if err == e.ErrInvalidRefreshToken {
+			common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Logout Success", nil)
 }
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/utils/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newClient reads the device a login or refresh request comes from
+func newClient(ctx *gin.Context) model.Client {
+	return model.Client{
+		UserAgent: helper.TruncateString(ctx.Request.UserAgent(), 255),
+		IPAddress: ctx.ClientIP(),
+	}
+}
@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/modules/auth/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorController interface {
+	Login(ctx *gin.Context)
+	SetupByChallenge(ctx *gin.Context)
+	ActivateByChallenge(ctx *gin.Context)
+	GetStatus(ctx *gin.Context)
+	Setup(ctx *gin.Context)
+	Enable(ctx *gin.Context)
+	Disable(ctx *gin.Context)
+	RegenerateRecoveryCodes(ctx *gin.Context)
+}
+
+type TwoFactorControllerImpl struct {
+	Service service.TwoFactorService
+}
+
+func NewTwoFactorController(twoFactorService service.TwoFactorService) TwoFactorController {
+	return &TwoFactorControllerImpl{Service: twoFactorService}
+}
+
+// Login Two Factor Login godoc
+// @Tags Authentication
+// @Summary Two Factor Login
+// @Description Finish an admin login with an authenticator code or a recovery code
+// @Accept  json
+// @Produce  json
+// @Param body body model.TwoFactorLoginRequest true "Body Request"
+// @Success 200 {object} src.AuthSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/login/2fa [post]
+func (controller *TwoFactorControllerImpl) Login(ctx *gin.Context) {
+	var request model.TwoFactorLoginRequest
+	if !bindTwoFactorRequest(ctx, &request) {
+		return
+	}
+
+	response, err := controller.Service.Login(request, newClient(ctx))
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Login Success", &response)
+}
+
+// SetupByChallenge Two Factor Setup On Login godoc
+// @Tags Authentication
+// @Summary Two Factor Setup On Login
+// @Description Generate the authenticator secret of a user whose role requires two factor authentication
+// @Accept  json
+// @Produce  json
+// @Param body body model.TwoFactorChallengeRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/2fa/setup [post]
+func (controller *TwoFactorControllerImpl) SetupByChallenge(ctx *gin.Context) {
+	var request model.TwoFactorChallengeRequest
+	if !bindTwoFactorRequest(ctx, &request) {
+		return
+	}
+
+	response, err := controller.Service.SetupByChallenge(request)
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Setup Two Factor Success", response)
+}
+
+// ActivateByChallenge Two Factor Activation On Login godoc
+// @Tags Authentication
+// @Summary Two Factor Activation On Login
+// @Description Confirm the first authenticator code, get the recovery codes and finish the login
+// @Accept  json
+// @Produce  json
+// @Param body body model.TwoFactorLoginRequest true "Body Request"
+// @Success 200 {object} src.AuthSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /auth/2fa/activate [post]
+func (controller *TwoFactorControllerImpl) ActivateByChallenge(ctx *gin.Context) {
+	var request model.TwoFactorLoginRequest
+	if !bindTwoFactorRequest(ctx, &request) {
+		return
+	}
+
+	response, err := controller.Service.ActivateByChallenge(request, newClient(ctx))
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Login Success", &response)
+}
+
+// GetStatus Two Factor Status godoc
+// @Tags User
+// @Summary Two Factor Status
+// @Description Get whether two factor authentication is enabled or required for the authenticated user
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Router /users/profile/2fa [get]
+func (controller *TwoFactorControllerImpl) GetStatus(ctx *gin.Context) {
+	response, err := controller.Service.GetStatus(ctx)
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Two Factor Status Success", response)
+}
+
+// Setup Two Factor Setup godoc
+// @Tags User
+// @Summary Two Factor Setup
+// @Description Generate a new authenticator secret and its provisioning URI for a QR code
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /users/profile/2fa/setup [post]
+func (controller *TwoFactorControllerImpl) Setup(ctx *gin.Context) {
+	response, err := controller.Service.Setup(ctx)
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Setup Two Factor Success", response)
+}
+
+// Enable Two Factor Enable godoc
+// @Tags User
+// @Summary Two Factor Enable
+// @Description Confirm the first authenticator code and get the recovery codes
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param body body model.TwoFactorCodeRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /users/profile/2fa/enable [post]
+func (controller *TwoFactorControllerImpl) Enable(ctx *gin.Context) {
+	var request model.TwoFactorCodeRequest
+	if !bindTwoFactorRequest(ctx, &request) {
+		return
+	}
+
+	response, err := controller.Service.Enable(ctx, request)
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Enable Two Factor Success", response)
+}
+
+// Disable Two Factor Disable godoc
+// @Tags User
+// @Summary Two Factor Disable
+// @Description Turn two factor authentication off, not allowed for roles that require it
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param body body model.TwoFactorCodeRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Router /users/profile/2fa/disable [post]
+func (controller *TwoFactorControllerImpl) Disable(ctx *gin.Context) {
+	var request model.TwoFactorCodeRequest
+	if !bindTwoFactorRequest(ctx, &request) {
+		return
+	}
+
+	if err := controller.Service.Disable(ctx, request); err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Disable Two Factor Success", nil)
+}
+
+// RegenerateRecoveryCodes Regenerate Recovery Codes godoc
+// @Tags User
+// @Summary Regenerate Recovery Codes
+// @Description Replace every recovery code with a new set
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param body body model.TwoFactorCodeRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /users/profile/2fa/recovery-codes [post]
+func (controller *TwoFactorControllerImpl) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var request model.TwoFactorCodeRequest
+	if !bindTwoFactorRequest(ctx, &request) {
+		return
+	}
+
+	response, err := controller.Service.RegenerateRecoveryCodes(ctx, request)
+	if err != nil {
+		sendTwoFactorError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Regenerate Recovery Codes Success", response)
+}
+
+func bindTwoFactorRequest(ctx *gin.Context, request interface{}) bool {
+	if errorBinding := ctx.ShouldBindJSON(request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return false
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return false
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return false
+	}
+	return true
+}
+
+func sendTwoFactorError(ctx *gin.Context, err error) {
+	switch err {
+	case e.ErrInvalidTwoFactorChallenge, e.ErrInvalidTwoFactorCode, e.ErrTooManyTwoFactorAttempts,
+		e.ErrUserIsNotActivated:
+		common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
+	case e.ErrTwoFactorAlreadyEnabled, e.ErrTwoFactorNotEnabled, e.ErrTwoFactorNotSetUp:
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+	case e.ErrTwoFactorRequired:
+		common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+	default:
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"be-sagara-hackathon/src/modules/auth/repository"
 	"be-sagara-hackathon/src/modules/auth/service"
 	er "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/outbox"
+	"be-sagara-hackathon/src/modules/promotion"
 	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/oauth"
 
 	"gorm.io/gorm"
 )
@@ -17,6 +22,7 @@ var (
 	authController      controller.AuthController
 	adminAuthService    service.AdminAuthService
 	adminAuthController controller.AdminAuthController
+	twoFactorController controller.TwoFactorController
 )
 
 type AuthModule interface {
@@ -39,6 +45,27 @@ func (module *AuthModuleImpl) InitModule() {
 	eventRepository := er.NewEventRepository(module.DB)
 	userRoleRepository := ur.NewUserRoleRepository(module.DB)
 	eventParticipantRepository := er.NewEventParticipantRepository(module.DB)
+	tokenService := service.NewTokenService(
+		repository.NewRefreshTokenRepository(module.DB),
+		ur.NewUserSessionRepository(module.DB),
+		userRepository,
+		audit.GetRecorder(),
+	)
+	timelineGuard := evs.NewEventTimelineGuard(er.NewEventTimelineRepository(module.DB))
+	enrolmentService := service.NewEnrolmentService(
+		eventRepository,
+		eventParticipantRepository,
+		participantRepository,
+		timelineGuard,
+		promotion.GetPricingService(),
+	)
+	twoFactorService := service.NewTwoFactorService(
+		repository.NewTwoFactorRepository(module.DB),
+		userRepository,
+		tokenService,
+		enrolmentService,
+		audit.GetRecorder(),
+	)
 	authService = service.NewAuthService(
 		authRepository,
 		verifCodeRepository,
@@ -46,10 +73,16 @@ func (module *AuthModuleImpl) InitModule() {
 		participantRepository,
 		eventRepository,
 		userRoleRepository,
-		eventParticipantRepository,
+		outbox.GetMailer(),
+		timelineGuard,
+		enrolmentService,
+		tokenService,
+		twoFactorService,
+		oauth.NewProviders(),
 	)
 	authController = controller.NewAuthController(authService)
-	adminAuthService = service.NewAdminAuthService(userRepository)
+	twoFactorController = controller.NewTwoFactorController(twoFactorService)
+	adminAuthService = service.NewAdminAuthService(userRepository, tokenService, twoFactorService)
 	adminAuthController = controller.NewAdminAuthController(adminAuthService)
 }
 
@@ -60,3 +93,7 @@ func GetController() controller.AuthController {
 func GetAdminController() controller.AdminAuthController {
 	return adminAuthController
 }
+
+func GetTwoFactorController() controller.TwoFactorController {
+	return twoFactorController
+}
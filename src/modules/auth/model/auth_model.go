@@ -11,9 +11,9 @@ type VerificationCode struct {
 	common.BaseEntity
 	UserID   uint
 	User     um.User
-	Code     string `gorm:"uniqueIndex;type:varchar(255)"`
-	Type     string `gorm:"type:varchar(20)"`
-	ExpireAt *time.Time
+	Code     string    `gorm:"uniqueIndex;type:varchar(255)"`
+	Type     string    `gorm:"type:varchar(20)"`
+	ExpireAt time.Time `gorm:"not null"`
 }
 
 type RegisterModel struct {
@@ -21,6 +21,9 @@ type RegisterModel struct {
 	LatestEventID uint
 	Invoice       pye.Invoice
 	Verification  *VerificationCode
+	// profile links prefilled from the sign in provider
+	LinkRepository *string
+	LinkLinkedin   *string
 }
 
 type RegisterRequest struct {
@@ -29,11 +32,13 @@ type RegisterRequest struct {
 	Email           string `json:"email" validate:"required"`
 	Password        string `json:"password" validate:"required"`
 	ConfirmPassword string `json:"confirm_password" validate:"required"`
+	VoucherCode     string `json:"voucher_code"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Email       string `json:"email" validate:"required"`
+	Password    string `json:"password" validate:"required"`
+	VoucherCode string `json:"voucher_code"` //applied when the login joins the participant to the latest event
 }
 
 type RegisterByGoogleRequest struct {
@@ -46,6 +51,20 @@ type LoginByGoogleRequest struct {
 	IdToken string `json:"id_token" validate:"required"`
 }
 
+// RegisterByOauthRequest carries the authorization code GitHub or LinkedIn redirected the participant with.
+// FullName falls back to the name on the account.
+type RegisterByOauthRequest struct {
+	Code        string `json:"code" validate:"required"`
+	FullName    string `json:"full_name"`
+	PhoneNumber string `json:"phone_number" validate:"required"`
+	VoucherCode string `json:"voucher_code"`
+}
+
+type LoginByOauthRequest struct {
+	Code        string `json:"code" validate:"required"`
+	VoucherCode string `json:"voucher_code"`
+}
+
 type VerifyEmailRequest struct {
 	//Email string `json:"email" validate:"required"`
 	Code string `json:"verification_code" validate:"required"`
@@ -55,19 +74,22 @@ type ValidateVerificationCodeRequest struct {
 	Code string `json:"verification_code" validate:"required"`
 }
 
+// SendVerificationCodeRequest only sends the codes users ask for themselves,
+// set-password codes are sent with the invitation of an imported account.
 type SendVerificationCodeRequest struct {
-	Type  string `json:"type" validate:"required"`
+	Type  string `json:"type" validate:"required,oneof=verify-email reset-password"`
 	Email string `json:"email" validate:"required"`
 }
 
 type ForgotPasswordRequest struct {
+	Email       string `json:"email" validate:"required,email"`
 	Code        string `json:"verification_code" validate:"required"`
 	NewPassword string `json:"new_password" validate:"required"`
 }
 
 type ResetPasswordRequest struct {
-	Email           string `json:"email" validate:"required"`
-	OldPassword     string `json:"old_password" validate:"required"`
+	Email           string `json:"email" validate:"required,email"`
+	Code            string `json:"verification_code" validate:"required"`
 	NewPassword     string `json:"new_password" validate:"required"`
 	ConfirmPassword string `json:"confirm_password" validate:"required"`
 }
@@ -84,6 +106,8 @@ type UserClaims struct {
 }
 
 type AuthResponse struct {
-	Token string          `json:"token"`
-	User  um.UserResponse `json:"user"`
+	TokenPair
+	User          *um.UserResponse    `json:"user,omitempty"`
+	TwoFactor     *TwoFactorChallenge `json:"two_factor,omitempty"`
+	RecoveryCodes []string            `json:"recovery_codes,omitempty"`
 }
@@ -0,0 +1,37 @@
+package model
+
+import (
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// RefreshToken is stored as a hash. Every refresh replaces the token with a new one of the same session,
+// so a token that is used twice means it was stolen and the whole session is revoked.
+type RefreshToken struct {
+	common.BaseEntity
+	UserID       uint           `gorm:"not null;index"`
+	User         um.User        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SessionID    uint           `gorm:"not null;index"`
+	Session      um.UserSession `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TokenHash    string         `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt    time.Time      `gorm:"not null"`
+	RevokedAt    *time.Time     `gorm:"null"`
+	ReplacedByID *uint          `gorm:"null"`
+}
+
+type TokenPair struct {
+	Token        string `json:"token,omitempty"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// Client describes the device a login comes from
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// TwoFactor holds the TOTP secret of a user. It is pending until the first code is confirmed.
+type TwoFactor struct {
+	common.BaseEntity
+	UserID    uint       `gorm:"not null;uniqueIndex"`
+	User      um.User    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Secret    string     `gorm:"type:varchar(64);not null"`
+	EnabledAt *time.Time `gorm:"null"`
+	// LastUsedStep is the time step of the last accepted code, a code is only accepted once
+	LastUsedStep int64 `gorm:"not null;default:0"`
+	// FailedAttempts counts the wrong codes sent with a challenge since the last accepted one
+	FailedAttempts int `gorm:"not null;default:0"`
+	// ChallengesRevokedAt rejects the challenges issued before it, it is set once too many codes were wrong
+	ChallengesRevokedAt *time.Time `gorm:"null"`
+}
+
+// RecoveryCode replaces a TOTP code once, for a user who lost the authenticator. Only its hash is stored.
+type RecoveryCode struct {
+	common.BaseEntity
+	UserID   uint       `gorm:"not null;index"`
+	User     um.User    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CodeHash string     `gorm:"type:varchar(64);not null"`
+	UsedAt   *time.Time `gorm:"null"`
+}
+
+// TwoFactorChallenge is returned instead of tokens when the login needs a second step.
+// SetupRequired means the role requires 2FA and the user still has to enrol.
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresAt      int64  `json:"expires_at"`
+	SetupRequired  bool   `json:"setup_required"`
+}
+
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool  `json:"enabled"`
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`          //authenticator code or recovery code
+	VoucherCode    string `json:"voucher_code" validate:"omitempty"` //the voucher given on the first step, if any
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/auth/model"
 	eve "be-sagara-hackathon/src/modules/event/model"
+	prr "be-sagara-hackathon/src/modules/promotion/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
@@ -45,7 +46,10 @@ func (repository *AuthRepositoryImpl) Register(request model.RegisterModel) (use
 			CreatedBy: "self",
 			UpdatedBy: "self",
 		},
-		UserID: request.User.ID,
+		UserID:         request.User.ID,
+		PaymentStatus:  request.Invoice.Status,
+		LinkRepository: request.LinkRepository,
+		LinkLinkedin:   request.LinkLinkedin,
 	}
 	if err = tx.Create(&participant).Error; err != nil {
 		tx.Rollback()
@@ -70,6 +74,13 @@ func (repository *AuthRepositoryImpl) Register(request model.RegisterModel) (use
 		return
 	}
 
+	if request.Invoice.VoucherID != nil {
+		if err = prr.RedeemVoucher(tx, *request.Invoice.VoucherID); err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
 	if request.Verification != nil {
 		request.Verification.UserID = request.User.ID
 		if err = tx.Create(&request.Verification).Error; err != nil {
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type RefreshTokenRepository interface {
+	Start(session *um.UserSession, token *model.RefreshToken) error
+	FindByHash(tokenHash string) (model.RefreshToken, error)
+	Rotate(oldID uint, token *model.RefreshToken, client model.Client) (rotated bool, err error)
+}
+
+type RefreshTokenRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{DB: db}
+}
+
+// Start saves a new session together with its first refresh token
+func (repository *RefreshTokenRepositoryImpl) Start(session *um.UserSession, token *model.RefreshToken) error {
+	tx := repository.DB.Begin()
+	if err := tx.Omit("User").Create(session).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	token.SessionID = session.ID
+	if err := tx.Omit("User", "Session").Create(token).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (repository *RefreshTokenRepositoryImpl) FindByHash(tokenHash string) (model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := repository.DB.Preload("Session").Where("token_hash=?", tokenHash).First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return token, err
+	}
+	return token, nil
+}
+
+// Rotate saves token and revokes the old one in its place. It reports false when the old token
+// has been revoked in the meantime, e.g. by a concurrent refresh with the same token.
+func (repository *RefreshTokenRepositoryImpl) Rotate(
+	oldID uint,
+	token *model.RefreshToken,
+	client model.Client,
+) (rotated bool, err error) {
+	tx := repository.DB.Begin()
+	if err = tx.Omit("User", "Session").Create(token).Error; err != nil {
+		tx.Rollback()
+		return
+	}
+
+	now := time.Now()
+	result := tx.Model(&model.RefreshToken{}).
+		Where("id=? AND revoked_at IS NULL", oldID).
+		Updates(map[string]interface{}{"revoked_at": now, "replaced_by_id": token.ID})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err = tx.Model(&um.UserSession{}).
+		Where("id=?", token.SessionID).
+		Updates(map[string]interface{}{
+			"user_agent":   client.UserAgent,
+			"ip_address":   client.IPAddress,
+			"last_seen_at": now,
+			"expires_at":   token.ExpiresAt,
+		}).Error; err != nil {
+		tx.Rollback()
+		return
+	}
+
+	tx.Commit()
+	return true, nil
+}
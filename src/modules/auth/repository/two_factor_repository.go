@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepository interface {
+	FindByUserID(userID uint) (model.TwoFactor, error)
+	SavePending(twoFactor model.TwoFactor) error
+	Enable(userID uint, step int64, codeHashes []string) error
+	Disable(userID uint) error
+	UseStep(userID uint, step int64) (used bool, err error)
+	UseRecoveryCode(userID uint, codeHash string) (used bool, err error)
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	CountRecoveryCodes(userID uint) (int64, error)
+	RecordFailedAttempt(userID uint, maxAttempts int) (revoked bool, err error)
+	ResetFailedAttempts(userID uint) error
+}
+
+type TwoFactorRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &TwoFactorRepositoryImpl{DB: db}
+}
+
+func (repository *TwoFactorRepositoryImpl) FindByUserID(userID uint) (model.TwoFactor, error) {
+	var twoFactor model.TwoFactor
+	if err := repository.DB.Where("user_id=?", userID).First(&twoFactor).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return twoFactor, err
+	}
+	return twoFactor, nil
+}
+
+// SavePending stores a new secret of the user, replacing a previous one that was never confirmed
+func (repository *TwoFactorRepositoryImpl) SavePending(twoFactor model.TwoFactor) error {
+	return repository.DB.Omit("User").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "updated_at", "updated_by"}),
+	}).Create(&twoFactor).Error
+}
+
+func (repository *TwoFactorRepositoryImpl) Enable(userID uint, step int64, codeHashes []string) error {
+	tx := repository.DB.Begin()
+	result := tx.Model(&model.TwoFactor{}).
+		Where("user_id=? AND enabled_at IS NULL", userID).
+		Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return e.ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (repository *TwoFactorRepositoryImpl) Disable(userID uint) error {
+	tx := repository.DB.Begin()
+	if err := tx.Unscoped().Where("user_id=?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Unscoped().Where("user_id=?", userID).Delete(&model.TwoFactor{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+// UseStep marks the time step of a code as used. It reports false when that step or a later one
+// was used already, so a code can't be replayed.
+func (repository *TwoFactorRepositoryImpl) UseStep(userID uint, step int64) (used bool, err error) {
+	result := repository.DB.Model(&model.TwoFactor{}).
+		Where("user_id=? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (repository *TwoFactorRepositoryImpl) UseRecoveryCode(userID uint, codeHash string) (used bool, err error) {
+	result := repository.DB.Model(&model.RecoveryCode{}).
+		Where("user_id=? AND code_hash=? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (repository *TwoFactorRepositoryImpl) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	tx := repository.DB.Begin()
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (repository *TwoFactorRepositoryImpl) CountRecoveryCodes(userID uint) (total int64, err error) {
+	err = repository.DB.Model(&model.RecoveryCode{}).
+		Where("user_id=? AND used_at IS NULL", userID).
+		Count(&total).Error
+	return
+}
+
+// RecordFailedAttempt counts a wrong code of the user. Once maxAttempts is reached the counter starts over
+// and the challenges issued until now are revoked, which it reports.
+func (repository *TwoFactorRepositoryImpl) RecordFailedAttempt(userID uint, maxAttempts int) (revoked bool, err error) {
+	tx := repository.DB.Begin()
+	if err = tx.Model(&model.TwoFactor{}).
+		Where("user_id=?", userID).
+		Update("failed_attempts", gorm.Expr("failed_attempts + 1")).Error; err != nil {
+		tx.Rollback()
+		return
+	}
+
+	result := tx.Model(&model.TwoFactor{}).
+		Where("user_id=? AND failed_attempts >= ?", userID, maxAttempts).
+		Updates(map[string]interface{}{"failed_attempts": 0, "challenges_revoked_at": time.Now()})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, tx.Commit().Error
+}
+
+func (repository *TwoFactorRepositoryImpl) ResetFailedAttempts(userID uint) error {
+	return repository.DB.Model(&model.TwoFactor{}).
+		Where("user_id=? AND failed_attempts > 0", userID).
+		Update("failed_attempts", 0).Error
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Unscoped().Where("user_id=?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	var codes []model.RecoveryCode
+	for _, hash := range codeHashes {
+		codes = append(codes, model.RecoveryCode{
+			BaseEntity: common.BaseEntity{CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			UserID:     userID,
+			CodeHash:   hash,
+		})
+	}
+	return tx.Omit("User").Create(&codes).Error
+}
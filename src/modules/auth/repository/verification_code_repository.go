@@ -8,7 +8,7 @@ import (
 )
 
 type VerificationCodeRepository interface {
-	Save(vc model.VerificationCode) error
+	Issue(vc model.VerificationCode) error
 	Delete(vcID, userID uint) error
 	DeleteByCode(code string) error
 	FindOne(vcId uint) (model.VerificationCode, error)
@@ -25,16 +25,36 @@ func NewVerificationCodeRepository(db *gorm.DB) VerificationCodeRepository {
 	return &VerificationCodeRepositoryImpl{DB: db}
 }
 
-func (repository *VerificationCodeRepositoryImpl) Save(vc model.VerificationCode) error {
-	result := repository.DB.Create(&vc)
-	return result.Error
+// Issue saves a new code and drops the codes of the same type the user was sent before
+func (repository *VerificationCodeRepositoryImpl) Issue(vc model.VerificationCode) error {
+	tx := repository.DB.Begin()
+	if err := tx.Unscoped().
+		Where("user_id=? AND type=?", vc.UserID, vc.Type).
+		Delete(&model.VerificationCode{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(&vc).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
 }
 
+// Delete uses up the code, ErrInvalidVerificationCode means another request already did
 func (repository *VerificationCodeRepositoryImpl) Delete(vcID, userID uint) error {
 	tx := repository.DB.Begin()
-	if err := tx.Delete(&model.VerificationCode{}, vcID).Error; err != nil {
+	result := tx.Unscoped().Delete(&model.VerificationCode{}, vcID)
+	if result.Error != nil {
 		tx.Rollback()
-		return err
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return e.ErrInvalidVerificationCode
 	}
 
 	//update user's status to 'active'
@@ -52,7 +72,7 @@ func (repository *VerificationCodeRepositoryImpl) Delete(vcID, userID uint) erro
 }
 
 func (repository *VerificationCodeRepositoryImpl) DeleteByCode(code string) error {
-	result := repository.DB.Where("code=?", code).Delete(&model.VerificationCode{})
+	result := repository.DB.Unscoped().Where("code=?", code).Delete(&model.VerificationCode{})
 	return result.Error
 }
 
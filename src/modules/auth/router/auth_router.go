@@ -1,25 +1,79 @@
 package router
 
 import (
+	"be-sagara-hackathon/src/middlewares"
 	"be-sagara-hackathon/src/modules/auth"
+	"be-sagara-hackathon/src/utils/ratelimit"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterRoutes(group *gin.RouterGroup) {
+	loginPerIP := ratelimit.RuleFromEnv("RATE_LIMIT_LOGIN_IP", ratelimit.Rule{Limit: 20, Window: time.Minute})
+	loginPerAccount := ratelimit.RuleFromEnv("RATE_LIMIT_LOGIN_ACCOUNT", ratelimit.Rule{Limit: 10, Window: time.Minute})
+	emailPerIP := ratelimit.RuleFromEnv("RATE_LIMIT_EMAIL_IP", ratelimit.Rule{Limit: 5, Window: time.Minute})
+	emailPerAccount := ratelimit.RuleFromEnv("RATE_LIMIT_EMAIL_ACCOUNT", ratelimit.Rule{Limit: 3, Window: 15 * time.Minute})
+	resetPerIP := ratelimit.RuleFromEnv("RATE_LIMIT_FORGOT_PASSWORD_IP", ratelimit.Rule{Limit: 10, Window: time.Minute})
+	resetPerAccount := ratelimit.RuleFromEnv("RATE_LIMIT_FORGOT_PASSWORD_ACCOUNT", ratelimit.Rule{Limit: 5, Window: 15 * time.Minute})
+	byEmail := middlewares.AccountFromBody("email")
+	byChallenge := middlewares.AccountFromBody("challenge_token")
+
 	authController := auth.GetController()
 	group.POST("/register", authController.Register)
-	group.POST("/login", authController.Login)
-	group.GET("/google/callback", authController.GoogleOauth)
+	group.POST("/login",
+		middlewares.RateLimit("login", loginPerIP, loginPerAccount, byEmail),
+		middlewares.LoginLockout("login", byEmail),
+		authController.Login,
+	)
+	group.GET("/:provider/callback", authController.OauthCallback)
 	group.POST("/register/google", authController.RegisterByGoogle)
 	group.POST("/login/google", authController.LoginByGoogle)
+	group.POST("/register/:provider",
+		middlewares.RateLimit("register-oauth", loginPerIP, ratelimit.Rule{}, nil),
+		authController.RegisterByOauth,
+	)
+	group.POST("/login/:provider",
+		middlewares.RateLimit("login-oauth", loginPerIP, ratelimit.Rule{}, nil),
+		authController.LoginByOauth,
+	)
 	group.POST("/verify-email", authController.VerifyEmail)
-	group.POST("/get-verification-code", authController.SendVerificationCode)
+	group.POST("/get-verification-code",
+		middlewares.RateLimit("verification-code", emailPerIP, emailPerAccount, byEmail),
+		authController.SendVerificationCode,
+	)
 	group.POST("/validate-verification-code", authController.ValidateVerificationCode)
-	group.POST("/forgot-password", authController.ForgotPassword)
-	//group.POST("/reset-password", authController.ResetPassword) //DEPRECATED
+	group.POST("/forgot-password",
+		middlewares.RateLimit("forgot-password", resetPerIP, resetPerAccount, byEmail),
+		authController.ForgotPassword,
+	)
+	group.POST("/reset-password",
+		middlewares.RateLimit("reset-password", resetPerIP, resetPerAccount, byEmail),
+		authController.ResetPassword,
+	)
+	group.POST("/refresh", authController.RefreshToken)
+	group.POST("/logout", authController.Logout)
 
 	//admin & internal
 	adminAuthController := auth.GetAdminController()
-	group.POST("/login/admin", adminAuthController.Login)
+	group.POST("/login/admin",
+		middlewares.RateLimit("login-admin", loginPerIP, loginPerAccount, byEmail),
+		middlewares.LoginLockout("login", byEmail),
+		adminAuthController.Login,
+	)
+
+	//second step of a login that needs two factor
+	twoFactorController := auth.GetTwoFactorController()
+	group.POST("/login/2fa",
+		middlewares.RateLimit("login-2fa", loginPerIP, loginPerAccount, byChallenge),
+		twoFactorController.Login,
+	)
+	group.POST("/2fa/setup",
+		middlewares.RateLimit("2fa-setup", loginPerIP, loginPerAccount, byChallenge),
+		twoFactorController.SetupByChallenge,
+	)
+	group.POST("/2fa/activate",
+		middlewares.RateLimit("2fa-activate", loginPerIP, loginPerAccount, byChallenge),
+		twoFactorController.ActivateByChallenge,
+	)
 }
@@ -2,7 +2,6 @@ package service
 
 import (
 	"be-sagara-hackathon/src/modules/auth/model"
-	um "be-sagara-hackathon/src/modules/user/model"
 	ur "be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/constants"
@@ -11,18 +10,20 @@ import (
 )
 
 type AdminAuthService interface {
-	Login(request model.LoginRequest) (response model.AuthResponse, err error)
+	Login(request model.LoginRequest, client model.Client) (response model.AuthResponse, err error)
 }
 
 type AdminAuthServiceImpl struct {
-	UserRepo ur.UserRepository
+	UserRepo  ur.UserRepository
+	Tokens    TokenService
+	TwoFactor TwoFactorService
 }
 
-func NewAdminAuthService(userRepo ur.UserRepository) AdminAuthService {
-	return &AdminAuthServiceImpl{UserRepo: userRepo}
+func NewAdminAuthService(userRepo ur.UserRepository, tokens TokenService, twoFactor TwoFactorService) AdminAuthService {
+	return &AdminAuthServiceImpl{UserRepo: userRepo, Tokens: tokens, TwoFactor: twoFactor}
 }
 
-func (service *AdminAuthServiceImpl) Login(request model.LoginRequest) (response model.AuthResponse, err error) {
+func (service *AdminAuthServiceImpl) Login(request model.LoginRequest, client model.Client) (response model.AuthResponse, err error) {
 	user, err := service.UserRepo.FindByEmail(request.Email)
 	if err != nil {
 		if err == e.ErrEmailNotRegistered {
@@ -51,21 +52,27 @@ func (service *AdminAuthServiceImpl) Login(request model.LoginRequest) (response
 		return
 	}
 
-	// Generate Token
-	token, err := utils.GenerateToken(user)
+	if !user.IsActive {
+		err = e.ErrUserIsNotActivated
+		return
+	}
+
+	// Superadmin and admin, or anyone who enrolled, pass a second factor before getting tokens
+	challenge, err := service.TwoFactor.Challenge(user)
 	if err != nil {
 		return
 	}
+	if challenge != nil {
+		response = model.AuthResponse{TwoFactor: challenge}
+		return
+	}
 
-	response = model.AuthResponse{
-		Token: token,
-		User: um.UserResponse{
-			Id:       user.ID,
-			FullName: user.Name,
-			Email:    user.Email,
-			RoleId:   user.UserRoleID,
-			RoleName: user.UserRole.Name,
-		},
+	// Generate Token
+	pair, err := service.Tokens.Issue(user, client)
+	if err != nil {
+		return
 	}
+
+	response = newAuthResponse(user, pair)
 	return
 }
@@ -4,9 +4,8 @@ import (
 	"be-sagara-hackathon/src/modules/auth/mapper"
 	"be-sagara-hackathon/src/modules/auth/model"
 	"be-sagara-hackathon/src/modules/auth/repository"
-	evm "be-sagara-hackathon/src/modules/event/model"
 	evr "be-sagara-hackathon/src/modules/event/repository"
-	pym "be-sagara-hackathon/src/modules/payment/model"
+	evs "be-sagara-hackathon/src/modules/event/service"
 	um "be-sagara-hackathon/src/modules/user/model"
 	ur "be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
@@ -19,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
+	"strings"
 	"time"
 
 	"google.golang.org/api/idtoken"
@@ -28,14 +29,19 @@ import (
 
 type AuthService interface {
 	Register(request model.RegisterRequest) error
-	Login(request model.LoginRequest) (response model.AuthResponse, err error)
-	GoogleOauth(request oauth.GoogleUserResult) (response model.AuthResponse, err error)
-	RegisterByGoogle(request model.RegisterByGoogleRequest) (model.AuthResponse, error)
-	LoginByGoogle(request model.LoginByGoogleRequest) (model.AuthResponse, error)
+	Login(request model.LoginRequest, client model.Client) (response model.AuthResponse, err error)
+	OauthCallback(provider, code string, client model.Client) (response model.AuthResponse, err error)
+	RegisterByOauth(provider string, request model.RegisterByOauthRequest, client model.Client) (model.AuthResponse, error)
+	LoginByOauth(provider string, request model.LoginByOauthRequest, client model.Client) (model.AuthResponse, error)
+	RegisterByGoogle(request model.RegisterByGoogleRequest, client model.Client) (model.AuthResponse, error)
+	LoginByGoogle(request model.LoginByGoogleRequest, client model.Client) (model.AuthResponse, error)
 	VerifyEmail(request model.VerifyEmailRequest) error
 	SendVerificationCode(request model.SendVerificationCodeRequest) error
 	ValidateVerificationCode(request model.ValidateVerificationCodeRequest) error
 	ForgotPassword(request model.ForgotPasswordRequest) error
+	ResetPassword(request model.ResetPasswordRequest) error
+	RefreshToken(request model.RefreshTokenRequest, client model.Client) (model.TokenPair, error)
+	Logout(request model.RefreshTokenRequest) error
 }
 
 type AuthServiceImpl struct {
@@ -45,7 +51,12 @@ type AuthServiceImpl struct {
 	ParticipantRepo      ur.ParticipantRepository
 	EventRepository      evr.EventRepository
 	UserRoleRepo         ur.UserRoleRepository
-	EventParticipantRepo evr.EventParticipantRepository
+	Mailer               email.Mailer
+	TimelineGuard        evs.EventTimelineGuard
+	Enrolment            EnrolmentService
+	Tokens               TokenService
+	TwoFactor            TwoFactorService
+	Oauth                oauth.Providers
 }
 
 func NewAuthService(
@@ -55,7 +66,12 @@ func NewAuthService(
 	participantRepo ur.ParticipantRepository,
 	eventRepo evr.EventRepository,
 	userRoleRepo ur.UserRoleRepository,
-	eventParticipantRepo evr.EventParticipantRepository,
+	mailer email.Mailer,
+	timelineGuard evs.EventTimelineGuard,
+	enrolment EnrolmentService,
+	tokens TokenService,
+	twoFactor TwoFactorService,
+	oauthProviders oauth.Providers,
 ) AuthService {
 	return &AuthServiceImpl{
 		AuthRepository:       authRepository,
@@ -64,7 +80,12 @@ func NewAuthService(
 		ParticipantRepo:      participantRepo,
 		EventRepository:      eventRepo,
 		UserRoleRepo:         userRoleRepo,
-		EventParticipantRepo: eventParticipantRepo,
+		Mailer:               mailer,
+		TimelineGuard:        timelineGuard,
+		Enrolment:            enrolment,
+		Tokens:               tokens,
+		TwoFactor:            twoFactor,
+		Oauth:                oauthProviders,
 	}
 }
 
@@ -72,6 +93,9 @@ func (service *AuthServiceImpl) Register(request model.RegisterRequest) error {
 	if request.Password != request.ConfirmPassword {
 		return e.ErrConfirmPasswordNotSame
 	}
+	if err := utils.ValidatePassword(request.Password); err != nil {
+		return err
+	}
 
 	//Find latest running event
 	latestEvent, err := service.EventRepository.FindLatest()
@@ -82,6 +106,15 @@ func (service *AuthServiceImpl) Register(request model.RegisterRequest) error {
 		return e.ErrEventNotRunning
 	}
 
+	if err = service.TimelineGuard.CheckPhase(latestEvent.ID, constants.TimelinePhaseRegistration); err != nil {
+		return err
+	}
+
+	invoice, err := service.Enrolment.NewInvoice(latestEvent, request.VoucherCode)
+	if err != nil {
+		return err
+	}
+
 	//Find user role participant
 	role, err := service.UserRoleRepo.FindByName(constants.UserParticipant)
 	if err != nil {
@@ -109,19 +142,11 @@ func (service *AuthServiceImpl) Register(request model.RegisterRequest) error {
 			},
 		},
 		LatestEventID: latestEvent.ID,
-		Invoice: pym.Invoice{
-			EventID:       latestEvent.ID,
-			InvoiceNumber: utils.GenerateInvoiceNumber(),
-			Status:        constants.InvoiceUnpaid,
-			Amount:        latestEvent.RegFee,
-			BaseEntity: common.BaseEntity{
-				CreatedBy: "self",
-				UpdatedBy: "self",
-			},
-		},
+		Invoice:       invoice,
 		Verification: &model.VerificationCode{
-			Code: verificationCode,
-			Type: constants.VerifCodeEmail,
+			Code:     verificationCode,
+			Type:     constants.VerifCodeEmail,
+			ExpireAt: time.Now().Add(verificationCodeTTL(constants.VerifCodeEmail)),
 			BaseEntity: common.BaseEntity{
 				CreatedBy: "self",
 				UpdatedBy: "self",
@@ -134,9 +159,6 @@ func (service *AuthServiceImpl) Register(request model.RegisterRequest) error {
 		return err
 	}
 
-	// TODO: email
-	// - send email verification [OK]
-	// - use goroutine/task queue/background task
 	url := fmt.Sprintf("%s%s", os.Getenv("BASE_FE_URL"), os.Getenv("VERIFY_EMAIL_REDIRECT_URL"))
 	templateData := email.TemplateData{
 		Name:        newUser.Name,
@@ -147,19 +169,19 @@ func (service *AuthServiceImpl) Register(request model.RegisterRequest) error {
 	}
 
 	r := email.NewRequest([]string{newUser.Email}, constants.EmailSubjectVerifyEmail, "")
-	if err = r.ParseTemplate("./src/utils/email/template_email.html", templateData); err == nil {
-		ok, err2 := r.SendEmail()
-		if !ok || err2 != nil {
-			return e.ErrFailedSendEmail
-		}
-	} else {
+	if err = r.ParseTemplate(email.TemplateVerificationCode, templateData); err != nil {
 		return e.ErrFailedParseEmailTemplate
 	}
 
+	// the user is already registered, they can ask for another verification email if this one is lost
+	if err = service.Mailer.Send(*r); err != nil {
+		log.Printf("failed to queue verification email for %s: %v", newUser.Email, err)
+	}
+
 	return nil
 }
 
-func (service *AuthServiceImpl) Login(request model.LoginRequest) (response model.AuthResponse, err error) {
+func (service *AuthServiceImpl) Login(request model.LoginRequest, client model.Client) (response model.AuthResponse, err error) {
 	user, err := service.UserRepo.FindByEmail(request.Email)
 	if err != nil {
 		if err == e.ErrEmailNotRegistered {
@@ -184,201 +206,283 @@ func (service *AuthServiceImpl) Login(request model.LoginRequest) (response mode
 		return
 	}
 
-	latestEvent, err := service.EventRepository.FindLatest()
+	return service.loginResponse(user, client, request.VoucherCode)
+}
+
+// OauthCallback signs in with the code the provider redirected back with, registering the participant
+// to the latest event when the email is new
+func (service *AuthServiceImpl) OauthCallback(
+	provider string,
+	code string,
+	client model.Client,
+) (response model.AuthResponse, err error) {
+	profile, err := service.oauthProfile(provider, code)
 	if err != nil {
 		return
 	}
 
-	_, err = service.EventParticipantRepo.FindOneByEventIDAndParticipantID(latestEvent.ID, user.Participant.ID)
-	if err != nil && err != e.ErrDataNotFound {
+	user, err := service.UserRepo.FindByEmail(profile.Email)
+	if err != nil && err != e.ErrEmailNotRegistered {
 		return
 	}
 
-	if err != nil && err == e.ErrDataNotFound {
-		evp := evm.EventParticipant{
-			BaseEntity: common.BaseEntity{
-				CreatedAt: time.Now(),
-				CreatedBy: "self",
-				UpdatedAt: time.Now(),
-				UpdatedBy: "self",
-			},
-			EventID:       latestEvent.ID,
-			ParticipantID: user.Participant.ID,
-		}
-		inv := pym.Invoice{
-			BaseEntity: common.BaseEntity{
-				CreatedAt: time.Now(),
-				CreatedBy: "self",
-				UpdatedAt: time.Now(),
-				UpdatedBy: "self",
-			},
-			EventID:       latestEvent.ID,
-			ParticipantID: user.Participant.ID,
-			InvoiceNumber: utils.GenerateInvoiceNumber(),
-			Amount:        latestEvent.RegFee,
-			Status:        constants.InvoiceUnpaid,
-		}
-		if err = service.ParticipantRepo.UpdateRegistrationStatus(
-			user.Participant.ID, false, &evp, &inv,
-		); err != nil {
+	if user.ID != 0 && user.AuthType != provider {
+		err = e.ErrWrongAuthMethod
+		return
+	}
+
+	if user.ID == 0 {
+		user, err = service.registerOauthUser(provider, profile, profile.Name, nil, "")
+		if err != nil {
 			return
 		}
-		user.Participant.IsRegistered = false
-		user.Participant.PaymentStatus = constants.InvoiceUnpaid
+		return service.authResponse(user, client)
+	}
+
+	if user.Participant == nil {
+		err = e.ErrForbidden
+		return
+	}
+
+	if !user.IsActive {
+		err = e.ErrUserIsNotActivated
+		return
 	}
 
-	// Generate Token
-	token, err := utils.GenerateToken(user)
+	return service.loginResponse(user, client, "")
+}
+
+// RegisterByOauth registers a participant with a GitHub or LinkedIn account and prefills the profile link
+func (service *AuthServiceImpl) RegisterByOauth(
+	provider string,
+	request model.RegisterByOauthRequest,
+	client model.Client,
+) (response model.AuthResponse, err error) {
+	profile, err := service.oauthProfile(provider, request.Code)
 	if err != nil {
 		return
 	}
 
-	response = model.AuthResponse{
-		Token: token,
-		User: um.UserResponse{
-			Id:                      user.ID,
-			FullName:                user.Name,
-			Email:                   user.Email,
-			RoleId:                  user.UserRoleID,
-			RoleName:                user.UserRole.Name,
-			IsRegistrationCompleted: user.Participant.IsRegistered,
-			PaymentStatus:           user.Participant.PaymentStatus,
-		},
+	_, err = service.UserRepo.FindByEmail(profile.Email)
+	if err == nil {
+		err = e.ErrEmailAlreadyExists
+		return
+	}
+	if err != e.ErrEmailNotRegistered {
+		return
+	}
+
+	name := request.FullName
+	if name == "" {
+		name = profile.Name
+	}
+
+	user, err := service.registerOauthUser(provider, profile, name, &request.PhoneNumber, request.VoucherCode)
+	if err != nil {
+		return
 	}
-	return
+
+	return service.authResponse(user, client)
 }
 
-func (service *AuthServiceImpl) GoogleOauth(request oauth.GoogleUserResult) (response model.AuthResponse, err error) {
-	user, err := service.UserRepo.FindByEmail(request.Email)
-	if err != nil && err != e.ErrEmailNotRegistered {
+// LoginByOauth logs in a participant who registered with the same provider
+func (service *AuthServiceImpl) LoginByOauth(
+	provider string,
+	request model.LoginByOauthRequest,
+	client model.Client,
+) (response model.AuthResponse, err error) {
+	profile, err := service.oauthProfile(provider, request.Code)
+	if err != nil {
+		return
+	}
+
+	user, err := service.UserRepo.FindByEmail(profile.Email)
+	if err != nil {
 		return
 	}
 
-	if user.ID != 0 && user.AuthType != constants.AuthTypeGoogle {
+	if user.AuthType != provider {
 		err = e.ErrWrongAuthMethod
 		return
 	}
 
+	// staff log in through the admin login, where the second factor is checked
+	if user.Participant == nil {
+		err = e.ErrForbidden
+		return
+	}
+
+	if !user.IsActive {
+		err = e.ErrUserIsNotActivated
+		return
+	}
+
+	return service.loginResponse(user, client, request.VoucherCode)
+}
+
+// oauthProfile trades the code for the user's account, which must have a verified email
+func (service *AuthServiceImpl) oauthProfile(provider, code string) (*oauth.Profile, error) {
+	oauthProvider, ok := service.Oauth.Get(provider)
+	if !ok {
+		return nil, e.ErrUnknownOauthProvider
+	}
+
+	token, err := oauthProvider.Exchange(code)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := oauthProvider.GetProfile(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Email == "" || !profile.VerifiedEmail {
+		return nil, e.ErrOauthEmailNotVerified
+	}
+	return profile, nil
+}
+
+func (service *AuthServiceImpl) registerOauthUser(
+	provider string,
+	profile *oauth.Profile,
+	name string,
+	phoneNumber *string,
+	voucherCode string,
+) (user um.User, err error) {
 	latestEvent, err := service.EventRepository.FindLatest()
 	if err != nil {
 		return
 	}
 
-	if user.ID == 0 {
-		if latestEvent.Status != constants.EventRunning {
-			err = e.ErrEventNotRunning
-			return
-		}
+	if latestEvent.Status != constants.EventRunning {
+		err = e.ErrEventNotRunning
+		return
+	}
 
-		//Find user role participant
-		role, err2 := service.UserRoleRepo.FindByName(constants.UserParticipant)
-		if err2 != nil {
-			err = err2
-			return
-		}
+	if err = service.TimelineGuard.CheckPhase(latestEvent.ID, constants.TimelinePhaseRegistration); err != nil {
+		return
+	}
 
-		var avatar *string
-		if request.Picture != "" {
-			avatar = &request.Picture
-		}
+	invoice, err := service.Enrolment.NewInvoice(latestEvent, voucherCode)
+	if err != nil {
+		return
+	}
 
-		registerModel := model.RegisterModel{
-			User: um.User{
-				BaseEntity: common.BaseEntity{
-					CreatedBy: "self",
-					UpdatedBy: "self",
-				},
-				UserRoleID: role.ID,
-				Name:       request.Name,
-				Email:      request.Email,
-				Avatar:     avatar,
-				AuthType:   constants.AuthTypeGoogle,
-				IsActive:   true,
-			},
-			LatestEventID: latestEvent.ID,
-			Invoice: pym.Invoice{
-				EventID:       latestEvent.ID,
-				InvoiceNumber: utils.GenerateInvoiceNumber(),
-				Status:        constants.InvoiceUnpaid,
-				Amount:        latestEvent.RegFee,
-				BaseEntity: common.BaseEntity{
-					CreatedBy: "self",
-					UpdatedBy: "self",
-				},
+	//Find user role participant
+	role, err := service.UserRoleRepo.FindByName(constants.UserParticipant)
+	if err != nil {
+		return
+	}
+
+	var avatar *string
+	if profile.Picture != "" {
+		avatar = &profile.Picture
+	}
+
+	registerModel := model.RegisterModel{
+		User: um.User{
+			BaseEntity: common.BaseEntity{
+				CreatedBy: "self",
+				UpdatedBy: "self",
 			},
-		}
+			UserRoleID:  role.ID,
+			Name:        name,
+			Email:       profile.Email,
+			PhoneNumber: phoneNumber,
+			Avatar:      avatar,
+			AuthType:    provider,
+			IsActive:    true,
+		},
+		LatestEventID: latestEvent.ID,
+		Invoice:       invoice,
+	}
 
-		_, err = service.AuthRepository.Register(registerModel)
-		if err != nil {
-			return
+	if profile.ProfileURL != "" {
+		switch provider {
+		case constants.AuthTypeGithub:
+			registerModel.LinkRepository = &profile.ProfileURL
+		case constants.AuthTypeLinkedin:
+			registerModel.LinkLinkedin = &profile.ProfileURL
 		}
+	}
 
-		user, err = service.UserRepo.FindByEmail(request.Email)
-		if err != nil {
-			return
-		}
-	} else {
-		_, err = service.EventParticipantRepo.FindOneByEventIDAndParticipantID(latestEvent.ID, user.Participant.ID)
-		if err != nil && err != e.ErrDataNotFound {
-			return
-		}
+	if _, err = service.AuthRepository.Register(registerModel); err != nil {
+		return
+	}
 
-		if err != nil && err == e.ErrDataNotFound {
-			evp := evm.EventParticipant{
-				BaseEntity: common.BaseEntity{
-					CreatedAt: time.Now(),
-					CreatedBy: "self",
-					UpdatedAt: time.Now(),
-					UpdatedBy: "self",
-				},
-				EventID:       latestEvent.ID,
-				ParticipantID: user.Participant.ID,
-			}
-			inv := pym.Invoice{
-				BaseEntity: common.BaseEntity{
-					CreatedAt: time.Now(),
-					CreatedBy: "self",
-					UpdatedAt: time.Now(),
-					UpdatedBy: "self",
-				},
-				EventID:       latestEvent.ID,
-				ParticipantID: user.Participant.ID,
-				InvoiceNumber: utils.GenerateInvoiceNumber(),
-				Amount:        latestEvent.RegFee,
-				Status:        constants.InvoiceUnpaid,
-			}
-			if err = service.ParticipantRepo.UpdateRegistrationStatus(
-				user.Participant.ID, false, &evp, &inv,
-			); err != nil {
-				return
-			}
-			user.Participant.IsRegistered = false
-			user.Participant.PaymentStatus = constants.InvoiceUnpaid
-		}
+	return service.UserRepo.FindByEmail(profile.Email)
+}
+
+// authResponse returns the tokens of user, or the challenge instead when the user has to pass a second factor
+// first. Every login hands out its tokens through here so none of them skips the second factor.
+func (service *AuthServiceImpl) authResponse(user um.User, client model.Client) (response model.AuthResponse, err error) {
+	challenge, err := service.TwoFactor.Challenge(user)
+	if err != nil {
+		return
+	}
+	if challenge != nil {
+		response = model.AuthResponse{TwoFactor: challenge}
+		return
+	}
+
+	pair, err := service.Tokens.Issue(user, client)
+	if err != nil {
+		return
+	}
+	return newAuthResponse(user, pair), nil
+}
+
+// loginResponse is authResponse for an existing participant, who is enrolled to the latest event once the
+// login went through. With a second factor that only happens after the code is checked, see TwoFactorService.
+func (service *AuthServiceImpl) loginResponse(
+	user um.User,
+	client model.Client,
+	voucherCode string,
+) (response model.AuthResponse, err error) {
+	challenge, err := service.TwoFactor.Challenge(user)
+	if err != nil {
+		return
+	}
+	if challenge != nil {
+		response = model.AuthResponse{TwoFactor: challenge}
+		return
+	}
+
+	if err = service.Enrolment.JoinLatestEvent(&user, voucherCode); err != nil {
+		return
 	}
 
-	// Generate Token
-	token, err := utils.GenerateToken(user)
+	pair, err := service.Tokens.Issue(user, client)
 	if err != nil {
 		return
 	}
+	return newAuthResponse(user, pair), nil
+}
 
-	response = model.AuthResponse{
-		Token: token,
-		User: um.UserResponse{
-			Id:                      user.ID,
-			FullName:                user.Name,
-			Email:                   user.Email,
-			RoleId:                  user.UserRoleID,
-			RoleName:                user.UserRole.Name,
-			IsRegistrationCompleted: user.Participant.IsRegistered,
-			PaymentStatus:           user.Participant.PaymentStatus,
+// newAuthResponse is what every login answers with, participants also get their registration state
+func newAuthResponse(user um.User, pair model.TokenPair) model.AuthResponse {
+	response := model.AuthResponse{
+		TokenPair: pair,
+		User: &um.UserResponse{
+			Id:       user.ID,
+			FullName: user.Name,
+			Email:    user.Email,
+			RoleId:   user.UserRoleID,
+			RoleName: user.UserRole.Name,
 		},
 	}
-	return
+	if user.Participant != nil {
+		response.User.IsRegistrationCompleted = user.Participant.IsRegistered
+		response.User.PaymentStatus = user.Participant.PaymentStatus
+	}
+	return response
 }
 
-func (service *AuthServiceImpl) RegisterByGoogle(request model.RegisterByGoogleRequest) (model.AuthResponse, error) {
+func (service *AuthServiceImpl) RegisterByGoogle(
+	request model.RegisterByGoogleRequest,
+	client model.Client,
+) (model.AuthResponse, error) {
 	// Validate Token
 	payload, err := idtoken.Validate(context.Background(), request.IdToken, os.Getenv("GOOGLE_CLIENT_ID"))
 
@@ -416,23 +520,13 @@ func (service *AuthServiceImpl) RegisterByGoogle(request model.RegisterByGoogleR
 		return model.AuthResponse{}, err
 	}
 
-	// Generate Token
-	token, err := utils.GenerateToken(user)
-	if err != nil {
-		return model.AuthResponse{}, err
-	}
-
-	return model.AuthResponse{
-		Token: token,
-		User: um.UserResponse{
-			Id:       user.ID,
-			FullName: user.Name,
-			Email:    user.Email,
-		},
-	}, nil
+	return service.authResponse(newUser, client)
 }
 
-func (service *AuthServiceImpl) LoginByGoogle(request model.LoginByGoogleRequest) (model.AuthResponse, error) {
+func (service *AuthServiceImpl) LoginByGoogle(
+	request model.LoginByGoogleRequest,
+	client model.Client,
+) (model.AuthResponse, error) {
 	// Validate Token
 	payload, err := idtoken.Validate(context.Background(), request.IdToken, os.Getenv("GOOGLE_CLIENT_ID"))
 	if err != nil {
@@ -456,21 +550,7 @@ func (service *AuthServiceImpl) LoginByGoogle(request model.LoginByGoogleRequest
 		return model.AuthResponse{}, e.ErrUserIsNotActivated
 	}
 
-	// Generate Token
-	token, err := utils.GenerateToken(user)
-	if err != nil {
-		return model.AuthResponse{}, err
-	}
-
-	// Return nil Error
-	return model.AuthResponse{
-		Token: token,
-		User: um.UserResponse{
-			Id:       user.ID,
-			FullName: user.Name,
-			Email:    user.Email,
-		},
-	}, nil
+	return service.authResponse(user, client)
 }
 
 func (service *AuthServiceImpl) VerifyEmail(request model.VerifyEmailRequest) error {
@@ -482,6 +562,10 @@ func (service *AuthServiceImpl) VerifyEmail(request model.VerifyEmailRequest) er
 		return err
 	}
 
+	if err = checkVerificationCode(verifCode, constants.VerifCodeEmail); err != nil {
+		return err
+	}
+
 	if err = service.VerificationCodeRepo.Delete(verifCode.ID, verifCode.UserID); err != nil {
 		return err
 	}
@@ -490,6 +574,10 @@ func (service *AuthServiceImpl) VerifyEmail(request model.VerifyEmailRequest) er
 }
 
 func (service *AuthServiceImpl) SendVerificationCode(request model.SendVerificationCodeRequest) error {
+	if request.Type != constants.VerifCodeEmail && request.Type != constants.VerifCodeResetPassword {
+		return e.ErrInvalidVerificationCodeType
+	}
+
 	user, err := service.UserRepo.FindByEmail(request.Email)
 	if err != nil {
 		if err == e.ErrEmailNotRegistered {
@@ -503,34 +591,22 @@ func (service *AuthServiceImpl) SendVerificationCode(request model.SendVerificat
 		return e.ErrCantResetPassword
 	}
 
-	//check if verification code exists
-	existingVerifCode, err := service.VerificationCodeRepo.FindByUserIdAndCodeType(user.ID, request.Type)
-	if err != nil && !errors.Is(err, e.ErrDataNotFound) {
-		return err
+	// every request gets a fresh code, the ones sent before stop working
+	code := utils.GenerateUuid()
+	verifCode := model.VerificationCode{
+		UserID:   user.ID,
+		Code:     code,
+		Type:     request.Type,
+		ExpireAt: time.Now().Add(verificationCodeTTL(request.Type)),
+		BaseEntity: common.BaseEntity{
+			CreatedBy: user.Email,
+			UpdatedBy: user.Email,
+		},
 	}
-
-	code := existingVerifCode.Code
-	if errors.Is(err, e.ErrDataNotFound) {
-		code = utils.GenerateUuid()
-		verifCode := model.VerificationCode{
-			UserID: user.ID,
-			Code:   code,
-			Type:   request.Type,
-			BaseEntity: common.BaseEntity{
-				CreatedBy: user.Email,
-				UpdatedBy: user.Email,
-			},
-		}
-		err = service.VerificationCodeRepo.Save(verifCode)
-		if err != nil {
-			return err
-		}
+	if err = service.VerificationCodeRepo.Issue(verifCode); err != nil {
+		return err
 	}
 
-	//TODO:
-	// - send email verification [OK]
-	// - use goroutine/task queue
-
 	templateData := email.TemplateData{
 		Name:        user.Name,
 		SenderEmail: "sagarahackathon@gmail.com",
@@ -556,16 +632,14 @@ func (service *AuthServiceImpl) SendVerificationCode(request model.SendVerificat
 
 	templateData.Title = emailSubject
 	r := email.NewRequest([]string{user.Email}, emailSubject, "")
-	if err = r.ParseTemplate("./src/utils/email/template_email.html", templateData); err == nil {
-		ok, err2 := r.SendEmail()
-		if !ok || err2 != nil {
-			fmt.Println(err2)
-			return e.ErrFailedSendEmail
-		}
-	} else {
+	if err = r.ParseTemplate(email.TemplateVerificationCode, templateData); err != nil {
 		return e.ErrFailedParseEmailTemplate
 	}
 
+	if err = service.Mailer.Send(*r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -578,15 +652,29 @@ func (service *AuthServiceImpl) ValidateVerificationCode(request model.ValidateV
 		return err
 	}
 
-	//TODO: check code expiration [TEST]
-	if verifCode.ExpireAt != nil && verifCode.ExpireAt.After(time.Now()) {
-		return e.ErrInvalidVerificationCode
-	}
-
-	return nil
+	return checkVerificationCode(verifCode, verifCode.Type)
 }
 
+// ForgotPassword is the older name of ResetPassword, kept for clients that don't send confirm_password
 func (service *AuthServiceImpl) ForgotPassword(request model.ForgotPasswordRequest) error {
+	return service.ResetPassword(model.ResetPasswordRequest{
+		Email:           request.Email,
+		Code:            request.Code,
+		NewPassword:     request.NewPassword,
+		ConfirmPassword: request.NewPassword,
+	})
+}
+
+// ResetPassword sets the password with a reset-password or set-password code, the code can be used once
+// and every session of the user is signed out
+func (service *AuthServiceImpl) ResetPassword(request model.ResetPasswordRequest) error {
+	if request.NewPassword != request.ConfirmPassword {
+		return e.ErrConfirmPasswordNotSame
+	}
+	if err := utils.ValidatePassword(request.NewPassword); err != nil {
+		return err
+	}
+
 	//check verification code
 	verifCode, err := service.VerificationCodeRepo.FindByCode(request.Code)
 	if err != nil {
@@ -596,7 +684,19 @@ func (service *AuthServiceImpl) ForgotPassword(request model.ForgotPasswordReque
 		return err
 	}
 
-	if verifCode.Type != constants.VerifCodeResetPassword {
+	if err = checkVerificationCode(verifCode, constants.VerifCodeResetPassword, constants.VerifCodeSetPassword); err != nil {
+		return err
+	}
+
+	//the code has to belong to the email the request is made for
+	user, err := service.UserRepo.FindByID(verifCode.UserID)
+	if err != nil {
+		if errors.Is(err, e.ErrDataNotFound) {
+			return e.ErrInvalidVerificationCode
+		}
+		return err
+	}
+	if !strings.EqualFold(user.Email, strings.TrimSpace(request.Email)) {
 		return e.ErrInvalidVerificationCode
 	}
 
@@ -607,10 +707,40 @@ func (service *AuthServiceImpl) ForgotPassword(request model.ForgotPasswordReque
 	}
 
 	//update password
-	err = service.UserRepo.UpdatePassword(verifCode.UserID, hashed, request.Code)
-	if err != nil {
-		return err
+	return service.UserRepo.UpdatePassword(verifCode.UserID, hashed, request.Code)
+}
+
+// verificationCodeTTL is VERIFY_EMAIL_CODE_TTL (24h) or RESET_PASSWORD_CODE_TTL (1h)
+func verificationCodeTTL(codeType string) time.Duration {
+	if codeType == constants.VerifCodeResetPassword {
+		return helper.GetEnvDuration("RESET_PASSWORD_CODE_TTL", time.Hour)
 	}
+	return helper.GetEnvDuration("VERIFY_EMAIL_CODE_TTL", 24*time.Hour)
+}
 
+func checkVerificationCode(verifCode model.VerificationCode, codeTypes ...string) error {
+	valid := false
+	for _, codeType := range codeTypes {
+		if verifCode.Type == codeType {
+			valid = true
+		}
+	}
+	if !valid {
+		return e.ErrInvalidVerificationCode
+	}
+	if !verifCode.ExpireAt.After(time.Now()) {
+		return e.ErrVerificationCodeExpired
+	}
 	return nil
 }
+
+func (service *AuthServiceImpl) RefreshToken(
+	request model.RefreshTokenRequest,
+	client model.Client,
+) (model.TokenPair, error) {
+	return service.Tokens.Refresh(request, client)
+}
+
+func (service *AuthServiceImpl) Logout(request model.RefreshTokenRequest) error {
+	return service.Tokens.Logout(request)
+}
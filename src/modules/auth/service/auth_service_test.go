@@ -0,0 +1,262 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/modules/auth/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/oauth"
+	"testing"
+	"time"
+)
+
+func (repository *fakeUserRepository) FindByEmail(email string) (um.User, error) {
+	if email != repository.user.Email {
+		return um.User{}, e.ErrEmailNotRegistered
+	}
+	return repository.user, nil
+}
+
+// fakeProvider signs in every code as profile
+type fakeProvider struct {
+	profile oauth.Profile
+}
+
+func (fakeProvider) Name() string {
+	return constants.AuthTypeGithub
+}
+
+func (fakeProvider) Exchange(string) (*oauth.Token, error) {
+	return &oauth.Token{AccessToken: "token"}, nil
+}
+
+func (provider fakeProvider) GetProfile(*oauth.Token) (*oauth.Profile, error) {
+	return &provider.profile, nil
+}
+
+func TestOauthCallbackInactiveUser(t *testing.T) {
+	user := um.User{Email: "jane@example.com", AuthType: constants.AuthTypeGithub, Participant: &um.Participant{}}
+	user.ID = 3
+	service := &AuthServiceImpl{
+		UserRepo: &fakeUserRepository{user: user},
+		Oauth: oauth.Providers{
+			constants.AuthTypeGithub: fakeProvider{profile: oauth.Profile{Email: user.Email, VerifiedEmail: true}},
+		},
+	}
+
+	if _, err := service.OauthCallback(constants.AuthTypeGithub, "code", model.Client{}); err != e.ErrUserIsNotActivated {
+		t.Errorf("callback of an inactive user returned %v, want %v", err, e.ErrUserIsNotActivated)
+	}
+}
+
+func TestOauthCallbackUnknownProvider(t *testing.T) {
+	service := &AuthServiceImpl{Oauth: oauth.Providers{}}
+
+	if _, err := service.OauthCallback("myspace", "code", model.Client{}); err != e.ErrUnknownOauthProvider {
+		t.Errorf("callback of an unknown provider returned %v, want %v", err, e.ErrUnknownOauthProvider)
+	}
+}
+
+// fakeVerificationCodeRepository keeps the issued codes, a new code drops the ones of the same user and type
+type fakeVerificationCodeRepository struct {
+	repository.VerificationCodeRepository
+	codes []model.VerificationCode
+}
+
+func (repository *fakeVerificationCodeRepository) Issue(vc model.VerificationCode) error {
+	kept := repository.codes[:0]
+	for _, code := range repository.codes {
+		if code.UserID != vc.UserID || code.Type != vc.Type {
+			kept = append(kept, code)
+		}
+	}
+	vc.ID = uint(len(repository.codes) + 100)
+	repository.codes = append(kept, vc)
+	return nil
+}
+
+func (repository *fakeVerificationCodeRepository) FindByCode(code string) (model.VerificationCode, error) {
+	for _, v := range repository.codes {
+		if v.Code == code {
+			return v, nil
+		}
+	}
+	return model.VerificationCode{}, e.ErrDataNotFound
+}
+
+// fakePasswordUserRepository uses up the code when the password is set, as the repository does
+type fakePasswordUserRepository struct {
+	*fakeUserRepository
+	codes    *fakeVerificationCodeRepository
+	password string
+}
+
+func (repository *fakePasswordUserRepository) UpdatePassword(userID uint, password, verifCode string) error {
+	kept := repository.codes.codes[:0]
+	found := false
+	for _, v := range repository.codes.codes {
+		if v.Code == verifCode && v.UserID == userID {
+			found = true
+			continue
+		}
+		kept = append(kept, v)
+	}
+	repository.codes.codes = kept
+	if !found {
+		return e.ErrInvalidVerificationCode
+	}
+	repository.password = password
+	return nil
+}
+
+type fakeCodeMailer struct {
+	sent int
+}
+
+func (mailer *fakeCodeMailer) Send(email.Request) error {
+	mailer.sent++
+	return nil
+}
+
+func newResetPasswordTest(codes ...model.VerificationCode) (*AuthServiceImpl, *fakePasswordUserRepository) {
+	user := um.User{Email: "jane@example.com", AuthType: constants.AuthTypeRegular, UserRole: &um.UserRole{Name: constants.UserParticipant}}
+	user.ID = 3
+	codeRepository := &fakeVerificationCodeRepository{codes: codes}
+	users := &fakePasswordUserRepository{fakeUserRepository: &fakeUserRepository{user: user}, codes: codeRepository}
+	service := &AuthServiceImpl{UserRepo: users, VerificationCodeRepo: codeRepository, Mailer: &fakeCodeMailer{}}
+	return service, users
+}
+
+func resetCode(code string, expireAt time.Time) model.VerificationCode {
+	return model.VerificationCode{UserID: 3, Code: code, Type: constants.VerifCodeResetPassword, ExpireAt: expireAt}
+}
+
+func resetPassword(service *AuthServiceImpl, email, code string) error {
+	return service.ResetPassword(model.ResetPasswordRequest{
+		Email:           email,
+		Code:            code,
+		NewPassword:     "violet-kettle-42",
+		ConfirmPassword: "violet-kettle-42",
+	})
+}
+
+func TestResetPasswordExpiredCode(t *testing.T) {
+	service, users := newResetPasswordTest(resetCode("expired", time.Now().Add(-time.Minute)))
+
+	if err := resetPassword(service, "jane@example.com", "expired"); err != e.ErrVerificationCodeExpired {
+		t.Fatalf("reset with an expired code returned %v, want %v", err, e.ErrVerificationCodeExpired)
+	}
+	if users.password != "" {
+		t.Errorf("reset with an expired code set the password")
+	}
+}
+
+func TestResetPasswordCodeWorksOnce(t *testing.T) {
+	service, users := newResetPasswordTest(resetCode("reset", time.Now().Add(time.Hour)))
+
+	if err := resetPassword(service, "Jane@Example.com ", "reset"); err != nil {
+		t.Fatalf("reset returned %v", err)
+	}
+	if users.password == "" || users.password == "violet-kettle-42" {
+		t.Errorf("reset didn't set the hashed password")
+	}
+
+	if err := resetPassword(service, "jane@example.com", "reset"); err != e.ErrInvalidVerificationCode {
+		t.Errorf("second reset with the same code returned %v, want %v", err, e.ErrInvalidVerificationCode)
+	}
+}
+
+func TestResetPasswordCodeOfAnotherEmail(t *testing.T) {
+	service, users := newResetPasswordTest(resetCode("reset", time.Now().Add(time.Hour)))
+
+	if err := resetPassword(service, "john@example.com", "reset"); err != e.ErrInvalidVerificationCode {
+		t.Fatalf("reset with the code of another email returned %v, want %v", err, e.ErrInvalidVerificationCode)
+	}
+	if users.password != "" {
+		t.Errorf("reset with the code of another email set the password")
+	}
+}
+
+func TestResetPasswordNewCodeInvalidatesOld(t *testing.T) {
+	service, users := newResetPasswordTest()
+	request := model.SendVerificationCodeRequest{Type: constants.VerifCodeResetPassword, Email: "jane@example.com"}
+
+	if err := service.SendVerificationCode(request); err != nil {
+		t.Fatalf("sending the first code returned %v", err)
+	}
+	oldCode := users.codes.codes[0].Code
+	if err := service.SendVerificationCode(request); err != nil {
+		t.Fatalf("sending the second code returned %v", err)
+	}
+	newCode := users.codes.codes[0].Code
+
+	if err := resetPassword(service, "jane@example.com", oldCode); err != e.ErrInvalidVerificationCode {
+		t.Errorf("reset with the replaced code returned %v, want %v", err, e.ErrInvalidVerificationCode)
+	}
+	if err := resetPassword(service, "jane@example.com", newCode); err != nil {
+		t.Errorf("reset with the new code returned %v", err)
+	}
+}
+
+func TestSendVerificationCodeRejectsSetPassword(t *testing.T) {
+	user := um.User{Email: "imported@example.com", AuthType: constants.AuthTypeRegular}
+	user.ID = 4
+	codes := &fakeVerificationCodeRepository{codes: []model.VerificationCode{
+		{UserID: user.ID, Code: "invitation", Type: constants.VerifCodeSetPassword},
+	}}
+	service := &AuthServiceImpl{UserRepo: &fakeUserRepository{user: user}, VerificationCodeRepo: codes}
+
+	request := model.SendVerificationCodeRequest{Type: constants.VerifCodeSetPassword, Email: user.Email}
+	if err := service.SendVerificationCode(request); err != e.ErrInvalidVerificationCodeType {
+		t.Fatalf("sending a set-password code returned %v, want %v", err, e.ErrInvalidVerificationCodeType)
+	}
+	if len(codes.codes) != 1 || codes.codes[0].Code != "invitation" {
+		t.Errorf("sending a set-password code replaced the invitation code")
+	}
+}
+
+// fakeChallengeService asks every user for a second factor
+type fakeChallengeService struct {
+	TwoFactorService
+}
+
+func (fakeChallengeService) Challenge(um.User) (*model.TwoFactorChallenge, error) {
+	return &model.TwoFactorChallenge{ChallengeToken: "challenge"}, nil
+}
+
+type fakeEnrolmentService struct {
+	EnrolmentService
+	joined int
+}
+
+func (service *fakeEnrolmentService) JoinLatestEvent(*um.User, string) error {
+	service.joined++
+	return nil
+}
+
+func TestOauthCallbackTwoFactor(t *testing.T) {
+	user := um.User{Email: "jane@example.com", AuthType: constants.AuthTypeGithub, IsActive: true, Participant: &um.Participant{}}
+	user.ID = 3
+	enrolment := &fakeEnrolmentService{}
+	service := &AuthServiceImpl{
+		UserRepo: &fakeUserRepository{user: user},
+		Oauth: oauth.Providers{
+			constants.AuthTypeGithub: fakeProvider{profile: oauth.Profile{Email: user.Email, VerifiedEmail: true}},
+		},
+		TwoFactor: fakeChallengeService{},
+		Enrolment: enrolment,
+	}
+
+	response, err := service.OauthCallback(constants.AuthTypeGithub, "code", model.Client{})
+	if err != nil {
+		t.Fatalf("callback returned %v", err)
+	}
+	if response.TwoFactor == nil || response.Token != "" {
+		t.Errorf("callback of a user with two factor returned %+v, want only the challenge", response)
+	}
+	if enrolment.joined != 0 {
+		t.Errorf("callback enrolled the participant before the second factor")
+	}
+}
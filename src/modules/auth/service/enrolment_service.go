@@ -0,0 +1,136 @@
+package service
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	pym "be-sagara-hackathon/src/modules/payment/model"
+	prs "be-sagara-hackathon/src/modules/promotion/service"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"time"
+)
+
+// EnrolmentService signs participants up to the latest event. Logins only enrol once every factor
+// has been checked, so it is shared by the password, oauth and two factor logins.
+type EnrolmentService interface {
+	JoinLatestEvent(user *um.User, voucherCode string) error
+	NewInvoice(event evm.Event, voucherCode string) (pym.Invoice, error)
+}
+
+type EnrolmentServiceImpl struct {
+	EventRepository      evr.EventRepository
+	EventParticipantRepo evr.EventParticipantRepository
+	ParticipantRepo      ur.ParticipantRepository
+	TimelineGuard        evs.EventTimelineGuard
+	Pricing              prs.PricingService
+}
+
+func NewEnrolmentService(
+	eventRepo evr.EventRepository,
+	eventParticipantRepo evr.EventParticipantRepository,
+	participantRepo ur.ParticipantRepository,
+	timelineGuard evs.EventTimelineGuard,
+	pricing prs.PricingService,
+) EnrolmentService {
+	return &EnrolmentServiceImpl{
+		EventRepository:      eventRepo,
+		EventParticipantRepo: eventParticipantRepo,
+		ParticipantRepo:      participantRepo,
+		TimelineGuard:        timelineGuard,
+		Pricing:              pricing,
+	}
+}
+
+// JoinLatestEvent enrolls an existing participant to the latest event on login.
+// It does nothing for staff, when the participant already joined or the registration phase is closed.
+func (service *EnrolmentServiceImpl) JoinLatestEvent(user *um.User, voucherCode string) error {
+	if user.Participant == nil {
+		return nil
+	}
+
+	latestEvent, err := service.EventRepository.FindLatest()
+	if err != nil {
+		return err
+	}
+
+	_, err = service.EventParticipantRepo.FindOneByEventIDAndParticipantID(latestEvent.ID, user.Participant.ID)
+	if err == nil {
+		return nil
+	}
+	if err != e.ErrDataNotFound {
+		return err
+	}
+
+	if err = service.TimelineGuard.CheckPhase(latestEvent.ID, constants.TimelinePhaseRegistration); err != nil {
+		if err == e.ErrOutsideTimelinePhase {
+			return nil
+		}
+		return err
+	}
+
+	evp := evm.EventParticipant{
+		BaseEntity: common.BaseEntity{
+			CreatedAt: time.Now(),
+			CreatedBy: "self",
+			UpdatedAt: time.Now(),
+			UpdatedBy: "self",
+		},
+		EventID:       latestEvent.ID,
+		ParticipantID: user.Participant.ID,
+	}
+	// the voucher was checked when the participant registered, one that ran out since doesn't block the login
+	inv, err := service.NewInvoice(latestEvent, voucherCode)
+	if err == e.ErrVoucherInvalid || err == e.ErrVoucherUsedUp {
+		inv, err = service.NewInvoice(latestEvent, "")
+	}
+	if err != nil {
+		return err
+	}
+	inv.ParticipantID = user.Participant.ID
+	if err = service.ParticipantRepo.UpdateRegistrationStatus(
+		user.Participant.ID, false, &evp, &inv,
+	); err != nil {
+		return err
+	}
+	user.Participant.IsRegistered = false
+	user.Participant.PaymentStatus = inv.Status
+	return nil
+}
+
+// NewInvoice builds the registration invoice of event, priced by the open fee tier and the voucher if any.
+// A registration that costs nothing is paid right away.
+func (service *EnrolmentServiceImpl) NewInvoice(event evm.Event, voucherCode string) (invoice pym.Invoice, err error) {
+	price, err := service.Pricing.Quote(event, voucherCode)
+	if err != nil {
+		return
+	}
+
+	invoice = pym.Invoice{
+		BaseEntity: common.BaseEntity{
+			CreatedAt: time.Now(),
+			CreatedBy: "self",
+			UpdatedAt: time.Now(),
+			UpdatedBy: "self",
+		},
+		EventID:        event.ID,
+		InvoiceNumber:  utils.GenerateInvoiceNumber(),
+		FeeTierID:      price.FeeTierID,
+		VoucherID:      price.VoucherID,
+		BaseAmount:     price.BaseAmount,
+		DiscountAmount: price.DiscountAmount,
+		Amount:         price.Amount,
+		Status:         constants.InvoiceUnpaid,
+	}
+	if invoice.Amount == 0 {
+		invoice.Status = constants.InvoicePaid
+		invoice.ApprovedAt = helper.ReferTime(time.Now())
+		invoice.ApprovedBy = helper.ReferString("system")
+	}
+	return
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/modules/auth/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+type TokenService interface {
+	Issue(user um.User, client model.Client) (pair model.TokenPair, err error)
+	Refresh(request model.RefreshTokenRequest, client model.Client) (pair model.TokenPair, err error)
+	Logout(request model.RefreshTokenRequest) error
+}
+
+type TokenServiceImpl struct {
+	Repository  repository.RefreshTokenRepository
+	SessionRepo ur.UserSessionRepository
+	UserRepo    ur.UserRepository
+	Audit       ads.Recorder
+}
+
+func NewTokenService(
+	repository repository.RefreshTokenRepository,
+	sessionRepo ur.UserSessionRepository,
+	userRepo ur.UserRepository,
+	audit ads.Recorder,
+) TokenService {
+	return &TokenServiceImpl{Repository: repository, SessionRepo: sessionRepo, UserRepo: userRepo, Audit: audit}
+}
+
+// Issue starts a new session for the user and returns its first access and refresh token.
+func (service *TokenServiceImpl) Issue(user um.User, client model.Client) (pair model.TokenPair, err error) {
+	pair.RefreshToken = utils.GenerateSecureToken(32)
+	now := time.Now()
+	token := newRefreshToken(user, pair.RefreshToken, now)
+	session := um.UserSession{
+		BaseEntity: token.BaseEntity,
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		LastSeenAt: now,
+		ExpiresAt:  token.ExpiresAt,
+	}
+	if err = service.Repository.Start(&session, &token); err != nil {
+		return
+	}
+
+	pair.Token, pair.ExpiresAt, err = utils.GenerateToken(user, session.ID)
+	return
+}
+
+// Refresh trades a refresh token for a new pair of the same session. Presenting a token that has
+// already been traded revokes the session, the legitimate user has to log in again.
+func (service *TokenServiceImpl) Refresh(
+	request model.RefreshTokenRequest,
+	client model.Client,
+) (pair model.TokenPair, err error) {
+	token, err := service.Repository.FindByHash(hashToken(request.RefreshToken))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			err = e.ErrInvalidRefreshToken
+		}
+		return
+	}
+
+	if token.RevokedAt != nil {
+		if err = service.revokeSession(token.SessionID); err != nil {
+			return
+		}
+		err = e.ErrInvalidRefreshToken
+		return
+	}
+	now := time.Now()
+	if token.Session.RevokedAt != nil || now.After(token.ExpiresAt) {
+		err = e.ErrInvalidRefreshToken
+		return
+	}
+
+	user, err := service.UserRepo.FindByID(token.UserID)
+	if err != nil {
+		return
+	}
+	if !user.IsActive {
+		if err = service.revokeSession(token.SessionID); err != nil {
+			return
+		}
+		err = e.ErrUserIsNotActivated
+		return
+	}
+
+	pair.RefreshToken = utils.GenerateSecureToken(32)
+	next := newRefreshToken(user, pair.RefreshToken, now)
+	next.SessionID = token.SessionID
+	rotated, err := service.Repository.Rotate(token.ID, &next, client)
+	if err != nil {
+		return
+	}
+	if !rotated {
+		if err = service.revokeSession(token.SessionID); err != nil {
+			return
+		}
+		err = e.ErrInvalidRefreshToken
+		return
+	}
+
+	pair.Token, pair.ExpiresAt, err = utils.GenerateToken(user, token.SessionID)
+	return
+}
+
+func (service *TokenServiceImpl) Logout(request model.RefreshTokenRequest) error {
+	token, err := service.Repository.FindByHash(hashToken(request.RefreshToken))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			return e.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	return service.revokeSession(token.SessionID)
+}
+
+// revokeSession ends the session and writes it to the audit log
+func (service *TokenServiceImpl) revokeSession(sessionID uint) error {
+	if err := service.SessionRepo.Revoke(sessionID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(context.Background(), constants.AuditRevoke, constants.AuditEntitySession, sessionID, nil, nil)
+	return nil
+}
+
+func newRefreshToken(user um.User, plain string, now time.Time) model.RefreshToken {
+	return model.RefreshToken{
+		BaseEntity: common.BaseEntity{
+			CreatedBy: user.Email,
+			UpdatedBy: user.Email,
+		},
+		UserID:    user.ID,
+		TokenHash: hashToken(plain),
+		ExpiresAt: now.Add(helper.GetEnvDuration("REFRESH_TOKEN_TTL", 720*time.Hour)),
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/modules/auth/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"testing"
+	"time"
+)
+
+// fakeRefreshTokenRepository keeps the tokens by hash and the revoked sessions
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+	tokens   map[string]*model.RefreshToken
+	sessions *fakeUserSessionRepository
+	// lostRace makes Rotate report the old token as revoked by a concurrent refresh
+	lostRace bool
+}
+
+func (repository *fakeRefreshTokenRepository) FindByHash(tokenHash string) (model.RefreshToken, error) {
+	token, ok := repository.tokens[tokenHash]
+	if !ok {
+		return model.RefreshToken{}, e.ErrDataNotFound
+	}
+
+	found := *token
+	found.Session.ID = token.SessionID
+	if repository.sessions.revoked[token.SessionID] {
+		now := time.Now()
+		found.Session.RevokedAt = &now
+	}
+	return found, nil
+}
+
+func (repository *fakeRefreshTokenRepository) Rotate(oldID uint, token *model.RefreshToken, _ model.Client) (bool, error) {
+	if repository.lostRace {
+		return false, nil
+	}
+
+	for _, old := range repository.tokens {
+		if old.ID == oldID {
+			now := time.Now()
+			token.ID = uint(len(repository.tokens) + 1)
+			old.RevokedAt = &now
+			old.ReplacedByID = &token.ID
+		}
+	}
+	saved := *token
+	repository.tokens[token.TokenHash] = &saved
+	return true, nil
+}
+
+type fakeUserSessionRepository struct {
+	ur.UserSessionRepository
+	revoked map[uint]bool
+}
+
+func (repository *fakeUserSessionRepository) Revoke(sessionID uint) error {
+	repository.revoked[sessionID] = true
+	return nil
+}
+
+func newTestTokenService(t *testing.T, user um.User, plain string) (*TokenServiceImpl, *fakeRefreshTokenRepository) {
+	t.Setenv("API_JWT_SECRET", "test")
+
+	token := newRefreshToken(user, plain, time.Now())
+	token.ID = 1
+	token.SessionID = 7
+	sessions := &fakeUserSessionRepository{revoked: map[uint]bool{}}
+	tokens := &fakeRefreshTokenRepository{
+		tokens:   map[string]*model.RefreshToken{token.TokenHash: &token},
+		sessions: sessions,
+	}
+
+	return &TokenServiceImpl{
+		Repository:  tokens,
+		SessionRepo: sessions,
+		UserRepo:    &fakeUserRepository{user: user},
+		Audit:       &fakeRecorder{},
+	}, tokens
+}
+
+func refreshUser() um.User {
+	user := um.User{Email: "jane@example.com", IsActive: true, UserRole: &um.UserRole{Name: constants.UserParticipant}}
+	user.ID = 3
+	return user
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	service, tokens := newTestTokenService(t, refreshUser(), "first")
+
+	pair, err := service.Refresh(model.RefreshTokenRequest{RefreshToken: "first"}, model.Client{})
+	if err != nil {
+		t.Fatalf("refresh returned %v", err)
+	}
+	if pair.Token == "" || pair.RefreshToken == "" || pair.RefreshToken == "first" {
+		t.Fatalf("refresh returned %+v, want a new access and refresh token", pair)
+	}
+
+	old := tokens.tokens[hashToken("first")]
+	if old.RevokedAt == nil || old.ReplacedByID == nil {
+		t.Errorf("old token = %+v, want it revoked and replaced", old)
+	}
+	next := tokens.tokens[hashToken(pair.RefreshToken)]
+	if next == nil || next.SessionID != 7 {
+		t.Fatalf("new token = %+v, want it saved in session 7", next)
+	}
+
+	if _, err = service.Refresh(model.RefreshTokenRequest{RefreshToken: pair.RefreshToken}, model.Client{}); err != nil {
+		t.Errorf("refresh with the new token returned %v", err)
+	}
+}
+
+func TestRefreshReuseRevokesSession(t *testing.T) {
+	service, tokens := newTestTokenService(t, refreshUser(), "first")
+
+	pair, err := service.Refresh(model.RefreshTokenRequest{RefreshToken: "first"}, model.Client{})
+	if err != nil {
+		t.Fatalf("refresh returned %v", err)
+	}
+
+	if _, err = service.Refresh(model.RefreshTokenRequest{RefreshToken: "first"}, model.Client{}); err != e.ErrInvalidRefreshToken {
+		t.Fatalf("refresh with a traded token returned %v, want %v", err, e.ErrInvalidRefreshToken)
+	}
+	if !tokens.sessions.revoked[7] {
+		t.Error("reusing a traded token did not revoke the session")
+	}
+	if records := service.Audit.(*fakeRecorder).records; len(records) != 1 || records[0] != "session revoke" {
+		t.Errorf("audit records = %v, want the session revoke", records)
+	}
+
+	if _, err = service.Refresh(model.RefreshTokenRequest{RefreshToken: pair.RefreshToken}, model.Client{}); err != e.ErrInvalidRefreshToken {
+		t.Errorf("refresh with the token of a revoked session returned %v, want %v", err, e.ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshLostRaceRevokesSession(t *testing.T) {
+	service, tokens := newTestTokenService(t, refreshUser(), "first")
+	tokens.lostRace = true
+
+	if _, err := service.Refresh(model.RefreshTokenRequest{RefreshToken: "first"}, model.Client{}); err != e.ErrInvalidRefreshToken {
+		t.Fatalf("refresh of a token traded concurrently returned %v, want %v", err, e.ErrInvalidRefreshToken)
+	}
+	if !tokens.sessions.revoked[7] {
+		t.Error("a concurrent refresh with the same token did not revoke the session")
+	}
+}
+
+func TestRefreshExpiredToken(t *testing.T) {
+	service, tokens := newTestTokenService(t, refreshUser(), "first")
+	tokens.tokens[hashToken("first")].ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := service.Refresh(model.RefreshTokenRequest{RefreshToken: "first"}, model.Client{}); err != e.ErrInvalidRefreshToken {
+		t.Errorf("refresh with an expired token returned %v, want %v", err, e.ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshInactiveUser(t *testing.T) {
+	user := refreshUser()
+	user.IsActive = false
+	service, tokens := newTestTokenService(t, user, "first")
+
+	if _, err := service.Refresh(model.RefreshTokenRequest{RefreshToken: "first"}, model.Client{}); err != e.ErrUserIsNotActivated {
+		t.Fatalf("refresh of an inactive user returned %v, want %v", err, e.ErrUserIsNotActivated)
+	}
+	if !tokens.sessions.revoked[7] {
+		t.Error("refresh of an inactive user did not revoke the session")
+	}
+}
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/modules/auth/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"be-sagara-hackathon/src/utils/totp"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	recoveryCodeCount = 10
+	// defaultMaxTwoFactorAttempts is the number of wrong codes a challenge takes before it is revoked
+	defaultMaxTwoFactorAttempts = 5
+)
+
+type TwoFactorService interface {
+	Challenge(user um.User) (challenge *model.TwoFactorChallenge, err error)
+	Login(request model.TwoFactorLoginRequest, client model.Client) (model.AuthResponse, error)
+	SetupByChallenge(request model.TwoFactorChallengeRequest) (model.TwoFactorSetup, error)
+	ActivateByChallenge(request model.TwoFactorLoginRequest, client model.Client) (model.AuthResponse, error)
+	GetStatus(ctx context.Context) (model.TwoFactorStatus, error)
+	Setup(ctx context.Context) (model.TwoFactorSetup, error)
+	Enable(ctx context.Context, request model.TwoFactorCodeRequest) (model.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, request model.TwoFactorCodeRequest) error
+	RegenerateRecoveryCodes(
+		ctx context.Context,
+		request model.TwoFactorCodeRequest,
+	) (model.RecoveryCodesResponse, error)
+}
+
+type TwoFactorServiceImpl struct {
+	Repository repository.TwoFactorRepository
+	UserRepo   ur.UserRepository
+	Tokens     TokenService
+	Enrolment  EnrolmentService
+	Audit      ads.Recorder
+}
+
+func NewTwoFactorService(
+	repository repository.TwoFactorRepository,
+	userRepo ur.UserRepository,
+	tokens TokenService,
+	enrolment EnrolmentService,
+	audit ads.Recorder,
+) TwoFactorService {
+	return &TwoFactorServiceImpl{
+		Repository: repository,
+		UserRepo:   userRepo,
+		Tokens:     tokens,
+		Enrolment:  enrolment,
+		Audit:      audit,
+	}
+}
+
+// Challenge returns the second step the login of user needs, or nil when the password is enough
+func (service *TwoFactorServiceImpl) Challenge(user um.User) (challenge *model.TwoFactorChallenge, err error) {
+	enabled, err := service.isEnabled(user.ID)
+	if err != nil {
+		return
+	}
+	if !enabled && !isTwoFactorRequired(user) {
+		return nil, nil
+	}
+
+	token, expired, err := utils.GenerateChallengeToken(
+		user.ID,
+		helper.GetEnvDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+	)
+	if err != nil {
+		return
+	}
+	return &model.TwoFactorChallenge{
+		ChallengeToken: token,
+		ExpiresAt:      expired,
+		SetupRequired:  !enabled,
+	}, nil
+}
+
+func (service *TwoFactorServiceImpl) Login(
+	request model.TwoFactorLoginRequest,
+	client model.Client,
+) (response model.AuthResponse, err error) {
+	user, err := service.challengedUser(request.ChallengeToken)
+	if err != nil {
+		return
+	}
+
+	if err = service.checkAttempt(user.ID, service.verify(user.ID, request.Code)); err != nil {
+		return
+	}
+
+	// participants join the latest event only now that the second factor passed
+	if err = service.Enrolment.JoinLatestEvent(&user, request.VoucherCode); err != nil {
+		return
+	}
+
+	pair, err := service.Tokens.Issue(user, client)
+	if err != nil {
+		return
+	}
+	return newAuthResponse(user, pair), nil
+}
+
+func (service *TwoFactorServiceImpl) SetupByChallenge(
+	request model.TwoFactorChallengeRequest,
+) (setup model.TwoFactorSetup, err error) {
+	user, err := service.challengedUser(request.ChallengeToken)
+	if err != nil {
+		return
+	}
+	return service.setup(user)
+}
+
+// ActivateByChallenge finishes the enrolment a mandatory role is forced into on login and logs the user in
+func (service *TwoFactorServiceImpl) ActivateByChallenge(
+	request model.TwoFactorLoginRequest,
+	client model.Client,
+) (response model.AuthResponse, err error) {
+	user, err := service.challengedUser(request.ChallengeToken)
+	if err != nil {
+		return
+	}
+
+	codes, err := service.enable(user, request.Code)
+	if err = service.checkAttempt(user.ID, err); err != nil {
+		return
+	}
+	service.recordEnabled(context.WithValue(context.Background(), "user", user), user)
+
+	if err = service.Enrolment.JoinLatestEvent(&user, request.VoucherCode); err != nil {
+		return
+	}
+
+	pair, err := service.Tokens.Issue(user, client)
+	if err != nil {
+		return
+	}
+	response = newAuthResponse(user, pair)
+	response.RecoveryCodes = codes
+	return
+}
+
+func (service *TwoFactorServiceImpl) GetStatus(ctx context.Context) (status model.TwoFactorStatus, err error) {
+	user := ctx.Value("user").(um.User)
+	status.Required = isTwoFactorRequired(user)
+	if status.Enabled, err = service.isEnabled(user.ID); err != nil || !status.Enabled {
+		return
+	}
+
+	status.RecoveryCodesLeft, err = service.Repository.CountRecoveryCodes(user.ID)
+	return
+}
+
+func (service *TwoFactorServiceImpl) Setup(ctx context.Context) (model.TwoFactorSetup, error) {
+	return service.setup(ctx.Value("user").(um.User))
+}
+
+func (service *TwoFactorServiceImpl) Enable(
+	ctx context.Context,
+	request model.TwoFactorCodeRequest,
+) (response model.RecoveryCodesResponse, err error) {
+	user := ctx.Value("user").(um.User)
+	if response.RecoveryCodes, err = service.enable(user, request.Code); err != nil {
+		return
+	}
+
+	service.recordEnabled(ctx, user)
+	return
+}
+
+func (service *TwoFactorServiceImpl) Disable(ctx context.Context, request model.TwoFactorCodeRequest) error {
+	user := ctx.Value("user").(um.User)
+	if isTwoFactorRequired(user) {
+		return e.ErrTwoFactorRequired
+	}
+
+	if err := service.checkAttempt(user.ID, service.verify(user.ID, request.Code)); err != nil {
+		return err
+	}
+	if err := service.Repository.Disable(user.ID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityTwoFactor, user.ID,
+		model.TwoFactorStatus{Enabled: true}, model.TwoFactorStatus{})
+	return nil
+}
+
+func (service *TwoFactorServiceImpl) RegenerateRecoveryCodes(
+	ctx context.Context,
+	request model.TwoFactorCodeRequest,
+) (response model.RecoveryCodesResponse, err error) {
+	user := ctx.Value("user").(um.User)
+	if err = service.checkAttempt(user.ID, service.verify(user.ID, request.Code)); err != nil {
+		return
+	}
+
+	codes, hashes := newRecoveryCodes()
+	if err = service.Repository.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return
+	}
+	response.RecoveryCodes = codes
+
+	service.Audit.Record(ctx, constants.AuditGenerate, constants.AuditEntityTwoFactor, user.ID,
+		nil, model.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: int64(len(codes))})
+	return
+}
+
+// recordEnabled writes the enabling of the two factor authentication of user to the audit log,
+// the secret and the recovery codes are left out
+func (service *TwoFactorServiceImpl) recordEnabled(ctx context.Context, user um.User) {
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityTwoFactor, user.ID,
+		nil, model.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: recoveryCodeCount})
+}
+
+// challengedUser returns the user of a challenge token, the token is refused once too many wrong codes
+// were sent since it was issued or when the user has been deactivated since
+func (service *TwoFactorServiceImpl) challengedUser(challengeToken string) (user um.User, err error) {
+	userID, issuedAt, err := utils.ParseChallengeToken(challengeToken)
+	if err != nil {
+		err = e.ErrInvalidTwoFactorChallenge
+		return
+	}
+
+	user, err = service.UserRepo.FindByID(userID)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			err = e.ErrInvalidTwoFactorChallenge
+		}
+		return
+	}
+	if !user.IsActive {
+		err = e.ErrUserIsNotActivated
+		return
+	}
+
+	twoFactor, err := service.Repository.FindByUserID(userID)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			err = nil
+		}
+		return
+	}
+	if twoFactor.ChallengesRevokedAt != nil && !issuedAt.After(*twoFactor.ChallengesRevokedAt) {
+		err = e.ErrInvalidTwoFactorChallenge
+	}
+	return
+}
+
+// checkAttempt counts the result of a code sent with a challenge or to change the second factor. A wrong
+// code revokes the challenges of the user once it is one too many, so codes can't be guessed without
+// logging in again.
+func (service *TwoFactorServiceImpl) checkAttempt(userID uint, err error) error {
+	if err == nil {
+		return service.Repository.ResetFailedAttempts(userID)
+	}
+	if err != e.ErrInvalidTwoFactorCode {
+		return err
+	}
+
+	maxAttempts := helper.GetEnvInt("TWO_FACTOR_MAX_ATTEMPTS", defaultMaxTwoFactorAttempts)
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxTwoFactorAttempts
+	}
+	revoked, errRecord := service.Repository.RecordFailedAttempt(userID, maxAttempts)
+	if errRecord != nil {
+		return errRecord
+	}
+	if revoked {
+		return e.ErrTooManyTwoFactorAttempts
+	}
+	return err
+}
+
+func (service *TwoFactorServiceImpl) isEnabled(userID uint) (bool, error) {
+	twoFactor, err := service.Repository.FindByUserID(userID)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return twoFactor.EnabledAt != nil, nil
+}
+
+// setup generates a new secret for the user, it only becomes active once enable confirms a code of it
+func (service *TwoFactorServiceImpl) setup(user um.User) (setup model.TwoFactorSetup, err error) {
+	enabled, err := service.isEnabled(user.ID)
+	if err != nil {
+		return
+	}
+	if enabled {
+		err = e.ErrTwoFactorAlreadyEnabled
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return
+	}
+
+	if err = service.Repository.SavePending(model.TwoFactor{
+		BaseEntity: common.BaseEntity{
+			CreatedBy: user.Email,
+			UpdatedBy: user.Email,
+		},
+		UserID: user.ID,
+		Secret: secret,
+	}); err != nil {
+		return
+	}
+
+	issuer := os.Getenv("TWO_FACTOR_ISSUER")
+	if issuer == "" {
+		issuer = "Sagara Hackathon"
+	}
+	return model.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(issuer, user.Email, secret),
+	}, nil
+}
+
+func (service *TwoFactorServiceImpl) enable(user um.User, code string) (codes []string, err error) {
+	twoFactor, err := service.Repository.FindByUserID(user.ID)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			err = e.ErrTwoFactorNotSetUp
+		}
+		return
+	}
+	if twoFactor.EnabledAt != nil {
+		err = e.ErrTwoFactorAlreadyEnabled
+		return
+	}
+
+	step, ok := totp.Validate(twoFactor.Secret, code, time.Now())
+	if !ok {
+		err = e.ErrInvalidTwoFactorCode
+		return
+	}
+
+	codes, hashes := newRecoveryCodes()
+	if err = service.Repository.Enable(user.ID, step, hashes); err != nil {
+		return
+	}
+	return codes, nil
+}
+
+// verify accepts an unused authenticator code or an unused recovery code of the user
+func (service *TwoFactorServiceImpl) verify(userID uint, code string) error {
+	twoFactor, err := service.Repository.FindByUserID(userID)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			return e.ErrTwoFactorNotEnabled
+		}
+		return err
+	}
+	if twoFactor.EnabledAt == nil {
+		return e.ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(twoFactor.Secret, code, time.Now()); ok {
+		used, err := service.Repository.UseStep(userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return e.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := service.Repository.UseRecoveryCode(userID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return e.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func isTwoFactorRequired(user um.User) bool {
+	return user.UserRole != nil && helper.StringInSlice(user.UserRole.Name, constants.TwoFactorRequiredRoles)
+}
+
+// newRecoveryCodes returns the codes to show the user once and the hashes to store
+func newRecoveryCodes() (codes []string, hashes []string) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := utils.GenerateSecureToken(5)
+		codes = append(codes, fmt.Sprintf("%s-%s", raw[:5], raw[5:]))
+		hashes = append(hashes, hashToken(raw))
+	}
+	return
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/auth/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/totp"
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeTwoFactorRepository keeps the second factor of one user in memory
+type fakeTwoFactorRepository struct {
+	twoFactor     *model.TwoFactor
+	recoveryCodes map[string]bool
+}
+
+func (repository *fakeTwoFactorRepository) FindByUserID(userID uint) (model.TwoFactor, error) {
+	if repository.twoFactor == nil || repository.twoFactor.UserID != userID {
+		return model.TwoFactor{}, e.ErrDataNotFound
+	}
+	return *repository.twoFactor, nil
+}
+
+func (repository *fakeTwoFactorRepository) SavePending(twoFactor model.TwoFactor) error {
+	repository.twoFactor = &twoFactor
+	return nil
+}
+
+func (repository *fakeTwoFactorRepository) Enable(userID uint, step int64, codeHashes []string) error {
+	now := time.Now()
+	repository.twoFactor.EnabledAt = &now
+	repository.twoFactor.LastUsedStep = step
+	return repository.ReplaceRecoveryCodes(userID, codeHashes)
+}
+
+func (repository *fakeTwoFactorRepository) Disable(uint) error {
+	repository.twoFactor = nil
+	repository.recoveryCodes = nil
+	return nil
+}
+
+func (repository *fakeTwoFactorRepository) UseStep(_ uint, step int64) (bool, error) {
+	if repository.twoFactor.LastUsedStep >= step {
+		return false, nil
+	}
+	repository.twoFactor.LastUsedStep = step
+	return true, nil
+}
+
+func (repository *fakeTwoFactorRepository) UseRecoveryCode(_ uint, codeHash string) (bool, error) {
+	if !repository.recoveryCodes[codeHash] {
+		return false, nil
+	}
+	repository.recoveryCodes[codeHash] = false
+	return true, nil
+}
+
+func (repository *fakeTwoFactorRepository) ReplaceRecoveryCodes(_ uint, codeHashes []string) error {
+	repository.recoveryCodes = map[string]bool{}
+	for _, hash := range codeHashes {
+		repository.recoveryCodes[hash] = true
+	}
+	return nil
+}
+
+func (repository *fakeTwoFactorRepository) CountRecoveryCodes(uint) (total int64, err error) {
+	for _, unused := range repository.recoveryCodes {
+		if unused {
+			total++
+		}
+	}
+	return
+}
+
+func (repository *fakeTwoFactorRepository) RecordFailedAttempt(_ uint, maxAttempts int) (bool, error) {
+	repository.twoFactor.FailedAttempts++
+	if repository.twoFactor.FailedAttempts < maxAttempts {
+		return false, nil
+	}
+	now := time.Now()
+	repository.twoFactor.FailedAttempts = 0
+	repository.twoFactor.ChallengesRevokedAt = &now
+	return true, nil
+}
+
+func (repository *fakeTwoFactorRepository) ResetFailedAttempts(uint) error {
+	repository.twoFactor.FailedAttempts = 0
+	return nil
+}
+
+type fakeUserRepository struct {
+	ur.UserRepository
+	user um.User
+}
+
+func (repository *fakeUserRepository) FindByID(id uint) (um.User, error) {
+	if id != repository.user.ID {
+		return um.User{}, e.ErrDataNotFound
+	}
+	return repository.user, nil
+}
+
+// fakeRecorder keeps the action and entity type of every record
+type fakeRecorder struct {
+	records []string
+}
+
+func (recorder *fakeRecorder) Record(_ context.Context, action, entityType string, _ uint, _, _ interface{}) {
+	recorder.records = append(recorder.records, entityType+" "+action)
+}
+
+type fakeTokenService struct {
+	TokenService
+}
+
+func (fakeTokenService) Issue(um.User, model.Client) (model.TokenPair, error) {
+	return model.TokenPair{}, nil
+}
+
+func newTestTwoFactorService(t *testing.T) (*TwoFactorServiceImpl, *fakeTwoFactorRepository, []string) {
+	t.Setenv("API_JWT_SECRET", "test")
+	t.Setenv("TWO_FACTOR_MAX_ATTEMPTS", "3")
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := um.User{Email: "judge@example.com", IsActive: true, UserRole: &um.UserRole{Name: constants.UserJudge}}
+	user.ID = 7
+	repository := &fakeTwoFactorRepository{twoFactor: &model.TwoFactor{UserID: user.ID, Secret: secret}}
+	service := &TwoFactorServiceImpl{
+		Repository: repository,
+		UserRepo:   &fakeUserRepository{user: user},
+		Tokens:     fakeTokenService{},
+		Enrolment:  &fakeEnrolmentService{},
+		Audit:      &fakeRecorder{},
+	}
+
+	// confirm a code of the step before so the current one is still unused
+	code, _ := totp.Code(secret, time.Now().Add(-totp.Period))
+	codes, err := service.enable(user, code)
+	if err != nil {
+		t.Fatalf("enable returned %v", err)
+	}
+	return service, repository, codes
+}
+
+func TestTwoFactorVerify(t *testing.T) {
+	service, repository, recoveryCodes := newTestTwoFactorService(t)
+
+	code, _ := totp.Code(repository.twoFactor.Secret, time.Now())
+	if err := service.verify(7, code); err != nil {
+		t.Fatalf("verify of the current code returned %v", err)
+	}
+	if err := service.verify(7, code); err != e.ErrInvalidTwoFactorCode {
+		t.Errorf("verify of a code used before returned %v, want %v", err, e.ErrInvalidTwoFactorCode)
+	}
+
+	previous, _ := totp.Code(repository.twoFactor.Secret, time.Now().Add(-totp.Period))
+	if err := service.verify(7, previous); err != e.ErrInvalidTwoFactorCode {
+		t.Errorf("verify of a code older than the last used one returned %v", err)
+	}
+
+	if err := service.verify(7, " "+recoveryCodes[0]+" "); err != nil {
+		t.Errorf("verify of a recovery code returned %v", err)
+	}
+	if err := service.verify(7, recoveryCodes[0]); err != e.ErrInvalidTwoFactorCode {
+		t.Errorf("verify of a used recovery code returned %v", err)
+	}
+
+	if err := service.verify(8, code); err != e.ErrTwoFactorNotEnabled {
+		t.Errorf("verify of a user without two factor returned %v", err)
+	}
+}
+
+func TestTwoFactorLoginAttemptLimit(t *testing.T) {
+	service, _, _ := newTestTwoFactorService(t)
+
+	challenge, _, err := utils.GenerateChallengeToken(7, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"}
+
+	for i := 1; i < 3; i++ {
+		if _, err = service.Login(request, model.Client{}); err != e.ErrInvalidTwoFactorCode {
+			t.Fatalf("wrong code %d returned %v, want %v", i, err, e.ErrInvalidTwoFactorCode)
+		}
+	}
+	if _, err = service.Login(request, model.Client{}); err != e.ErrTooManyTwoFactorAttempts {
+		t.Fatalf("last wrong code returned %v, want %v", err, e.ErrTooManyTwoFactorAttempts)
+	}
+	if _, err = service.Login(request, model.Client{}); err != e.ErrInvalidTwoFactorChallenge {
+		t.Errorf("revoked challenge returned %v, want %v", err, e.ErrInvalidTwoFactorChallenge)
+	}
+
+	// a challenge issued after the revocation works again
+	time.Sleep(2 * time.Millisecond)
+	challenge, _, _ = utils.GenerateChallengeToken(7, time.Minute)
+	code, _ := totp.Code(service.Repository.(*fakeTwoFactorRepository).twoFactor.Secret, time.Now())
+	if _, err = service.Login(model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: code}, model.Client{}); err != nil {
+		t.Errorf("new challenge returned %v", err)
+	}
+}
+
+func TestTwoFactorLoginResetsAttempts(t *testing.T) {
+	service, repository, _ := newTestTwoFactorService(t)
+
+	challenge, _, _ := utils.GenerateChallengeToken(7, time.Minute)
+	if _, err := service.Login(model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"}, model.Client{}); err != e.ErrInvalidTwoFactorCode {
+		t.Fatalf("wrong code returned %v", err)
+	}
+
+	code, _ := totp.Code(repository.twoFactor.Secret, time.Now())
+	if _, err := service.Login(model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: code}, model.Client{}); err != nil {
+		t.Fatalf("right code returned %v", err)
+	}
+	if repository.twoFactor.FailedAttempts != 0 {
+		t.Errorf("failed attempts = %d after a login, want 0", repository.twoFactor.FailedAttempts)
+	}
+}
+
+func TestTwoFactorLoginInactiveUser(t *testing.T) {
+	service, repository, _ := newTestTwoFactorService(t)
+	service.UserRepo.(*fakeUserRepository).user.IsActive = false
+
+	challenge, _, _ := utils.GenerateChallengeToken(7, time.Minute)
+	code, _ := totp.Code(repository.twoFactor.Secret, time.Now())
+	if _, err := service.Login(model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: code}, model.Client{}); err != e.ErrUserIsNotActivated {
+		t.Errorf("login of a user deactivated after the challenge returned %v, want %v", err, e.ErrUserIsNotActivated)
+	}
+}
+
+func TestAdminLoginInactiveUser(t *testing.T) {
+	password, err := utils.HashPassword("violet-kettle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := um.User{Email: "judge@example.com", Password: &password, UserRole: &um.UserRole{Name: constants.UserJudge}}
+	user.ID = 7
+	service := &AdminAuthServiceImpl{UserRepo: &fakeUserRepository{user: user}, Tokens: fakeTokenService{}}
+
+	request := model.LoginRequest{Email: user.Email, Password: "violet-kettle"}
+	if _, err = service.Login(request, model.Client{}); err != e.ErrUserIsNotActivated {
+		t.Errorf("login of an inactive user returned %v, want %v", err, e.ErrUserIsNotActivated)
+	}
+
+	request.Password = "wrong-kettle"
+	if _, err = service.Login(request, model.Client{}); err != e.ErrWrongLoginCredential {
+		t.Errorf("login of an inactive user with a wrong password returned %v, want %v", err, e.ErrWrongLoginCredential)
+	}
+}
+
+func TestTwoFactorChangesAreAudited(t *testing.T) {
+	service, repository, _ := newTestTwoFactorService(t)
+	user := um.User{Email: "jane@example.com", UserRole: &um.UserRole{Name: constants.UserParticipant}}
+	user.ID = 7
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	code, _ := totp.Code(repository.twoFactor.Secret, time.Now())
+	response, err := service.RegenerateRecoveryCodes(ctx, model.TwoFactorCodeRequest{Code: code})
+	if err != nil {
+		t.Fatalf("regenerate returned %v", err)
+	}
+	if err = service.Disable(ctx, model.TwoFactorCodeRequest{Code: "000000"}); err != e.ErrInvalidTwoFactorCode {
+		t.Fatalf("disable with a wrong code returned %v", err)
+	}
+	if err = service.Disable(ctx, model.TwoFactorCodeRequest{Code: response.RecoveryCodes[0]}); err != nil {
+		t.Fatalf("disable returned %v", err)
+	}
+
+	want := []string{
+		constants.AuditEntityTwoFactor + " " + constants.AuditGenerate,
+		constants.AuditEntityTwoFactor + " " + constants.AuditDelete,
+	}
+	got := service.Audit.(*fakeRecorder).records
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+}
+
+func TestTwoFactorDisableAttemptLimit(t *testing.T) {
+	service, repository, _ := newTestTwoFactorService(t)
+	user := um.User{Email: "jane@example.com", UserRole: &um.UserRole{Name: constants.UserParticipant}}
+	user.ID = 7
+	ctx := context.WithValue(context.Background(), "user", user)
+	request := model.TwoFactorCodeRequest{Code: "000000"}
+
+	for i := 1; i < 3; i++ {
+		if err := service.Disable(ctx, request); err != e.ErrInvalidTwoFactorCode {
+			t.Fatalf("wrong code %d returned %v, want %v", i, err, e.ErrInvalidTwoFactorCode)
+		}
+	}
+	if _, err := service.RegenerateRecoveryCodes(ctx, request); err != e.ErrTooManyTwoFactorAttempts {
+		t.Fatalf("last wrong code returned %v, want %v", err, e.ErrTooManyTwoFactorAttempts)
+	}
+	if repository.twoFactor.ChallengesRevokedAt == nil {
+		t.Errorf("too many wrong codes left the login challenges of the user valid")
+	}
+}
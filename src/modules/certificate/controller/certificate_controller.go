@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/modules/certificate/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type CertificateController interface {
+	Generate(ctx *gin.Context)
+	GetList(ctx *gin.Context)
+	GetMine(ctx *gin.Context)
+	Verify(ctx *gin.Context)
+}
+
+type CertificateControllerImpl struct {
+	Service service.CertificateService
+}
+
+func NewCertificateController(service service.CertificateService) CertificateController {
+	return &CertificateControllerImpl{Service: service}
+}
+
+// Generate Generate Certificates godoc
+// @Tags Certificates
+// @Summary Generate Certificates
+// @Description Issue the certificates of every participant, mentor and judge of a finished event. Certificates issued before are skipped unless regenerate is set
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body model.GenerateCertificateRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /certificates/generate [post]
+func (controller *CertificateControllerImpl) Generate(ctx *gin.Context) {
+	var request model.GenerateCertificateRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	data, err := controller.Service.Generate(ctx, request)
+	if err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Generate Certificates Success", data)
+}
+
+// GetList Get List Certificate godoc
+// @Tags Certificates
+// @Summary Get List Certificate
+// @Description Get the certificates issued for an event
+// @Produce json
+// @Security ApiKeyAuth
+// @Param event query int true "Event ID"
+// @Param role query string false "participant, mentor or judge"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Router /certificates [get]
+func (controller *CertificateControllerImpl) GetList(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Query("event"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{"Invalid event"})
+		return
+	}
+
+	data, err := controller.Service.GetList(ctx, model.FilterCertificate{
+		EventID: uint(eventID),
+		Role:    ctx.Query("role"),
+	})
+	if err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Certificate Success", data)
+}
+
+// GetMine Get My Certificates godoc
+// @Tags Certificates
+// @Summary Get My Certificates
+// @Description Get the certificates issued to the logged in user
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Router /certificates/me [get]
+func (controller *CertificateControllerImpl) GetMine(ctx *gin.Context) {
+	data, err := controller.Service.GetMine(ctx)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get My Certificates Success", data)
+}
+
+// Verify Verify Certificate godoc
+// @Tags Certificates
+// @Summary Verify Certificate
+// @Description Check a certificate against the code printed on it
+// @Produce json
+// @Param code path string true "Certificate Code"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 404 {object} src.BaseFailure
+// @Router /certificates/verify/{code} [get]
+func (controller *CertificateControllerImpl) Verify(ctx *gin.Context) {
+	data, err := controller.Service.Verify(ctx.Param("code"))
+	if err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Verify Certificate Success", data)
+}
+
+func sendCertificateError(ctx *gin.Context, err error) {
+	switch err {
+	case e.ErrDataNotFound:
+		common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+	case e.ErrNotEventStaff:
+		common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+	case e.ErrEventNotFinished, e.ErrNoCertificateTemplate, e.ErrCertificateTemplateExist, e.ErrUnknownCertificatePlaceholder:
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+	default:
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+	}
+}
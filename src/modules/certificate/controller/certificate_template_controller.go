@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/modules/certificate/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type CertificateTemplateController interface {
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	GetList(ctx *gin.Context)
+}
+
+type CertificateTemplateControllerImpl struct {
+	Service service.CertificateTemplateService
+}
+
+func NewCertificateTemplateController(service service.CertificateTemplateService) CertificateTemplateController {
+	return &CertificateTemplateControllerImpl{Service: service}
+}
+
+// Create Create Certificate Template godoc
+// @Tags Certificates
+// @Summary Create Certificate Template
+// @Description Create the participant, winner, mentor or judge certificate of an event. Title and body may use {{name}}, {{event}}, {{team}}, {{project}}, {{rank}} and {{role}}
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body model.CreateCertificateTemplateRequest true "Body Request"
+// @Success 201 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Router /certificates/templates [post]
+func (controller *CertificateTemplateControllerImpl) Create(ctx *gin.Context) {
+	var request model.CreateCertificateTemplateRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	if err := controller.Service.Create(ctx, request); err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Create Certificate Template Success", nil)
+}
+
+// Update Update Certificate Template godoc
+// @Tags Certificates
+// @Summary Update Certificate Template
+// @Description Update the text of a certificate template, certificates issued before keep their pdf until they are regenerated
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Certificate Template ID"
+// @Param body body model.UpdateCertificateTemplateRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /certificates/templates/{id} [put]
+func (controller *CertificateTemplateControllerImpl) Update(ctx *gin.Context) {
+	var request model.UpdateCertificateTemplateRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid certificate template id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Update(ctx, request, uint(id)); err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Update Certificate Template Success", nil)
+}
+
+// Delete Delete Certificate Template godoc
+// @Tags Certificates
+// @Summary Delete Certificate Template
+// @Description Delete Certificate Template
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Certificate Template ID"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 403 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /certificates/templates/{id} [delete]
+func (controller *CertificateTemplateControllerImpl) Delete(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid certificate template id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Delete(ctx, uint(id)); err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Delete Certificate Template Success", nil)
+}
+
+// GetList Get List Certificate Template godoc
+// @Tags Certificates
+// @Summary Get List Certificate Template
+// @Description Get the certificate templates of an event
+// @Produce json
+// @Security ApiKeyAuth
+// @Param event query int true "Event ID"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Router /certificates/templates [get]
+func (controller *CertificateTemplateControllerImpl) GetList(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Query("event"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{"Invalid event"})
+		return
+	}
+
+	data, err := controller.Service.GetList(ctx, model.FilterCertificateTemplate{EventID: uint(eventID)})
+	if err != nil {
+		sendCertificateError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Certificate Template Success", data)
+}
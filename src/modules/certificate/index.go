@@ -0,0 +1,63 @@
+package certificate
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/controller"
+	"be-sagara-hackathon/src/modules/certificate/repository"
+	"be-sagara-hackathon/src/modules/certificate/service"
+	"be-sagara-hackathon/src/modules/event"
+	er "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/audit"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	certificateService            service.CertificateService
+	certificateController         controller.CertificateController
+	certificateTemplateController controller.CertificateTemplateController
+)
+
+type CertificateModule interface {
+	InitModule()
+}
+
+type CertificateModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(database *gorm.DB) CertificateModule {
+	return &CertificateModuleImpl{DB: database}
+}
+
+// InitModule should run after the event module, it registers the generation of certificates for events
+// being finished on the event service
+func (module *CertificateModuleImpl) InitModule() {
+	eventRepository := er.NewEventRepository(module.DB)
+	eventScope := evs.NewEventScope(er.NewEventStaffRepository(module.DB), ur.NewPermissionRepository(module.DB))
+	certificateRepository := repository.NewCertificateRepository(module.DB)
+	certificateTemplateRepository := repository.NewCertificateTemplateRepository(module.DB)
+
+	certificateTemplateService := service.NewCertificateTemplateService(
+		certificateTemplateRepository, eventRepository, eventScope, audit.GetRecorder())
+	certificateTemplateController = controller.NewCertificateTemplateController(certificateTemplateService)
+
+	certificateService = service.NewCertificateService(
+		certificateRepository, certificateTemplateRepository, eventRepository, eventScope, audit.GetRecorder())
+	certificateController = controller.NewCertificateController(certificateService)
+
+	event.GetService().OnFinished(certificateService.GenerateOnFinished)
+}
+
+func GetController() controller.CertificateController {
+	return certificateController
+}
+
+func GetTemplateController() controller.CertificateTemplateController {
+	return certificateTemplateController
+}
+
+func GetService() service.CertificateService {
+	return certificateService
+}
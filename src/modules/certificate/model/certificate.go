@@ -0,0 +1,88 @@
+package model
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// Certificate is issued to a user for the role they had in an event. Code is printed on the pdf and is
+// what the public verification endpoint looks the certificate up by.
+type Certificate struct {
+	common.BaseEntity
+	EventID       uint                `gorm:"not null;index" json:"event_id"`
+	Event         evm.Event           `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	TemplateID    uint                `gorm:"not null" json:"template_id"`
+	Template      CertificateTemplate `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	UserID        uint                `gorm:"not null;index" json:"user_id"`
+	User          um.User             `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	Role          string              `gorm:"type:varchar(15);not null" json:"role"` //participant, mentor or judge
+	Type          string              `gorm:"type:varchar(15);not null" json:"type"` //type of the template, winner for winning participants
+	RecipientName string              `gorm:"type:varchar(255);not null" json:"recipient_name"`
+	TeamName      *string             `gorm:"type:varchar(255);null" json:"team_name"`
+	ProjectName   *string             `gorm:"type:varchar(255);null" json:"project_name"`
+	Rank          *uint               `gorm:"null" json:"rank"`
+	Code          string              `gorm:"type:varchar(20);uniqueIndex;not null" json:"code"`
+	FilePath      string              `gorm:"type:text;not null" json:"file_path"`
+	FileUrl       string              `gorm:"type:text;not null" json:"file_url"`
+	IssuedAt      time.Time           `gorm:"not null" json:"issued_at"`
+}
+
+// CertificateRecipient is a participant, mentor or judge of an event who should get a certificate
+type CertificateRecipient struct {
+	UserID      uint
+	Name        string
+	Role        string
+	TeamName    *string
+	ProjectName *string
+	Rank        *uint
+}
+
+type GenerateCertificateRequest struct {
+	EventID    uint `json:"event_id" validate:"required"`
+	Regenerate bool `json:"regenerate"` //render the certificates that were already issued again, codes are kept
+}
+
+type GenerateCertificateReport struct {
+	Generated int      `json:"generated"`
+	Skipped   int      `json:"skipped"`
+	Failed    int      `json:"failed"`
+	Errors    []string `json:"errors"`
+}
+
+type FilterCertificate struct {
+	EventID uint
+	UserID  uint
+	Role    string
+}
+
+type CertificateLite struct {
+	ID            uint      `json:"id"`
+	EventID       uint      `json:"event_id"`
+	EventName     string    `json:"event_name"`
+	UserID        uint      `json:"user_id"`
+	RecipientName string    `json:"recipient_name"`
+	Role          string    `json:"role"`
+	Type          string    `json:"type"`
+	TeamName      *string   `json:"team_name"`
+	ProjectName   *string   `json:"project_name"`
+	Rank          *uint     `json:"rank"`
+	Code          string    `json:"code"`
+	FileUrl       string    `json:"file_url"`
+	IssuedAt      time.Time `json:"issued_at"`
+}
+
+// CertificateVerification is what anyone holding a certificate code may see
+type CertificateVerification struct {
+	Code          string    `json:"code"`
+	EventName     string    `json:"event_name"`
+	RecipientName string    `json:"recipient_name"`
+	Role          string    `json:"role"`
+	Type          string    `json:"type"`
+	TeamName      *string   `json:"team_name"`
+	ProjectName   *string   `json:"project_name"`
+	Rank          *uint     `json:"rank"`
+	IssuedAt      time.Time `json:"issued_at"`
+	FileUrl       string    `json:"file_url"`
+}
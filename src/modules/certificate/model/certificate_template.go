@@ -0,0 +1,42 @@
+package model
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
+)
+
+// CertificateTemplate is the text of one type of certificate of an event, see constants.CertificateWinner.
+// Title and Body can use the placeholders in constants.CertificatePlaceholders.
+type CertificateTemplate struct {
+	common.BaseEntity
+	EventID        uint      `gorm:"not null;index" json:"event_id"`
+	Event          evm.Event `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Type           string    `gorm:"type:varchar(15);not null" json:"type"`
+	MaxRank        uint      `gorm:"not null;default:0" json:"max_rank"` //winner templates only, ranks 1 to max rank win
+	Title          string    `gorm:"type:varchar(255);not null" json:"title"`
+	Body           string    `gorm:"type:text;not null" json:"body"`
+	SignatoryName  *string   `gorm:"type:varchar(255);null" json:"signatory_name"`
+	SignatoryTitle *string   `gorm:"type:varchar(255);null" json:"signatory_title"`
+}
+
+type CertificateTemplateContent struct {
+	MaxRank        uint    `json:"max_rank"`
+	Title          string  `json:"title" validate:"required,max=255"`
+	Body           string  `json:"body" validate:"required"`
+	SignatoryName  *string `json:"signatory_name" validate:"omitempty,max=255"`
+	SignatoryTitle *string `json:"signatory_title" validate:"omitempty,max=255"`
+}
+
+type CreateCertificateTemplateRequest struct {
+	EventID uint   `json:"event_id" validate:"required"`
+	Type    string `json:"type" validate:"required,oneof=participant winner mentor judge"`
+	CertificateTemplateContent
+}
+
+type UpdateCertificateTemplateRequest struct {
+	CertificateTemplateContent
+}
+
+type FilterCertificateTemplate struct {
+	EventID uint
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+)
+
+type CertificateRepository interface {
+	Save(certificate model.Certificate) (model.Certificate, error)
+	Update(certificateID uint, certificate model.Certificate) error
+	FindAll(filter model.FilterCertificate) ([]model.CertificateLite, error)
+	FindManyByEventID(eventID uint) ([]model.Certificate, error)
+	FindByCode(code string) (model.CertificateVerification, error)
+	FindParticipantRecipients(eventID uint) ([]model.CertificateRecipient, error)
+	FindStaffRecipients(eventID uint) ([]model.CertificateRecipient, error)
+}
+
+type CertificateRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewCertificateRepository(db *gorm.DB) CertificateRepository {
+	return &CertificateRepositoryImpl{DB: db}
+}
+
+func (repository *CertificateRepositoryImpl) Save(certificate model.Certificate) (model.Certificate, error) {
+	if err := repository.DB.Omit("Event", "Template", "User").Create(&certificate).Error; err != nil {
+		return certificate, err
+	}
+	return certificate, nil
+}
+
+func (repository *CertificateRepositoryImpl) Update(certificateID uint, certificate model.Certificate) error {
+	if err := repository.DB.Select("*").Omit("Event", "Template", "User").
+		Where("id=?", certificateID).Updates(&certificate).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *CertificateRepositoryImpl) FindAll(filter model.FilterCertificate) (certificates []model.CertificateLite, err error) {
+	query := repository.DB.Table("certificates c").
+		Select(`c.id, c.event_id, ev.name as event_name, c.user_id, c.recipient_name, c.role, c.type, c.team_name,
+			c.project_name, c.rank, c.code, c.file_url, c.issued_at`).
+		Joins("inner join events ev on ev.id = c.event_id").
+		Where("c.deleted_at IS NULL")
+	if filter.EventID != 0 {
+		query = query.Where("c.event_id = ?", filter.EventID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("c.user_id = ?", filter.UserID)
+	}
+	if filter.Role != "" {
+		query = query.Where("c.role = ?", filter.Role)
+	}
+
+	certificates = []model.CertificateLite{}
+	err = query.Order("c.event_id desc, c.role asc, c.recipient_name asc").Find(&certificates).Error
+	return
+}
+
+func (repository *CertificateRepositoryImpl) FindManyByEventID(eventID uint) (certificates []model.Certificate, err error) {
+	err = repository.DB.Where("event_id=?", eventID).Find(&certificates).Error
+	return
+}
+
+func (repository *CertificateRepositoryImpl) FindByCode(code string) (verification model.CertificateVerification, err error) {
+	if err = repository.DB.Table("certificates c").
+		Select(`c.code, ev.name as event_name, c.recipient_name, c.role, c.type, c.team_name, c.project_name, c.rank,
+			c.issued_at, c.file_url`).
+		Joins("inner join events ev on ev.id = c.event_id").
+		Where("c.code = ? AND c.deleted_at IS NULL", code).
+		Take(&verification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+	}
+	return
+}
+
+// FindParticipantRecipients returns every participant of the event once, with the team they joined for it,
+// the team's project and the project's rank on the frozen leaderboard, when there is one. A participant
+// in several teams of the event gets the team they joined first.
+func (repository *CertificateRepositoryImpl) FindParticipantRecipients(eventID uint) (recipients []model.CertificateRecipient, err error) {
+	err = repository.DB.Table("event_participants ep").
+		Select(`u.id as user_id, u.name, ? as role, t.name as team_name, pj.name as project_name, pr.rank`,
+			constants.CertificateParticipant).
+		Joins("inner join participants p on p.id = ep.participant_id AND p.deleted_at IS NULL").
+		Joins("inner join users u on u.id = p.user_id AND u.deleted_at IS NULL").
+		Joins(`left join team_members tm on tm.id = (SELECT MIN(ftm.id) FROM team_members ftm
+			INNER JOIN teams ft ON ft.id = ftm.team_id AND ft.deleted_at IS NULL
+			INNER JOIN team_events te ON te.team_id = ftm.team_id AND te.event_id = ep.event_id
+			WHERE ftm.participant_id = p.id AND ftm.deleted_at IS NULL)`).
+		Joins("left join teams t on t.id = tm.team_id").
+		Joins("left join projects pj on pj.team_id = t.id AND pj.event_id = ep.event_id AND pj.deleted_at IS NULL").
+		Joins("left join project_results pr on pr.project_id = pj.id AND pr.event_id = ep.event_id AND pr.deleted_at IS NULL").
+		Where("ep.event_id = ? AND ep.deleted_at IS NULL", eventID).
+		Order("u.name asc, u.id asc").
+		Scan(&recipients).Error
+	return
+}
+
+// FindStaffRecipients returns the mentors and judges of the event
+func (repository *CertificateRepositoryImpl) FindStaffRecipients(eventID uint) (recipients []model.CertificateRecipient, err error) {
+	err = repository.DB.Table("event_staffs es").
+		Select("DISTINCT u.id as user_id, u.name, es.role").
+		Joins("inner join users u on u.id = es.user_id AND u.deleted_at IS NULL").
+		Where("es.event_id = ? AND es.role IN ? AND es.deleted_at IS NULL",
+			eventID, []string{constants.EventStaffMentor, constants.EventStaffJudge}).
+		Order("es.role asc, u.name asc").
+		Scan(&recipients).Error
+	return
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+)
+
+type CertificateTemplateRepository interface {
+	Save(template model.CertificateTemplate) (model.CertificateTemplate, error)
+	Update(templateID uint, template model.CertificateTemplate) error
+	Delete(templateID uint, deletedBy string) error
+	FindAll(filter model.FilterCertificateTemplate) ([]model.CertificateTemplate, error)
+	FindOne(templateID uint) (model.CertificateTemplate, error)
+	FindOneByEventIDAndType(eventID uint, templateType string) (model.CertificateTemplate, error)
+}
+
+type CertificateTemplateRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewCertificateTemplateRepository(db *gorm.DB) CertificateTemplateRepository {
+	return &CertificateTemplateRepositoryImpl{DB: db}
+}
+
+func (repository *CertificateTemplateRepositoryImpl) Save(template model.CertificateTemplate) (model.CertificateTemplate, error) {
+	if err := repository.DB.Omit("Event").Create(&template).Error; err != nil {
+		return template, err
+	}
+	return template, nil
+}
+
+func (repository *CertificateTemplateRepositoryImpl) Update(templateID uint, template model.CertificateTemplate) error {
+	if err := repository.DB.Select("*").Omit("Event").Where("id=?", templateID).Updates(&template).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *CertificateTemplateRepositoryImpl) Delete(templateID uint, deletedBy string) error {
+	if err := repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.CertificateTemplate{}, templateID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *CertificateTemplateRepositoryImpl) FindAll(filter model.FilterCertificateTemplate) ([]model.CertificateTemplate, error) {
+	var templates []model.CertificateTemplate
+	if err := repository.DB.Where("event_id=?", filter.EventID).
+		Order("id asc").
+		Find(&templates).Error; err != nil {
+		return templates, err
+	}
+	return templates, nil
+}
+
+func (repository *CertificateTemplateRepositoryImpl) FindOne(templateID uint) (model.CertificateTemplate, error) {
+	var template model.CertificateTemplate
+	if err := repository.DB.Where("id=?", templateID).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return template, err
+	}
+	return template, nil
+}
+
+func (repository *CertificateTemplateRepositoryImpl) FindOneByEventIDAndType(eventID uint, templateType string) (model.CertificateTemplate, error) {
+	var template model.CertificateTemplate
+	if err := repository.DB.Where("event_id=? AND type=?", eventID, templateType).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return template, err
+	}
+	return template, nil
+}
@@ -0,0 +1,46 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/certificate"
+	"be-sagara-hackathon/src/utils/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CertificateRouter(group *gin.RouterGroup) {
+	group.POST("/generate",
+		middlewares.Permission(constants.PermissionCertificateManage),
+		certificate.GetController().Generate,
+	)
+	group.GET("/",
+		middlewares.Permission(constants.PermissionCertificateManage),
+		certificate.GetController().GetList,
+	)
+	group.GET("/me", certificate.GetController().GetMine)
+
+	/// Certificate Template Routes ///
+	ct := group.Group("/templates")
+	{
+		ct.POST("/",
+			middlewares.Permission(constants.PermissionCertificateManage),
+			certificate.GetTemplateController().Create,
+		)
+		ct.PUT("/:id",
+			middlewares.Permission(constants.PermissionCertificateManage),
+			certificate.GetTemplateController().Update,
+		)
+		ct.DELETE("/:id",
+			middlewares.Permission(constants.PermissionCertificateManage),
+			certificate.GetTemplateController().Delete,
+		)
+		ct.GET("/",
+			middlewares.Permission(constants.PermissionCertificateManage),
+			certificate.GetTemplateController().GetList,
+		)
+	}
+}
+
+func CertificateVerificationRouter(group *gin.RouterGroup) {
+	group.GET("/:code", certificate.GetController().Verify)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"bytes"
+	"regexp"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+var placeholderPattern = regexp.MustCompile(`{{\s*(\w+)\s*}}`)
+
+var roleLabels = map[string]string{
+	constants.CertificateParticipant: "Participant",
+	constants.CertificateWinner:      "Winner",
+	constants.CertificateMentor:      "Mentor",
+	constants.CertificateJudge:       "Judge",
+}
+
+// checkPlaceholders returns ErrUnknownCertificatePlaceholder when the text uses a placeholder that can't be filled
+func checkPlaceholders(texts ...string) error {
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !helper.StringInSlice(match[1], constants.CertificatePlaceholders) {
+				return e.ErrUnknownCertificatePlaceholder
+			}
+		}
+	}
+	return nil
+}
+
+// fillPlaceholders replaces the placeholders of the text, the ones the certificate has no value for are left empty
+func fillPlaceholders(text, eventName string, certificate model.Certificate) string {
+	values := map[string]string{
+		"name":    certificate.RecipientName,
+		"event":   eventName,
+		"team":    helper.DereferString(certificate.TeamName),
+		"project": helper.DereferString(certificate.ProjectName),
+		"role":    roleLabels[certificate.Type],
+	}
+	if certificate.Rank != nil {
+		values["rank"] = strconv.Itoa(int(*certificate.Rank))
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+func renderCertificatePDF(template model.CertificateTemplate, certificate model.Certificate, eventName, verifyURL string) ([]byte, error) {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(25, 25, 25)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	width, height := pdf.GetPageSize()
+	pdf.SetDrawColor(60, 60, 60)
+	pdf.SetLineWidth(1.2)
+	pdf.Rect(10, 10, width-20, height-20, "D")
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(13, 13, width-26, height-26, "D")
+
+	pdf.SetY(35)
+	pdf.SetFont("Helvetica", "B", 30)
+	pdf.MultiCell(0, 13, tr(fillPlaceholders(template.Title, eventName, certificate)), "", "C", false)
+	pdf.Ln(8)
+
+	pdf.SetFont("Helvetica", "B", 26)
+	pdf.CellFormat(0, 14, tr(certificate.RecipientName), "", 1, "C", false, 0, "")
+	pdf.Line(width/2-70, pdf.GetY()+1, width/2+70, pdf.GetY()+1)
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.MultiCell(0, 8, tr(fillPlaceholders(template.Body, eventName, certificate)), "", "C", false)
+
+	if template.SignatoryName != nil {
+		pdf.SetY(height - 62)
+		pdf.Line(width/2-40, pdf.GetY(), width/2+40, pdf.GetY())
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 6, tr(*template.SignatoryName), "", 1, "C", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(0, 6, tr(helper.DereferString(template.SignatoryTitle)), "", 1, "C", false, 0, "")
+	}
+
+	verification := "Certificate code: " + certificate.Code
+	if verifyURL != "" {
+		verification += "\nThis certificate can be verified at " + verifyURL + certificate.Code
+	}
+	pdf.SetY(height - 32)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.MultiCell(0, 5, verification, "", "C", false)
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/modules/certificate/repository"
+	evm "be-sagara-hackathon/src/modules/event/model"
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/upload"
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CertificateService interface {
+	Generate(ctx context.Context, request model.GenerateCertificateRequest) (model.GenerateCertificateReport, error)
+	GenerateForEvent(eventID uint, regenerate bool, issuedBy string) (model.GenerateCertificateReport, error)
+	GenerateOnFinished(eventID uint, finishedBy string)
+	GetList(ctx context.Context, filter model.FilterCertificate) ([]model.CertificateLite, error)
+	GetMine(ctx context.Context) ([]model.CertificateLite, error)
+	Verify(code string) (model.CertificateVerification, error)
+}
+
+type CertificateServiceImpl struct {
+	Repository         repository.CertificateRepository
+	TemplateRepository repository.CertificateTemplateRepository
+	EventRepository    evr.EventRepository
+	Scope              evs.EventScope
+	Audit              ads.Recorder
+	// Store uploads a rendered pdf and returns its url
+	Store func(path string, pdf []byte) (string, error)
+	// mutex keeps a generation started by hand and one started by the event finishing from issuing twice
+	mutex sync.Mutex
+}
+
+func NewCertificateService(
+	repository repository.CertificateRepository,
+	templateRepository repository.CertificateTemplateRepository,
+	eventRepository evr.EventRepository,
+	scope evs.EventScope,
+	audit ads.Recorder,
+) CertificateService {
+	return &CertificateServiceImpl{
+		Repository:         repository,
+		TemplateRepository: templateRepository,
+		EventRepository:    eventRepository,
+		Scope:              scope,
+		Audit:              audit,
+		Store:              storeCertificate,
+	}
+}
+
+func (service *CertificateServiceImpl) Generate(ctx context.Context, request model.GenerateCertificateRequest) (report model.GenerateCertificateReport, err error) {
+	if _, err = service.EventRepository.FindOne(request.EventID); err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+
+	authUser := ctx.Value("user").(um.User)
+	if report, err = service.GenerateForEvent(request.EventID, request.Regenerate, authUser.Email); err != nil {
+		return
+	}
+
+	service.Audit.Record(ctx, constants.AuditGenerate, constants.AuditEntityCertificate, request.EventID, nil, report)
+	return
+}
+
+// GenerateForEvent issues a certificate to every participant, mentor and judge of a finished event that has a
+// template for their role. A recipient that can't be issued is reported and doesn't stop the others.
+func (service *CertificateServiceImpl) GenerateForEvent(eventID uint, regenerate bool, issuedBy string) (report model.GenerateCertificateReport, err error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	event, err := service.EventRepository.FindOne(eventID)
+	if err != nil {
+		return
+	}
+
+	if event.Status != constants.EventFinished {
+		err = e.ErrEventNotFinished
+		return
+	}
+
+	templates, err := service.TemplateRepository.FindAll(model.FilterCertificateTemplate{EventID: eventID})
+	if err != nil {
+		return
+	}
+	if len(templates) == 0 {
+		err = e.ErrNoCertificateTemplate
+		return
+	}
+	templateByType := make(map[string]model.CertificateTemplate, len(templates))
+	for _, template := range templates {
+		templateByType[template.Type] = template
+	}
+
+	participants, err := service.Repository.FindParticipantRecipients(eventID)
+	if err != nil {
+		return
+	}
+	staffs, err := service.Repository.FindStaffRecipients(eventID)
+	if err != nil {
+		return
+	}
+
+	certificates, err := service.Repository.FindManyByEventID(eventID)
+	if err != nil {
+		return
+	}
+	issued := make(map[string]model.Certificate, len(certificates))
+	for _, certificate := range certificates {
+		issued[recipientKey(certificate.UserID, certificate.Role)] = certificate
+	}
+
+	report.Errors = []string{}
+	verifyURL := os.Getenv("CERTIFICATE_VERIFY_URL")
+	handled := make(map[string]bool)
+	for _, recipient := range append(participants, staffs...) {
+		// a recipient listed twice gets the one certificate of the first listing
+		key := recipientKey(recipient.UserID, recipient.Role)
+		if handled[key] {
+			continue
+		}
+		handled[key] = true
+
+		template, ok := pickTemplate(templateByType, recipient)
+		if !ok {
+			report.Skipped++
+			continue
+		}
+
+		certificate, found := issued[key]
+		if found && !regenerate {
+			report.Skipped++
+			continue
+		}
+
+		if _, err := service.issue(event, template, recipient, certificate, verifyURL, issuedBy); err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s (%s): %v", recipient.Name, recipient.Role, err))
+			continue
+		}
+		report.Generated++
+	}
+	return
+}
+
+// GenerateOnFinished is run in the background once an event is set to finished, events without a
+// template are left for the organizers to generate by hand
+func (service *CertificateServiceImpl) GenerateOnFinished(eventID uint, finishedBy string) {
+	report, err := service.GenerateForEvent(eventID, false, finishedBy)
+	if err != nil {
+		if err != e.ErrNoCertificateTemplate {
+			log.Printf("certificates: failed to generate the certificates of event %d: %v", eventID, err)
+		}
+		return
+	}
+
+	log.Printf("certificates: generated %d certificates of event %d, %d failed", report.Generated, eventID, report.Failed)
+}
+
+func (service *CertificateServiceImpl) GetList(ctx context.Context, filter model.FilterCertificate) ([]model.CertificateLite, error) {
+	if err := service.Scope.Check(ctx, filter.EventID, constants.EventStaffOrganizer); err != nil {
+		return nil, err
+	}
+	return service.Repository.FindAll(filter)
+}
+
+func (service *CertificateServiceImpl) GetMine(ctx context.Context) ([]model.CertificateLite, error) {
+	authUser := ctx.Value("user").(um.User)
+	return service.Repository.FindAll(model.FilterCertificate{UserID: authUser.ID})
+}
+
+func (service *CertificateServiceImpl) Verify(code string) (model.CertificateVerification, error) {
+	return service.Repository.FindByCode(strings.ToUpper(code))
+}
+
+// issue renders and uploads the certificate of one recipient and returns it as saved. A certificate issued
+// before keeps its code, so the pdf is overwritten and links to it stay valid.
+func (service *CertificateServiceImpl) issue(
+	event evm.Event,
+	template model.CertificateTemplate,
+	recipient model.CertificateRecipient,
+	certificate model.Certificate,
+	verifyURL, issuedBy string,
+) (_ model.Certificate, err error) {
+	now := time.Now()
+	if certificate.ID == 0 {
+		certificate = model.Certificate{
+			BaseEntity: common.BaseEntity{CreatedAt: now, CreatedBy: issuedBy},
+			EventID:    event.ID,
+			UserID:     recipient.UserID,
+			Role:       recipient.Role,
+			Code:       strings.ToUpper(utils.GenerateSecureToken(8)),
+		}
+	}
+	certificate.UpdatedAt = now
+	certificate.UpdatedBy = issuedBy
+	certificate.TemplateID = template.ID
+	certificate.Type = template.Type
+	certificate.RecipientName = recipient.Name
+	certificate.TeamName = recipient.TeamName
+	certificate.ProjectName = recipient.ProjectName
+	certificate.Rank = recipient.Rank
+	certificate.IssuedAt = now
+	certificate.FilePath = fmt.Sprintf("certificates/%d/%s.pdf", event.ID, certificate.Code)
+
+	pdf, err := renderCertificatePDF(template, certificate, event.Name, verifyURL)
+	if err != nil {
+		return
+	}
+
+	if certificate.FileUrl, err = service.Store(certificate.FilePath, pdf); err != nil {
+		return
+	}
+
+	if certificate.ID == 0 {
+		return service.Repository.Save(certificate)
+	}
+	return certificate, service.Repository.Update(certificate.ID, certificate)
+}
+
+// pickTemplate gives participants whose project ranked within the winner template's max rank the winner
+// template, everyone else gets the template of their role
+func pickTemplate(templates map[string]model.CertificateTemplate, recipient model.CertificateRecipient) (model.CertificateTemplate, bool) {
+	if recipient.Role == constants.CertificateParticipant && recipient.Rank != nil {
+		if winner, ok := templates[constants.CertificateWinner]; ok && *recipient.Rank <= winner.MaxRank {
+			return winner, true
+		}
+	}
+
+	template, ok := templates[recipient.Role]
+	return template, ok
+}
+
+func recipientKey(userID uint, role string) string {
+	return fmt.Sprintf("%d-%s", userID, role)
+}
+
+// storeCertificate uploads the pdf to the bucket the upload module uses and returns its public url
+func storeCertificate(path string, pdf []byte) (string, error) {
+	if err := upload.PushS3Buffer(bytes.NewReader(pdf), upload.S3Info{
+		Key:      os.Getenv("AWS_S3_ACCESS_KEY"),
+		Secret:   os.Getenv("AWS_S3_SECRET_KEY"),
+		Region:   os.Getenv("AWS_S3_REGION"),
+		Bucket:   os.Getenv("AWS_S3_BUCKET"),
+		Filename: path,
+		Filemime: "application/pdf",
+		Filesize: int64(len(pdf)),
+	}); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s", os.Getenv("LINODE_BASE_FILE_URL"), path), nil
+}
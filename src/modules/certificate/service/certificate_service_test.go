@@ -0,0 +1,317 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/modules/certificate/repository"
+	evm "be-sagara-hackathon/src/modules/event/model"
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"testing"
+)
+
+func TestPickTemplate(t *testing.T) {
+	templates := map[string]model.CertificateTemplate{
+		constants.CertificateParticipant: {Type: constants.CertificateParticipant},
+		constants.CertificateWinner:      {Type: constants.CertificateWinner, MaxRank: 3},
+		constants.CertificateMentor:      {Type: constants.CertificateMentor},
+	}
+	withoutWinner := map[string]model.CertificateTemplate{
+		constants.CertificateParticipant: {Type: constants.CertificateParticipant},
+	}
+
+	tests := []struct {
+		name      string
+		templates map[string]model.CertificateTemplate
+		recipient model.CertificateRecipient
+		want      string
+		ok        bool
+	}{
+		{
+			name:      "first rank wins",
+			templates: templates,
+			recipient: model.CertificateRecipient{Role: constants.CertificateParticipant, Rank: helper.ReferUint(1)},
+			want:      constants.CertificateWinner,
+			ok:        true,
+		},
+		{
+			name:      "max rank wins",
+			templates: templates,
+			recipient: model.CertificateRecipient{Role: constants.CertificateParticipant, Rank: helper.ReferUint(3)},
+			want:      constants.CertificateWinner,
+			ok:        true,
+		},
+		{
+			name:      "below max rank falls back to the participant template",
+			templates: templates,
+			recipient: model.CertificateRecipient{Role: constants.CertificateParticipant, Rank: helper.ReferUint(4)},
+			want:      constants.CertificateParticipant,
+			ok:        true,
+		},
+		{
+			name:      "unranked participant",
+			templates: templates,
+			recipient: model.CertificateRecipient{Role: constants.CertificateParticipant},
+			want:      constants.CertificateParticipant,
+			ok:        true,
+		},
+		{
+			name:      "no winner template",
+			templates: withoutWinner,
+			recipient: model.CertificateRecipient{Role: constants.CertificateParticipant, Rank: helper.ReferUint(1)},
+			want:      constants.CertificateParticipant,
+			ok:        true,
+		},
+		{
+			name:      "staff",
+			templates: templates,
+			recipient: model.CertificateRecipient{Role: constants.CertificateMentor, Rank: helper.ReferUint(1)},
+			want:      constants.CertificateMentor,
+			ok:        true,
+		},
+		{
+			name:      "no template of the role",
+			templates: templates,
+			recipient: model.CertificateRecipient{Role: constants.CertificateJudge},
+			ok:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, ok := pickTemplate(tt.templates, tt.recipient)
+			if ok != tt.ok || template.Type != tt.want {
+				t.Errorf("pickTemplate() = %q, %v, want %q, %v", template.Type, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	certificate := model.Certificate{
+		Type:          constants.CertificateWinner,
+		RecipientName: "Jane",
+		TeamName:      helper.ReferString("Rocket"),
+		ProjectName:   helper.ReferString("Launchpad"),
+		Rank:          helper.ReferUint(2),
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		certificate model.Certificate
+		want        string
+	}{
+		{
+			name:        "every placeholder",
+			text:        "{{name}} of {{team}} ranked {{rank}} at {{event}} with {{project}} as {{role}}",
+			certificate: certificate,
+			want:        "Jane of Rocket ranked 2 at Hackathon with Launchpad as Winner",
+		},
+		{
+			name:        "spaces inside the braces",
+			text:        "Awarded to {{ name }}",
+			certificate: certificate,
+			want:        "Awarded to Jane",
+		},
+		{
+			name:        "values the certificate doesn't have",
+			text:        "{{name}} of {{team}} ranked {{rank}}",
+			certificate: model.Certificate{Type: constants.CertificateMentor, RecipientName: "John"},
+			want:        "John of  ranked ",
+		},
+		{
+			name:        "no placeholders",
+			text:        "Thank you",
+			certificate: certificate,
+			want:        "Thank you",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fillPlaceholders(tt.text, "Hackathon", tt.certificate); got != tt.want {
+				t.Errorf("fillPlaceholders() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPlaceholders(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  error
+	}{
+		{name: "known placeholders", texts: []string{"{{name}}", "{{ event }} {{rank}}"}, want: nil},
+		{name: "no placeholders", texts: []string{"Certificate", ""}, want: nil},
+		{name: "unknown placeholder", texts: []string{"{{name}}", "{{score}}"}, want: e.ErrUnknownCertificatePlaceholder},
+		{name: "placeholders are case sensitive", texts: []string{"{{Name}}"}, want: e.ErrUnknownCertificatePlaceholder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkPlaceholders(tt.texts...); got != tt.want {
+				t.Errorf("checkPlaceholders(%q) = %v, want %v", tt.texts, got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeEventRepository struct {
+	evr.EventRepository
+	event evm.Event
+}
+
+func (repository *fakeEventRepository) FindOne(eventID uint) (evm.Event, error) {
+	if eventID != repository.event.ID {
+		return evm.Event{}, e.ErrDataNotFound
+	}
+	return repository.event, nil
+}
+
+type fakeTemplateRepository struct {
+	repository.CertificateTemplateRepository
+	templates []model.CertificateTemplate
+}
+
+func (repository *fakeTemplateRepository) FindAll(model.FilterCertificateTemplate) ([]model.CertificateTemplate, error) {
+	return repository.templates, nil
+}
+
+// fakeCertificateRepository keeps the saved certificates, updated ones are counted
+type fakeCertificateRepository struct {
+	repository.CertificateRepository
+	participants []model.CertificateRecipient
+	staffs       []model.CertificateRecipient
+	certificates []model.Certificate
+	updated      int
+}
+
+func (repository *fakeCertificateRepository) FindParticipantRecipients(uint) ([]model.CertificateRecipient, error) {
+	return repository.participants, nil
+}
+
+func (repository *fakeCertificateRepository) FindStaffRecipients(uint) ([]model.CertificateRecipient, error) {
+	return repository.staffs, nil
+}
+
+func (repository *fakeCertificateRepository) FindManyByEventID(uint) ([]model.Certificate, error) {
+	return repository.certificates, nil
+}
+
+func (repository *fakeCertificateRepository) Save(certificate model.Certificate) (model.Certificate, error) {
+	certificate.ID = uint(len(repository.certificates) + 1)
+	repository.certificates = append(repository.certificates, certificate)
+	return certificate, nil
+}
+
+func (repository *fakeCertificateRepository) Update(certificateID uint, certificate model.Certificate) error {
+	repository.updated++
+	repository.certificates[certificateID-1] = certificate
+	return nil
+}
+
+func newGenerateTest(certificates *fakeCertificateRepository) (*CertificateServiceImpl, *int) {
+	stored := 0
+	service := &CertificateServiceImpl{
+		Repository: certificates,
+		TemplateRepository: &fakeTemplateRepository{templates: []model.CertificateTemplate{
+			{BaseEntity: common.BaseEntity{ID: 1}, Type: constants.CertificateParticipant, Title: "Certificate", Body: "{{name}} of {{team}}"},
+			{BaseEntity: common.BaseEntity{ID: 2}, Type: constants.CertificateMentor, Title: "Certificate", Body: "{{name}}"},
+		}},
+		EventRepository: &fakeEventRepository{event: evm.Event{
+			BaseEntity: common.BaseEntity{ID: 9},
+			Name:       "Hackathon",
+			Status:     constants.EventFinished,
+		}},
+		Store: func(path string, pdf []byte) (string, error) {
+			stored++
+			return "https://files.example.com/" + path, nil
+		},
+	}
+	return service, &stored
+}
+
+func TestGenerateForEvent(t *testing.T) {
+	certificates := &fakeCertificateRepository{
+		participants: []model.CertificateRecipient{
+			{UserID: 1, Name: "Jane", Role: constants.CertificateParticipant, TeamName: helper.ReferString("Rocket")},
+			{UserID: 2, Name: "John", Role: constants.CertificateParticipant},
+		},
+		staffs: []model.CertificateRecipient{
+			{UserID: 3, Name: "Mia", Role: constants.CertificateMentor},
+			// no judge template
+			{UserID: 4, Name: "Max", Role: constants.CertificateJudge},
+		},
+	}
+	service, stored := newGenerateTest(certificates)
+
+	report, err := service.GenerateForEvent(9, false, "organizer@example.com")
+	if err != nil {
+		t.Fatalf("generate returned %v", err)
+	}
+	if report.Generated != 3 || report.Skipped != 1 || report.Failed != 0 {
+		t.Errorf("first generation = %+v, want 3 generated and 1 skipped", report)
+	}
+
+	report, err = service.GenerateForEvent(9, false, "organizer@example.com")
+	if err != nil {
+		t.Fatalf("second generate returned %v", err)
+	}
+	if report.Generated != 0 || report.Skipped != 4 || len(certificates.certificates) != 3 {
+		t.Errorf("generating again = %+v with %d certificates, want everyone skipped", report, len(certificates.certificates))
+	}
+
+	codes := map[uint]string{}
+	for _, v := range certificates.certificates {
+		codes[v.UserID] = v.Code
+	}
+	report, err = service.GenerateForEvent(9, true, "organizer@example.com")
+	if err != nil {
+		t.Fatalf("regenerate returned %v", err)
+	}
+	if report.Generated != 3 || certificates.updated != 3 || len(certificates.certificates) != 3 || *stored != 6 {
+		t.Errorf("regenerating = %+v with %d updated, want the 3 certificates issued again", report, certificates.updated)
+	}
+	for _, v := range certificates.certificates {
+		if v.Code != codes[v.UserID] {
+			t.Errorf("regenerating changed the code of user %d", v.UserID)
+		}
+	}
+}
+
+func TestGenerateForEventParticipantListedTwice(t *testing.T) {
+	certificates := &fakeCertificateRepository{participants: []model.CertificateRecipient{
+		{UserID: 1, Name: "Jane", Role: constants.CertificateParticipant, TeamName: helper.ReferString("Rocket")},
+		{UserID: 1, Name: "Jane", Role: constants.CertificateParticipant, TeamName: helper.ReferString("Comet")},
+	}}
+	service, _ := newGenerateTest(certificates)
+
+	for _, regenerate := range []bool{false, true} {
+		report, err := service.GenerateForEvent(9, regenerate, "organizer@example.com")
+		if err != nil {
+			t.Fatalf("generate returned %v", err)
+		}
+		if report.Generated != 1 || len(certificates.certificates) != 1 {
+			t.Fatalf("generate (regenerate %v) = %+v with %d certificates, want one", regenerate, report, len(certificates.certificates))
+		}
+		if team := *certificates.certificates[0].TeamName; team != "Rocket" {
+			t.Errorf("certificate (regenerate %v) names team %s, want the first listed Rocket", regenerate, team)
+		}
+	}
+}
+
+func TestGenerateForEventNotFinished(t *testing.T) {
+	service, _ := newGenerateTest(&fakeCertificateRepository{})
+	service.EventRepository = &fakeEventRepository{event: evm.Event{
+		BaseEntity: common.BaseEntity{ID: 9},
+		Status:     constants.EventRunning,
+	}}
+
+	if _, err := service.GenerateForEvent(9, false, "organizer@example.com"); err != e.ErrEventNotFinished {
+		t.Errorf("generate of a running event returned %v, want %v", err, e.ErrEventNotFinished)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/certificate/model"
+	"be-sagara-hackathon/src/modules/certificate/repository"
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+)
+
+// defaultWinnerMaxRank is used for winner templates that don't set a max rank, the usual first three places
+const defaultWinnerMaxRank = 3
+
+type CertificateTemplateService interface {
+	Create(ctx context.Context, request model.CreateCertificateTemplateRequest) error
+	Update(ctx context.Context, request model.UpdateCertificateTemplateRequest, templateID uint) error
+	Delete(ctx context.Context, templateID uint) error
+	GetList(ctx context.Context, filter model.FilterCertificateTemplate) ([]model.CertificateTemplate, error)
+}
+
+type CertificateTemplateServiceImpl struct {
+	Repository      repository.CertificateTemplateRepository
+	EventRepository evr.EventRepository
+	Scope           evs.EventScope
+	Audit           ads.Recorder
+}
+
+func NewCertificateTemplateService(
+	repository repository.CertificateTemplateRepository,
+	eventRepository evr.EventRepository,
+	scope evs.EventScope,
+	audit ads.Recorder,
+) CertificateTemplateService {
+	return &CertificateTemplateServiceImpl{
+		Repository:      repository,
+		EventRepository: eventRepository,
+		Scope:           scope,
+		Audit:           audit,
+	}
+}
+
+func (service *CertificateTemplateServiceImpl) Create(ctx context.Context, request model.CreateCertificateTemplateRequest) error {
+	if _, err := service.EventRepository.FindOne(request.EventID); err != nil {
+		return err
+	}
+
+	if err := service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	if err := checkPlaceholders(request.Title, request.Body); err != nil {
+		return err
+	}
+
+	_, err := service.Repository.FindOneByEventIDAndType(request.EventID, request.Type)
+	if err == nil {
+		return e.ErrCertificateTemplateExist
+	}
+	if err != e.ErrDataNotFound {
+		return err
+	}
+
+	template, err := service.Repository.Save(model.CertificateTemplate{
+		BaseEntity:     builder.BuildBaseEntity(ctx, true, nil),
+		EventID:        request.EventID,
+		Type:           request.Type,
+		MaxRank:        maxRank(request.Type, request.MaxRank),
+		Title:          request.Title,
+		Body:           request.Body,
+		SignatoryName:  request.SignatoryName,
+		SignatoryTitle: request.SignatoryTitle,
+	})
+	if err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityCertificateTemplate, template.ID, nil, template)
+	return nil
+}
+
+func (service *CertificateTemplateServiceImpl) Update(ctx context.Context, request model.UpdateCertificateTemplateRequest, templateID uint) error {
+	existing, err := service.Repository.FindOne(templateID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	if err = checkPlaceholders(request.Title, request.Body); err != nil {
+		return err
+	}
+
+	updated := model.CertificateTemplate{
+		BaseEntity:     builder.BuildBaseEntity(ctx, false, &existing.BaseEntity),
+		EventID:        existing.EventID,
+		Type:           existing.Type,
+		MaxRank:        maxRank(existing.Type, request.MaxRank),
+		Title:          request.Title,
+		Body:           request.Body,
+		SignatoryName:  request.SignatoryName,
+		SignatoryTitle: request.SignatoryTitle,
+	}
+	if err = service.Repository.Update(templateID, updated); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityCertificateTemplate, templateID, existing, updated)
+	return nil
+}
+
+func (service *CertificateTemplateServiceImpl) Delete(ctx context.Context, templateID uint) error {
+	existing, err := service.Repository.FindOne(templateID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(templateID, authUser.Email); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityCertificateTemplate, templateID, existing, nil)
+	return nil
+}
+
+func (service *CertificateTemplateServiceImpl) GetList(ctx context.Context, filter model.FilterCertificateTemplate) ([]model.CertificateTemplate, error) {
+	if err := service.Scope.Check(ctx, filter.EventID, constants.EventStaffOrganizer); err != nil {
+		return nil, err
+	}
+	return service.Repository.FindAll(filter)
+}
+
+// maxRank only keeps the max rank of winner templates
+func maxRank(templateType string, requested uint) uint {
+	if templateType != constants.CertificateWinner {
+		return 0
+	}
+	if requested == 0 {
+		return defaultWinnerMaxRank
+	}
+	return requested
+}
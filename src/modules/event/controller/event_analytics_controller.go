@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type EventAnalyticsController interface {
+	GetAnalytics(ctx *gin.Context)
+}
+
+type EventAnalyticsControllerImpl struct {
+	Service service.EventAnalyticsService
+}
+
+func NewEventAnalyticsController(service service.EventAnalyticsService) EventAnalyticsController {
+	return &EventAnalyticsControllerImpl{Service: service}
+}
+
+// GetAnalytics Get Event Analytics godoc
+// @Tags Events
+// @Summary Get Event Analytics
+// @Description Registrations over time, profile completion, payment funnel, teams, projects, judging progress and participant breakdowns of one event
+// @Param id path int true "Event ID"
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /events/{id}/analytics [get]
+func (controller *EventAnalyticsControllerImpl) GetAnalytics(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid event id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetAnalytics(ctx, uint(eventID))
+	if err != nil {
+		switch err {
+		case e.ErrDataNotFound:
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+		case e.ErrNotEventStaff:
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+		default:
+			common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		}
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Event Analytics Success", data)
+}
@@ -53,6 +53,11 @@ func (controller *EventAssessmentCriteriaControllerImpl) Create(ctx *gin.Context
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -91,6 +96,11 @@ func (controller *EventAssessmentCriteriaControllerImpl) Update(ctx *gin.Context
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -105,12 +115,17 @@ func (controller *EventAssessmentCriteriaControllerImpl) Delete(ctx *gin.Context
 		return
 	}
 
-	if err = controller.Service.Delete(uint(id)); err != nil {
+	if err = controller.Service.Delete(ctx, uint(id)); err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -57,6 +57,11 @@ func (controller *EventCompanyControllerImpl) Create(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -100,6 +105,11 @@ func (controller *EventCompanyControllerImpl) Update(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -114,12 +124,17 @@ func (controller *EventCompanyControllerImpl) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err = controller.Service.Delete(uint(ecID)); err != nil {
+	if err = controller.Service.Delete(ctx, uint(ecID)); err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -117,6 +117,11 @@ func (controller *EventControllerImpl) Update(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -147,6 +152,11 @@ func (controller *EventControllerImpl) Delete(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -176,7 +186,7 @@ func (controller *EventControllerImpl) GetList(ctx *gin.Context) {
 		StartDate: ctx.Query("start"),
 		EndDate:   ctx.Query("end"),
 	}
-	data, err := controller.Service.GetListEvent(filter, pg)
+	data, err := controller.Service.GetListEvent(ctx, filter, pg)
 	if err != nil {
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
@@ -202,12 +212,16 @@ func (controller *EventControllerImpl) GetDetail(ctx *gin.Context) {
 		return
 	}
 
-	data, err := controller.Service.GetDetailEvent(uint(eventId))
+	data, err := controller.Service.GetDetailEvent(ctx, uint(eventId))
 	if err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendSuccess(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
 		common.SendError(ctx, http.StatusInternalServerError, "Internal server error", []string{err.Error()})
 		return
 	}
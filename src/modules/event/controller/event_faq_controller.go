@@ -52,6 +52,11 @@ func (controller *EventFaqControllerImpl) Create(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -90,6 +95,11 @@ func (controller *EventFaqControllerImpl) Update(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -104,12 +114,17 @@ func (controller *EventFaqControllerImpl) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err = controller.Service.Delete(uint(id)); err != nil {
+	if err = controller.Service.Delete(ctx, uint(id)); err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
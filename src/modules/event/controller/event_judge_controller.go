@@ -59,6 +59,10 @@ func (controller *EventJudgeControllerImpl) Create(ctx *gin.Context) {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -83,12 +87,17 @@ func (controller *EventJudgeControllerImpl) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err = controller.Service.Delete(uint(eventJudgeID)); err != nil {
+	if err = controller.Service.Delete(ctx, uint(eventJudgeID)); err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type EventStaffController interface {
+	Create(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	GetAll(ctx *gin.Context)
+}
+
+type EventStaffControllerImpl struct {
+	Service service.EventStaffService
+}
+
+func NewEventStaffController(service service.EventStaffService) EventStaffController {
+	return &EventStaffControllerImpl{Service: service}
+}
+
+// Create Create Event Staff godoc
+// @Tags Events
+// @Summary Create Event Staff
+// @Description Give a user the organizer, mentor or judge role in one event
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body model.EventStaffRequest true "Body Request"
+// @Success 201 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Router /events/staff [post]
+func (controller *EventStaffControllerImpl) Create(ctx *gin.Context) {
+	var request model.EventStaffRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	if err := controller.Service.Create(ctx, request); err != nil {
+		sendEventStaffError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Create Event Staff Success", nil)
+}
+
+// Delete Delete Event Staff godoc
+// @Tags Events
+// @Summary Delete Event Staff
+// @Description Delete Event Staff
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path int true "Event Staff ID"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Router /events/staff/{id} [delete]
+func (controller *EventStaffControllerImpl) Delete(ctx *gin.Context) {
+	staffID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid event staff id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Delete(ctx, uint(staffID)); err != nil {
+		sendEventStaffError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Delete Event Staff Success", nil)
+}
+
+// GetAll Get All Event Staff godoc
+// @Tags Events
+// @Summary Get All Event Staff
+// @Description Get the staff of the events the user organizes
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param event query int false "Event ID"
+// @Param user query int false "User ID"
+// @Param role query string false "organizer, mentor or judge"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /events/staff [get]
+func (controller *EventStaffControllerImpl) GetAll(ctx *gin.Context) {
+	eventID, _ := strconv.Atoi(ctx.Query("event"))
+	userID, _ := strconv.Atoi(ctx.Query("user"))
+	filter := model.FilterEventStaff{
+		EventID: uint(eventID),
+		UserID:  uint(userID),
+		Role:    ctx.Query("role"),
+	}
+
+	data, err := controller.Service.GetAll(ctx, filter)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get All Event Staff Success", data)
+}
+
+func sendEventStaffError(ctx *gin.Context, err error) {
+	switch err {
+	case e.ErrDataNotFound:
+		common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+	case e.ErrEventStaffExist:
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+	case e.ErrNotEventStaff:
+		common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+	default:
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+	}
+}
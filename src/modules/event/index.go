@@ -4,8 +4,10 @@ import (
 	"be-sagara-hackathon/src/modules/event/controller"
 	"be-sagara-hackathon/src/modules/event/repository"
 	"be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/audit"
 	scr "be-sagara-hackathon/src/modules/schedule/repository"
 	tr "be-sagara-hackathon/src/modules/team/repository"
+	ur "be-sagara-hackathon/src/modules/user/repository"
 
 	"gorm.io/gorm"
 )
@@ -23,6 +25,8 @@ var (
 	eventRuleController               controller.EventRuleController
 	eventFaqController                controller.EventFaqController
 	eventAssessmentCriteriaController controller.EventAssessmentCriteriaController
+	eventStaffController              controller.EventStaffController
+	eventAnalyticsController          controller.EventAnalyticsController
 )
 
 type EventModule interface {
@@ -41,38 +45,51 @@ func (module *EventModuleImpl) InitModule() {
 	teamMemberRepo := tr.NewTeamMemberRepository(module.DB)
 	scheduleRepo := scr.NewScheduleRepository(module.DB)
 
+	eventStaffRepository := repository.NewEventStaffRepository(module.DB)
+	eventScope := service.NewEventScope(eventStaffRepository, ur.NewPermissionRepository(module.DB))
+
 	eventParticipantRepository = repository.NewEventParticipantRepository(module.DB)
 	eventRepository = repository.NewEventRepository(module.DB)
-	eventService = service.NewEventRepository(eventRepository, eventParticipantRepository, teamMemberRepo, scheduleRepo)
+	eventTimelineRepository := repository.NewEventTimelineRepository(module.DB)
+	eventService = service.NewEventRepository(
+		eventRepository, eventParticipantRepository, teamMemberRepo, scheduleRepo, eventTimelineRepository,
+		eventStaffRepository, eventScope, audit.GetRecorder())
 	eventController = controller.NewEventController(eventService)
 
+	eventStaffService := service.NewEventStaffService(
+		eventStaffRepository, eventRepository, ur.NewUserRepository(module.DB), eventScope, audit.GetRecorder())
+	eventStaffController = controller.NewEventStaffController(eventStaffService)
+
 	eventMentorRepository := repository.NewEventMentorRepository(module.DB)
-	eventMentorService := service.NewEventMentorService(eventMentorRepository)
+	eventMentorService := service.NewEventMentorService(eventMentorRepository, eventScope, audit.GetRecorder())
 	eventMentorController = controller.NewEventMentorController(eventMentorService)
 
 	eventJudgeRepository := repository.NewEventJudgeRepository(module.DB)
-	eventJudgeService := service.NewEventJudgeService(eventJudgeRepository)
+	eventJudgeService := service.NewEventJudgeService(eventJudgeRepository, eventScope, audit.GetRecorder())
 	eventJudgeController = controller.NewEventJudgeController(eventJudgeService)
 
 	eventCompanyRepository := repository.NewEventCompanyRepository(module.DB)
-	eventCompanyService := service.NewEventCompanyService(eventCompanyRepository, eventRepository)
+	eventCompanyService := service.NewEventCompanyService(eventCompanyRepository, eventRepository, eventScope, audit.GetRecorder())
 	eventCompanyController = controller.NewEventCompanyController(eventCompanyService)
 
-	eventTimelineRepository := repository.NewEventTimelineRepository(module.DB)
-	eventTimelineService := service.NewEventTimelineService(eventTimelineRepository, eventRepository)
+	eventTimelineService := service.NewEventTimelineService(eventTimelineRepository, eventRepository, eventScope, audit.GetRecorder())
 	eventTimelineController = controller.NewEventTimelineController(eventTimelineService)
 
 	eventRuleRepository := repository.NewEventRuleRepository(module.DB)
-	eventRuleService := service.NewEventRuleService(eventRuleRepository, eventRepository)
+	eventRuleService := service.NewEventRuleService(eventRuleRepository, eventRepository, eventScope, audit.GetRecorder())
 	eventRuleController = controller.NewEventRuleController(eventRuleService)
 
 	eventFaqRepository := repository.NewEventFaqRepository(module.DB)
-	eventFaqService := service.NewEventFaqService(eventFaqRepository, eventRepository)
+	eventFaqService := service.NewEventFaqService(eventFaqRepository, eventRepository, eventScope, audit.GetRecorder())
 	eventFaqController = controller.NewEventFaqController(eventFaqService)
 
 	eventAssessmentCriteriaRepository := repository.NewEventAssessmentCriteriaRepository(module.DB)
-	eventAssessmentCriteriaService := service.NewEventAssessmentCriteriaService(eventAssessmentCriteriaRepository, eventRepository)
+	eventAssessmentCriteriaService := service.NewEventAssessmentCriteriaService(eventAssessmentCriteriaRepository, eventRepository, eventScope, audit.GetRecorder())
 	eventAssessmentCriteriaController = controller.NewEventAssessmentCriteriaController(eventAssessmentCriteriaService)
+
+	eventAnalyticsRepository := repository.NewEventAnalyticsRepository(module.DB)
+	eventAnalyticsService := service.NewEventAnalyticsService(eventAnalyticsRepository, eventRepository, eventScope)
+	eventAnalyticsController = controller.NewEventAnalyticsController(eventAnalyticsService)
 }
 
 func GetController() controller.EventController {
@@ -107,6 +124,14 @@ func GetEventAssessmentCriteriaController() controller.EventAssessmentCriteriaCo
 	return eventAssessmentCriteriaController
 }
 
+func GetEventStaffController() controller.EventStaffController {
+	return eventStaffController
+}
+
+func GetEventAnalyticsController() controller.EventAnalyticsController {
+	return eventAnalyticsController
+}
+
 func GetService() service.EventService {
 	return eventService
 }
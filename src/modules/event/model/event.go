@@ -25,6 +25,7 @@ type Event struct {
 	Companies      []EventCompany  `json:"companies" json:"companies"`
 	Rules          []EventRule     `json:"rules" json:"rules"`
 	FAQs           []EventFaq      `json:"faqs" json:"faqs"`
+	CurrentPhase   *string         `gorm:"-" json:"current_phase"`
 }
 
 type CreateEventRequest struct {
@@ -48,6 +49,7 @@ type FilterEvent struct {
 	EndDate   string
 	Status    string
 	Search    string
+	IDs       []uint // nil means every event
 }
 
 type EventLite struct {
@@ -76,4 +78,5 @@ type EventResponse struct {
 	PaymentDueDate time.Time `json:"payment_due_date" `
 	TeamMinMember  uint      `json:"team_min_member"`
 	TeamMaxMember  uint      `json:"team_max_member"`
+	CurrentPhase   *string   `json:"current_phase"`
 }
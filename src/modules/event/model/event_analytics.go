@@ -0,0 +1,86 @@
+package model
+
+// EventAnalytics is the dashboard of one event, every number is counted by the database
+type EventAnalytics struct {
+	EventID       uint                   `json:"event_id"`
+	Registrations []RegistrationCount    `json:"registrations"`
+	Profiles      ProfileCompletionStats `json:"profiles"`
+	Payments      PaymentFunnelStats     `json:"payments"`
+	Teams         TeamStats              `json:"teams"`
+	Projects      ProjectStats           `json:"projects"`
+	Judging       JudgingStats           `json:"judging"`
+	Breakdowns    AnalyticsBreakdowns    `json:"breakdowns"`
+}
+
+// RegistrationCount is the number of participants that joined the event on one day
+type RegistrationCount struct {
+	Date       string `json:"date"`
+	Total      int64  `json:"total"`
+	Cumulative int64  `json:"cumulative"`
+}
+
+type ProfileCompletionStats struct {
+	Participants int64   `json:"participants"`
+	Completed    int64   `json:"completed"`
+	Rate         float64 `json:"rate"`
+}
+
+// PaymentFunnelStats counts the latest invoice of every participant, participants without one are
+// counted as without invoice
+type PaymentFunnelStats struct {
+	WithoutInvoice int64   `json:"without_invoice"`
+	Unpaid         int64   `json:"unpaid"`
+	Processing     int64   `json:"processing"`
+	Paid           int64   `json:"paid"`
+	Expired        int64   `json:"expired"`
+	PaidAmount     uint64  `json:"paid_amount"`
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+type TeamStats struct {
+	Teams                   int64 `json:"teams"`
+	ActiveTeams             int64 `json:"active_teams"`
+	ParticipantsInTeam      int64 `json:"participants_in_team"`
+	ParticipantsWithoutTeam int64 `json:"participants_without_team"`
+}
+
+type ProjectStats struct {
+	Total     int64 `json:"total"`
+	Draft     int64 `json:"draft"`
+	Submitted int64 `json:"submitted"`
+	Assessed  int64 `json:"assessed"`
+	Inactive  int64 `json:"inactive"`
+}
+
+// JudgingStats counts an assignment as completed once the judge scored every criteria of the event
+type JudgingStats struct {
+	Criteria             int64   `json:"criteria"`
+	Judges               int64   `json:"judges"`
+	Assignments          int64   `json:"assignments"`
+	CompletedAssignments int64   `json:"completed_assignments"`
+	ProjectsToAssess     int64   `json:"projects_to_assess"`
+	ProjectsAssessed     int64   `json:"projects_assessed"`
+	Conflicts            int64   `json:"conflicts"`
+	Progress             float64 `json:"progress"`
+}
+
+type AnalyticsBreakdowns struct {
+	Provinces     []AnalyticsCount `json:"provinces"`
+	Cities        []AnalyticsCount `json:"cities"`
+	Specialities  []AnalyticsCount `json:"specialities"`
+	Skills        []AnalyticsCount `json:"skills"`
+	LevelsOfStudy []AnalyticsCount `json:"levels_of_study"`
+	Genders       []AnalyticsCount `json:"genders"`
+}
+
+// AnalyticsCount is one group of a breakdown, label is null for participants that left it empty
+type AnalyticsCount struct {
+	Label *string `json:"label"`
+	Total int64   `json:"total"`
+}
+
+// StatusCount is a row of a GROUP BY status query
+type StatusCount struct {
+	Status string
+	Total  int64
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+)
+
+// EventStaff gives a user a role in one event only, see constants.EventStaffRoles.
+// Mentors and judges added through their own endpoints get a membership as well.
+type EventStaff struct {
+	common.BaseEntity
+	EventID uint    `gorm:"not null" json:"event_id"`
+	Event   Event   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	UserID  uint    `gorm:"not null" json:"user_id"`
+	User    um.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	Role    string  `gorm:"type:varchar(15);not null" json:"role"`
+}
+
+type EventStaffRequest struct {
+	EventID uint   `json:"event_id" validate:"required"`
+	UserID  uint   `json:"user_id" validate:"required"`
+	Role    string `json:"role" validate:"required,oneof=organizer mentor judge"`
+}
+
+type FilterEventStaff struct {
+	EventID  uint
+	EventIDs []uint
+	UserID   uint
+	Role     string
+}
+
+type EventStaffLite struct {
+	ID        uint   `json:"id"`
+	EventID   uint   `json:"event_id"`
+	EventName string `json:"event_name"`
+	UserID    uint   `json:"user_id"`
+	UserName  string `json:"name"`
+	UserEmail string `json:"email"`
+	Role      string `json:"role"`
+}
@@ -10,20 +10,49 @@ type EventTimeline struct {
 	EventID   uint      `gorm:"not null" json:"event_id"`
 	Event     Event     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 	Title     string    `gorm:"type:varchar(255);not null" json:"title"`
+	Phase     *string   `gorm:"type:varchar(20);null;index" json:"phase"` //registration, team-formation, submission, judging, announcement
 	StartDate time.Time `gorm:"not null" json:"start_date"`
 	EndDate   time.Time `gorm:"not null" json:"end_date"`
 	Note      string    `gorm:"type:text;not null" json:"note"`
 }
 
 type EventTimelineRequest struct {
-	Action    string `json:"-"`
-	EventID   uint   `json:"event_id" validate:"required_if=Action create"`
-	Title     string `json:"title"  validate:"required"`
-	StartDate string `json:"start_date" validate:"required"`
-	EndDate   string `json:"end_date" validate:"required"`
-	Note      string `json:"note" validate:"required"`
+	Action    string  `json:"-"`
+	EventID   uint    `json:"event_id" validate:"required_if=Action create"`
+	Title     string  `json:"title"  validate:"required"`
+	Phase     *string `json:"phase" validate:"omitempty,oneof=registration team-formation submission judging announcement"`
+	StartDate string  `json:"start_date" validate:"required"`
+	EndDate   string  `json:"end_date" validate:"required"`
+	Note      string  `json:"note" validate:"required"`
 }
 
 type FilterEventTimeline struct {
 	EventID uint
 }
+
+// IsOpenAt reports whether t is inside the timeline. A date-only EndDate covers the whole day.
+func (etl EventTimeline) IsOpenAt(t time.Time) bool {
+	endDate := etl.EndDate
+	if endDate.Hour() == 0 && endDate.Minute() == 0 && endDate.Second() == 0 {
+		endDate = endDate.AddDate(0, 0, 1)
+	}
+	return !t.Before(etl.StartDate) && t.Before(endDate)
+}
+
+// CurrentPhase returns the phase that is open at t. When several phases overlap, the one that started last wins.
+func CurrentPhase(timelines []EventTimeline, t time.Time) *string {
+	var current *EventTimeline
+	for k, v := range timelines {
+		if v.Phase == nil || !v.IsOpenAt(t) {
+			continue
+		}
+		if current == nil || v.StartDate.After(current.StartDate) {
+			current = &timelines[k]
+		}
+	}
+
+	if current == nil {
+		return nil
+	}
+	return current.Phase
+}
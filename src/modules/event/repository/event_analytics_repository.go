@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/constants"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// registrationDay groups registrations by calendar day, it is the same expression in the select,
+// group by and window so the query stays valid under ONLY_FULL_GROUP_BY
+const registrationDay = "DATE_FORMAT(ep.created_at, '%Y-%m-%d')"
+
+// inTeam is true when the participant of ep is a member of a team that joined the same event
+const inTeam = `EXISTS (SELECT 1 FROM team_members tm
+	INNER JOIN teams t ON t.id = tm.team_id AND t.deleted_at IS NULL
+	INNER JOIN team_events te ON te.team_id = tm.team_id
+	WHERE tm.participant_id = ep.participant_id AND te.event_id = ep.event_id AND tm.deleted_at IS NULL)`
+
+type EventAnalyticsRepository interface {
+	CountRegistrations(eventID uint) ([]model.RegistrationCount, error)
+	CountProfiles(eventID uint) (model.ProfileCompletionStats, error)
+	CountPayments(eventID uint) (model.PaymentFunnelStats, error)
+	CountTeams(eventID uint) (model.TeamStats, error)
+	CountProjects(eventID uint) ([]model.StatusCount, error)
+	CountJudging(eventID uint) (model.JudgingStats, error)
+	CountBreakdowns(eventID uint) (model.AnalyticsBreakdowns, error)
+}
+
+type EventAnalyticsRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewEventAnalyticsRepository(db *gorm.DB) EventAnalyticsRepository {
+	return &EventAnalyticsRepositoryImpl{DB: db}
+}
+
+// participants is every participant registered to the event whose account still exists
+func (repository *EventAnalyticsRepositoryImpl) participants(eventID uint) *gorm.DB {
+	return repository.DB.Table("event_participants as ep").
+		Joins("inner join participants p on p.id = ep.participant_id AND p.deleted_at IS NULL").
+		Joins("inner join users u on u.id = p.user_id AND u.deleted_at IS NULL").
+		Where("ep.event_id = ? AND ep.deleted_at IS NULL", eventID)
+}
+
+func (repository *EventAnalyticsRepositoryImpl) CountRegistrations(eventID uint) (registrations []model.RegistrationCount, err error) {
+	registrations = []model.RegistrationCount{}
+	err = repository.participants(eventID).
+		Select(fmt.Sprintf("%s as date, COUNT(*) as total, SUM(COUNT(*)) OVER (ORDER BY %s) as cumulative",
+			registrationDay, registrationDay)).
+		Group(registrationDay).
+		Order("date").
+		Scan(&registrations).Error
+	return
+}
+
+func (repository *EventAnalyticsRepositoryImpl) CountProfiles(eventID uint) (stats model.ProfileCompletionStats, err error) {
+	err = repository.participants(eventID).
+		Select("COUNT(*) as participants, COALESCE(SUM(p.is_registered), 0) as completed").
+		Scan(&stats).Error
+	return
+}
+
+// CountPayments puts every participant in the funnel by the status of their latest invoice of the
+// event, the paid amount is summed over all of the event's invoices
+func (repository *EventAnalyticsRepositoryImpl) CountPayments(eventID uint) (stats model.PaymentFunnelStats, err error) {
+	err = repository.participants(eventID).
+		Joins(`left join invoices inv on inv.id = (SELECT MAX(i.id) FROM invoices i
+			WHERE i.event_id = ep.event_id AND i.participant_id = ep.participant_id AND i.deleted_at IS NULL)`).
+		Select(`COALESCE(SUM(inv.id IS NULL), 0) as without_invoice,
+			COALESCE(SUM(inv.status = ?), 0) as unpaid,
+			COALESCE(SUM(inv.status = ?), 0) as processing,
+			COALESCE(SUM(inv.status = ?), 0) as paid,
+			COALESCE(SUM(inv.status = ?), 0) as expired,
+			(SELECT COALESCE(SUM(i.paid_amount), 0) FROM invoices i WHERE i.event_id = ? AND i.deleted_at IS NULL) as paid_amount`,
+			constants.InvoiceUnpaid, constants.InvoiceProcessing, constants.InvoicePaid, constants.InvoiceExpired, eventID).
+		Scan(&stats).Error
+	return
+}
+
+func (repository *EventAnalyticsRepositoryImpl) CountTeams(eventID uint) (stats model.TeamStats, err error) {
+	var teams struct {
+		Teams       int64
+		ActiveTeams int64
+	}
+	if err = repository.DB.Table("teams as t").
+		Joins("inner join team_events te on te.team_id = t.id").
+		Where("te.event_id = ? AND t.deleted_at IS NULL", eventID).
+		Select("COUNT(*) as teams, COALESCE(SUM(t.is_active), 0) as active_teams").
+		Scan(&teams).Error; err != nil {
+		return
+	}
+
+	if err = repository.participants(eventID).
+		Select(fmt.Sprintf(`COALESCE(SUM(%[1]s), 0) as participants_in_team,
+			COALESCE(SUM(NOT %[1]s), 0) as participants_without_team`, inTeam)).
+		Scan(&stats).Error; err != nil {
+		return
+	}
+
+	stats.Teams = teams.Teams
+	stats.ActiveTeams = teams.ActiveTeams
+	return
+}
+
+func (repository *EventAnalyticsRepositoryImpl) CountProjects(eventID uint) (counts []model.StatusCount, err error) {
+	err = repository.DB.Table("projects").
+		Where("event_id = ? AND deleted_at IS NULL", eventID).
+		Select("status, COUNT(*) as total").
+		Group("status").
+		Scan(&counts).Error
+	return
+}
+
+// CountJudging counts the assignments whose judge scored every active criteria of the event.
+// Scores given to criteria that were deactivated or deleted since are left out
+func (repository *EventAnalyticsRepositoryImpl) CountJudging(eventID uint) (stats model.JudgingStats, err error) {
+	if err = repository.DB.Table("event_assessment_criteria").
+		Where("event_id = ? AND is_active = 1 AND deleted_at IS NULL", eventID).
+		Count(&stats.Criteria).Error; err != nil {
+		return
+	}
+
+	var assignments struct {
+		Judges               int64
+		Assignments          int64
+		CompletedAssignments int64
+		Conflicts            int64
+	}
+	if err = repository.DB.Table("project_judges as pj").
+		Joins("inner join projects p on p.id = pj.project_id AND p.deleted_at IS NULL").
+		Where("p.event_id = ? AND pj.deleted_at IS NULL", eventID).
+		Select(`COUNT(DISTINCT pj.judge_id) as judges,
+			COUNT(*) as assignments,
+			COALESCE(SUM(? > 0 AND (SELECT COUNT(DISTINCT pa.criteria_id) FROM project_assessments pa
+				INNER JOIN event_assessment_criteria c ON c.id = pa.criteria_id AND c.is_active = 1 AND c.deleted_at IS NULL
+				WHERE pa.project_id = pj.project_id AND pa.judge_id = pj.judge_id AND pa.deleted_at IS NULL) >= ?), 0) as completed_assignments,
+			(SELECT COUNT(*) FROM judge_conflicts jc
+				INNER JOIN projects cp ON cp.id = jc.project_id AND cp.deleted_at IS NULL
+				WHERE cp.event_id = ? AND jc.deleted_at IS NULL) as conflicts`,
+			stats.Criteria, stats.Criteria, eventID).
+		Scan(&assignments).Error; err != nil {
+		return
+	}
+
+	stats.Judges = assignments.Judges
+	stats.Assignments = assignments.Assignments
+	stats.CompletedAssignments = assignments.CompletedAssignments
+	stats.Conflicts = assignments.Conflicts
+	return
+}
+
+func (repository *EventAnalyticsRepositoryImpl) CountBreakdowns(eventID uint) (breakdowns model.AnalyticsBreakdowns, err error) {
+	if breakdowns.Provinces, err = repository.breakdown(eventID, "prov.name",
+		"left join reg_provinces prov on prov.id = p.province_id"); err != nil {
+		return
+	}
+	if breakdowns.Cities, err = repository.breakdown(eventID, "city.name",
+		"left join reg_cities city on city.id = p.city_id"); err != nil {
+		return
+	}
+	if breakdowns.Specialities, err = repository.breakdown(eventID, "spe.name",
+		"left join specialities spe on spe.id = p.speciality_id"); err != nil {
+		return
+	}
+	if breakdowns.Skills, err = repository.breakdown(eventID, "sk.name",
+		"inner join participant_skills ps on ps.participant_id = p.id",
+		"inner join skills sk on sk.id = ps.skill_id AND sk.deleted_at IS NULL"); err != nil {
+		return
+	}
+	if breakdowns.LevelsOfStudy, err = repository.breakdown(eventID, "p.level_of_study"); err != nil {
+		return
+	}
+	breakdowns.Genders, err = repository.breakdown(eventID, "p.gender")
+	return
+}
+
+// breakdown counts the participants of the event per label, biggest group first
+func (repository *EventAnalyticsRepositoryImpl) breakdown(eventID uint, label string, joins ...string) (counts []model.AnalyticsCount, err error) {
+	query := repository.participants(eventID)
+	for _, join := range joins {
+		query = query.Joins(join)
+	}
+
+	counts = []model.AnalyticsCount{}
+	err = query.
+		Select(fmt.Sprintf("%s as label, COUNT(*) as total", label)).
+		Group(label).
+		Order("total desc, label").
+		Scan(&counts).Error
+	return
+}
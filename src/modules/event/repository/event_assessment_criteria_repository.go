@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"fmt"
 	"gorm.io/gorm"
@@ -11,9 +12,9 @@ import (
 )
 
 type EventAssessmentCriteriaRepository interface {
-	Save(req model.EventAssessmentCriteria) (err error)
+	Save(req model.EventAssessmentCriteria) (model.EventAssessmentCriteria, error)
 	Update(id uint, req model.EventAssessmentCriteria) (err error)
-	Delete(id uint) (err error)
+	Delete(id uint, deletedBy string) (err error)
 	Find(
 		filter model.FilterEventAssessmentCriteria,
 		pg *utils.PaginateQueryOffset,
@@ -30,11 +31,11 @@ func NewEventAssessmentCriteriaRepository(db *gorm.DB) EventAssessmentCriteriaRe
 	return &EventAssessmentCriteriaRepositoryImpl{DB: db}
 }
 
-func (repository *EventAssessmentCriteriaRepositoryImpl) Save(req model.EventAssessmentCriteria) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *EventAssessmentCriteriaRepositoryImpl) Save(req model.EventAssessmentCriteria) (model.EventAssessmentCriteria, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *EventAssessmentCriteriaRepositoryImpl) Update(id uint, req model.EventAssessmentCriteria) (err error) {
@@ -44,8 +45,8 @@ func (repository *EventAssessmentCriteriaRepositoryImpl) Update(id uint, req mod
 	return
 }
 
-func (repository *EventAssessmentCriteriaRepositoryImpl) Delete(id uint) (err error) {
-	if err = repository.DB.Delete(&model.EventAssessmentCriteria{}, id).Error; err != nil {
+func (repository *EventAssessmentCriteriaRepositoryImpl) Delete(id uint, deletedBy string) (err error) {
+	if err = repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.EventAssessmentCriteria{}, id).Error; err != nil {
 		return
 	}
 	return
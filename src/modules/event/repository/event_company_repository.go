@@ -2,6 +2,7 @@ package repository
 
 import (
 	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"errors"
 	"github.com/go-sql-driver/mysql"
@@ -10,9 +11,9 @@ import (
 )
 
 type EventCompanyRepository interface {
-	Save(ec model.EventCompany) error
+	Save(ec model.EventCompany) (model.EventCompany, error)
 	Update(ecID uint, ec model.EventCompany) error
-	Delete(ecID uint) error
+	Delete(ecID uint, deletedBy string) error
 	FindAll(filter model.FilterEventCompany) (companies []model.EventCompany, err error)
 	FindOne(ecID uint) (company model.EventCompany, err error)
 	FindManyByEventID(eventID uint) (companies []model.EventCompany, err error)
@@ -26,7 +27,7 @@ func NewEventCompanyRepository(db *gorm.DB) EventCompanyRepository {
 	return &EventCompanyRepositoryImpl{DB: db}
 }
 
-func (repository *EventCompanyRepositoryImpl) Save(ec model.EventCompany) error {
+func (repository *EventCompanyRepositoryImpl) Save(ec model.EventCompany) (model.EventCompany, error) {
 	if err := repository.DB.Create(&ec).Error; err != nil {
 		var mySqlErr *mysql.MySQLError
 		if errors.As(err, &mySqlErr) && mySqlErr.Number == 1062 {
@@ -38,9 +39,9 @@ func (repository *EventCompanyRepositoryImpl) Save(ec model.EventCompany) error
 				err = e.ErrNameAlreadyExists
 			}
 		}
-		return err
+		return ec, err
 	}
-	return nil
+	return ec, nil
 }
 
 func (repository *EventCompanyRepositoryImpl) Update(ecID uint, ec model.EventCompany) error {
@@ -60,8 +61,8 @@ func (repository *EventCompanyRepositoryImpl) Update(ecID uint, ec model.EventCo
 	return nil
 }
 
-func (repository *EventCompanyRepositoryImpl) Delete(ecID uint) error {
-	if err := repository.DB.Delete(&model.EventCompany{}, ecID).Error; err != nil {
+func (repository *EventCompanyRepositoryImpl) Delete(ecID uint, deletedBy string) error {
+	if err := repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.EventCompany{}, ecID).Error; err != nil {
 		return err
 	}
 	return nil
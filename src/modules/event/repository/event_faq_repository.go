@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"fmt"
 	"gorm.io/gorm"
@@ -11,9 +12,9 @@ import (
 )
 
 type EventFaqRepository interface {
-	Save(req model.EventFaq) (err error)
+	Save(req model.EventFaq) (model.EventFaq, error)
 	Update(id uint, req model.EventFaq) (err error)
-	Delete(id uint) (err error)
+	Delete(id uint, deletedBy string) (err error)
 	Find(
 		filter model.FilterEventFaq,
 		pg *utils.PaginateQueryOffset,
@@ -30,11 +31,11 @@ func NewEventFaqRepository(db *gorm.DB) EventFaqRepository {
 	return &EventFaqRepositoryImpl{DB: db}
 }
 
-func (repository *EventFaqRepositoryImpl) Save(req model.EventFaq) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *EventFaqRepositoryImpl) Save(req model.EventFaq) (model.EventFaq, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *EventFaqRepositoryImpl) Update(id uint, req model.EventFaq) (err error) {
@@ -44,8 +45,8 @@ func (repository *EventFaqRepositoryImpl) Update(id uint, req model.EventFaq) (e
 	return
 }
 
-func (repository *EventFaqRepositoryImpl) Delete(id uint) (err error) {
-	if err = repository.DB.Delete(&model.EventFaq{}, id).Error; err != nil {
+func (repository *EventFaqRepositoryImpl) Delete(id uint, deletedBy string) (err error) {
+	if err = repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.EventFaq{}, id).Error; err != nil {
 		return
 	}
 	return
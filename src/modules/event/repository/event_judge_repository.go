@@ -2,12 +2,14 @@ package repository
 
 import (
 	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"gorm.io/gorm"
 )
 
 type EventJudgeRepository interface {
-	Save(ej model.EventJudge) error
+	Save(ej model.EventJudge) (model.EventJudge, error)
 	Delete(ejID uint) error
 	FindAll(filter model.FilterEventJudge) (judges []model.EventJudgeLite, err error)
 	FindOne(ejID uint) (judge model.EventJudge, err error)
@@ -23,18 +25,45 @@ func NewEventJudgeRepository(db *gorm.DB) EventJudgeRepository {
 	return &EventJudgeRepositoryImpl{DB: db}
 }
 
-func (repository *EventJudgeRepositoryImpl) Save(ej model.EventJudge) error {
-	if err := repository.DB.Create(&ej).Error; err != nil {
-		return err
+func (repository *EventJudgeRepositoryImpl) Save(ej model.EventJudge) (model.EventJudge, error) {
+	tx := repository.DB.Begin()
+	if err := tx.Create(&ej).Error; err != nil {
+		tx.Rollback()
+		return ej, err
+	}
+
+	if err := saveStaff(tx, model.EventStaff{
+		BaseEntity: common.BaseEntity{CreatedBy: ej.CreatedBy, UpdatedBy: ej.UpdatedBy},
+		EventID:    ej.EventID,
+		UserID:     ej.JudgeID,
+		Role:       constants.EventStaffJudge,
+	}); err != nil {
+		tx.Rollback()
+		return ej, err
 	}
-	return nil
+	return ej, tx.Commit().Error
 }
 
 func (repository *EventJudgeRepositoryImpl) Delete(ejID uint) error {
-	if err := repository.DB.Delete(&model.EventJudge{}, ejID).Error; err != nil {
+	var ej model.EventJudge
+	if err := repository.DB.First(&ej, ejID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return e.ErrDataNotFound
+		}
+		return err
+	}
+
+	tx := repository.DB.Begin()
+	if err := tx.Unscoped().Delete(&model.EventJudge{}, ejID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := deleteStaff(tx, ej.EventID, ej.JudgeID, constants.EventStaffJudge); err != nil {
+		tx.Rollback()
 		return err
 	}
-	return nil
+	return tx.Commit().Error
 }
 
 func (repository *EventJudgeRepositoryImpl) FindAll(filter model.FilterEventJudge) (judges []model.EventJudgeLite, err error) {
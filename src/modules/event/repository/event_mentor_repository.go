@@ -2,12 +2,14 @@ package repository
 
 import (
 	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"gorm.io/gorm"
 )
 
 type EventMentorRepository interface {
-	Save(em model.EventMentor) error
+	Save(em model.EventMentor) (model.EventMentor, error)
 	Delete(emID uint) error
 	FindAll(filter model.FilterEventMentor) (mentors []model.EventMentorLite, err error)
 	FindOne(emID uint) (mentor model.EventMentor, err error)
@@ -23,18 +25,45 @@ func NewEventMentorRepository(db *gorm.DB) EventMentorRepository {
 	return &EventMentorRepositoryImpl{DB: db}
 }
 
-func (repository *EventMentorRepositoryImpl) Save(em model.EventMentor) error {
-	if err := repository.DB.Create(&em).Error; err != nil {
-		return err
+func (repository *EventMentorRepositoryImpl) Save(em model.EventMentor) (model.EventMentor, error) {
+	tx := repository.DB.Begin()
+	if err := tx.Create(&em).Error; err != nil {
+		tx.Rollback()
+		return em, err
+	}
+
+	if err := saveStaff(tx, model.EventStaff{
+		BaseEntity: common.BaseEntity{CreatedBy: em.CreatedBy, UpdatedBy: em.UpdatedBy},
+		EventID:    em.EventID,
+		UserID:     em.MentorID,
+		Role:       constants.EventStaffMentor,
+	}); err != nil {
+		tx.Rollback()
+		return em, err
 	}
-	return nil
+	return em, tx.Commit().Error
 }
 
 func (repository *EventMentorRepositoryImpl) Delete(emID uint) error {
-	if err := repository.DB.Delete(&model.EventMentor{}, emID).Error; err != nil {
+	var em model.EventMentor
+	if err := repository.DB.First(&em, emID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return e.ErrDataNotFound
+		}
+		return err
+	}
+
+	tx := repository.DB.Begin()
+	if err := tx.Unscoped().Delete(&model.EventMentor{}, emID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := deleteStaff(tx, em.EventID, em.MentorID, constants.EventStaffMentor); err != nil {
+		tx.Rollback()
 		return err
 	}
-	return nil
+	return tx.Commit().Error
 }
 
 func (repository *EventMentorRepositoryImpl) FindAll(filter model.FilterEventMentor) (mentors []model.EventMentorLite, err error) {
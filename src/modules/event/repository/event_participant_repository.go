@@ -2,6 +2,7 @@ package repository
 
 import (
 	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"gorm.io/gorm"
 )
@@ -34,8 +35,7 @@ func (repository *EventParticipantRepositoryImpl) Update(eventParticipant model.
 }
 
 func (repository *EventParticipantRepositoryImpl) Delete(eventParticipantId uint, deleteBy string) error {
-	query := `UPDATE event_participants SET deleted_at=NOW(), deleted_by=? WHERE id=?`
-	result := repository.DB.Exec(query, deleteBy, eventParticipantId)
+	result := repository.DB.Scopes(common.DeletedBy(deleteBy)).Delete(&model.EventParticipant{}, eventParticipantId)
 
 	return result.Error
 }
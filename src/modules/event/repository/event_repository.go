@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"database/sql"
 	"fmt"
@@ -48,8 +49,7 @@ func (repository *EventRepositoryImpl) Update(event model.Event, eventID uint) e
 }
 
 func (repository *EventRepositoryImpl) Delete(eventID uint, deleteBy string) error {
-	query := `UPDATE events SET deleted_at=NOW(), deleted_by=? WHERE id=?`
-	if err := repository.DB.Exec(query, deleteBy, eventID).Error; err != nil {
+	if err := repository.DB.Scopes(common.DeletedBy(deleteBy)).Delete(&model.Event{}, eventID).Error; err != nil {
 		return err
 	}
 
@@ -133,6 +133,11 @@ func BuildFilter(filter model.FilterEvent) (where []string, whereVal []interface
 		whereVal = append(whereVal, sql.Named("end", filter.EndDate))
 	}
 
+	if filter.IDs != nil {
+		where = append(where, "id IN @ids")
+		whereVal = append(whereVal, sql.Named("ids", filter.IDs))
+	}
+
 	return
 }
 
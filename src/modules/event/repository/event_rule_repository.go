@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"fmt"
 	"gorm.io/gorm"
@@ -11,9 +12,9 @@ import (
 )
 
 type EventRuleRepository interface {
-	Save(req model.EventRule) (err error)
+	Save(req model.EventRule) (model.EventRule, error)
 	Update(id uint, req model.EventRule) (err error)
-	Delete(id uint) (err error)
+	Delete(id uint, deletedBy string) (err error)
 	Find(
 		filter model.FilterEventRule,
 		pg *utils.PaginateQueryOffset,
@@ -30,11 +31,11 @@ func NewEventRuleRepository(db *gorm.DB) EventRuleRepository {
 	return &EventRuleRepositoryImpl{DB: db}
 }
 
-func (repository *EventRuleRepositoryImpl) Save(req model.EventRule) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *EventRuleRepositoryImpl) Save(req model.EventRule) (model.EventRule, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *EventRuleRepositoryImpl) Update(id uint, req model.EventRule) (err error) {
@@ -44,8 +45,8 @@ func (repository *EventRuleRepositoryImpl) Update(id uint, req model.EventRule)
 	return
 }
 
-func (repository *EventRuleRepositoryImpl) Delete(id uint) (err error) {
-	if err = repository.DB.Delete(&model.EventRule{}, id).Error; err != nil {
+func (repository *EventRuleRepositoryImpl) Delete(id uint, deletedBy string) (err error) {
+	if err = repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.EventRule{}, id).Error; err != nil {
 		return
 	}
 	return
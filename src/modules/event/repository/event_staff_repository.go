@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+)
+
+type EventStaffRepository interface {
+	Save(staff model.EventStaff) error
+	Delete(staff model.EventStaff) error
+	FindAll(filter model.FilterEventStaff) (staffs []model.EventStaffLite, err error)
+	FindOne(staffID uint) (staff model.EventStaff, err error)
+	FindOneByEventIDUserIDAndRole(eventID, userID uint, role string) (staff model.EventStaff, err error)
+	HasRole(eventID, userID uint, roles []string) (bool, error)
+	FindEventIDs(userID uint, roles []string) (eventIDs []uint, err error)
+}
+
+type EventStaffRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewEventStaffRepository(db *gorm.DB) EventStaffRepository {
+	return &EventStaffRepositoryImpl{DB: db}
+}
+
+// Save also adds mentors and judges to event_mentors and event_judges, which the rest of the app reads from
+func (repository *EventStaffRepositoryImpl) Save(staff model.EventStaff) error {
+	tx := repository.DB.Begin()
+
+	var err error
+	switch staff.Role {
+	case constants.EventStaffMentor:
+		err = tx.Create(&model.EventMentor{BaseEntity: staff.BaseEntity, EventID: staff.EventID, MentorID: staff.UserID}).Error
+	case constants.EventStaffJudge:
+		err = tx.Create(&model.EventJudge{BaseEntity: staff.BaseEntity, EventID: staff.EventID, JudgeID: staff.UserID}).Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = saveStaff(tx, staff); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (repository *EventStaffRepositoryImpl) Delete(staff model.EventStaff) error {
+	tx := repository.DB.Begin()
+
+	var err error
+	switch staff.Role {
+	case constants.EventStaffMentor:
+		err = tx.Unscoped().Where("event_id=? AND mentor_id=?", staff.EventID, staff.UserID).Delete(&model.EventMentor{}).Error
+	case constants.EventStaffJudge:
+		err = tx.Unscoped().Where("event_id=? AND judge_id=?", staff.EventID, staff.UserID).Delete(&model.EventJudge{}).Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = deleteStaff(tx, staff.EventID, staff.UserID, staff.Role); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (repository *EventStaffRepositoryImpl) FindAll(filter model.FilterEventStaff) (staffs []model.EventStaffLite, err error) {
+	query := repository.DB.Table("event_staffs es").
+		Select("es.id, es.event_id, e.name as event_name, es.user_id, u.name as user_name, u.email as user_email, es.role").
+		Joins("INNER JOIN events e on e.id = es.event_id").
+		Joins("INNER JOIN users u on u.id = es.user_id").
+		Where("es.deleted_at IS NULL")
+
+	if filter.EventID > 0 {
+		query = query.Where("es.event_id = ?", filter.EventID)
+	}
+	if len(filter.EventIDs) > 0 {
+		query = query.Where("es.event_id IN ?", filter.EventIDs)
+	}
+	if filter.UserID > 0 {
+		query = query.Where("es.user_id = ?", filter.UserID)
+	}
+	if filter.Role != "" {
+		query = query.Where("es.role = ?", filter.Role)
+	}
+
+	err = query.Order("es.event_id desc, es.role asc, u.name asc").Scan(&staffs).Error
+	return
+}
+
+func (repository *EventStaffRepositoryImpl) FindOne(staffID uint) (staff model.EventStaff, err error) {
+	if err = repository.DB.Where("id=?", staffID).First(&staff).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+	}
+	return
+}
+
+func (repository *EventStaffRepositoryImpl) FindOneByEventIDUserIDAndRole(eventID, userID uint, role string) (staff model.EventStaff, err error) {
+	if err = repository.DB.Where("event_id=? AND user_id=? AND role=?", eventID, userID, role).
+		First(&staff).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+	}
+	return
+}
+
+func (repository *EventStaffRepositoryImpl) HasRole(eventID, userID uint, roles []string) (bool, error) {
+	var total int64
+	if err := repository.DB.Model(&model.EventStaff{}).
+		Where("event_id=? AND user_id=? AND role IN ?", eventID, userID, roles).
+		Count(&total).Error; err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+func (repository *EventStaffRepositoryImpl) FindEventIDs(userID uint, roles []string) (eventIDs []uint, err error) {
+	err = repository.DB.Model(&model.EventStaff{}).
+		Where("user_id=? AND role IN ?", userID, roles).
+		Distinct().
+		Pluck("event_id", &eventIDs).Error
+	return
+}
+
+// saveStaff adds the membership unless the user already has the role in the event
+func saveStaff(tx *gorm.DB, staff model.EventStaff) error {
+	var total int64
+	if err := tx.Model(&model.EventStaff{}).
+		Where("event_id=? AND user_id=? AND role=?", staff.EventID, staff.UserID, staff.Role).
+		Count(&total).Error; err != nil {
+		return err
+	}
+	if total > 0 {
+		return nil
+	}
+	return tx.Omit("Event", "User").Create(&staff).Error
+}
+
+func deleteStaff(tx *gorm.DB, eventID, userID uint, role string) error {
+	return tx.Unscoped().Where("event_id=? AND user_id=? AND role=?", eventID, userID, role).Delete(&model.EventStaff{}).Error
+}
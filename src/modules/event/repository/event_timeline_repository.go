@@ -2,17 +2,19 @@ package repository
 
 import (
 	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"gorm.io/gorm"
 )
 
 type EventTimelineRepository interface {
-	Save(etl model.EventTimeline) error
+	Save(etl model.EventTimeline) (model.EventTimeline, error)
 	Update(etlID uint, etl model.EventTimeline) error
-	Delete(etlID uint) error
+	Delete(etlID uint, deletedBy string) error
 	FindAll(filter model.FilterEventTimeline) ([]model.EventTimeline, error)
 	FindOne(etlID uint) (model.EventTimeline, error)
 	FindManyByEventID(eventID uint) ([]model.EventTimeline, error)
+	FindManyByEventIDAndPhase(eventID uint, phase string) ([]model.EventTimeline, error)
 }
 
 type EventTimelineRepositoryImpl struct {
@@ -23,11 +25,11 @@ func NewEventTimelineRepository(db *gorm.DB) EventTimelineRepository {
 	return &EventTimelineRepositoryImpl{DB: db}
 }
 
-func (repository EventTimelineRepositoryImpl) Save(etl model.EventTimeline) error {
+func (repository EventTimelineRepositoryImpl) Save(etl model.EventTimeline) (model.EventTimeline, error) {
 	if err := repository.DB.Create(&etl).Error; err != nil {
-		return err
+		return etl, err
 	}
-	return nil
+	return etl, nil
 }
 
 func (repository EventTimelineRepositoryImpl) Update(etlID uint, etl model.EventTimeline) error {
@@ -37,8 +39,8 @@ func (repository EventTimelineRepositoryImpl) Update(etlID uint, etl model.Event
 	return nil
 }
 
-func (repository EventTimelineRepositoryImpl) Delete(etlID uint) error {
-	if err := repository.DB.Delete(&model.EventTimeline{}, etlID).Error; err != nil {
+func (repository EventTimelineRepositoryImpl) Delete(etlID uint, deletedBy string) error {
+	if err := repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.EventTimeline{}, etlID).Error; err != nil {
 		return err
 	}
 	return nil
@@ -72,3 +74,12 @@ func (repository EventTimelineRepositoryImpl) FindManyByEventID(eventID uint) ([
 	}
 	return eventTimelines, nil
 }
+
+func (repository EventTimelineRepositoryImpl) FindManyByEventIDAndPhase(eventID uint, phase string) ([]model.EventTimeline, error) {
+	var eventTimelines []model.EventTimeline
+	if err := repository.DB.Where("event_id=? AND phase=?", eventID, phase).
+		Find(&eventTimelines).Error; err != nil {
+		return eventTimelines, err
+	}
+	return eventTimelines, nil
+}
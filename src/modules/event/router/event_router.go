@@ -10,23 +10,23 @@ import (
 
 func EventRouter(group *gin.RouterGroup) {
 	group.POST("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionEventManage),
 		event.GetController().Create,
 	)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionEventManage),
 		event.GetController().Update,
 	)
 	group.DELETE("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionEventManage),
 		event.GetController().Delete,
 	)
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionEventManage),
 		event.GetController().GetList,
 	)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionEventManage),
 		event.GetController().GetDetail,
 	)
 	group.GET("/:id/rules",
@@ -38,24 +38,28 @@ func EventRouter(group *gin.RouterGroup) {
 		middlewares.RolePermission(constants.UserParticipant),
 		event.GetController().GetSchedules,
 	)
+	group.GET("/:id/analytics",
+		middlewares.Permission(constants.PermissionAnalyticsView),
+		event.GetEventAnalyticsController().GetAnalytics,
+	)
 
 	/// Event Mentor Routes ///
 	em := group.Group("/mentors")
 	{
 		em.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventMentorController().Create,
 		)
 		em.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventMentorController().Delete,
 		)
 		em.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventMentorController().GetAll,
 		)
 		em.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventMentorController().GetDetail,
 		)
 	}
@@ -64,44 +68,53 @@ func EventRouter(group *gin.RouterGroup) {
 	ej := group.Group("/judges")
 	{
 		ej.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventJudgeController().Create,
 		)
 		ej.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventJudgeController().Delete,
 		)
 		ej.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventJudgeController().GetAll,
 		)
 		ej.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventJudgeController().GetDetail,
 		)
 	}
 
+	/// Event Staff Routes ///
+	es := group.Group("/staff")
+	{
+		es.Use(middlewares.Permission(constants.PermissionEventManage))
+		es.POST("/", event.GetEventStaffController().Create)
+		es.DELETE("/:id", event.GetEventStaffController().Delete)
+		es.GET("/", event.GetEventStaffController().GetAll)
+	}
+
 	/// Event Company Routes ///
 	ec := group.Group("/companies")
 	{
 		ec.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventCompanyController().Create,
 		)
 		ec.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventCompanyController().Update,
 		)
 		ec.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventCompanyController().Delete,
 		)
 		ec.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventCompanyController().GetList,
 		)
 		ec.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventCompanyController().GetDetail,
 		)
 	}
@@ -110,23 +123,23 @@ func EventRouter(group *gin.RouterGroup) {
 	etl := group.Group("/timelines")
 	{
 		etl.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventTimelineController().Create,
 		)
 		etl.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventTimelineController().Update,
 		)
 		etl.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventTimelineController().Delete,
 		)
 		etl.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventTimelineController().GetList,
 		)
 		etl.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventTimelineController().GetDetail,
 		)
 	}
@@ -135,23 +148,23 @@ func EventRouter(group *gin.RouterGroup) {
 	evr := group.Group("/rules")
 	{
 		evr.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventRuleController().Create,
 		)
 		evr.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventRuleController().Update,
 		)
 		evr.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventRuleController().Delete,
 		)
 		evr.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventRuleController().GetList,
 		)
 		evr.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventRuleController().GetDetail,
 		)
 	}
@@ -160,23 +173,23 @@ func EventRouter(group *gin.RouterGroup) {
 	efaq := group.Group("/faqs")
 	{
 		efaq.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventFaqController().Create,
 		)
 		efaq.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventFaqController().Update,
 		)
 		efaq.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventFaqController().Delete,
 		)
 		efaq.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventFaqController().GetList,
 		)
 		efaq.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventFaqController().GetDetail,
 		)
 	}
@@ -185,23 +198,23 @@ func EventRouter(group *gin.RouterGroup) {
 	eac := group.Group("/assessments")
 	{
 		eac.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventAssessmentCriteriaController().Create,
 		)
 		eac.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventAssessmentCriteriaController().Update,
 		)
 		eac.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventAssessmentCriteriaController().Delete,
 		)
 		eac.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin, constants.UserJudge),
+			middlewares.Permission(constants.PermissionEventManage, constants.PermissionAssessmentCriteriaView),
 			event.GetEventAssessmentCriteriaController().GetList,
 		)
 		eac.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionEventManage),
 			event.GetEventAssessmentCriteriaController().GetDetail,
 		)
 	}
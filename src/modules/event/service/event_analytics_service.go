@@ -0,0 +1,96 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	"context"
+	"math"
+)
+
+type EventAnalyticsService interface {
+	GetAnalytics(ctx context.Context, eventID uint) (model.EventAnalytics, error)
+}
+
+type EventAnalyticsServiceImpl struct {
+	Repository repository.EventAnalyticsRepository
+	EventRepo  repository.EventRepository
+	Scope      EventScope
+}
+
+func NewEventAnalyticsService(
+	repository repository.EventAnalyticsRepository,
+	eventRepo repository.EventRepository,
+	scope EventScope,
+) EventAnalyticsService {
+	return &EventAnalyticsServiceImpl{
+		Repository: repository,
+		EventRepo:  eventRepo,
+		Scope:      scope,
+	}
+}
+
+func (service *EventAnalyticsServiceImpl) GetAnalytics(ctx context.Context, eventID uint) (analytics model.EventAnalytics, err error) {
+	if _, err = service.EventRepo.FindOne(eventID); err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+
+	analytics.EventID = eventID
+	if analytics.Registrations, err = service.Repository.CountRegistrations(eventID); err != nil {
+		return
+	}
+
+	if analytics.Profiles, err = service.Repository.CountProfiles(eventID); err != nil {
+		return
+	}
+	analytics.Profiles.Rate = rate(analytics.Profiles.Completed, analytics.Profiles.Participants)
+
+	if analytics.Payments, err = service.Repository.CountPayments(eventID); err != nil {
+		return
+	}
+	analytics.Payments.ConversionRate = rate(analytics.Payments.Paid, analytics.Profiles.Participants)
+
+	if analytics.Teams, err = service.Repository.CountTeams(eventID); err != nil {
+		return
+	}
+
+	projects, err := service.Repository.CountProjects(eventID)
+	if err != nil {
+		return
+	}
+	for _, count := range projects {
+		analytics.Projects.Total += count.Total
+		switch count.Status {
+		case constants.ProjectStatusDraft:
+			analytics.Projects.Draft = count.Total
+		case constants.ProjectStatusSubmitted:
+			analytics.Projects.Submitted = count.Total
+		case constants.ProjectStatusAssessed:
+			analytics.Projects.Assessed = count.Total
+		case constants.ProjectStatusInactive:
+			analytics.Projects.Inactive = count.Total
+		}
+	}
+
+	if analytics.Judging, err = service.Repository.CountJudging(eventID); err != nil {
+		return
+	}
+	analytics.Judging.ProjectsToAssess = analytics.Projects.Submitted + analytics.Projects.Assessed
+	analytics.Judging.ProjectsAssessed = analytics.Projects.Assessed
+	analytics.Judging.Progress = rate(analytics.Judging.CompletedAssignments, analytics.Judging.Assignments)
+
+	analytics.Breakdowns, err = service.Repository.CountBreakdowns(eventID)
+	return
+}
+
+// rate is part of total as a percentage rounded to two decimals, zero when there is nothing to count
+func rate(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"testing"
+)
+
+type fakeEventRepository struct {
+	repository.EventRepository
+}
+
+func (fakeEventRepository) FindOne(eventID uint) (event model.Event, err error) {
+	event.ID = eventID
+	return
+}
+
+// fakeAnalyticsRepository returns the same counts for every event and tells whether it was asked
+type fakeAnalyticsRepository struct {
+	repository.EventAnalyticsRepository
+	counted bool
+}
+
+func (repository *fakeAnalyticsRepository) CountRegistrations(uint) ([]model.RegistrationCount, error) {
+	repository.counted = true
+	return nil, nil
+}
+
+func (repository *fakeAnalyticsRepository) CountProfiles(uint) (model.ProfileCompletionStats, error) {
+	return model.ProfileCompletionStats{Participants: 3, Completed: 2}, nil
+}
+
+func (repository *fakeAnalyticsRepository) CountPayments(uint) (model.PaymentFunnelStats, error) {
+	return model.PaymentFunnelStats{Unpaid: 2, Paid: 1}, nil
+}
+
+func (repository *fakeAnalyticsRepository) CountTeams(uint) (model.TeamStats, error) {
+	return model.TeamStats{}, nil
+}
+
+func (repository *fakeAnalyticsRepository) CountProjects(uint) ([]model.StatusCount, error) {
+	return []model.StatusCount{
+		{Status: constants.ProjectStatusDraft, Total: 1},
+		{Status: constants.ProjectStatusSubmitted, Total: 2},
+		{Status: constants.ProjectStatusAssessed, Total: 3},
+	}, nil
+}
+
+func (repository *fakeAnalyticsRepository) CountJudging(uint) (model.JudgingStats, error) {
+	return model.JudgingStats{Assignments: 8, CompletedAssignments: 5}, nil
+}
+
+func (repository *fakeAnalyticsRepository) CountBreakdowns(uint) (model.AnalyticsBreakdowns, error) {
+	return model.AnalyticsBreakdowns{}, nil
+}
+
+func TestGetAnalytics(t *testing.T) {
+	service := NewEventAnalyticsService(&fakeAnalyticsRepository{}, fakeEventRepository{}, newTestEventScope())
+
+	analytics, err := service.GetAnalytics(scopeContext(3, 2), 1)
+	if err != nil {
+		t.Fatalf("analytics returned %v", err)
+	}
+
+	if analytics.Profiles.Rate != 66.67 || analytics.Payments.ConversionRate != 33.33 {
+		t.Errorf("profile rate = %v and conversion rate = %v, want 66.67 and 33.33",
+			analytics.Profiles.Rate, analytics.Payments.ConversionRate)
+	}
+	if want := (model.ProjectStats{Total: 6, Draft: 1, Submitted: 2, Assessed: 3}); analytics.Projects != want {
+		t.Errorf("projects = %+v, want %+v", analytics.Projects, want)
+	}
+	judging := analytics.Judging
+	if judging.ProjectsToAssess != 5 || judging.ProjectsAssessed != 3 || judging.Progress != 62.5 {
+		t.Errorf("judging = %+v, want 5 projects to assess, 3 assessed and 62.5 progress", judging)
+	}
+}
+
+func TestGetAnalyticsOfAnotherEvent(t *testing.T) {
+	repository := &fakeAnalyticsRepository{}
+	service := NewEventAnalyticsService(repository, fakeEventRepository{}, newTestEventScope())
+
+	if _, err := service.GetAnalytics(scopeContext(4, 2), 1); err != e.ErrNotEventStaff {
+		t.Errorf("analytics of an event the user doesn't organize returned %v, want %v", err, e.ErrNotEventStaff)
+	}
+	if repository.counted {
+		t.Error("analytics of an event the user doesn't organize were counted")
+	}
+}
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		part, total int64
+		want        float64
+	}{
+		{part: 0, total: 0, want: 0},
+		{part: 1, total: 3, want: 33.33},
+		{part: 2, total: 3, want: 66.67},
+		{part: 3, total: 3, want: 100},
+	}
+
+	for _, tt := range tests {
+		if got := rate(tt.part, tt.total); got != tt.want {
+			t.Errorf("rate(%d, %d) = %v, want %v", tt.part, tt.total, got, tt.want)
+		}
+	}
+}
@@ -3,15 +3,18 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 )
 
 type EventAssessmentCriteriaService interface {
 	Create(ctx context.Context, req model.EventAssessmentCriteriaRequest) error
 	Update(ctx context.Context, req model.UpdateEventAssessmentCriteriaRequest, id uint) error
-	Delete(id uint) error
+	Delete(ctx context.Context, id uint) error
 	GetList(
 		filter model.FilterEventAssessmentCriteria,
 		pg *utils.PaginateQueryOffset,
@@ -23,13 +26,17 @@ type EventAssessmentCriteriaService interface {
 type EventAssessmentCriteriaServiceImpl struct {
 	Repository      repository.EventAssessmentCriteriaRepository
 	EventRepository repository.EventRepository
+	Scope           EventScope
+	Audit           ads.Recorder
 }
 
 func NewEventAssessmentCriteriaService(
 	repository repository.EventAssessmentCriteriaRepository,
 	eventRepository repository.EventRepository,
+	scope EventScope,
+	audit ads.Recorder,
 ) EventAssessmentCriteriaService {
-	return &EventAssessmentCriteriaServiceImpl{Repository: repository, EventRepository: eventRepository}
+	return &EventAssessmentCriteriaServiceImpl{Repository: repository, EventRepository: eventRepository, Scope: scope, Audit: audit}
 }
 
 func (service *EventAssessmentCriteriaServiceImpl) Create(ctx context.Context, req model.EventAssessmentCriteriaRequest) error {
@@ -37,7 +44,11 @@ func (service *EventAssessmentCriteriaServiceImpl) Create(ctx context.Context, r
 		return err
 	}
 
-	if err := service.Repository.Save(model.EventAssessmentCriteria{
+	if err := service.Scope.Check(ctx, req.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	saved, err := service.Repository.Save(model.EventAssessmentCriteria{
 		BaseEntity:    builder.BuildBaseEntity(ctx, true, nil),
 		EventID:       req.EventID,
 		Criteria:      req.Criteria,
@@ -45,10 +56,12 @@ func (service *EventAssessmentCriteriaServiceImpl) Create(ctx context.Context, r
 		ScoreStart:    req.ScoreStart,
 		ScoreEnd:      req.ScoreEnd,
 		IsActive:      true,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityAssessmentCriteria, saved.ID, nil, saved)
 	return nil
 }
 
@@ -58,7 +71,11 @@ func (service *EventAssessmentCriteriaServiceImpl) Update(ctx context.Context, r
 		return err
 	}
 
-	if err = service.Repository.Update(id, model.EventAssessmentCriteria{
+	if err = service.Scope.Check(ctx, criteria.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	updated := model.EventAssessmentCriteria{
 		BaseEntity:    builder.BuildBaseEntity(ctx, false, &criteria.BaseEntity),
 		EventID:       criteria.EventID,
 		Criteria:      req.Criteria,
@@ -66,22 +83,31 @@ func (service *EventAssessmentCriteriaServiceImpl) Update(ctx context.Context, r
 		ScoreStart:    req.ScoreStart,
 		ScoreEnd:      req.ScoreEnd,
 		IsActive:      req.IsActive,
-	}); err != nil {
+	}
+	if err = service.Repository.Update(id, updated); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityAssessmentCriteria, id, criteria, updated)
 	return nil
 }
 
-func (service *EventAssessmentCriteriaServiceImpl) Delete(id uint) error {
-	if _, err := service.Repository.FindOne(id); err != nil {
+func (service *EventAssessmentCriteriaServiceImpl) Delete(ctx context.Context, id uint) error {
+	existing, err := service.Repository.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(id); err != nil {
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(id, authUser.Email); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityAssessmentCriteria, id, existing, nil)
 	return nil
 }
 
@@ -3,14 +3,17 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 )
 
 type EventCompanyService interface {
 	Create(ctx context.Context, req model.EventCompanyRequest) error
 	Update(ctx context.Context, req model.EventCompanyRequest, ecID uint) error
-	Delete(ecID uint) error
+	Delete(ctx context.Context, ecID uint) error
 	GetList(filter model.FilterEventCompany) (companies []model.EventCompany, err error)
 	GetDetail(ecID uint) (company model.EventCompany, err error)
 }
@@ -18,13 +21,17 @@ type EventCompanyService interface {
 type EventCompanyServiceImpl struct {
 	Repository      repository.EventCompanyRepository
 	EventRepository repository.EventRepository
+	Scope           EventScope
+	Audit           ads.Recorder
 }
 
 func NewEventCompanyService(
 	repository repository.EventCompanyRepository,
 	eventRepository repository.EventRepository,
+	scope EventScope,
+	audit ads.Recorder,
 ) EventCompanyService {
-	return &EventCompanyServiceImpl{Repository: repository, EventRepository: eventRepository}
+	return &EventCompanyServiceImpl{Repository: repository, EventRepository: eventRepository, Scope: scope, Audit: audit}
 }
 
 func (service *EventCompanyServiceImpl) Create(ctx context.Context, req model.EventCompanyRequest) error {
@@ -32,7 +39,11 @@ func (service *EventCompanyServiceImpl) Create(ctx context.Context, req model.Ev
 		return err
 	}
 
-	if err := service.Repository.Save(model.EventCompany{
+	if err := service.Scope.Check(ctx, req.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	saved, err := service.Repository.Save(model.EventCompany{
 		BaseEntity:        builder.BuildBaseEntity(ctx, true, nil),
 		EventID:           req.EventID,
 		Name:              req.Name,
@@ -42,10 +53,12 @@ func (service *EventCompanyServiceImpl) Create(ctx context.Context, req model.Ev
 		SponsorshipLevel:  req.SponsorshipLevel,
 		SponsorshipAmount: req.SponsorshipAmount,
 		Logo:              req.Logo,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventCompany, saved.ID, nil, saved)
 	return nil
 }
 
@@ -55,7 +68,11 @@ func (service *EventCompanyServiceImpl) Update(ctx context.Context, req model.Ev
 		return err
 	}
 
-	if err = service.Repository.Update(ecID, model.EventCompany{
+	if err = service.Scope.Check(ctx, eventCompany.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	updated := model.EventCompany{
 		BaseEntity:        builder.BuildBaseEntity(ctx, false, &eventCompany.BaseEntity),
 		EventID:           eventCompany.EventID,
 		Name:              req.Name,
@@ -65,22 +82,31 @@ func (service *EventCompanyServiceImpl) Update(ctx context.Context, req model.Ev
 		SponsorshipLevel:  req.SponsorshipLevel,
 		SponsorshipAmount: req.SponsorshipAmount,
 		Logo:              req.Logo,
-	}); err != nil {
+	}
+	if err = service.Repository.Update(ecID, updated); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityEventCompany, ecID, eventCompany, updated)
 	return nil
 }
 
-func (service *EventCompanyServiceImpl) Delete(ecID uint) error {
-	if _, err := service.Repository.FindOne(ecID); err != nil {
+func (service *EventCompanyServiceImpl) Delete(ctx context.Context, ecID uint) error {
+	existing, err := service.Repository.FindOne(ecID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(ecID); err != nil {
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(ecID, authUser.Email); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventCompany, ecID, existing, nil)
 	return nil
 }
 
@@ -3,15 +3,18 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 )
 
 type EventFaqService interface {
 	Create(ctx context.Context, req model.EventFaqRequest) error
 	Update(ctx context.Context, req model.UpdateEventFaqRequest, id uint) error
-	Delete(id uint) error
+	Delete(ctx context.Context, id uint) error
 	GetList(
 		filter model.FilterEventFaq,
 		pg *utils.PaginateQueryOffset,
@@ -22,13 +25,17 @@ type EventFaqService interface {
 type EventFaqServiceImpl struct {
 	Repository      repository.EventFaqRepository
 	EventRepository repository.EventRepository
+	Scope           EventScope
+	Audit           ads.Recorder
 }
 
 func NewEventFaqService(
 	repository repository.EventFaqRepository,
 	eventRepository repository.EventRepository,
+	scope EventScope,
+	audit ads.Recorder,
 ) EventFaqService {
-	return &EventFaqServiceImpl{Repository: repository, EventRepository: eventRepository}
+	return &EventFaqServiceImpl{Repository: repository, EventRepository: eventRepository, Scope: scope, Audit: audit}
 }
 
 func (service *EventFaqServiceImpl) Create(ctx context.Context, req model.EventFaqRequest) error {
@@ -36,16 +43,22 @@ func (service *EventFaqServiceImpl) Create(ctx context.Context, req model.EventF
 		return err
 	}
 
-	if err := service.Repository.Save(model.EventFaq{
+	if err := service.Scope.Check(ctx, req.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	saved, err := service.Repository.Save(model.EventFaq{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		EventID:    req.EventID,
 		Title:      req.Title,
 		Note:       req.Note,
 		IsActive:   true,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventFaq, saved.ID, nil, saved)
 	return nil
 }
 
@@ -55,28 +68,41 @@ func (service *EventFaqServiceImpl) Update(ctx context.Context, req model.Update
 		return err
 	}
 
-	if err = service.Repository.Update(id, model.EventFaq{
+	if err = service.Scope.Check(ctx, eventFaq.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	updated := model.EventFaq{
 		BaseEntity: builder.BuildBaseEntity(ctx, false, &eventFaq.BaseEntity),
 		EventID:    eventFaq.EventID,
 		Title:      req.Title,
 		Note:       req.Note,
 		IsActive:   req.IsActive,
-	}); err != nil {
+	}
+	if err = service.Repository.Update(id, updated); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityEventFaq, id, eventFaq, updated)
 	return nil
 }
 
-func (service *EventFaqServiceImpl) Delete(id uint) error {
-	if _, err := service.Repository.FindOne(id); err != nil {
+func (service *EventFaqServiceImpl) Delete(ctx context.Context, id uint) error {
+	existing, err := service.Repository.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(id); err != nil {
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(id, authUser.Email); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventFaq, id, existing, nil)
 	return nil
 }
 
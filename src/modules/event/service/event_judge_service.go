@@ -3,27 +3,35 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"context"
 )
 
 type EventJudgeService interface {
 	Create(ctx context.Context, request model.EventJudgeRequest) error
-	Delete(ejID uint) error
+	Delete(ctx context.Context, ejID uint) error
 	GetAll(filter model.FilterEventJudge) (judges []model.EventJudgeLite, err error)
 	GetDetail(ejID uint) (judge model.EventJudge, err error)
 }
 
 type EventJudgeServiceImpl struct {
 	Repository repository.EventJudgeRepository
+	Scope      EventScope
+	Audit      ads.Recorder
 }
 
-func NewEventJudgeService(repository repository.EventJudgeRepository) EventJudgeService {
-	return &EventJudgeServiceImpl{Repository: repository}
+func NewEventJudgeService(repository repository.EventJudgeRepository, scope EventScope, audit ads.Recorder) EventJudgeService {
+	return &EventJudgeServiceImpl{Repository: repository, Scope: scope, Audit: audit}
 }
 
 func (service *EventJudgeServiceImpl) Create(ctx context.Context, request model.EventJudgeRequest) error {
+	if err := service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	existing, err := service.Repository.FindOneByJudgeIDAndEventID(request.JudgeID, request.EventID)
 	if err != nil && err != e.ErrDataNotFound {
 		return err
@@ -33,23 +41,32 @@ func (service *EventJudgeServiceImpl) Create(ctx context.Context, request model.
 		return e.ErrEventJudgeExist
 	}
 
-	if err = service.Repository.Save(model.EventJudge{
+	saved, err := service.Repository.Save(model.EventJudge{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		EventID:    request.EventID,
 		JudgeID:    request.JudgeID,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventJudge, saved.ID, nil, saved)
 	return nil
 }
 
-func (service *EventJudgeServiceImpl) Delete(ejID uint) error {
-	if _, err := service.Repository.FindOne(ejID); err != nil {
+func (service *EventJudgeServiceImpl) Delete(ctx context.Context, ejID uint) error {
+	judge, err := service.Repository.FindOne(ejID)
+	if err != nil {
 		return err
 	}
-	if err := service.Repository.Delete(ejID); err != nil {
+	if err = service.Scope.Check(ctx, judge.EventID, constants.EventStaffOrganizer); err != nil {
 		return err
 	}
+	if err = service.Repository.Delete(ejID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventJudge, ejID, judge, nil)
 	return nil
 }
 
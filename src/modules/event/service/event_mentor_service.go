@@ -3,27 +3,35 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"context"
 )
 
 type EventMentorService interface {
 	Create(ctx context.Context, request model.EventMentorRequest) error
-	Delete(emID uint) error
+	Delete(ctx context.Context, emID uint) error
 	GetAll(filter model.FilterEventMentor) (mentors []model.EventMentorLite, err error)
 	GetDetail(emID uint) (mentor model.EventMentor, err error)
 }
 
 type EventMentorServiceImpl struct {
 	Repository repository.EventMentorRepository
+	Scope      EventScope
+	Audit      ads.Recorder
 }
 
-func NewEventMentorService(repository repository.EventMentorRepository) EventMentorService {
-	return &EventMentorServiceImpl{Repository: repository}
+func NewEventMentorService(repository repository.EventMentorRepository, scope EventScope, audit ads.Recorder) EventMentorService {
+	return &EventMentorServiceImpl{Repository: repository, Scope: scope, Audit: audit}
 }
 
 func (service *EventMentorServiceImpl) Create(ctx context.Context, request model.EventMentorRequest) error {
+	if err := service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	existing, err := service.Repository.FindOneByMentorIDAndEventID(request.MentorID, request.EventID)
 	if err != nil && err != e.ErrDataNotFound {
 		return err
@@ -33,23 +41,32 @@ func (service *EventMentorServiceImpl) Create(ctx context.Context, request model
 		return e.ErrEventMentorExist
 	}
 
-	if err = service.Repository.Save(model.EventMentor{
+	saved, err := service.Repository.Save(model.EventMentor{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		EventID:    request.EventID,
 		MentorID:   request.MentorID,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventMentor, saved.ID, nil, saved)
 	return nil
 }
 
-func (service *EventMentorServiceImpl) Delete(emID uint) error {
-	if _, err := service.Repository.FindOne(emID); err != nil {
+func (service *EventMentorServiceImpl) Delete(ctx context.Context, emID uint) error {
+	mentor, err := service.Repository.FindOne(emID)
+	if err != nil {
 		return err
 	}
-	if err := service.Repository.Delete(emID); err != nil {
+	if err = service.Scope.Check(ctx, mentor.EventID, constants.EventStaffOrganizer); err != nil {
 		return err
 	}
+	if err = service.Repository.Delete(emID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventMentor, emID, mentor, nil)
 	return nil
 }
 
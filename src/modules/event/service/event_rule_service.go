@@ -3,15 +3,18 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 )
 
 type EventRuleService interface {
 	Create(ctx context.Context, req model.EventRuleRequest) error
 	Update(ctx context.Context, req model.UpdateEventRuleRequest, erID uint) error
-	Delete(erID uint) error
+	Delete(ctx context.Context, erID uint) error
 	GetList(
 		filter model.FilterEventRule,
 		pg *utils.PaginateQueryOffset,
@@ -23,13 +26,17 @@ type EventRuleService interface {
 type EventRuleServiceImpl struct {
 	Repository      repository.EventRuleRepository
 	EventRepository repository.EventRepository
+	Scope           EventScope
+	Audit           ads.Recorder
 }
 
 func NewEventRuleService(
 	repository repository.EventRuleRepository,
 	eventRepository repository.EventRepository,
+	scope EventScope,
+	audit ads.Recorder,
 ) EventRuleService {
-	return &EventRuleServiceImpl{Repository: repository, EventRepository: eventRepository}
+	return &EventRuleServiceImpl{Repository: repository, EventRepository: eventRepository, Scope: scope, Audit: audit}
 }
 
 func (service *EventRuleServiceImpl) Create(ctx context.Context, req model.EventRuleRequest) error {
@@ -37,16 +44,22 @@ func (service *EventRuleServiceImpl) Create(ctx context.Context, req model.Event
 		return err
 	}
 
-	if err := service.Repository.Save(model.EventRule{
+	if err := service.Scope.Check(ctx, req.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	saved, err := service.Repository.Save(model.EventRule{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		EventID:    req.EventID,
 		Title:      req.Title,
 		Note:       req.Note,
 		IsActive:   true,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventRule, saved.ID, nil, saved)
 	return nil
 }
 
@@ -56,28 +69,41 @@ func (service *EventRuleServiceImpl) Update(ctx context.Context, req model.Updat
 		return err
 	}
 
-	if err = service.Repository.Update(erID, model.EventRule{
+	if err = service.Scope.Check(ctx, eventRule.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	updated := model.EventRule{
 		BaseEntity: builder.BuildBaseEntity(ctx, false, &eventRule.BaseEntity),
 		EventID:    eventRule.EventID,
 		Title:      req.Title,
 		Note:       req.Note,
 		IsActive:   req.IsActive,
-	}); err != nil {
+	}
+	if err = service.Repository.Update(erID, updated); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityEventRule, erID, eventRule, updated)
 	return nil
 }
 
-func (service *EventRuleServiceImpl) Delete(erID uint) error {
-	if _, err := service.Repository.FindOne(erID); err != nil {
+func (service *EventRuleServiceImpl) Delete(ctx context.Context, erID uint) error {
+	existing, err := service.Repository.FindOne(erID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(erID); err != nil {
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(erID, authUser.Email); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventRule, erID, existing, nil)
 	return nil
 }
 
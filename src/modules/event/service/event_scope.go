@@ -0,0 +1,60 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+)
+
+// EventScope is used by other modules to limit staff to the events they have a role in.
+// Users whose role has PermissionEventManageAll are not limited.
+type EventScope interface {
+	Check(ctx context.Context, eventID uint, roles ...string) error
+	EventIDs(ctx context.Context, roles ...string) (eventIDs []uint, all bool, err error)
+}
+
+type EventScopeImpl struct {
+	Repository     repository.EventStaffRepository
+	PermissionRepo ur.PermissionRepository
+}
+
+func NewEventScope(repository repository.EventStaffRepository, permissionRepo ur.PermissionRepository) EventScope {
+	return &EventScopeImpl{Repository: repository, PermissionRepo: permissionRepo}
+}
+
+// Check returns ErrNotEventStaff when the authenticated user has none of the roles in the event
+func (scope *EventScopeImpl) Check(ctx context.Context, eventID uint, roles ...string) error {
+	authenticatedUser := ctx.Value("user").(um.User)
+	all, err := scope.manageAll(authenticatedUser)
+	if err != nil || all {
+		return err
+	}
+
+	ok, err := scope.Repository.HasRole(eventID, authenticatedUser.ID, roles)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.ErrNotEventStaff
+	}
+	return nil
+}
+
+// EventIDs returns the events the authenticated user has one of the roles in, all is true when
+// the user isn't limited and eventIDs should be ignored
+func (scope *EventScopeImpl) EventIDs(ctx context.Context, roles ...string) (eventIDs []uint, all bool, err error) {
+	authenticatedUser := ctx.Value("user").(um.User)
+	if all, err = scope.manageAll(authenticatedUser); err != nil || all {
+		return
+	}
+
+	eventIDs, err = scope.Repository.FindEventIDs(authenticatedUser.ID, roles)
+	return
+}
+
+func (scope *EventScopeImpl) manageAll(user um.User) (bool, error) {
+	return scope.PermissionRepo.HasAny(user.UserRoleID, []string{constants.PermissionEventManageAll})
+}
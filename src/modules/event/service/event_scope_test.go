@@ -0,0 +1,103 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/event/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"testing"
+)
+
+// fakeEventStaffRepository keeps the staff of every event
+type fakeEventStaffRepository struct {
+	repository.EventStaffRepository
+	staffs []model.EventStaff
+}
+
+func (repository *fakeEventStaffRepository) HasRole(eventID, userID uint, roles []string) (bool, error) {
+	for _, staff := range repository.staffs {
+		if staff.EventID == eventID && staff.UserID == userID && helper.StringInSlice(staff.Role, roles) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (repository *fakeEventStaffRepository) FindEventIDs(userID uint, roles []string) (eventIDs []uint, err error) {
+	for _, staff := range repository.staffs {
+		if staff.UserID == userID && helper.StringInSlice(staff.Role, roles) && !helper.UintInSlice(staff.EventID, eventIDs) {
+			eventIDs = append(eventIDs, staff.EventID)
+		}
+	}
+	return
+}
+
+// fakePermissionRepository grants PermissionEventManageAll to the roles in manageAll
+type fakePermissionRepository struct {
+	ur.PermissionRepository
+	manageAll []uint
+}
+
+func (repository fakePermissionRepository) HasAny(roleID uint, names []string) (bool, error) {
+	return helper.UintInSlice(roleID, repository.manageAll) &&
+		helper.StringInSlice(constants.PermissionEventManageAll, names), nil
+}
+
+func newTestEventScope() *EventScopeImpl {
+	return &EventScopeImpl{
+		Repository: &fakeEventStaffRepository{staffs: []model.EventStaff{
+			{EventID: 1, UserID: 3, Role: constants.EventStaffOrganizer},
+			{EventID: 2, UserID: 3, Role: constants.EventStaffJudge},
+			{EventID: 2, UserID: 4, Role: constants.EventStaffOrganizer},
+		}},
+		PermissionRepo: fakePermissionRepository{manageAll: []uint{1}},
+	}
+}
+
+func scopeContext(userID, roleID uint) context.Context {
+	user := um.User{UserRoleID: roleID}
+	user.ID = userID
+	return context.WithValue(context.Background(), "user", user)
+}
+
+func TestEventScopeCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		roleID  uint
+		eventID uint
+		roles   []string
+		want    error
+	}{
+		{name: "organizer of the event", userID: 3, roleID: 2, eventID: 1, roles: []string{constants.EventStaffOrganizer}},
+		{name: "judge asked for organizer", userID: 3, roleID: 2, eventID: 2, roles: []string{constants.EventStaffOrganizer}, want: e.ErrNotEventStaff},
+		{name: "judge among the roles", userID: 3, roleID: 2, eventID: 2, roles: []string{constants.EventStaffOrganizer, constants.EventStaffJudge}},
+		{name: "organizer of another event", userID: 4, roleID: 2, eventID: 1, roles: []string{constants.EventStaffOrganizer}, want: e.ErrNotEventStaff},
+		{name: "manages every event", userID: 5, roleID: 1, eventID: 1, roles: []string{constants.EventStaffOrganizer}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newTestEventScope().Check(scopeContext(tt.userID, tt.roleID), tt.eventID, tt.roles...); err != tt.want {
+				t.Errorf("check of %s returned %v, want %v", tt.name, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventScopeEventIDs(t *testing.T) {
+	scope := newTestEventScope()
+
+	eventIDs, all, err := scope.EventIDs(scopeContext(3, 2), constants.EventStaffOrganizer)
+	if err != nil || all || len(eventIDs) != 1 || eventIDs[0] != 1 {
+		t.Errorf("event ids of an organizer = %v, %v, %v, want [1], false, nil", eventIDs, all, err)
+	}
+
+	if _, all, err = scope.EventIDs(scopeContext(5, 1), constants.EventStaffOrganizer); err != nil || !all {
+		t.Errorf("event ids of a user managing every event = %v, %v, want all", all, err)
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	scm "be-sagara-hackathon/src/modules/schedule/model"
 	scr "be-sagara-hackathon/src/modules/schedule/repository"
 	tr "be-sagara-hackathon/src/modules/team/repository"
@@ -13,6 +14,8 @@ import (
 	e "be-sagara-hackathon/src/utils/errors"
 	"be-sagara-hackathon/src/utils/helper"
 	"context"
+	"log"
+	"runtime/debug"
 	"time"
 )
 
@@ -21,19 +24,29 @@ type EventService interface {
 	UpdateEvent(ctx context.Context, request model.UpdateEventRequest, eventID uint) error
 	DeleteEvent(ctx context.Context, eventID uint) error
 	GetListEvent(
+		ctx context.Context,
 		filter model.FilterEvent,
 		pg *utils.PaginateQueryOffset,
 	) (response model.ListEventResponse, err error)
-	GetDetailEvent(eventID uint) (event model.Event, err error)
+	GetDetailEvent(ctx context.Context, eventID uint) (event model.Event, err error)
 	GetLatestEvent() (response model.EventResponse, err error)
 	GetSchedules(ctx context.Context, eventID uint) (schedules []scm.ScheduleLite2, err error)
+	OnFinished(listener EventFinishedListener)
 }
 
+// EventFinishedListener is run in the background once an event is updated to finished
+type EventFinishedListener func(eventID uint, finishedBy string)
+
 type EventServiceImpl struct {
 	Repository           repository.EventRepository
 	EventParticipantRepo repository.EventParticipantRepository
 	TeamMemberRepo       tr.TeamMemberRepository
 	ScheduleRepo         scr.ScheduleRepository
+	TimelineRepo         repository.EventTimelineRepository
+	StaffRepo            repository.EventStaffRepository
+	Scope                EventScope
+	Audit                ads.Recorder
+	finishedListeners    []EventFinishedListener
 }
 
 func NewEventRepository(
@@ -41,12 +54,20 @@ func NewEventRepository(
 	eventParticipantRepo repository.EventParticipantRepository,
 	teamMemberRepo tr.TeamMemberRepository,
 	scheduleRepo scr.ScheduleRepository,
+	timelineRepo repository.EventTimelineRepository,
+	staffRepo repository.EventStaffRepository,
+	scope EventScope,
+	audit ads.Recorder,
 ) EventService {
 	return &EventServiceImpl{
 		Repository:           repository,
 		EventParticipantRepo: eventParticipantRepo,
 		TeamMemberRepo:       teamMemberRepo,
 		ScheduleRepo:         scheduleRepo,
+		TimelineRepo:         timelineRepo,
+		StaffRepo:            staffRepo,
+		Scope:                scope,
+		Audit:                audit,
 	}
 }
 
@@ -93,6 +114,18 @@ func (service *EventServiceImpl) CreateEvent(ctx context.Context, request model.
 		event = nil
 		return
 	}
+
+	// the creator organizes the event, so it stays manageable without PermissionEventManageAll
+	if err = service.StaffRepo.Save(model.EventStaff{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		EventID:    event.ID,
+		UserID:     authUser.ID,
+		Role:       constants.EventStaffOrganizer,
+	}); err != nil {
+		return
+	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEvent, event.ID, nil, event)
 	return
 }
 
@@ -115,6 +148,10 @@ func (service *EventServiceImpl) UpdateEvent(ctx context.Context, request model.
 		return err
 	}
 
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	// Parse Event Date
 	startDate, err := helper.ParseDateStringToTime(request.StartDate)
 	if err != nil {
@@ -129,6 +166,7 @@ func (service *EventServiceImpl) UpdateEvent(ctx context.Context, request model.
 		return err
 	}
 
+	before := event
 	event.Name = request.Name
 	event.StartDate = startDate
 	event.EndDate = endDate
@@ -143,26 +181,71 @@ func (service *EventServiceImpl) UpdateEvent(ctx context.Context, request model.
 	if err = service.Repository.Update(event, eventID); err != nil {
 		return err
 	}
+
+	action := constants.AuditUpdate
+	if before.Status != event.Status {
+		action = constants.AuditStatus
+	}
+	service.Audit.Record(ctx, action, constants.AuditEntityEvent, eventID, before, event)
+
+	if before.Status != constants.EventFinished && event.Status == constants.EventFinished {
+		for _, listener := range service.finishedListeners {
+			go runFinishedListener(listener, eventID, event.UpdatedBy)
+		}
+	}
 	return nil
 }
 
+// runFinishedListener keeps a panicking listener from taking the whole API down with it
+func runFinishedListener(listener EventFinishedListener, eventID uint, finishedBy string) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("event: listener of finished event %d panicked: %v\n%s", eventID, r, debug.Stack())
+		}
+	}()
+	listener(eventID, finishedBy)
+}
+
+// OnFinished registers a listener for events being finished, modules register theirs when they are initialized
+func (service *EventServiceImpl) OnFinished(listener EventFinishedListener) {
+	service.finishedListeners = append(service.finishedListeners, listener)
+}
+
 func (service *EventServiceImpl) DeleteEvent(ctx context.Context, eventID uint) error {
-	_, err := service.Repository.FindOne(eventID)
+	event, err := service.Repository.FindOne(eventID)
 	if err != nil {
 		return err
 	}
 
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	authUser := ctx.Value("user").(um.User)
 	if err = service.Repository.Delete(eventID, authUser.Email); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEvent, eventID, event, nil)
 	return nil
 }
 
 func (service *EventServiceImpl) GetListEvent(
+	ctx context.Context,
 	filter model.FilterEvent,
 	pg *utils.PaginateQueryOffset,
 ) (response model.ListEventResponse, err error) {
+	eventIDs, all, err := service.Scope.EventIDs(ctx, constants.EventStaffOrganizer)
+	if err != nil {
+		return
+	}
+	if !all {
+		if len(eventIDs) == 0 {
+			return
+		}
+		filter.IDs = eventIDs
+	}
+
 	events, totalData, totalPage, err := service.Repository.Find(filter, pg)
 	if err != nil {
 		return
@@ -185,10 +268,15 @@ func (service *EventServiceImpl) GetListEvent(
 	return
 }
 
-func (service *EventServiceImpl) GetDetailEvent(eventID uint) (event model.Event, err error) {
+func (service *EventServiceImpl) GetDetailEvent(ctx context.Context, eventID uint) (event model.Event, err error) {
 	if event, err = service.Repository.FindOne(eventID); err != nil {
 		return
 	}
+
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+	event.CurrentPhase = model.CurrentPhase(event.Timelines, time.Now())
 	return
 }
 
@@ -198,6 +286,11 @@ func (service *EventServiceImpl) GetLatestEvent() (response model.EventResponse,
 		return
 	}
 
+	timelines, err := service.TimelineRepo.FindManyByEventID(event.ID)
+	if err != nil {
+		return
+	}
+
 	response = model.EventResponse{
 		Id:             event.ID,
 		Name:           event.Name,
@@ -209,6 +302,7 @@ func (service *EventServiceImpl) GetLatestEvent() (response model.EventResponse,
 		PaymentDueDate: event.PaymentDueDate,
 		TeamMinMember:  event.TeamMinMember,
 		TeamMaxMember:  event.TeamMaxMember,
+		CurrentPhase:   model.CurrentPhase(timelines, time.Now()),
 	}
 	return
 }
@@ -0,0 +1,15 @@
+package service
+
+import "testing"
+
+func TestRunFinishedListenerRecovers(t *testing.T) {
+	runFinishedListener(func(uint, string) { panic("render failed") }, 1, "admin@example.com")
+
+	called := false
+	runFinishedListener(func(eventID uint, finishedBy string) {
+		called = eventID == 2 && finishedBy == "admin@example.com"
+	}, 2, "admin@example.com")
+	if !called {
+		t.Error("the listener should get the event and who finished it")
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+)
+
+type EventStaffService interface {
+	Create(ctx context.Context, request model.EventStaffRequest) error
+	Delete(ctx context.Context, staffID uint) error
+	GetAll(ctx context.Context, filter model.FilterEventStaff) (staffs []model.EventStaffLite, err error)
+}
+
+type EventStaffServiceImpl struct {
+	Repository repository.EventStaffRepository
+	EventRepo  repository.EventRepository
+	UserRepo   ur.UserRepository
+	Scope      EventScope
+	Audit      ads.Recorder
+}
+
+func NewEventStaffService(
+	repository repository.EventStaffRepository,
+	eventRepo repository.EventRepository,
+	userRepo ur.UserRepository,
+	scope EventScope,
+	audit ads.Recorder,
+) EventStaffService {
+	return &EventStaffServiceImpl{
+		Repository: repository,
+		EventRepo:  eventRepo,
+		UserRepo:   userRepo,
+		Scope:      scope,
+		Audit:      audit,
+	}
+}
+
+func (service *EventStaffServiceImpl) Create(ctx context.Context, request model.EventStaffRequest) error {
+	if _, err := service.EventRepo.FindOne(request.EventID); err != nil {
+		return err
+	}
+
+	if err := service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	if _, err := service.UserRepo.FindByID(request.UserID); err != nil {
+		return err
+	}
+
+	_, err := service.Repository.FindOneByEventIDUserIDAndRole(request.EventID, request.UserID, request.Role)
+	if err != nil && err != e.ErrDataNotFound {
+		return err
+	}
+	if err == nil {
+		return e.ErrEventStaffExist
+	}
+
+	if err = service.Repository.Save(model.EventStaff{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		EventID:    request.EventID,
+		UserID:     request.UserID,
+		Role:       request.Role,
+	}); err != nil {
+		return err
+	}
+
+	staff, _ := service.Repository.FindOneByEventIDUserIDAndRole(request.EventID, request.UserID, request.Role)
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventStaff, staff.ID, nil, staff)
+	return nil
+}
+
+func (service *EventStaffServiceImpl) Delete(ctx context.Context, staffID uint) error {
+	staff, err := service.Repository.FindOne(staffID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, staff.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+	if err = service.Repository.Delete(staff); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventStaff, staffID, staff, nil)
+	return nil
+}
+
+// GetAll only lists the staff of events the authenticated user organizes, unless the user can manage every event
+func (service *EventStaffServiceImpl) GetAll(ctx context.Context, filter model.FilterEventStaff) (staffs []model.EventStaffLite, err error) {
+	eventIDs, all, err := service.Scope.EventIDs(ctx, constants.EventStaffOrganizer)
+	if err != nil {
+		return
+	}
+
+	if !all {
+		if len(eventIDs) == 0 {
+			return
+		}
+		filter.EventIDs = eventIDs
+	}
+	return service.Repository.FindAll(filter)
+}
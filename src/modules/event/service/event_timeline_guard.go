@@ -0,0 +1,41 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/repository"
+	e "be-sagara-hackathon/src/utils/errors"
+	"time"
+)
+
+// EventTimelineGuard is used by other modules to check that an action happens inside its timeline phase.
+type EventTimelineGuard interface {
+	CheckPhase(eventID uint, phase string) error
+}
+
+type EventTimelineGuardImpl struct {
+	Repository repository.EventTimelineRepository
+}
+
+func NewEventTimelineGuard(repository repository.EventTimelineRepository) EventTimelineGuard {
+	return &EventTimelineGuardImpl{Repository: repository}
+}
+
+// CheckPhase returns ErrOutsideTimelinePhase when the event has timelines for the phase but none of them is open.
+// An event without any timeline for the phase is not restricted.
+func (guard *EventTimelineGuardImpl) CheckPhase(eventID uint, phase string) error {
+	timelines, err := guard.Repository.FindManyByEventIDAndPhase(eventID, phase)
+	if err != nil {
+		return err
+	}
+
+	if len(timelines) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, v := range timelines {
+		if v.IsOpenAt(now) {
+			return nil
+		}
+	}
+	return e.ErrOutsideTimelinePhase
+}
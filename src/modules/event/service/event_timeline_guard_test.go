@@ -0,0 +1,86 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"testing"
+	"time"
+)
+
+type fakeEventTimelineRepository struct {
+	repository.EventTimelineRepository
+	timelines []model.EventTimeline
+}
+
+func (repository *fakeEventTimelineRepository) FindManyByEventIDAndPhase(eventID uint, phase string) (timelines []model.EventTimeline, err error) {
+	for _, timeline := range repository.timelines {
+		if timeline.EventID == eventID && timeline.Phase != nil && *timeline.Phase == phase {
+			timelines = append(timelines, timeline)
+		}
+	}
+	return
+}
+
+func timeline(phase string, start, end time.Time) model.EventTimeline {
+	return model.EventTimeline{EventID: 1, Phase: &phase, StartDate: start, EndDate: end}
+}
+
+func TestCheckPhase(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	registration := constants.TimelinePhaseRegistration
+
+	tests := []struct {
+		name      string
+		timelines []model.EventTimeline
+		want      error
+	}{
+		{name: "no timeline for the phase"},
+		{
+			name:      "only other phases",
+			timelines: []model.EventTimeline{timeline(constants.TimelinePhaseSubmission, now.Add(-time.Hour), now.Add(-time.Minute))},
+		},
+		{
+			name:      "open timeline",
+			timelines: []model.EventTimeline{timeline(registration, now.Add(-time.Hour), now.Add(time.Hour))},
+		},
+		{
+			name:      "date-only end of today",
+			timelines: []model.EventTimeline{timeline(registration, today.AddDate(0, 0, -7), today)},
+		},
+		{
+			name: "one of the timelines open",
+			timelines: []model.EventTimeline{
+				timeline(registration, now.Add(-48*time.Hour), now.Add(-24*time.Hour)),
+				timeline(registration, now.Add(-time.Hour), now.Add(time.Hour)),
+			},
+		},
+		{
+			name:      "ended",
+			timelines: []model.EventTimeline{timeline(registration, now.Add(-2*time.Hour), now.Add(-time.Hour))},
+			want:      e.ErrOutsideTimelinePhase,
+		},
+		{
+			name:      "date-only end of yesterday",
+			timelines: []model.EventTimeline{timeline(registration, today.AddDate(0, 0, -7), today.AddDate(0, 0, -1))},
+			want:      e.ErrOutsideTimelinePhase,
+		},
+		{
+			name:      "not started",
+			timelines: []model.EventTimeline{timeline(registration, now.Add(time.Hour), now.Add(2*time.Hour))},
+			want:      e.ErrOutsideTimelinePhase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := NewEventTimelineGuard(&fakeEventTimelineRepository{timelines: tt.timelines})
+
+			if err := guard.CheckPhase(1, registration); err != tt.want {
+				t.Errorf("check with %s returned %v, want %v", tt.name, err, tt.want)
+			}
+		})
+	}
+}
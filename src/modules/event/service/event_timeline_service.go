@@ -3,7 +3,10 @@ package service
 import (
 	"be-sagara-hackathon/src/modules/event/model"
 	"be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"be-sagara-hackathon/src/utils/helper"
 	"context"
 )
@@ -11,7 +14,7 @@ import (
 type EventTimelineService interface {
 	Create(ctx context.Context, request model.EventTimelineRequest) error
 	Update(ctx context.Context, request model.EventTimelineRequest, etlID uint) error
-	Delete(etlID uint) error
+	Delete(ctx context.Context, etlID uint) error
 	GetList(filter model.FilterEventTimeline) ([]model.EventTimeline, error)
 	GetDetail(etlID uint) (model.EventTimeline, error)
 }
@@ -19,13 +22,17 @@ type EventTimelineService interface {
 type EventTimelineServiceImpl struct {
 	Repository      repository.EventTimelineRepository
 	EventRepository repository.EventRepository
+	Scope           EventScope
+	Audit           ads.Recorder
 }
 
 func NewEventTimelineService(
 	repository repository.EventTimelineRepository,
 	eventRepository repository.EventRepository,
+	scope EventScope,
+	audit ads.Recorder,
 ) EventTimelineService {
-	return &EventTimelineServiceImpl{Repository: repository, EventRepository: eventRepository}
+	return &EventTimelineServiceImpl{Repository: repository, EventRepository: eventRepository, Scope: scope, Audit: audit}
 }
 
 func (service EventTimelineServiceImpl) Create(ctx context.Context, request model.EventTimelineRequest) error {
@@ -33,6 +40,10 @@ func (service EventTimelineServiceImpl) Create(ctx context.Context, request mode
 		return err
 	}
 
+	if err := service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	startDate, err := helper.ParseDateStringToTime(request.StartDate)
 	if err != nil {
 		return err
@@ -41,17 +52,20 @@ func (service EventTimelineServiceImpl) Create(ctx context.Context, request mode
 	if err != nil {
 		return err
 	}
-	if err = service.Repository.Save(model.EventTimeline{
+	saved, err := service.Repository.Save(model.EventTimeline{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		EventID:    request.EventID,
 		Title:      request.Title,
+		Phase:      request.Phase,
 		StartDate:  startDate,
 		EndDate:    endDate,
 		Note:       request.Note,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityEventTimeline, saved.ID, nil, saved)
 	return nil
 }
 
@@ -62,6 +76,10 @@ func (service EventTimelineServiceImpl) Update(ctx context.Context, request mode
 		return err
 	}
 
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	startDate, err := helper.ParseDateStringToTime(request.StartDate)
 	if err != nil {
 		return err
@@ -70,30 +88,40 @@ func (service EventTimelineServiceImpl) Update(ctx context.Context, request mode
 	if err != nil {
 		return err
 	}
-	if err = service.Repository.Update(etlID, model.EventTimeline{
+	updated := model.EventTimeline{
 		BaseEntity: builder.BuildBaseEntity(ctx, false, &existing.BaseEntity),
 		EventID:    existing.EventID,
 		Title:      request.Title,
+		Phase:      request.Phase,
 		StartDate:  startDate,
 		EndDate:    endDate,
 		Note:       request.Note,
-	}); err != nil {
+	}
+	if err = service.Repository.Update(etlID, updated); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityEventTimeline, etlID, existing, updated)
 	return nil
 }
 
-func (service EventTimelineServiceImpl) Delete(etlID uint) error {
+func (service EventTimelineServiceImpl) Delete(ctx context.Context, etlID uint) error {
 	//check event timeline
-	_, err := service.Repository.FindOne(etlID)
+	existing, err := service.Repository.FindOne(etlID)
 	if err != nil {
 		return err
 	}
 
-	if err = service.Repository.Delete(etlID); err != nil {
+	if err = service.Scope.Check(ctx, existing.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(etlID, authUser.Email); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityEventTimeline, etlID, existing, nil)
 	return nil
 }
 
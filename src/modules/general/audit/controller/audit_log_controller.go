@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit/model"
+	"be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/helper"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AuditLogController interface {
+	GetList(ctx *gin.Context)
+	Export(ctx *gin.Context)
+}
+
+type AuditLogControllerImpl struct {
+	Service service.AuditLogService
+}
+
+func NewAuditLogController(service service.AuditLogService) AuditLogController {
+	return &AuditLogControllerImpl{Service: service}
+}
+
+// GetList Get List Audit Log godoc
+// @Tags Audit Logs
+// @Summary Get List Audit Log
+// @Description Get the administrative and state changing actions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param actor query int false "Actor User ID"
+// @Param action query string false "Action, e.g. create, update, delete, approve"
+// @Param entity_type query string false "Entity Type, e.g. payment, event, team"
+// @Param entity_id query int false "Entity ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /audit-logs [get]
+func (controller *AuditLogControllerImpl) GetList(ctx *gin.Context) {
+	pg, err := utils.GetPaginateQueryOffset(ctx.Request)
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetList(filter, pg)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Audit Log Success", data)
+}
+
+// Export Export Audit Log godoc
+// @Tags Audit Logs
+// @Summary Export Audit Log
+// @Description Download the audit log as CSV, with the same filters as the list
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param actor query int false "Actor User ID"
+// @Param action query string false "Action"
+// @Param entity_type query string false "Entity Type"
+// @Param entity_id query int false "Entity ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, inclusive (YYYY-MM-DD)"
+// @Success 200 {file} file
+// @Failure 400 {object} src.BaseFailure
+// @Router /audit-logs/export [get]
+func (controller *AuditLogControllerImpl) Export(ctx *gin.Context) {
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.Export(filter)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("audit-log-%s.csv", time.Now().Format("20060102150405"))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "text/csv", data)
+}
+
+func parseFilter(ctx *gin.Context) (filter model.FilterAuditLog, err error) {
+	actorID, _ := strconv.Atoi(ctx.Query("actor"))
+	entityID, _ := strconv.Atoi(ctx.Query("entity_id"))
+	filter = model.FilterAuditLog{
+		ActorID:    uint(actorID),
+		Action:     ctx.Query("action"),
+		EntityType: ctx.Query("entity_type"),
+		EntityID:   uint(entityID),
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		date, errParse := helper.ParseDateStringToTime(from)
+		if errParse != nil {
+			err = fmt.Errorf("invalid from date: %s", from)
+			return
+		}
+		filter.From = &date
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		date, errParse := helper.ParseDateStringToTime(to)
+		if errParse != nil {
+			err = fmt.Errorf("invalid to date: %s", to)
+			return
+		}
+		date = date.AddDate(0, 0, 1)
+		filter.To = &date
+	}
+
+	return
+}
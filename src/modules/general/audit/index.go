@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit/controller"
+	"be-sagara-hackathon/src/modules/general/audit/repository"
+	"be-sagara-hackathon/src/modules/general/audit/service"
+	"gorm.io/gorm"
+)
+
+var (
+	auditLogRepository repository.AuditLogRepository
+	auditLogService    service.AuditLogService
+	auditLogController controller.AuditLogController
+)
+
+type Module interface {
+	InitModule()
+}
+
+type ModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(db *gorm.DB) Module {
+	return &ModuleImpl{DB: db}
+}
+
+func (module ModuleImpl) InitModule() {
+	auditLogRepository = repository.NewAuditLogRepository(module.DB)
+	auditLogService = service.NewAuditLogService(auditLogRepository)
+	auditLogController = controller.NewAuditLogController(auditLogService)
+}
+
+// GetRecorder returns what other modules use to write to the audit log, so it has to be initialized before them
+func GetRecorder() service.Recorder {
+	return auditLogService
+}
+
+func GetAuditLogController() controller.AuditLogController {
+	return auditLogController
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// AuditLog is one state changing action. Before and After hold the changed entity as JSON, Changes only
+// the fields that differ between them.
+type AuditLog struct {
+	common.BaseEntity
+	ActorID    *uint   `gorm:"null;index"`
+	ActorEmail string  `gorm:"type:varchar(255)"`
+	Action     string  `gorm:"type:varchar(30);not null;index"`
+	EntityType string  `gorm:"type:varchar(30);not null;index:idx_audit_logs_entity"`
+	EntityID   uint    `gorm:"not null;index:idx_audit_logs_entity"`
+	Before     *string `gorm:"type:longtext;null"`
+	After      *string `gorm:"type:longtext;null"`
+	Changes    *string `gorm:"type:longtext;null"`
+	IPAddress  string  `gorm:"type:varchar(45)"`
+	UserAgent  string  `gorm:"type:varchar(255)"`
+}
+
+// Change is one field of Changes
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type FilterAuditLog struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditLogLite struct {
+	ID         uint      `json:"id"`
+	ActorID    *uint     `json:"actor_id"`
+	ActorEmail string    `json:"actor_email"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uint      `json:"entity_id"`
+	Before     *string   `json:"before"`
+	After      *string   `json:"after"`
+	Changes    *string   `json:"changes"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ListAuditLogResponse struct {
+	Logs      []AuditLogLite `json:"logs"`
+	TotalPage int64          `json:"total_page"`
+	TotalItem int64          `json:"total_item"`
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit/model"
+	"be-sagara-hackathon/src/utils"
+	"database/sql"
+	"fmt"
+	"gorm.io/gorm"
+	"math"
+	"strings"
+)
+
+type AuditLogRepository interface {
+	Save(log model.AuditLog) error
+	FindAll(
+		filter model.FilterAuditLog,
+		pg *utils.PaginateQueryOffset,
+	) (logs []model.AuditLogLite, totalData, totalPage int64, err error)
+	FindForExport(filter model.FilterAuditLog, limit int) (logs []model.AuditLogLite, err error)
+}
+
+type AuditLogRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &AuditLogRepositoryImpl{DB: db}
+}
+
+const auditLogColumns = `id, actor_id, actor_email, action, entity_type, entity_id, ` +
+	"`before`, `after`, changes, ip_address, user_agent, created_at"
+
+var auditLogOrderFields = []string{"id", "created_at", "action", "entity_type", "actor_email"}
+
+func (repository *AuditLogRepositoryImpl) Save(log model.AuditLog) error {
+	return repository.DB.Create(&log).Error
+}
+
+func (repository *AuditLogRepositoryImpl) FindAll(
+	filter model.FilterAuditLog,
+	pg *utils.PaginateQueryOffset,
+) (logs []model.AuditLogLite, totalData, totalPage int64, err error) {
+	where, whereVals := BuildFilter(filter)
+	buildWhereQuery := strings.Join(where, " AND ")
+
+	order := "created_at DESC"
+	if pg.Order.Field != "" {
+		by := strings.ToUpper(pg.Order.By)
+		if by != "ASC" && by != "DESC" {
+			by = "ASC"
+		}
+		for _, field := range auditLogOrderFields {
+			if field == pg.Order.Field {
+				order = fmt.Sprintf("%s %s", field, by)
+			}
+		}
+	}
+
+	if err = repository.DB.Model(&model.AuditLog{}).
+		Select(auditLogColumns).
+		Order(order).
+		Limit(pg.Limit).Offset(pg.Offset).
+		Where(buildWhereQuery, whereVals...).
+		Find(&logs).Error; err != nil {
+		return
+	}
+
+	if err = repository.DB.Model(&model.AuditLog{}).
+		Where(buildWhereQuery, whereVals...).
+		Count(&totalData).Error; err != nil {
+		return
+	}
+
+	if pg.Limit > 0 {
+		totalPage = int64(math.Ceil(float64(totalData) / float64(pg.Limit)))
+	} else {
+		totalPage = 1
+	}
+
+	return
+}
+
+func (repository *AuditLogRepositoryImpl) FindForExport(filter model.FilterAuditLog, limit int) (logs []model.AuditLogLite, err error) {
+	where, whereVals := BuildFilter(filter)
+	err = repository.DB.Model(&model.AuditLog{}).
+		Select(auditLogColumns).
+		Where(strings.Join(where, " AND "), whereVals...).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&logs).Error
+	return
+}
+
+func BuildFilter(filter model.FilterAuditLog) (where []string, whereVal []interface{}) {
+	where = append(where, "deleted_at IS NULL")
+
+	if filter.ActorID > 0 {
+		where = append(where, "actor_id = @actor")
+		whereVal = append(whereVal, sql.Named("actor", filter.ActorID))
+	}
+
+	if filter.Action != "" {
+		where = append(where, "action = @action")
+		whereVal = append(whereVal, sql.Named("action", filter.Action))
+	}
+
+	if filter.EntityType != "" {
+		where = append(where, "entity_type = @entity_type")
+		whereVal = append(whereVal, sql.Named("entity_type", filter.EntityType))
+	}
+
+	if filter.EntityID > 0 {
+		where = append(where, "entity_id = @entity_id")
+		whereVal = append(whereVal, sql.Named("entity_id", filter.EntityID))
+	}
+
+	if filter.From != nil {
+		where = append(where, "created_at >= @from")
+		whereVal = append(whereVal, sql.Named("from", *filter.From))
+	}
+
+	if filter.To != nil {
+		where = append(where, "created_at < @to")
+		whereVal = append(whereVal, sql.Named("to", *filter.To))
+	}
+
+	return
+}
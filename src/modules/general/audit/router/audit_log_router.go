@@ -0,0 +1,19 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/utils/constants"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditLogRouter(group *gin.RouterGroup) {
+	group.GET("/",
+		middlewares.Permission(constants.PermissionAuditView),
+		audit.GetAuditLogController().GetList,
+	)
+	group.GET("/export",
+		middlewares.Permission(constants.PermissionAuditView),
+		audit.GetAuditLogController().Export,
+	)
+}
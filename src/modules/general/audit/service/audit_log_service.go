@@ -0,0 +1,200 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit/model"
+	"be-sagara-hackathon/src/modules/general/audit/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/helper"
+	"be-sagara-hackathon/src/utils/spreadsheet"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recorder is what other modules use to write to the audit log
+type Recorder interface {
+	// Record saves who did action on the entity. before is nil for creations and after is nil for deletions.
+	// Failures are only logged, the action itself already happened.
+	Record(ctx context.Context, action, entityType string, entityID uint, before, after interface{})
+}
+
+type AuditLogService interface {
+	Recorder
+	GetList(
+		filter model.FilterAuditLog,
+		pg *utils.PaginateQueryOffset,
+	) (response model.ListAuditLogResponse, err error)
+	Export(filter model.FilterAuditLog) ([]byte, error)
+}
+
+type AuditLogServiceImpl struct {
+	Repository repository.AuditLogRepository
+}
+
+func NewAuditLogService(repository repository.AuditLogRepository) AuditLogService {
+	return &AuditLogServiceImpl{Repository: repository}
+}
+
+// redactedFields never end up in the audit log, whatever entity they belong to
+var redactedFields = []string{"password", "secret", "token", "recovery_code"}
+
+func (service *AuditLogServiceImpl) Record(ctx context.Context, action, entityType string, entityID uint, before, after interface{}) {
+	beforeFields, afterFields := snapshot(before), snapshot(after)
+
+	auditLog := model.AuditLog{
+		BaseEntity: common.BaseEntity{CreatedBy: "system", UpdatedBy: "system"},
+		ActorEmail: "system",
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     marshal(beforeFields),
+		After:      marshal(afterFields),
+		Changes:    marshal(diff(beforeFields, afterFields)),
+	}
+
+	if user, ok := ctx.Value("user").(um.User); ok {
+		auditLog.ActorID = &user.ID
+		auditLog.ActorEmail = user.Email
+		auditLog.CreatedBy = user.Email
+		auditLog.UpdatedBy = auditLog.CreatedBy
+	}
+
+	// Requests pass their gin context down to the services
+	if request, ok := ctx.(interface {
+		ClientIP() string
+		GetHeader(key string) string
+	}); ok {
+		auditLog.IPAddress = request.ClientIP()
+		auditLog.UserAgent = helper.TruncateString(request.GetHeader("User-Agent"), 255)
+	}
+
+	if err := service.Repository.Save(auditLog); err != nil {
+		log.Printf("audit: failed to record %s of %s %d: %v", action, entityType, entityID, err)
+	}
+}
+
+func (service *AuditLogServiceImpl) GetList(
+	filter model.FilterAuditLog,
+	pg *utils.PaginateQueryOffset,
+) (response model.ListAuditLogResponse, err error) {
+	response.Logs, response.TotalItem, response.TotalPage, err = service.Repository.FindAll(filter, pg)
+	return
+}
+
+// Export writes the matching logs as CSV, newest first, up to AUDIT_EXPORT_MAX_ROWS rows
+func (service *AuditLogServiceImpl) Export(filter model.FilterAuditLog) ([]byte, error) {
+	logs, err := service.Repository.FindForExport(filter, helper.GetEnvInt("AUDIT_EXPORT_MAX_ROWS", 50000))
+	if err != nil {
+		return nil, err
+	}
+
+	// the spreadsheet writer escapes formulas, user agents and changed values come from the requests
+	var buf bytes.Buffer
+	writer, err := spreadsheet.NewWriter(spreadsheet.FormatCSV, &buf)
+	if err != nil {
+		return nil, err
+	}
+	_ = writer.Write([]string{
+		"id", "created_at", "actor_id", "actor_email", "action", "entity_type", "entity_id",
+		"changes", "before", "after", "ip_address", "user_agent",
+	})
+
+	for _, l := range logs {
+		actorID := ""
+		if l.ActorID != nil {
+			actorID = strconv.Itoa(int(*l.ActorID))
+		}
+		_ = writer.Write([]string{
+			strconv.Itoa(int(l.ID)),
+			l.CreatedAt.Format(time.RFC3339),
+			actorID,
+			l.ActorEmail,
+			l.Action,
+			l.EntityType,
+			strconv.Itoa(int(l.EntityID)),
+			helper.DereferString(l.Changes),
+			helper.DereferString(l.Before),
+			helper.DereferString(l.After),
+			l.IPAddress,
+			l.UserAgent,
+		})
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// snapshot turns an entity into its JSON fields. Nested objects and lists, i.e. preloaded relations, are
+// left out so that only the entity's own columns are compared.
+func snapshot(entity interface{}) map[string]interface{} {
+	if entity == nil || (reflect.ValueOf(entity).Kind() == reflect.Ptr && reflect.ValueOf(entity).IsNil()) {
+		return nil
+	}
+
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	for key, value := range fields {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			delete(fields, key)
+			continue
+		}
+		for _, redacted := range redactedFields {
+			if strings.Contains(strings.ToLower(key), redacted) {
+				delete(fields, key)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+func diff(before, after map[string]interface{}) map[string]model.Change {
+	changes := map[string]model.Change{}
+	for key, value := range before {
+		if !reflect.DeepEqual(value, after[key]) {
+			changes[key] = model.Change{Before: value, After: after[key]}
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = model.Change{After: value}
+		}
+	}
+
+	// Bookkeeping columns change on every update
+	delete(changes, "updated_at")
+	delete(changes, "updated_by")
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func marshal(v interface{}) *string {
+	if reflect.ValueOf(v).IsNil() {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return helper.ReferString(string(raw))
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit/model"
+	"be-sagara-hackathon/src/modules/general/audit/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"context"
+	"strings"
+	"testing"
+)
+
+type fakeAuditLogRepository struct {
+	repository.AuditLogRepository
+	logs []model.AuditLog
+}
+
+func (repository *fakeAuditLogRepository) Save(log model.AuditLog) error {
+	repository.logs = append(repository.logs, log)
+	return nil
+}
+
+func (repository *fakeAuditLogRepository) FindForExport(model.FilterAuditLog, int) ([]model.AuditLogLite, error) {
+	return []model.AuditLogLite{{ID: 1, Action: "update", UserAgent: "=cmd|' /C calc'!A0"}}, nil
+}
+
+type auditedEntity struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func TestRecord(t *testing.T) {
+	repository := &fakeAuditLogRepository{}
+	service := &AuditLogServiceImpl{Repository: repository}
+
+	user := um.User{Email: "admin@example.com"}
+	user.ID = 4
+	ctx := context.WithValue(context.Background(), "user", user)
+	service.Record(ctx, "update", "event", 9,
+		auditedEntity{Name: "Before", Password: "old"},
+		auditedEntity{Name: "After", Password: "new"},
+	)
+
+	if len(repository.logs) != 1 {
+		t.Fatalf("saved %d logs, want 1", len(repository.logs))
+	}
+	log := repository.logs[0]
+	if log.CreatedBy != user.Email || log.UpdatedBy != user.Email || log.ActorEmail != user.Email {
+		t.Errorf("log is by %q/%q/%q, want %q", log.CreatedBy, log.UpdatedBy, log.ActorEmail, user.Email)
+	}
+	if log.ActorID == nil || *log.ActorID != user.ID {
+		t.Errorf("actor id = %v, want %d", log.ActorID, user.ID)
+	}
+	if log.Changes == nil || !strings.Contains(*log.Changes, `"name"`) {
+		t.Errorf("changes = %v, want the name", log.Changes)
+	}
+	if strings.Contains(*log.Before+*log.After, "old") || strings.Contains(*log.After, "new") {
+		t.Error("passwords should be redacted")
+	}
+}
+
+func TestRecordWithoutUser(t *testing.T) {
+	repository := &fakeAuditLogRepository{}
+	service := &AuditLogServiceImpl{Repository: repository}
+
+	service.Record(context.Background(), "expire", "invoice", 1, nil, nil)
+	if log := repository.logs[0]; log.CreatedBy != "system" || log.ActorID != nil {
+		t.Errorf("log without a user is by %q, want system", log.CreatedBy)
+	}
+}
+
+func TestExportEscapesFormulas(t *testing.T) {
+	service := &AuditLogServiceImpl{Repository: &fakeAuditLogRepository{}}
+
+	export, err := service.Export(model.FilterAuditLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(export), "'=cmd") {
+		t.Errorf("export %q should escape the user agent", export)
+	}
+}
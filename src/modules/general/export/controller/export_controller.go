@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/general/export/model"
+	"be-sagara-hackathon/src/modules/general/export/service"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type ExportController interface {
+	Export(ctx *gin.Context)
+}
+
+type ExportControllerImpl struct {
+	Service service.ExportService
+}
+
+func NewExportController(service service.ExportService) ExportController {
+	return &ExportControllerImpl{Service: service}
+}
+
+// Export Export Event Data godoc
+// @Tags Export
+// @Summary Export Event Data
+// @Description Download the participants, teams, projects or payments of an event as CSV or XLSX.
+// @Description The filters are the ones of the list endpoints. Times are in the EXPORT_TIMEZONE (Asia/Jakarta by default).
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
+// @Param type path string true "participants, teams, projects or payments"
+// @Param event_id query int true "Event ID"
+// @Param format query string false "csv (default) or xlsx"
+// @Param q query string false "Search"
+// @Param status query string false "Status (teams, projects and payments)"
+// @Param specialities query string false "Comma separated speciality ids (participants)"
+// @Param skills query string false "Comma separated skill ids (participants)"
+// @Param without_team query bool false "Only participants without a team"
+// @Param team_id query int false "Team ID (projects)"
+// @Param created_at query string false "Created date, YYYY-MM-DD (projects)"
+// @Success 200 {file} file
+// @Failure 400 {object} src.BaseFailure
+// @Failure 403 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /exports/{type} [get]
+func (controller *ExportControllerImpl) Export(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Query("event_id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid Event Id", []string{"event_id is required"})
+		return
+	}
+	teamID, _ := strconv.Atoi(ctx.Query("team_id"))
+	withoutTeam, _ := strconv.ParseBool(ctx.Query("without_team"))
+
+	request := model.ExportRequest{
+		Type:    ctx.Param("type"),
+		Format:  strings.ToLower(ctx.Query("format")),
+		EventID: uint(eventID),
+	}
+	request.Participant.Search = ctx.Query("q")
+	request.Participant.Specialities = parseIDs(ctx.Query("specialities"))
+	request.Participant.Skills = parseIDs(ctx.Query("skills"))
+	request.Participant.InTeam = !withoutTeam
+	request.Team.Search = ctx.Query("q")
+	request.Team.Status = ctx.Query("status")
+	request.Project.Search = ctx.Query("q")
+	request.Project.Status = ctx.Query("status")
+	request.Project.TeamID = uint(teamID)
+	request.Project.CreatedAt = ctx.Query("created_at")
+	request.Invoice.Search = ctx.Query("q")
+	request.Invoice.Status = ctx.Query("status")
+
+	started := false
+	err = controller.Service.Export(ctx, request, func(filename, contentType string) io.Writer {
+		started = true
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		ctx.Header("Content-Type", contentType)
+		ctx.Status(http.StatusOK)
+		return ctx.Writer
+	})
+	if err == nil {
+		return
+	}
+
+	// the file is partly sent, the status can't be changed anymore
+	if started {
+		log.Printf("export of %s for event %d stopped: %v", request.Type, request.EventID, err)
+		return
+	}
+
+	switch err {
+	case e.ErrInvalidExportType, e.ErrInvalidExportFormat:
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+	case e.ErrNotEventStaff:
+		common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+	case e.ErrDataNotFound:
+		common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+	default:
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+	}
+}
+
+// parseIDs reads a comma separated list of ids, anything that isn't a number is skipped
+func parseIDs(value string) (ids []uint) {
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err == nil && id > 0 {
+			ids = append(ids, uint(id))
+		}
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	er "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/export/controller"
+	"be-sagara-hackathon/src/modules/general/export/repository"
+	"be-sagara-hackathon/src/modules/general/export/service"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	exportRepository repository.ExportRepository
+	exportService    service.ExportService
+	exportController controller.ExportController
+)
+
+type Module interface {
+	InitModule()
+}
+
+type ModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(db *gorm.DB) Module {
+	return &ModuleImpl{DB: db}
+}
+
+func (module ModuleImpl) InitModule() {
+	exportRepository = repository.NewExportRepository(module.DB)
+	exportService = service.NewExportService(
+		exportRepository,
+		er.NewEventRepository(module.DB),
+		evs.NewEventScope(er.NewEventStaffRepository(module.DB), ur.NewPermissionRepository(module.DB)),
+	)
+	exportController = controller.NewExportController(exportService)
+}
+
+func GetExportController() controller.ExportController {
+	return exportController
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	pym "be-sagara-hackathon/src/modules/payment/model"
+	pjm "be-sagara-hackathon/src/modules/project/model"
+	tm "be-sagara-hackathon/src/modules/team/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"time"
+)
+
+// ExportRequest takes the filters of the list endpoints, only the one of Type is used.
+// The event id of the filters is set from EventID.
+type ExportRequest struct {
+	Type        string
+	Format      string
+	EventID     uint
+	Participant um.FilterParticipantSearch
+	Team        tm.FilterTeam
+	Project     pjm.FilterProject
+	Invoice     pym.FilterInvoice
+}
+
+type ParticipantRow struct {
+	ID             uint
+	Name           string
+	Email          string
+	PhoneNumber    *string
+	Gender         *string
+	Birthdate      *time.Time
+	CityName       *string
+	School         *string
+	Major          *string
+	LevelOfStudy   *string
+	GraduationYear uint16
+	OccupationName *string
+	SpecialityName *string
+	Institution    *string
+	TeamName       *string
+	PaymentStatus  *string
+	RegisteredAt   time.Time
+}
+
+type TeamRow struct {
+	ID          uint
+	Code        string
+	Name        string
+	LeaderName  string
+	LeaderEmail string
+	NumOfMember uint
+	Members     *string
+	IsActive    bool
+	CreatedAt   time.Time
+}
+
+type ProjectRow struct {
+	ID          uint
+	Name        string
+	TeamID      uint
+	TeamName    string
+	Status      string
+	SubmittedAt *time.Time
+	CreatedAt   time.Time
+}
+
+type InvoiceRow struct {
+	InvoiceNumber   string
+	ParticipantName string
+	Email           string
+	BaseAmount      uint64
+	DiscountAmount  uint64
+	Amount          uint64
+	PaidAmount      uint64
+	CreditAmount    uint64
+	Status          string
+	ApprovedAt      *time.Time
+	ApprovedBy      *string
+	CreatedAt       time.Time
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/general/export/model"
+	pym "be-sagara-hackathon/src/modules/payment/model"
+	pyr "be-sagara-hackathon/src/modules/payment/repository"
+	pjm "be-sagara-hackathon/src/modules/project/model"
+	pjr "be-sagara-hackathon/src/modules/project/repository"
+	tm "be-sagara-hackathon/src/modules/team/model"
+	tr "be-sagara-hackathon/src/modules/team/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"database/sql"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ExportRepository reads the rows one by one from the database cursor and hands each to fn, so a large
+// event is never loaded at once. The where clauses come from the filter builders of each module.
+type ExportRepository interface {
+	StreamParticipants(eventID uint, filter um.FilterParticipantSearch, fn func(row model.ParticipantRow) error) error
+	StreamTeams(filter tm.FilterTeam, fn func(row model.TeamRow) error) error
+	StreamProjects(filter pjm.FilterProject, fn func(row model.ProjectRow) error) error
+	StreamInvoices(filter pym.FilterInvoice, fn func(row model.InvoiceRow) error) error
+}
+
+type ExportRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) ExportRepository {
+	return &ExportRepositoryImpl{DB: db}
+}
+
+func (repository *ExportRepositoryImpl) StreamParticipants(
+	eventID uint,
+	filter um.FilterParticipantSearch,
+	fn func(row model.ParticipantRow) error,
+) error {
+	where, whereVals := ur.BuildFilterParticipant(filter)
+	where = append(where, "ep.event_id = @export_event AND ep.deleted_at IS NULL")
+	whereVals = append(whereVals, sql.Named("export_event", eventID))
+
+	query := repository.DB.Table("event_participants as ep").
+		Select(`p.id, u.name, u.email, u.phone_number, p.gender, p.birthdate, city.name as city_name,
+			p.school, p.major, p.level_of_study, p.graduation_year, occ.name as occupation_name,
+			spe.name as speciality_name, u.institution,
+			(select t.name from team_members tm
+				inner join teams t on t.id = tm.team_id
+				inner join team_events te on te.team_id = t.id
+				where tm.participant_id = p.id and te.event_id = ep.event_id and t.deleted_at is null
+				limit 1) as team_name,
+			(select inv.status from invoices inv
+				where inv.participant_id = p.id and inv.event_id = ep.event_id and inv.deleted_at is null
+				order by inv.id desc limit 1) as payment_status,
+			ep.created_at as registered_at`).
+		Joins("inner join participants p on p.id = ep.participant_id").
+		Joins("inner join users u on u.id = p.user_id").
+		Joins("left join occupations occ on occ.id = u.occupation_id").
+		Joins("left join specialities spe on spe.id = p.speciality_id").
+		Joins("left join reg_cities city on city.id = p.city_id").
+		Where(strings.Join(where, " AND "), whereVals...).
+		Order("ep.id asc")
+
+	return repository.stream(query, func(rows *sql.Rows) error {
+		var row model.ParticipantRow
+		if err := repository.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return fn(row)
+	})
+}
+
+func (repository *ExportRepositoryImpl) StreamTeams(filter tm.FilterTeam, fn func(row model.TeamRow) error) error {
+	where, whereVals := tr.BuildFilterTeam(filter)
+
+	query := repository.DB.Table("teams as t").
+		Select(`t.id, t.code, t.name, u.name as leader_name, u.email as leader_email, count(tm.id) as num_of_member,
+			GROUP_CONCAT(mu.name ORDER BY tm.joined_at SEPARATOR ', ') as members, t.is_active, t.created_at`).
+		Joins("inner join team_events te on te.team_id = t.id").
+		Joins("inner join team_members tm on tm.team_id = t.id").
+		Joins("inner join participants mp on mp.id = tm.participant_id").
+		Joins("inner join users mu on mu.id = mp.user_id").
+		Joins("inner join participants p on p.id = t.participant_id").
+		Joins("inner join users u on u.id = p.user_id")
+
+	if filter.ScheduleID != 0 {
+		query = query.Joins("left join schedule_teams st on st.team_id = t.id")
+	}
+
+	query = query.Where(strings.Join(where, " AND "), whereVals...).
+		Group("t.id, t.code, t.name, u.name, u.email, t.is_active, t.created_at").
+		Order("t.id asc")
+
+	return repository.stream(query, func(rows *sql.Rows) error {
+		var row model.TeamRow
+		if err := repository.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return fn(row)
+	})
+}
+
+// StreamProjects filters in a subquery, the project filter uses bare column names that the team join would
+// make ambiguous
+func (repository *ExportRepositoryImpl) StreamProjects(filter pjm.FilterProject, fn func(row model.ProjectRow) error) error {
+	where, whereVals := pjr.BuildFilter(filter)
+	where = append(where, "deleted_at IS NULL")
+
+	filtered := repository.DB.Table("projects").Where(strings.Join(where, " AND "), whereVals...)
+	query := repository.DB.Table("(?) as pj", filtered).
+		Select("pj.id, pj.name, pj.team_id, t.name as team_name, pj.status, pj.submitted_at, pj.created_at").
+		Joins("inner join teams t on t.id = pj.team_id").
+		Order("pj.id asc")
+
+	return repository.stream(query, func(rows *sql.Rows) error {
+		var row model.ProjectRow
+		if err := repository.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return fn(row)
+	})
+}
+
+func (repository *ExportRepositoryImpl) StreamInvoices(filter pym.FilterInvoice, fn func(row model.InvoiceRow) error) error {
+	where, whereVals := pyr.BuildFilterInvoice(filter)
+
+	query := repository.DB.Table("invoices as inv").
+		Select(`inv.invoice_number, u.name as participant_name, u.email, inv.base_amount, inv.discount_amount,
+			inv.amount, inv.paid_amount, inv.credit_amount, inv.status, inv.approved_at, inv.approved_by, inv.created_at`).
+		Joins("inner join participants p on p.id = inv.participant_id").
+		Joins("inner join users u on u.id = p.user_id").
+		Where(strings.Join(where, " AND "), whereVals...).
+		Order("inv.id asc")
+
+	return repository.stream(query, func(rows *sql.Rows) error {
+		var row model.InvoiceRow
+		if err := repository.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return fn(row)
+	})
+}
+
+func (repository *ExportRepositoryImpl) stream(query *gorm.DB, scan func(rows *sql.Rows) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/general/export"
+	"be-sagara-hackathon/src/utils/constants"
+	"github.com/gin-gonic/gin"
+)
+
+func ExportRouter(group *gin.RouterGroup) {
+	group.GET("/:type",
+		middlewares.Permission(constants.PermissionDataExport),
+		export.GetExportController().Export,
+	)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/export/model"
+	"be-sagara-hackathon/src/modules/general/export/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/spreadsheet"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ExportService interface {
+	Export(ctx context.Context, request model.ExportRequest, open OpenFunc) error
+}
+
+// OpenFunc is called once the request is checked, right before the first row. It returns where the file
+// is written to, an error after that can no longer change the response.
+type OpenFunc func(filename, contentType string) io.Writer
+
+type ExportServiceImpl struct {
+	Repository      repository.ExportRepository
+	EventRepository evr.EventRepository
+	Scope           evs.EventScope
+}
+
+func NewExportService(
+	repository repository.ExportRepository,
+	eventRepository evr.EventRepository,
+	scope evs.EventScope,
+) ExportService {
+	return &ExportServiceImpl{
+		Repository:      repository,
+		EventRepository: eventRepository,
+		Scope:           scope,
+	}
+}
+
+// exportColumns is the header of each export type. Columns are snake case, ids end with _id,
+// times end with _at and are written in the EXPORT_TIMEZONE.
+var exportColumns = map[string][]string{
+	"participants": {
+		"participant_id", "name", "email", "phone_number", "gender", "birthdate", "city", "school", "major",
+		"level_of_study", "graduation_year", "occupation", "speciality", "institution", "team_name",
+		"payment_status", "registered_at",
+	},
+	"teams": {
+		"team_id", "code", "name", "leader_name", "leader_email", "num_of_member", "members", "is_active", "created_at",
+	},
+	"projects": {
+		"project_id", "name", "team_id", "team_name", "status", "submitted_at", "created_at",
+	},
+	"payments": {
+		"invoice_number", "participant_name", "email", "base_amount", "discount_amount", "amount", "paid_amount",
+		"credit_amount", "status", "approved_at", "approved_by", "created_at",
+	},
+}
+
+// Export writes the participants, teams, projects or payments of one event as csv or xlsx.
+// Organizers can only export the events they organize.
+func (service *ExportServiceImpl) Export(ctx context.Context, request model.ExportRequest, open OpenFunc) error {
+	columns, ok := exportColumns[request.Type]
+	if !ok {
+		return e.ErrInvalidExportType
+	}
+	if request.Format == "" {
+		request.Format = spreadsheet.FormatCSV
+	}
+	if request.Format != spreadsheet.FormatCSV && request.Format != spreadsheet.FormatXLSX {
+		return e.ErrInvalidExportFormat
+	}
+
+	if _, err := service.EventRepository.FindOne(request.EventID); err != nil {
+		return err
+	}
+	if err := service.Scope.Check(ctx, request.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s-event-%d-%s.%s",
+		request.Type, request.EventID, time.Now().In(spreadsheet.Location()).Format("20060102150405"), request.Format)
+	writer, err := spreadsheet.NewWriter(request.Format, open(filename, spreadsheet.ContentType(request.Format)))
+	if err != nil {
+		return err
+	}
+	if err = writer.Write(columns); err != nil {
+		return err
+	}
+
+	if err = service.writeRows(request, writer); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func (service *ExportServiceImpl) writeRows(request model.ExportRequest, writer spreadsheet.Writer) error {
+	switch request.Type {
+	case "participants":
+		return service.Repository.StreamParticipants(request.EventID, request.Participant, func(row model.ParticipantRow) error {
+			return writer.Write([]string{
+				formatID(row.ID),
+				row.Name,
+				row.Email,
+				spreadsheet.FormatString(row.PhoneNumber),
+				spreadsheet.FormatString(row.Gender),
+				spreadsheet.FormatDate(row.Birthdate),
+				spreadsheet.FormatString(row.CityName),
+				spreadsheet.FormatString(row.School),
+				spreadsheet.FormatString(row.Major),
+				spreadsheet.FormatString(row.LevelOfStudy),
+				formatYear(row.GraduationYear),
+				spreadsheet.FormatString(row.OccupationName),
+				spreadsheet.FormatString(row.SpecialityName),
+				spreadsheet.FormatString(row.Institution),
+				spreadsheet.FormatString(row.TeamName),
+				spreadsheet.FormatString(row.PaymentStatus),
+				spreadsheet.FormatTime(&row.RegisteredAt),
+			})
+		})
+	case "teams":
+		request.Team.EventID = request.EventID
+		return service.Repository.StreamTeams(request.Team, func(row model.TeamRow) error {
+			return writer.Write([]string{
+				formatID(row.ID),
+				row.Code,
+				row.Name,
+				row.LeaderName,
+				row.LeaderEmail,
+				spreadsheet.FormatUint(uint64(row.NumOfMember)),
+				spreadsheet.FormatString(row.Members),
+				spreadsheet.FormatBool(row.IsActive),
+				spreadsheet.FormatTime(&row.CreatedAt),
+			})
+		})
+	case "projects":
+		request.Project.EventID = request.EventID
+		return service.Repository.StreamProjects(request.Project, func(row model.ProjectRow) error {
+			return writer.Write([]string{
+				formatID(row.ID),
+				row.Name,
+				formatID(row.TeamID),
+				row.TeamName,
+				row.Status,
+				spreadsheet.FormatTime(row.SubmittedAt),
+				spreadsheet.FormatTime(&row.CreatedAt),
+			})
+		})
+	default:
+		request.Invoice.EventID = request.EventID
+		return service.Repository.StreamInvoices(request.Invoice, func(row model.InvoiceRow) error {
+			return writer.Write([]string{
+				row.InvoiceNumber,
+				row.ParticipantName,
+				row.Email,
+				spreadsheet.FormatUint(row.BaseAmount),
+				spreadsheet.FormatUint(row.DiscountAmount),
+				spreadsheet.FormatUint(row.Amount),
+				spreadsheet.FormatUint(row.PaidAmount),
+				spreadsheet.FormatUint(row.CreditAmount),
+				row.Status,
+				spreadsheet.FormatTime(row.ApprovedAt),
+				spreadsheet.FormatString(row.ApprovedBy),
+				spreadsheet.FormatTime(&row.CreatedAt),
+			})
+		})
+	}
+}
+
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// formatYear leaves the graduation year empty when the participant hasn't filled it in
+func formatYear(year uint16) string {
+	if year == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(year))
+}
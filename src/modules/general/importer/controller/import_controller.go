@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/general/importer/model"
+	"be-sagara-hackathon/src/modules/general/importer/service"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/spreadsheet"
+	"be-sagara-hackathon/src/utils/upload"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ImportController interface {
+	Import(ctx *gin.Context)
+}
+
+type ImportControllerImpl struct {
+	Service service.ImportService
+}
+
+func NewImportController(service service.ImportService) ImportController {
+	return &ImportControllerImpl{Service: service}
+}
+
+// Import Import Users godoc
+// @Tags Import
+// @Summary Import Users
+// @Description Import mentors, judges or participants from a .csv or .xlsx file with a header row.
+// @Description Mentors and judges need name, email, phone_number, occupation and institution columns,
+// @Description participants need name, email, phone_number, occupation and speciality and join the latest event.
+// @Description With dry_run the rows are only checked. Nothing is imported when a row is invalid.
+// @Description With send_invitation the users get an email with a link to set their password.
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "mentors, judges or participants"
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run formData bool false "Only validate the file"
+// @Param send_invitation formData bool false "Send set password emails"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /imports/{type} [post]
+func (controller *ImportControllerImpl) Import(ctx *gin.Context) {
+	// limit upload file size
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, 5*upload.MB) // 5 Mb
+
+	file, fileHeader, err := ctx.Request.FormFile("file")
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+	defer file.Close()
+
+	request := model.ImportRequest{
+		Type:     ctx.Param("type"),
+		Filename: fileHeader.Filename,
+		File:     file,
+		Size:     fileHeader.Size,
+	}
+	request.DryRun, _ = strconv.ParseBool(ctx.PostForm("dry_run"))
+	request.SendInvitation, _ = strconv.ParseBool(ctx.PostForm("send_invitation"))
+
+	report, err := controller.Service.Import(ctx, request)
+	if err != nil {
+		switch err {
+		case e.ErrImportInvalidRows:
+			common.SendError(ctx, http.StatusBadRequest, err.Error(), report.Errors)
+		case e.ErrInvalidImportType,
+			e.ErrImportFileEmpty,
+			e.ErrEmailAlreadyExists,
+			e.ErrPhoneNumberAlreadyExists,
+			e.ErrDataNotFound,
+			spreadsheet.ErrUnsupportedFormat,
+			spreadsheet.ErrInvalidXLSX:
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		default:
+			common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		}
+		return
+	}
+
+	message := "Import Success"
+	if request.DryRun {
+		message = "Import Validation Success"
+	}
+	common.SendSuccess(ctx, http.StatusOK, message, report)
+}
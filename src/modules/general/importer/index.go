@@ -0,0 +1,48 @@
+package importer
+
+import (
+	er "be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/importer/controller"
+	"be-sagara-hackathon/src/modules/general/importer/repository"
+	"be-sagara-hackathon/src/modules/general/importer/service"
+	"be-sagara-hackathon/src/modules/general/outbox"
+	"be-sagara-hackathon/src/modules/promotion"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	importRepository repository.ImportRepository
+	importService    service.ImportService
+	importController controller.ImportController
+)
+
+type Module interface {
+	InitModule()
+}
+
+type ModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(db *gorm.DB) Module {
+	return &ModuleImpl{DB: db}
+}
+
+func (module ModuleImpl) InitModule() {
+	importRepository = repository.NewImportRepository(module.DB)
+	importService = service.NewImportService(
+		importRepository,
+		ur.NewUserRoleRepository(module.DB),
+		er.NewEventRepository(module.DB),
+		promotion.GetPricingService(),
+		outbox.GetMailer(),
+		audit.GetRecorder(),
+	)
+	importController = controller.NewImportController(importService)
+}
+
+func GetImportController() controller.ImportController {
+	return importController
+}
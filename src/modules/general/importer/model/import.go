@@ -0,0 +1,54 @@
+package model
+
+import (
+	aum "be-sagara-hackathon/src/modules/auth/model"
+	pym "be-sagara-hackathon/src/modules/payment/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"io"
+)
+
+// ImportRequest is the uploaded file. With DryRun the rows are only checked.
+type ImportRequest struct {
+	Type           string
+	DryRun         bool
+	SendInvitation bool
+	Filename       string
+	File           io.ReaderAt
+	Size           int64
+}
+
+// ImportRow is one line of the file, Line is the line number in the file with the header being line 1
+type ImportRow struct {
+	Line        int
+	Name        string
+	Email       string
+	PhoneNumber string
+	Occupation  string
+	Institution string
+	Speciality  string
+}
+
+// ImportRecord is what a valid row is saved as. Participant, Invoice and EventID are only set for participants.
+type ImportRecord struct {
+	User         um.User
+	Participant  *um.Participant
+	EventID      uint
+	Invoice      *pym.Invoice
+	Verification *aum.VerificationCode
+}
+
+type ImportRowError struct {
+	Line   int      `json:"line"`
+	Email  string   `json:"email"`
+	Errors []string `json:"errors"`
+}
+
+type ImportReport struct {
+	Type           string           `json:"type"`
+	DryRun         bool             `json:"dry_run"`
+	TotalRow       int              `json:"total_row"`
+	ValidRow       int              `json:"valid_row"`
+	Imported       int              `json:"imported"`
+	InvitationSent int              `json:"invitation_sent"`
+	Errors         []ImportRowError `json:"errors"`
+}
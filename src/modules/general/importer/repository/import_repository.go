@@ -0,0 +1,144 @@
+package repository
+
+import (
+	eve "be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/general/importer/model"
+	ocm "be-sagara-hackathon/src/modules/master-data/occupation/model"
+	spm "be-sagara-hackathon/src/modules/master-data/speciality/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type ImportRepository interface {
+	FindOccupationIDs() (ids map[string]uint, err error)
+	FindSpecialityIDs() (ids map[string]uint, err error)
+	FindExistingEmails(emails []string) (existing map[string]bool, err error)
+	FindExistingPhoneNumbers(phoneNumbers []string) (existing map[string]bool, err error)
+	Import(records []model.ImportRecord) error
+}
+
+type ImportRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) ImportRepository {
+	return &ImportRepositoryImpl{DB: db}
+}
+
+// FindOccupationIDs maps the lower cased names of the active occupations to their id
+func (repository *ImportRepositoryImpl) FindOccupationIDs() (ids map[string]uint, err error) {
+	return repository.findIDsByName(&ocm.Occupation{})
+}
+
+// FindSpecialityIDs maps the lower cased names of the active specialities to their id
+func (repository *ImportRepositoryImpl) FindSpecialityIDs() (ids map[string]uint, err error) {
+	return repository.findIDsByName(&spm.Speciality{})
+}
+
+func (repository *ImportRepositoryImpl) findIDsByName(entity interface{}) (ids map[string]uint, err error) {
+	var rows []struct {
+		ID   uint
+		Name string
+	}
+	if err = repository.DB.Model(entity).
+		Select("id, name").
+		Where("is_active = ?", true).
+		Find(&rows).Error; err != nil {
+		return
+	}
+
+	ids = make(map[string]uint)
+	for _, row := range rows {
+		ids[strings.ToLower(strings.TrimSpace(row.Name))] = row.ID
+	}
+	return
+}
+
+func (repository *ImportRepositoryImpl) FindExistingEmails(emails []string) (existing map[string]bool, err error) {
+	return repository.findExisting("email", emails)
+}
+
+func (repository *ImportRepositoryImpl) FindExistingPhoneNumbers(phoneNumbers []string) (existing map[string]bool, err error) {
+	return repository.findExisting("phone_number", phoneNumbers)
+}
+
+// findExisting returns the values of column that already belong to a user, lower cased
+func (repository *ImportRepositoryImpl) findExisting(column string, values []string) (existing map[string]bool, err error) {
+	existing = make(map[string]bool)
+	if len(values) == 0 {
+		return
+	}
+
+	var found []string
+	if err = repository.DB.Model(&um.User{}).
+		Where(column+" IN ?", values).
+		Pluck(column, &found).Error; err != nil {
+		return
+	}
+
+	for _, value := range found {
+		existing[strings.ToLower(value)] = true
+	}
+	return
+}
+
+// Import saves every record in one transaction, a failing row leaves the database untouched
+func (repository *ImportRepositoryImpl) Import(records []model.ImportRecord) (err error) {
+	tx := repository.DB.Begin()
+	for i := range records {
+		if err = createRecord(tx, &records[i]); err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func createRecord(tx *gorm.DB, record *model.ImportRecord) (err error) {
+	err = tx.Create(&record.User).Error
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == 1062 {
+		if strings.Contains(mySqlErr.Message, "idx_unique_user_phone") {
+			return e.ErrPhoneNumberAlreadyExists
+		}
+		return e.ErrEmailAlreadyExists
+	}
+	if err != nil {
+		return
+	}
+
+	if record.Participant != nil {
+		record.Participant.UserID = record.User.ID
+		if err = tx.Create(record.Participant).Error; err != nil {
+			return
+		}
+
+		if err = tx.Create(&eve.EventParticipant{
+			BaseEntity: common.BaseEntity{
+				CreatedBy: record.User.CreatedBy,
+				UpdatedBy: record.User.UpdatedBy,
+			},
+			EventID:       record.EventID,
+			ParticipantID: record.Participant.ID,
+		}).Error; err != nil {
+			return
+		}
+
+		record.Invoice.ParticipantID = record.Participant.ID
+		if err = tx.Create(record.Invoice).Error; err != nil {
+			return
+		}
+	}
+
+	if record.Verification != nil {
+		record.Verification.UserID = record.User.ID
+		err = tx.Create(record.Verification).Error
+	}
+	return
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/general/importer"
+	"be-sagara-hackathon/src/utils/constants"
+	"github.com/gin-gonic/gin"
+)
+
+func ImportRouter(group *gin.RouterGroup) {
+	group.POST("/:type",
+		middlewares.Permission(constants.PermissionUserImport),
+		importer.GetImportController().Import,
+	)
+}
@@ -0,0 +1,412 @@
+package service
+
+import (
+	aum "be-sagara-hackathon/src/modules/auth/model"
+	evm "be-sagara-hackathon/src/modules/event/model"
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/general/importer/model"
+	"be-sagara-hackathon/src/modules/general/importer/repository"
+	pym "be-sagara-hackathon/src/modules/payment/model"
+	prs "be-sagara-hackathon/src/modules/promotion/service"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"be-sagara-hackathon/src/utils/spreadsheet"
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+)
+
+type ImportService interface {
+	Import(ctx context.Context, request model.ImportRequest) (report model.ImportReport, err error)
+}
+
+type ImportServiceImpl struct {
+	Repository      repository.ImportRepository
+	RoleRepository  ur.UserRoleRepository
+	EventRepository evr.EventRepository
+	Pricing         prs.PricingService
+	Mailer          email.Mailer
+	Audit           ads.Recorder
+}
+
+func NewImportService(
+	repository repository.ImportRepository,
+	roleRepository ur.UserRoleRepository,
+	eventRepository evr.EventRepository,
+	pricing prs.PricingService,
+	mailer email.Mailer,
+	audit ads.Recorder,
+) ImportService {
+	return &ImportServiceImpl{
+		Repository:      repository,
+		RoleRepository:  roleRepository,
+		EventRepository: eventRepository,
+		Pricing:         pricing,
+		Mailer:          mailer,
+		Audit:           audit,
+	}
+}
+
+type importType struct {
+	Role     string
+	Required []string
+}
+
+// importTypes maps the type in the url to the role the users get and the columns the file must have
+var importTypes = map[string]importType{
+	"mentors": {
+		Role:     constants.UserMentor,
+		Required: []string{"name", "email", "phone_number", "occupation", "institution"},
+	},
+	"judges": {
+		Role:     constants.UserJudge,
+		Required: []string{"name", "email", "phone_number", "occupation", "institution"},
+	},
+	"participants": {
+		Role:     constants.UserParticipant,
+		Required: []string{"name", "email", "phone_number", "occupation", "speciality"},
+	},
+}
+
+// columnAliases are the other header names a column is recognised by
+var columnAliases = map[string]string{
+	"full_name": "name",
+	"phone":     "phone_number",
+	"specialty": "speciality",
+}
+
+// Import checks every row of the file and saves them all or none. Participants are registered to the
+// latest event with an invoice priced like a self registration. Imported users have no password,
+// SendInvitation mails them a link to set one.
+func (service *ImportServiceImpl) Import(ctx context.Context, request model.ImportRequest) (report model.ImportReport, err error) {
+	t, ok := importTypes[request.Type]
+	if !ok {
+		err = e.ErrInvalidImportType
+		return
+	}
+
+	lines, err := spreadsheet.Read(request.Filename, request.File, request.Size)
+	if err != nil {
+		return
+	}
+	if len(lines) < 2 {
+		err = e.ErrImportFileEmpty
+		return
+	}
+
+	report = model.ImportReport{Type: request.Type, DryRun: request.DryRun, Errors: []model.ImportRowError{}}
+
+	columns := readHeader(lines[0])
+	var missing []string
+	for _, column := range t.Required {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, fmt.Sprintf("column %s is missing", column))
+		}
+	}
+	if len(missing) > 0 {
+		report.Errors = append(report.Errors, model.ImportRowError{Line: 1, Errors: missing})
+		if !request.DryRun {
+			err = e.ErrImportInvalidRows
+		}
+		return
+	}
+
+	rows := readRows(lines[1:], columns)
+	report.TotalRow = len(rows)
+	if report.TotalRow == 0 {
+		err = e.ErrImportFileEmpty
+		return
+	}
+
+	rowErrors, err := service.validate(t, rows)
+	if err != nil {
+		return
+	}
+	report.ValidRow = report.TotalRow - len(rowErrors)
+	if len(rowErrors) > 0 {
+		report.Errors = rowErrors
+		if !request.DryRun {
+			err = e.ErrImportInvalidRows
+		}
+		return
+	}
+	if request.DryRun {
+		return
+	}
+
+	records, err := service.buildRecords(ctx, t, rows, request.SendInvitation)
+	if err != nil {
+		return
+	}
+	if err = service.Repository.Import(records); err != nil {
+		return
+	}
+	report.Imported = len(records)
+
+	for _, record := range records {
+		service.Audit.Record(ctx, constants.AuditImport, constants.AuditEntityUser, record.User.ID, nil, record.User)
+	}
+
+	if request.SendInvitation {
+		report.InvitationSent = service.sendInvitations(t, records)
+	}
+	return
+}
+
+// readHeader maps the column names to their index, a UTF-8 BOM left by Excel's CSV export is dropped
+func readHeader(header []string) map[string]int {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if alias, ok := columnAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	return columns
+}
+
+// readRows skips empty lines, the line numbers still count them
+func readRows(lines [][]string, columns map[string]int) (rows []model.ImportRow) {
+	cell := func(line []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(line) {
+			return ""
+		}
+		return line[i]
+	}
+
+	for i, line := range lines {
+		row := model.ImportRow{
+			Line:        i + 2,
+			Name:        cell(line, "name"),
+			Email:       cell(line, "email"),
+			PhoneNumber: cell(line, "phone_number"),
+			Occupation:  cell(line, "occupation"),
+			Institution: cell(line, "institution"),
+			Speciality:  cell(line, "speciality"),
+		}
+		if row == (model.ImportRow{Line: row.Line}) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return
+}
+
+// validate reports duplicate emails and phone numbers, in the file or already registered,
+// and occupation or speciality names that don't exist
+func (service *ImportServiceImpl) validate(t importType, rows []model.ImportRow) (rowErrors []model.ImportRowError, err error) {
+	occupations, err := service.Repository.FindOccupationIDs()
+	if err != nil {
+		return
+	}
+	specialities, err := service.Repository.FindSpecialityIDs()
+	if err != nil {
+		return
+	}
+
+	var emails, phoneNumbers []string
+	for _, row := range rows {
+		emails = append(emails, row.Email)
+		phoneNumbers = append(phoneNumbers, row.PhoneNumber)
+	}
+	existingEmails, err := service.Repository.FindExistingEmails(emails)
+	if err != nil {
+		return
+	}
+	existingPhoneNumbers, err := service.Repository.FindExistingPhoneNumbers(phoneNumbers)
+	if err != nil {
+		return
+	}
+
+	required := make(map[string]bool)
+	for _, column := range t.Required {
+		required[column] = true
+	}
+
+	emailLines := make(map[string]int)
+	phoneLines := make(map[string]int)
+	for _, row := range rows {
+		var errs []string
+		check := func(failed bool, message string, args ...interface{}) {
+			if failed {
+				errs = append(errs, fmt.Sprintf(message, args...))
+			}
+		}
+
+		emailKey := strings.ToLower(row.Email)
+		address, errAddress := mail.ParseAddress(row.Email)
+
+		check(row.Name == "", "name is required")
+		check(row.Email == "", "email is required")
+		check(row.Email != "" && (errAddress != nil || address.Address != row.Email), "email %s is not valid", row.Email)
+		check(row.Email != "" && emailLines[emailKey] != 0, "email %s is already on line %d", row.Email, emailLines[emailKey])
+		check(existingEmails[emailKey], "email %s is already registered", row.Email)
+		check(row.PhoneNumber == "", "phone_number is required")
+		check(len(row.PhoneNumber) > 13, "phone_number %s is longer than 13 characters", row.PhoneNumber)
+		check(row.PhoneNumber != "" && phoneLines[row.PhoneNumber] != 0, "phone_number %s is already on line %d", row.PhoneNumber, phoneLines[row.PhoneNumber])
+		check(existingPhoneNumbers[strings.ToLower(row.PhoneNumber)], "phone_number %s is already registered", row.PhoneNumber)
+		check(row.Occupation == "", "occupation is required")
+		check(row.Occupation != "" && occupations[strings.ToLower(row.Occupation)] == 0, "occupation %s is unknown", row.Occupation)
+		check(required["institution"] && row.Institution == "", "institution is required")
+		check(required["speciality"] && row.Speciality == "", "speciality is required")
+		check(required["speciality"] && row.Speciality != "" && specialities[strings.ToLower(row.Speciality)] == 0, "speciality %s is unknown", row.Speciality)
+
+		if row.Email != "" && emailLines[emailKey] == 0 {
+			emailLines[emailKey] = row.Line
+		}
+		if row.PhoneNumber != "" && phoneLines[row.PhoneNumber] == 0 {
+			phoneLines[row.PhoneNumber] = row.Line
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, model.ImportRowError{Line: row.Line, Email: row.Email, Errors: errs})
+		}
+	}
+	return
+}
+
+func (service *ImportServiceImpl) buildRecords(
+	ctx context.Context,
+	t importType,
+	rows []model.ImportRow,
+	sendInvitation bool,
+) (records []model.ImportRecord, err error) {
+	role, err := service.RoleRepository.FindByName(t.Role)
+	if err != nil {
+		return
+	}
+	occupations, err := service.Repository.FindOccupationIDs()
+	if err != nil {
+		return
+	}
+	specialities, err := service.Repository.FindSpecialityIDs()
+	if err != nil {
+		return
+	}
+
+	var latestEvent evm.Event
+	if t.Role == constants.UserParticipant {
+		if latestEvent, err = service.EventRepository.FindLatest(); err != nil {
+			return
+		}
+	}
+
+	for _, row := range rows {
+		occupationID := occupations[strings.ToLower(row.Occupation)]
+		record := model.ImportRecord{
+			User: um.User{
+				BaseEntity:   builder.BuildBaseEntity(ctx, true, nil),
+				UserRoleID:   role.ID,
+				Name:         row.Name,
+				Email:        row.Email,
+				PhoneNumber:  helper.ReferString(row.PhoneNumber),
+				AuthType:     constants.AuthTypeRegular,
+				IsActive:     true,
+				OccupationID: &occupationID,
+			},
+		}
+		if row.Institution != "" {
+			record.User.Institution = helper.ReferString(row.Institution)
+		}
+
+		if t.Role == constants.UserParticipant {
+			specialityID := specialities[strings.ToLower(row.Speciality)]
+			var invoice pym.Invoice
+			if invoice, err = service.newInvoice(ctx, latestEvent); err != nil {
+				return
+			}
+
+			record.EventID = latestEvent.ID
+			record.Invoice = &invoice
+			record.Participant = &um.Participant{
+				BaseEntity:    builder.BuildBaseEntity(ctx, true, nil),
+				PaymentStatus: invoice.Status,
+				SpecialityID:  &specialityID,
+			}
+		}
+
+		if sendInvitation {
+			record.Verification = &aum.VerificationCode{
+				BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+				Code:       utils.GenerateUuid(),
+				Type:       constants.VerifCodeSetPassword,
+				ExpireAt:   time.Now().Add(helper.GetEnvDuration("SET_PASSWORD_CODE_TTL", 72*time.Hour)),
+			}
+		}
+
+		records = append(records, record)
+	}
+	return
+}
+
+func (service *ImportServiceImpl) newInvoice(ctx context.Context, event evm.Event) (invoice pym.Invoice, err error) {
+	price, err := service.Pricing.Quote(event, "")
+	if err != nil {
+		return
+	}
+
+	invoice = pym.Invoice{
+		BaseEntity:     builder.BuildBaseEntity(ctx, true, nil),
+		EventID:        event.ID,
+		InvoiceNumber:  utils.GenerateInvoiceNumber(),
+		FeeTierID:      price.FeeTierID,
+		BaseAmount:     price.BaseAmount,
+		DiscountAmount: price.DiscountAmount,
+		Amount:         price.Amount,
+		Status:         constants.InvoiceUnpaid,
+	}
+	if invoice.Amount == 0 {
+		invoice.Status = constants.InvoicePaid
+		invoice.ApprovedAt = helper.ReferTime(time.Now())
+		invoice.ApprovedBy = helper.ReferString("system")
+	}
+	return
+}
+
+// sendInvitations queues the set password emails, the users are already saved so a failure is only logged
+func (service *ImportServiceImpl) sendInvitations(t importType, records []model.ImportRecord) (sent int) {
+	baseURL := os.Getenv("BASE_FE_ADMIN_URL")
+	if t.Role == constants.UserParticipant {
+		baseURL = os.Getenv("BASE_FE_URL")
+	}
+	url := fmt.Sprintf("%s%s", baseURL, os.Getenv("FORGET_PASSWORD_REDIRECT_URL"))
+
+	for _, record := range records {
+		templateData := email.TemplateData{
+			Name:        record.User.Name,
+			Link:        fmt.Sprintf("%s%s", url, record.Verification.Code),
+			SenderEmail: "sagarahackathon@gmail.com",
+			Title:       constants.EmailSubjectSetPassword,
+			Type:        constants.VerifCodeSetPassword,
+		}
+
+		r := email.NewRequest([]string{record.User.Email}, constants.EmailSubjectSetPassword, "")
+		if err := r.ParseTemplate(email.TemplateVerificationCode, templateData); err != nil {
+			log.Printf("failed to parse invitation email for %s: %v", record.User.Email, err)
+			continue
+		}
+		if err := service.Mailer.Send(*r); err != nil {
+			log.Printf("failed to queue invitation email for %s: %v", record.User.Email, err)
+			continue
+		}
+		sent++
+	}
+	return
+}
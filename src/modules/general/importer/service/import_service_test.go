@@ -0,0 +1,40 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/general/importer/model"
+	"reflect"
+	"testing"
+)
+
+func TestReadHeader(t *testing.T) {
+	columns := readHeader([]string{"\ufeffFull Name", " E-mail ", "Phone", "email", "Specialty"})
+
+	want := map[string]int{"name": 0, "e_mail": 1, "phone_number": 2, "email": 3, "speciality": 4}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("readHeader = %v, want %v", columns, want)
+	}
+}
+
+func TestReadHeaderKeepsFirstDuplicate(t *testing.T) {
+	columns := readHeader([]string{"name", "email", "Name"})
+	if columns["name"] != 0 {
+		t.Errorf("name is column %d, want the first one", columns["name"])
+	}
+}
+
+func TestReadRows(t *testing.T) {
+	columns := readHeader([]string{"name", "email", "phone_number"})
+	rows := readRows([][]string{
+		{"Jane", "jane@example.com", "0811"},
+		{"", "", ""},
+		{"John", "john@example.com"},
+	}, columns)
+
+	want := []model.ImportRow{
+		{Line: 2, Name: "Jane", Email: "jane@example.com", PhoneNumber: "0811"},
+		{Line: 4, Name: "John", Email: "john@example.com"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("readRows = %+v, want %+v", rows, want)
+	}
+}
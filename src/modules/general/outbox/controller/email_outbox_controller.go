@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/general/outbox/model"
+	"be-sagara-hackathon/src/modules/general/outbox/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type EmailOutboxController interface {
+	GetList(ctx *gin.Context)
+	Resend(ctx *gin.Context)
+}
+
+type EmailOutboxControllerImpl struct {
+	Service service.EmailOutboxService
+}
+
+func NewEmailOutboxController(service service.EmailOutboxService) EmailOutboxController {
+	return &EmailOutboxControllerImpl{Service: service}
+}
+
+// GetList Get List Email Outbox godoc
+// @Tags Emails
+// @Summary Get All Outgoing Emails
+// @Description Get All Outgoing Emails
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string false "Search by recipient or subject"
+// @Param status query string false "pending, processing, sent, failed"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /emails [get]
+func (controller *EmailOutboxControllerImpl) GetList(ctx *gin.Context) {
+	pg, err := utils.GetPaginateQueryOffset(ctx.Request)
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+
+	filter := model.FilterEmailOutbox{
+		Search: ctx.Query("q"),
+		Status: ctx.Query("status"),
+	}
+
+	data, err := controller.Service.GetList(filter, pg)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Email Success", data)
+}
+
+// Resend Re-send Failed Email godoc
+// @Tags Emails
+// @Summary Re-send Failed Email
+// @Description Put a failed email back to the queue
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Email Id"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /emails/{id}/resend [post]
+func (controller *EmailOutboxControllerImpl) Resend(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid Id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Resend(ctx, uint(id)); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrEmailNotFailed {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Resend Email Success", nil)
+}
@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/outbox/controller"
+	"be-sagara-hackathon/src/modules/general/outbox/repository"
+	"be-sagara-hackathon/src/modules/general/outbox/service"
+	"be-sagara-hackathon/src/utils/email"
+	"gorm.io/gorm"
+)
+
+var (
+	emailOutboxRepository repository.EmailOutboxRepository
+	emailOutboxService    service.EmailOutboxService
+	emailOutboxController controller.EmailOutboxController
+	emailWorker           service.EmailWorker
+	queuedMailer          email.Mailer
+)
+
+type Module interface {
+	InitModule()
+}
+
+type ModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(db *gorm.DB) Module {
+	return &ModuleImpl{DB: db}
+}
+
+func (module ModuleImpl) InitModule() {
+	emailOutboxRepository = repository.NewEmailOutboxRepository(module.DB)
+	emailOutboxService = service.NewEmailOutboxService(emailOutboxRepository, audit.GetRecorder())
+	emailOutboxController = controller.NewEmailOutboxController(emailOutboxService)
+	queuedMailer = service.NewQueuedMailer(emailOutboxService)
+
+	emailWorker = service.NewEmailWorker(emailOutboxRepository, email.NewMailer(), service.NewEmailWorkerConfig())
+	emailWorker.Start()
+}
+
+// StopWorker stops the email worker on shutdown, the emails being delivered are finished first
+func StopWorker() {
+	if emailWorker != nil {
+		emailWorker.Stop()
+	}
+}
+
+func GetEmailOutboxService() service.EmailOutboxService {
+	return emailOutboxService
+}
+
+// GetMailer returns the mailer other modules should use. Emails sent through it are delivered by the outbox worker.
+func GetMailer() email.Mailer {
+	return queuedMailer
+}
+
+func GetEmailOutboxController() controller.EmailOutboxController {
+	return emailOutboxController
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+type EmailOutbox struct {
+	common.BaseEntity
+	Recipients    string     `gorm:"type:text;not null"`
+	Subject       string     `gorm:"type:varchar(255);not null"`
+	Body          string     `gorm:"type:longtext;not null"`
+	Status        string     `gorm:"type:varchar(15);not null;index"` //pending,processing,sent,failed
+	Attempts      uint       `gorm:"not null;default:0"`
+	MaxAttempts   uint       `gorm:"not null"`
+	NextAttemptAt time.Time  `gorm:"not null;index"`
+	LastError     *string    `gorm:"type:text;null"`
+	SentAt        *time.Time `gorm:"null"`
+	LockedAt      *time.Time `gorm:"null"` //when a worker claimed it
+}
+
+type FilterEmailOutbox struct {
+	Search string //recipient, subject
+	Status string
+}
+
+type EmailOutboxLite struct {
+	ID            uint       `json:"id"`
+	Recipients    string     `json:"recipients"`
+	Subject       string     `json:"subject"`
+	Status        string     `json:"status"`
+	Attempts      uint       `json:"attempts"`
+	MaxAttempts   uint       `json:"max_attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     *string    `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type ListEmailOutboxResponse struct {
+	Emails    []EmailOutboxLite `json:"emails"`
+	TotalPage int64             `json:"total_page"`
+	TotalItem int64             `json:"total_item"`
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/general/outbox/model"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"math"
+	"strings"
+	"time"
+)
+
+type EmailOutboxRepository interface {
+	Save(outbox model.EmailOutbox) error
+	FindAll(
+		filter model.FilterEmailOutbox,
+		pg *utils.PaginateQueryOffset,
+	) (emails []model.EmailOutboxLite, totalData, totalPage int64, err error)
+	FindByID(id uint) (outbox model.EmailOutbox, err error)
+	FindDue(limit int) (emails []model.EmailOutbox, err error)
+	Claim(id uint) (bool, error)
+	UpdateDelivery(id uint, outbox model.EmailOutbox) error
+	Requeue(id uint, updatedBy string) error
+	ReleaseProcessing(lockTimeout time.Duration) error
+}
+
+type EmailOutboxRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewEmailOutboxRepository(db *gorm.DB) EmailOutboxRepository {
+	return &EmailOutboxRepositoryImpl{DB: db}
+}
+
+func (repository *EmailOutboxRepositoryImpl) Save(outbox model.EmailOutbox) error {
+	if err := repository.DB.Create(&outbox).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *EmailOutboxRepositoryImpl) FindAll(
+	filter model.FilterEmailOutbox,
+	pg *utils.PaginateQueryOffset,
+) (emails []model.EmailOutboxLite, totalData, totalPage int64, err error) {
+	where, whereVals := BuildFilter(filter)
+
+	var buildWhereQuery string
+	if where != nil {
+		buildWhereQuery = strings.Join(where, " AND ")
+	}
+
+	if err = repository.DB.Model(&model.EmailOutbox{}).
+		Select(`id, recipients, subject, status, attempts, max_attempts, next_attempt_at,
+			last_error, sent_at, created_at`).
+		Order(fmt.Sprintf("%s %s", pg.Order.Field, pg.Order.By)).
+		Limit(pg.Limit).Offset(pg.Offset).
+		Where(buildWhereQuery, whereVals...).
+		Find(&emails).Error; err != nil {
+		return
+	}
+
+	if err = repository.DB.Model(&model.EmailOutbox{}).
+		Where(buildWhereQuery, whereVals...).
+		Count(&totalData).Error; err != nil {
+		return
+	}
+
+	if pg.Limit > 0 {
+		totalPage = int64(math.Ceil(float64(totalData) / float64(pg.Limit)))
+	} else {
+		totalPage = 1
+	}
+
+	return
+}
+
+func BuildFilter(filter model.FilterEmailOutbox) (where []string, whereVal []interface{}) {
+	if filter.Search != "" {
+		filter.Search = strings.ToLower(filter.Search)
+		where = append(where, "(LOWER(recipients) LIKE @q OR LOWER(subject) LIKE @q)")
+		whereVal = append(whereVal, sql.Named("q", "%"+filter.Search+"%"))
+	}
+
+	if filter.Status != "" {
+		where = append(where, "status = @status")
+		whereVal = append(whereVal, sql.Named("status", filter.Status))
+	}
+
+	return
+}
+
+func (repository *EmailOutboxRepositoryImpl) FindByID(id uint) (outbox model.EmailOutbox, err error) {
+	result := repository.DB.
+		Where("id = ?", id).
+		First(&outbox)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		err = e.ErrDataNotFound
+		return
+	}
+	err = result.Error
+	return
+}
+
+func (repository *EmailOutboxRepositoryImpl) FindDue(limit int) (emails []model.EmailOutbox, err error) {
+	err = repository.DB.
+		Where("status = ? AND next_attempt_at <= ?", constants.EmailOutboxPending, time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&emails).Error
+	return
+}
+
+// Claim marks a pending email as processing. It returns false when another worker got it first.
+func (repository *EmailOutboxRepositoryImpl) Claim(id uint) (bool, error) {
+	result := repository.DB.Model(&model.EmailOutbox{}).
+		Where("id = ? AND status = ?", id, constants.EmailOutboxPending).
+		Updates(map[string]interface{}{
+			"status":    constants.EmailOutboxProcessing,
+			"locked_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (repository *EmailOutboxRepositoryImpl) UpdateDelivery(id uint, outbox model.EmailOutbox) error {
+	return repository.DB.Model(&model.EmailOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          outbox.Status,
+			"attempts":        outbox.Attempts,
+			"next_attempt_at": outbox.NextAttemptAt,
+			"last_error":      outbox.LastError,
+			"sent_at":         outbox.SentAt,
+			"locked_at":       nil,
+		}).Error
+}
+
+// Requeue only puts back an email that still failed, two resends of the same email queue it once
+func (repository *EmailOutboxRepositoryImpl) Requeue(id uint, updatedBy string) error {
+	result := repository.DB.Model(&model.EmailOutbox{}).
+		Where("id = ? AND status = ?", id, constants.EmailOutboxFailed).
+		Updates(map[string]interface{}{
+			"status":          constants.EmailOutboxPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"updated_by":      updatedBy,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrEmailNotFailed
+	}
+	return nil
+}
+
+// ReleaseProcessing puts back emails that were claimed more than lockTimeout ago and never finished, e.g. because
+// the app was restarted in the middle of a delivery. Emails another worker is still delivering are left alone.
+func (repository *EmailOutboxRepositoryImpl) ReleaseProcessing(lockTimeout time.Duration) error {
+	return repository.DB.Model(&model.EmailOutbox{}).
+		Where("status = ? AND (locked_at IS NULL OR locked_at < ?)",
+			constants.EmailOutboxProcessing, time.Now().Add(-lockTimeout)).
+		Updates(map[string]interface{}{
+			"status":    constants.EmailOutboxPending,
+			"locked_at": nil,
+		}).Error
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/general/outbox"
+	"be-sagara-hackathon/src/utils/constants"
+	"github.com/gin-gonic/gin"
+)
+
+func EmailOutboxRouter(group *gin.RouterGroup) {
+	group.GET("/",
+		middlewares.Permission(constants.PermissionEmailManage),
+		outbox.GetEmailOutboxController().GetList,
+	)
+	group.POST("/:id/resend",
+		middlewares.Permission(constants.PermissionEmailManage),
+		outbox.GetEmailOutboxController().Resend,
+	)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/general/outbox/model"
+	"be-sagara-hackathon/src/modules/general/outbox/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"strings"
+	"time"
+)
+
+type EmailOutboxService interface {
+	Enqueue(request email.Request) error
+	GetList(
+		filter model.FilterEmailOutbox,
+		pg *utils.PaginateQueryOffset,
+	) (response model.ListEmailOutboxResponse, err error)
+	Resend(ctx context.Context, id uint) error
+}
+
+type EmailOutboxServiceImpl struct {
+	Repository repository.EmailOutboxRepository
+	Audit      ads.Recorder
+}
+
+func NewEmailOutboxService(repository repository.EmailOutboxRepository, audit ads.Recorder) EmailOutboxService {
+	return &EmailOutboxServiceImpl{Repository: repository, Audit: audit}
+}
+
+func (service *EmailOutboxServiceImpl) Enqueue(request email.Request) error {
+	return service.Repository.Save(model.EmailOutbox{
+		BaseEntity: common.BaseEntity{
+			CreatedBy: "system",
+			UpdatedBy: "system",
+		},
+		Recipients:    strings.Join(request.To, ","),
+		Subject:       request.Subject,
+		Body:          request.Body,
+		Status:        constants.EmailOutboxPending,
+		MaxAttempts:   uint(helper.GetEnvInt("EMAIL_MAX_ATTEMPTS", 5)),
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// QueuedMailer hands emails over to the outbox instead of delivering them right away.
+type QueuedMailer struct {
+	Outbox EmailOutboxService
+}
+
+func NewQueuedMailer(outbox EmailOutboxService) email.Mailer {
+	return &QueuedMailer{Outbox: outbox}
+}
+
+func (mailer *QueuedMailer) Send(request email.Request) error {
+	return mailer.Outbox.Enqueue(request)
+}
+
+func (service *EmailOutboxServiceImpl) GetList(
+	filter model.FilterEmailOutbox,
+	pg *utils.PaginateQueryOffset,
+) (response model.ListEmailOutboxResponse, err error) {
+	response.Emails, response.TotalItem, response.TotalPage, err = service.Repository.FindAll(filter, pg)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func (service *EmailOutboxServiceImpl) Resend(ctx context.Context, id uint) error {
+	authenticatedUser := ctx.Value("user").(um.User)
+
+	outbox, err := service.Repository.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if outbox.Status != constants.EmailOutboxFailed {
+		return e.ErrEmailNotFailed
+	}
+
+	if err = service.Repository.Requeue(outbox.ID, authenticatedUser.Email); err != nil {
+		return err
+	}
+
+	requeued, _ := service.Repository.FindByID(outbox.ID)
+	service.Audit.Record(ctx, constants.AuditResend, constants.AuditEntityEmail, outbox.ID, outbox, requeued)
+	return nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/general/outbox/model"
+	"be-sagara-hackathon/src/modules/general/outbox/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	"be-sagara-hackathon/src/utils/helper"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+type EmailWorker interface {
+	Start()
+	Stop()
+}
+
+type EmailWorkerConfig struct {
+	Workers      int
+	BatchSize    int
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	LockTimeout  time.Duration
+}
+
+func NewEmailWorkerConfig() EmailWorkerConfig {
+	return EmailWorkerConfig{
+		Workers:      helper.GetEnvInt("EMAIL_WORKER_COUNT", 2),
+		BatchSize:    helper.GetEnvInt("EMAIL_WORKER_BATCH_SIZE", 20),
+		PollInterval: helper.GetEnvDuration("EMAIL_WORKER_POLL_INTERVAL", 5*time.Second),
+		BaseBackoff:  helper.GetEnvDuration("EMAIL_RETRY_BASE_BACKOFF", 30*time.Second),
+		MaxBackoff:   helper.GetEnvDuration("EMAIL_RETRY_MAX_BACKOFF", time.Hour),
+		LockTimeout:  helper.GetEnvDuration("EMAIL_WORKER_LOCK_TIMEOUT", 10*time.Minute),
+	}
+}
+
+type EmailWorkerImpl struct {
+	Repository repository.EmailOutboxRepository
+	Mailer     email.Mailer
+	Config     EmailWorkerConfig
+
+	jobs chan model.EmailOutbox
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewEmailWorker(
+	repository repository.EmailOutboxRepository,
+	mailer email.Mailer,
+	config EmailWorkerConfig,
+) EmailWorker {
+	return &EmailWorkerImpl{
+		Repository: repository,
+		Mailer:     mailer,
+		Config:     config,
+	}
+}
+
+// Start runs the poller and the worker pool in background goroutines.
+func (worker *EmailWorkerImpl) Start() {
+	worker.jobs = make(chan model.EmailOutbox)
+	worker.quit = make(chan struct{})
+
+	for i := 0; i < worker.Config.Workers; i++ {
+		worker.wg.Add(1)
+		go worker.work()
+	}
+
+	worker.wg.Add(1)
+	go worker.poll()
+}
+
+// Stop waits until the emails being delivered are finished. It does nothing when the worker isn't running.
+func (worker *EmailWorkerImpl) Stop() {
+	if worker.quit == nil {
+		return
+	}
+
+	close(worker.quit)
+	worker.wg.Wait()
+	worker.quit = nil
+}
+
+func (worker *EmailWorkerImpl) poll() {
+	defer worker.wg.Done()
+	defer close(worker.jobs)
+
+	ticker := time.NewTicker(worker.Config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		worker.dispatch()
+
+		select {
+		case <-worker.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (worker *EmailWorkerImpl) dispatch() {
+	// emails claimed by a worker that died are picked up again once their lock is stale
+	if err := worker.Repository.ReleaseProcessing(worker.Config.LockTimeout); err != nil {
+		log.Printf("email worker: failed to release processing emails: %v", err)
+	}
+
+	emails, err := worker.Repository.FindDue(worker.Config.BatchSize)
+	if err != nil {
+		log.Printf("email worker: failed to fetch due emails: %v", err)
+		return
+	}
+
+	for _, outbox := range emails {
+		claimed, err := worker.Repository.Claim(outbox.ID)
+		if err != nil {
+			log.Printf("email worker: failed to claim email %d: %v", outbox.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		select {
+		case worker.jobs <- outbox:
+		case <-worker.quit:
+			// give it back so it's picked up on the next start
+			outbox.Status = constants.EmailOutboxPending
+			if err = worker.Repository.UpdateDelivery(outbox.ID, outbox); err != nil {
+				log.Printf("email worker: failed to release email %d: %v", outbox.ID, err)
+			}
+			return
+		}
+	}
+}
+
+func (worker *EmailWorkerImpl) work() {
+	defer worker.wg.Done()
+	for outbox := range worker.jobs {
+		worker.deliver(outbox)
+	}
+}
+
+func (worker *EmailWorkerImpl) deliver(outbox model.EmailOutbox) {
+	err := worker.Mailer.Send(email.Request{
+		To:      strings.Split(outbox.Recipients, ","),
+		Subject: outbox.Subject,
+		Body:    outbox.Body,
+	})
+
+	outbox.Attempts++
+	if err == nil {
+		now := time.Now()
+		outbox.Status = constants.EmailOutboxSent
+		outbox.SentAt = &now
+		outbox.LastError = nil
+	} else {
+		lastError := err.Error()
+		outbox.LastError = &lastError
+		if outbox.Attempts >= outbox.MaxAttempts {
+			outbox.Status = constants.EmailOutboxFailed
+		} else {
+			outbox.Status = constants.EmailOutboxPending
+			outbox.NextAttemptAt = time.Now().Add(worker.backoff(outbox.Attempts))
+		}
+	}
+
+	if err = worker.Repository.UpdateDelivery(outbox.ID, outbox); err != nil {
+		log.Printf("email worker: failed to update email %d: %v", outbox.ID, err)
+	}
+}
+
+// backoff doubles the waiting time on every failed attempt, capped at MaxBackoff.
+func (worker *EmailWorkerImpl) backoff(attempts uint) time.Duration {
+	wait := worker.Config.BaseBackoff
+	for i := uint(1); i < attempts; i++ {
+		wait *= 2
+		if wait >= worker.Config.MaxBackoff {
+			return worker.Config.MaxBackoff
+		}
+	}
+	return wait
+}
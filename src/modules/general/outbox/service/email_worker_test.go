@@ -0,0 +1,228 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/general/outbox/model"
+	"be-sagara-hackathon/src/modules/general/outbox/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	e "be-sagara-hackathon/src/utils/errors"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEmailOutboxRepository keeps the outbox in memory
+type fakeEmailOutboxRepository struct {
+	repository.EmailOutboxRepository
+	mu     sync.Mutex
+	emails map[uint]model.EmailOutbox
+}
+
+func newFakeEmailOutboxRepository(emails ...model.EmailOutbox) *fakeEmailOutboxRepository {
+	repository := &fakeEmailOutboxRepository{emails: map[uint]model.EmailOutbox{}}
+	for _, outbox := range emails {
+		repository.emails[outbox.ID] = outbox
+	}
+	return repository
+}
+
+func (repository *fakeEmailOutboxRepository) Save(outbox model.EmailOutbox) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	outbox.ID = uint(len(repository.emails) + 1)
+	repository.emails[outbox.ID] = outbox
+	return nil
+}
+
+func (repository *fakeEmailOutboxRepository) FindByID(id uint) (model.EmailOutbox, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	outbox, ok := repository.emails[id]
+	if !ok {
+		return model.EmailOutbox{}, e.ErrDataNotFound
+	}
+	return outbox, nil
+}
+
+func (repository *fakeEmailOutboxRepository) FindDue(int) (emails []model.EmailOutbox, err error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	for _, outbox := range repository.emails {
+		if outbox.Status == constants.EmailOutboxPending && !outbox.NextAttemptAt.After(time.Now()) {
+			emails = append(emails, outbox)
+		}
+	}
+	return
+}
+
+func (repository *fakeEmailOutboxRepository) Claim(id uint) (bool, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	outbox := repository.emails[id]
+	if outbox.Status != constants.EmailOutboxPending {
+		return false, nil
+	}
+	outbox.Status = constants.EmailOutboxProcessing
+	repository.emails[id] = outbox
+	return true, nil
+}
+
+func (repository *fakeEmailOutboxRepository) UpdateDelivery(id uint, outbox model.EmailOutbox) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	repository.emails[id] = outbox
+	return nil
+}
+
+func (repository *fakeEmailOutboxRepository) ReleaseProcessing(time.Duration) error {
+	return nil
+}
+
+// fakeMailer fails the first failures sends and keeps the ones after
+type fakeMailer struct {
+	mu       sync.Mutex
+	failures int
+	sent     []email.Request
+}
+
+func (mailer *fakeMailer) Send(request email.Request) error {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	if mailer.failures > 0 {
+		mailer.failures--
+		return errors.New("smtp unavailable")
+	}
+	mailer.sent = append(mailer.sent, request)
+	return nil
+}
+
+func (mailer *fakeMailer) count() int {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	return len(mailer.sent)
+}
+
+func testWorkerConfig() EmailWorkerConfig {
+	return EmailWorkerConfig{
+		Workers:      2,
+		BatchSize:    10,
+		PollInterval: 10 * time.Millisecond,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   5 * time.Minute,
+		LockTimeout:  time.Minute,
+	}
+}
+
+func pendingEmail(id uint) model.EmailOutbox {
+	outbox := model.EmailOutbox{
+		Recipients:  "a@example.com,b@example.com",
+		Subject:     "Subject",
+		Body:        "Body",
+		Status:      constants.EmailOutboxPending,
+		MaxAttempts: 2,
+	}
+	outbox.ID = id
+	return outbox
+}
+
+func TestEmailWorkerDeliver(t *testing.T) {
+	repository := newFakeEmailOutboxRepository(pendingEmail(1))
+	mailer := &fakeMailer{}
+	worker := &EmailWorkerImpl{Repository: repository, Mailer: mailer, Config: testWorkerConfig()}
+
+	worker.deliver(repository.emails[1])
+
+	outbox, _ := repository.FindByID(1)
+	if outbox.Status != constants.EmailOutboxSent || outbox.SentAt == nil || outbox.Attempts != 1 {
+		t.Errorf("delivered email = %s after %d attempts, want sent after 1", outbox.Status, outbox.Attempts)
+	}
+	if len(mailer.sent) != 1 || len(mailer.sent[0].To) != 2 {
+		t.Fatalf("mailer got %+v, want one email to two recipients", mailer.sent)
+	}
+}
+
+func TestEmailWorkerRetry(t *testing.T) {
+	repository := newFakeEmailOutboxRepository(pendingEmail(1))
+	worker := &EmailWorkerImpl{Repository: repository, Mailer: &fakeMailer{failures: 2}, Config: testWorkerConfig()}
+
+	before := time.Now()
+	worker.deliver(repository.emails[1])
+	outbox, _ := repository.FindByID(1)
+	if outbox.Status != constants.EmailOutboxPending || outbox.LastError == nil {
+		t.Fatalf("failed email = %s, want pending with the error", outbox.Status)
+	}
+	if outbox.NextAttemptAt.Before(before.Add(time.Minute)) {
+		t.Errorf("next attempt at %v, want at least one base backoff later", outbox.NextAttemptAt)
+	}
+
+	worker.deliver(outbox)
+	outbox, _ = repository.FindByID(1)
+	if outbox.Status != constants.EmailOutboxFailed || outbox.Attempts != 2 {
+		t.Errorf("email = %s after %d attempts, want failed after the max attempts", outbox.Status, outbox.Attempts)
+	}
+}
+
+func TestEmailWorkerBackoff(t *testing.T) {
+	worker := &EmailWorkerImpl{Config: testWorkerConfig()}
+	cases := map[uint]time.Duration{
+		1: time.Minute,
+		2: 2 * time.Minute,
+		3: 4 * time.Minute,
+		4: 5 * time.Minute,
+		9: 5 * time.Minute,
+	}
+	for attempts, want := range cases {
+		if got := worker.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestEmailWorkerStartStop(t *testing.T) {
+	repository := newFakeEmailOutboxRepository(pendingEmail(1), pendingEmail(2), pendingEmail(3))
+	mailer := &fakeMailer{}
+	worker := NewEmailWorker(repository, mailer, testWorkerConfig())
+
+	worker.Start()
+	deadline := time.Now().Add(time.Second)
+	for mailer.count() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	worker.Stop()
+
+	if mailer.count() != 3 {
+		t.Fatalf("mailer got %d emails, want 3", mailer.count())
+	}
+	for id := uint(1); id <= 3; id++ {
+		if outbox, _ := repository.FindByID(id); outbox.Status != constants.EmailOutboxSent {
+			t.Errorf("email %d = %s, want sent", id, outbox.Status)
+		}
+	}
+}
+
+func TestEmailWorkerStopWhenNotRunning(t *testing.T) {
+	worker := NewEmailWorker(newFakeEmailOutboxRepository(), &fakeMailer{}, testWorkerConfig())
+
+	worker.Stop()
+	worker.Start()
+	worker.Stop()
+	worker.Stop()
+}
+
+func TestQueuedMailer(t *testing.T) {
+	repository := newFakeEmailOutboxRepository()
+	mailer := NewQueuedMailer(NewEmailOutboxService(repository, nil))
+
+	if err := mailer.Send(email.Request{To: []string{"a@example.com", "b@example.com"}, Subject: "Subject", Body: "Body"}); err != nil {
+		t.Fatal(err)
+	}
+
+	outbox, err := repository.FindByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outbox.Status != constants.EmailOutboxPending || outbox.Recipients != "a@example.com,b@example.com" {
+		t.Errorf("queued email = %s to %q, want pending to both recipients", outbox.Status, outbox.Recipients)
+	}
+}
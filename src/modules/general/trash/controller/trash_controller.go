@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/general/trash/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type TrashController interface {
+	GetList(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+}
+
+type TrashControllerImpl struct {
+	Service service.TrashService
+}
+
+func NewTrashController(service service.TrashService) TrashController {
+	return &TrashControllerImpl{Service: service}
+}
+
+// GetList Get List Deleted godoc
+// @Tags Trash
+// @Summary Get List Deleted
+// @Description Get the deleted events, teams, projects or users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "events, teams, projects or users"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /trash/{type} [get]
+func (controller *TrashControllerImpl) GetList(ctx *gin.Context) {
+	pg, err := utils.GetPaginateQueryOffset(ctx.Request)
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetList(ctx.Param("type"), pg)
+	if err != nil {
+		sendTrashError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Deleted Success", data)
+}
+
+// Restore Restore Deleted godoc
+// @Tags Trash
+// @Summary Restore Deleted
+// @Description Restore a deleted event, team, project or user
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "events, teams, projects or users"
+// @Param id path int true "ID"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /trash/{type}/{id}/restore [post]
+func (controller *TrashControllerImpl) Restore(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid Id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Restore(ctx, ctx.Param("type"), uint(id)); err != nil {
+		sendTrashError(ctx, err)
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Restore Success", nil)
+}
+
+func sendTrashError(ctx *gin.Context, err error) {
+	switch err {
+	case e.ErrDataNotFound:
+		common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+	case e.ErrInvalidTrashType, e.ErrRestoreConflict:
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+	default:
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+	}
+}
@@ -0,0 +1,37 @@
+package trash
+
+import (
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/trash/controller"
+	"be-sagara-hackathon/src/modules/general/trash/repository"
+	"be-sagara-hackathon/src/modules/general/trash/service"
+	"gorm.io/gorm"
+)
+
+var (
+	trashRepository repository.TrashRepository
+	trashService    service.TrashService
+	trashController controller.TrashController
+)
+
+type Module interface {
+	InitModule()
+}
+
+type ModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(db *gorm.DB) Module {
+	return &ModuleImpl{DB: db}
+}
+
+func (module ModuleImpl) InitModule() {
+	trashRepository = repository.NewTrashRepository(module.DB)
+	trashService = service.NewTrashService(trashRepository, audit.GetRecorder())
+	trashController = controller.NewTrashController(trashService)
+}
+
+func GetTrashController() controller.TrashController {
+	return trashController
+}
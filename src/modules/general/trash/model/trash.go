@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// TrashItem is a soft deleted event, team, project or user
+type TrashItem struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	DeletedBy *string   `json:"deleted_by"`
+}
+
+type ListTrashResponse struct {
+	Items     []TrashItem `json:"items"`
+	TotalPage int64       `json:"total_page"`
+	TotalItem int64       `json:"total_item"`
+}
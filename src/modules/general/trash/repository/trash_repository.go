@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/general/trash/model"
+	"be-sagara-hackathon/src/utils"
+	e "be-sagara-hackathon/src/utils/errors"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"math"
+	"strings"
+	"time"
+)
+
+// TrashRepository reads the soft deleted rows of a table. The table name comes from the service,
+// never from the request.
+type TrashRepository interface {
+	FindAll(table string, pg *utils.PaginateQueryOffset) (items []model.TrashItem, totalData, totalPage int64, err error)
+	FindOne(table string, id uint) (item model.TrashItem, err error)
+	Restore(table string, id uint, restoredBy string) error
+}
+
+type TrashRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &TrashRepositoryImpl{DB: db}
+}
+
+var trashOrderFields = []string{"id", "name", "deleted_at", "deleted_by"}
+
+func (repository *TrashRepositoryImpl) FindAll(
+	table string,
+	pg *utils.PaginateQueryOffset,
+) (items []model.TrashItem, totalData, totalPage int64, err error) {
+	order := "deleted_at DESC"
+	if pg.Order.Field != "" {
+		by := strings.ToUpper(pg.Order.By)
+		if by != "ASC" && by != "DESC" {
+			by = "ASC"
+		}
+		for _, field := range trashOrderFields {
+			if field == pg.Order.Field {
+				order = fmt.Sprintf("%s %s", field, by)
+			}
+		}
+	}
+
+	if err = repository.DB.Unscoped().Table(table).
+		Select("id, name, deleted_at, deleted_by").
+		Where("deleted_at IS NOT NULL").
+		Order(order).
+		Limit(pg.Limit).Offset(pg.Offset).
+		Find(&items).Error; err != nil {
+		return
+	}
+
+	if err = repository.DB.Unscoped().Table(table).
+		Where("deleted_at IS NOT NULL").
+		Count(&totalData).Error; err != nil {
+		return
+	}
+
+	if pg.Limit > 0 {
+		totalPage = int64(math.Ceil(float64(totalData) / float64(pg.Limit)))
+	} else {
+		totalPage = 1
+	}
+
+	return
+}
+
+func (repository *TrashRepositoryImpl) FindOne(table string, id uint) (item model.TrashItem, err error) {
+	if err = repository.DB.Unscoped().Table(table).
+		Select("id, name, deleted_at, deleted_by").
+		Where("id=? AND deleted_at IS NOT NULL", id).
+		Take(&item).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+	}
+	return
+}
+
+// Restore fails with ErrRestoreConflict when an active row took the unique values of the deleted one meanwhile
+func (repository *TrashRepositoryImpl) Restore(table string, id uint, restoredBy string) error {
+	result := repository.DB.Unscoped().Table(table).
+		Where("id=? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": nil,
+			"updated_at": time.Now(),
+			"updated_by": restoredBy,
+		})
+	if result.Error != nil {
+		var mySqlErr *mysql.MySQLError
+		if errors.As(result.Error, &mySqlErr) && mySqlErr.Number == 1062 {
+			return e.ErrRestoreConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrDataNotFound
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/general/trash"
+	"be-sagara-hackathon/src/utils/constants"
+	"github.com/gin-gonic/gin"
+)
+
+func TrashRouter(group *gin.RouterGroup) {
+	group.GET("/:type",
+		middlewares.Permission(constants.PermissionTrashManage),
+		trash.GetTrashController().GetList,
+	)
+	group.POST("/:type/:id/restore",
+		middlewares.Permission(constants.PermissionTrashManage),
+		trash.GetTrashController().Restore,
+	)
+}
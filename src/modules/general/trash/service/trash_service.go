@@ -0,0 +1,81 @@
+package service
+
+import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/general/trash/model"
+	"be-sagara-hackathon/src/modules/general/trash/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+)
+
+type TrashService interface {
+	GetList(trashType string, pg *utils.PaginateQueryOffset) (data model.ListTrashResponse, err error)
+	Restore(ctx context.Context, trashType string, id uint) error
+}
+
+type TrashServiceImpl struct {
+	Repository repository.TrashRepository
+	Audit      ads.Recorder
+}
+
+func NewTrashService(repository repository.TrashRepository, audit ads.Recorder) TrashService {
+	return &TrashServiceImpl{
+		Repository: repository,
+		Audit:      audit,
+	}
+}
+
+type trashType struct {
+	Table  string
+	Entity string
+}
+
+// trashTypes maps the type in the url to its table and audit log entity
+var trashTypes = map[string]trashType{
+	"events":   {Table: "events", Entity: constants.AuditEntityEvent},
+	"teams":    {Table: "teams", Entity: constants.AuditEntityTeam},
+	"projects": {Table: "projects", Entity: constants.AuditEntityProject},
+	"users":    {Table: "users", Entity: constants.AuditEntityUser},
+}
+
+func (service *TrashServiceImpl) GetList(trashType string, pg *utils.PaginateQueryOffset) (data model.ListTrashResponse, err error) {
+	t, ok := trashTypes[trashType]
+	if !ok {
+		err = e.ErrInvalidTrashType
+		return
+	}
+
+	items, totalData, totalPage, err := service.Repository.FindAll(t.Table, pg)
+	if err != nil {
+		return
+	}
+
+	data = model.ListTrashResponse{
+		Items:     items,
+		TotalPage: totalPage,
+		TotalItem: totalData,
+	}
+	return
+}
+
+func (service *TrashServiceImpl) Restore(ctx context.Context, trashType string, id uint) error {
+	t, ok := trashTypes[trashType]
+	if !ok {
+		return e.ErrInvalidTrashType
+	}
+
+	item, err := service.Repository.FindOne(t.Table, id)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Repository.Restore(t.Table, id, ctx.Value("user").(um.User).Email); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditRestore, t.Entity, id, item, nil)
+	return nil
+}
@@ -1,6 +1,7 @@
 package occupation
 
 import (
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/master-data/occupation/controller"
 	"be-sagara-hackathon/src/modules/master-data/occupation/repository"
 	"be-sagara-hackathon/src/modules/master-data/occupation/service"
@@ -27,7 +28,7 @@ func New(database *gorm.DB) OccupationModule {
 
 func (module *OccupationModuleImpl) InitModule() {
 	occupationRepository = repository.NewOccupationRepository(module.DB)
-	occupationService = service.NewOccupationService(occupationRepository)
+	occupationService = service.NewOccupationService(occupationRepository, audit.GetRecorder())
 	occupationController = controller.NewOccupationController(occupationService)
 }
 
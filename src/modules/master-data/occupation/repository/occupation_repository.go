@@ -11,7 +11,7 @@ import (
 )
 
 type OccupationRepository interface {
-	Save(req model.Occupation) (err error)
+	Save(req model.Occupation) (model.Occupation, error)
 	Update(req model.Occupation, id uint) (err error)
 	Find(
 		filter model.FilterOccupation,
@@ -28,11 +28,11 @@ func NewOccupationRepository(db *gorm.DB) OccupationRepository {
 	return &OccupationRepositoryImpl{DB: db}
 }
 
-func (repository *OccupationRepositoryImpl) Save(req model.Occupation) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *OccupationRepositoryImpl) Save(req model.Occupation) (model.Occupation, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *OccupationRepositoryImpl) Update(req model.Occupation, id uint) (err error) {
@@ -10,12 +10,12 @@ import (
 func OccupationRouter(group *gin.RouterGroup) {
 	group.POST("/", occupation.GetController().CreateOccupation)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		occupation.GetController().UpdateOccupation,
 	)
 	group.GET("/", occupation.GetController().GetListOccupation)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		occupation.GetController().GetDetailOccupation,
 	)
 }
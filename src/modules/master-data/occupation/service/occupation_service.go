@@ -1,11 +1,13 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/master-data/occupation/model"
 	"be-sagara-hackathon/src/modules/master-data/occupation/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 	"time"
 )
@@ -22,19 +24,23 @@ type OccupationService interface {
 
 type OccupationServiceImpl struct {
 	Repository repository.OccupationRepository
+	Audit      ads.Recorder
 }
 
-func NewOccupationService(repository repository.OccupationRepository) OccupationService {
-	return &OccupationServiceImpl{Repository: repository}
+func NewOccupationService(repository repository.OccupationRepository, audit ads.Recorder) OccupationService {
+	return &OccupationServiceImpl{Repository: repository, Audit: audit}
 }
 
 func (service *OccupationServiceImpl) CreateOccupation(ctx context.Context, req model.OccupationRequest) (err error) {
-	if err = service.Repository.Save(model.Occupation{
+	saved, err := service.Repository.Save(model.Occupation{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		Name:       req.Name,
-	}); err != nil {
+	})
+	if err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityOccupation, saved.ID, nil, saved)
 	return
 }
 
@@ -44,6 +50,7 @@ func (service *OccupationServiceImpl) UpdateOccupation(ctx context.Context, req
 		return
 	}
 
+	before := occupation
 	occupation.Name = req.Name
 	occupation.IsActive = req.IsActive
 	occupation.UpdatedAt = time.Now()
@@ -51,6 +58,8 @@ func (service *OccupationServiceImpl) UpdateOccupation(ctx context.Context, req
 	if err = service.Repository.Update(occupation, id); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityOccupation, id, before, occupation)
 	return
 }
 
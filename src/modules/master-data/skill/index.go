@@ -1,6 +1,7 @@
 package skill
 
 import (
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/master-data/skill/controller"
 	"be-sagara-hackathon/src/modules/master-data/skill/repository"
 	"be-sagara-hackathon/src/modules/master-data/skill/service"
@@ -27,7 +28,7 @@ func New(database *gorm.DB) SkillModule {
 
 func (module *SkillModuleImpl) InitModule() {
 	skillRepository = repository.NewSkillRepository(module.DB)
-	skillService = service.NewSkillService(skillRepository)
+	skillService = service.NewSkillService(skillRepository, audit.GetRecorder())
 	skillController = controller.NewSkillController(skillService)
 }
 
@@ -11,7 +11,7 @@ import (
 )
 
 type SkillRepository interface {
-	Save(req model.Skill) (err error)
+	Save(req model.Skill) (model.Skill, error)
 	Update(req model.Skill, id uint) (err error)
 	Find(
 		filter model.FilterSkill,
@@ -28,11 +28,11 @@ func NewSkillRepository(db *gorm.DB) SkillRepository {
 	return &SkillRepositoryImpl{DB: db}
 }
 
-func (repository *SkillRepositoryImpl) Save(req model.Skill) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *SkillRepositoryImpl) Save(req model.Skill) (model.Skill, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *SkillRepositoryImpl) Update(req model.Skill, id uint) (err error) {
@@ -10,12 +10,12 @@ import (
 func SkillRouter(group *gin.RouterGroup) {
 	group.GET("/", skill.GetController().GetListSkill)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		skill.GetController().GetDetailSkill,
 	)
 	group.POST("/", skill.GetController().CreateSkill)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		skill.GetController().UpdateSkill,
 	)
 }
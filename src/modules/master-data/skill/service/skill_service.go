@@ -1,11 +1,13 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/master-data/skill/model"
 	"be-sagara-hackathon/src/modules/master-data/skill/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 	"time"
 )
@@ -22,19 +24,23 @@ type SkillService interface {
 
 type SkillServiceImpl struct {
 	Repository repository.SkillRepository
+	Audit      ads.Recorder
 }
 
-func NewSkillService(repository repository.SkillRepository) SkillService {
-	return &SkillServiceImpl{Repository: repository}
+func NewSkillService(repository repository.SkillRepository, audit ads.Recorder) SkillService {
+	return &SkillServiceImpl{Repository: repository, Audit: audit}
 }
 
 func (service *SkillServiceImpl) CreateSkill(ctx context.Context, req model.SkillRequest) (err error) {
-	if err = service.Repository.Save(model.Skill{
+	saved, err := service.Repository.Save(model.Skill{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		Name:       req.Name,
-	}); err != nil {
+	})
+	if err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntitySkill, saved.ID, nil, saved)
 	return
 }
 
@@ -44,6 +50,7 @@ func (service *SkillServiceImpl) UpdateSkill(ctx context.Context, req model.Upda
 		return
 	}
 
+	before := skill
 	skill.Name = req.Name
 	skill.IsActive = req.IsActive
 	skill.UpdatedAt = time.Now()
@@ -51,6 +58,8 @@ func (service *SkillServiceImpl) UpdateSkill(ctx context.Context, req model.Upda
 	if err = service.Repository.Update(skill, id); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntitySkill, id, before, skill)
 	return
 }
 
@@ -1,6 +1,7 @@
 package speciality
 
 import (
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/master-data/speciality/controller"
 	"be-sagara-hackathon/src/modules/master-data/speciality/repository"
 	"be-sagara-hackathon/src/modules/master-data/speciality/service"
@@ -27,7 +28,7 @@ func New(database *gorm.DB) SpecialityModule {
 
 func (module *SpecialityModuleImpl) InitModule() {
 	specialityRepository = repository.NewSpecialityRepository(module.DB)
-	specialityService = service.NewSpecialityService(specialityRepository)
+	specialityService = service.NewSpecialityService(specialityRepository, audit.GetRecorder())
 	specialityController = controller.NewSpecialityController(specialityService)
 }
 
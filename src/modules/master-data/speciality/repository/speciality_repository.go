@@ -11,7 +11,7 @@ import (
 )
 
 type SpecialityRepository interface {
-	Save(req model.Speciality) (err error)
+	Save(req model.Speciality) (model.Speciality, error)
 	Update(req model.Speciality, id uint) (err error)
 	Find(
 		filter model.FilterSpeciality,
@@ -28,11 +28,11 @@ func NewSpecialityRepository(db *gorm.DB) SpecialityRepository {
 	return &SpecialityRepositoryImpl{DB: db}
 }
 
-func (repository *SpecialityRepositoryImpl) Save(req model.Speciality) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *SpecialityRepositoryImpl) Save(req model.Speciality) (model.Speciality, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *SpecialityRepositoryImpl) Update(req model.Speciality, id uint) (err error) {
@@ -10,12 +10,12 @@ import (
 func SpecialityRouter(group *gin.RouterGroup) {
 	group.POST("/", speciality.GetController().CreateSpeciality)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		speciality.GetController().UpdateSpeciality,
 	)
 	group.GET("/", speciality.GetController().GetListSpeciality)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		speciality.GetController().GetDetailSpeciality,
 	)
 }
@@ -1,11 +1,13 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/master-data/speciality/model"
 	"be-sagara-hackathon/src/modules/master-data/speciality/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 	"time"
 )
@@ -22,19 +24,23 @@ type SpecialityService interface {
 
 type SpecialityServiceImpl struct {
 	Repository repository.SpecialityRepository
+	Audit      ads.Recorder
 }
 
-func NewSpecialityService(repository repository.SpecialityRepository) SpecialityService {
-	return &SpecialityServiceImpl{Repository: repository}
+func NewSpecialityService(repository repository.SpecialityRepository, audit ads.Recorder) SpecialityService {
+	return &SpecialityServiceImpl{Repository: repository, Audit: audit}
 }
 
 func (service *SpecialityServiceImpl) CreateSpeciality(ctx context.Context, req model.SpecialityRequest) (err error) {
-	if err = service.Repository.Save(model.Speciality{
+	saved, err := service.Repository.Save(model.Speciality{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		Name:       req.Name,
-	}); err != nil {
+	})
+	if err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntitySpeciality, saved.ID, nil, saved)
 	return
 }
 
@@ -44,6 +50,7 @@ func (service *SpecialityServiceImpl) UpdateSpeciality(ctx context.Context, req
 		return
 	}
 
+	before := speciality
 	speciality.Name = req.Name
 	speciality.IsActive = req.IsActive
 	speciality.UpdatedAt = time.Now()
@@ -51,6 +58,8 @@ func (service *SpecialityServiceImpl) UpdateSpeciality(ctx context.Context, req
 	if err = service.Repository.Update(speciality, id); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntitySpeciality, id, before, speciality)
 	return
 }
 
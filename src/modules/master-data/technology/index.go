@@ -1,6 +1,7 @@
 package technology
 
 import (
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/master-data/technology/controller"
 	"be-sagara-hackathon/src/modules/master-data/technology/repository"
 	"be-sagara-hackathon/src/modules/master-data/technology/service"
@@ -27,7 +28,7 @@ func New(database *gorm.DB) TechnologyModule {
 
 func (module *TechnologyModuleImpl) InitModule() {
 	technologyRepository = repository.NewTechnologyRepository(module.DB)
-	technologyService = service.NewTechnologyService(technologyRepository)
+	technologyService = service.NewTechnologyService(technologyRepository, audit.GetRecorder())
 	technologyController = controller.NewTechnologyController(technologyService)
 }
 
@@ -11,7 +11,7 @@ import (
 )
 
 type TechnologyRepository interface {
-	Save(req model.Technology) (err error)
+	Save(req model.Technology) (model.Technology, error)
 	Update(req model.Technology, id uint) (err error)
 	Find(
 		filter model.FilterTechnology,
@@ -28,11 +28,11 @@ func NewTechnologyRepository(db *gorm.DB) TechnologyRepository {
 	return &TechnologyRepositoryImpl{DB: db}
 }
 
-func (repository *TechnologyRepositoryImpl) Save(req model.Technology) (err error) {
-	if err = repository.DB.Create(&req).Error; err != nil {
-		return
+func (repository *TechnologyRepositoryImpl) Save(req model.Technology) (model.Technology, error) {
+	if err := repository.DB.Create(&req).Error; err != nil {
+		return req, err
 	}
-	return
+	return req, nil
 }
 
 func (repository *TechnologyRepositoryImpl) Update(req model.Technology, id uint) (err error) {
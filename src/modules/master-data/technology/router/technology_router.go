@@ -10,12 +10,12 @@ import (
 func TechnologyRouter(group *gin.RouterGroup) {
 	group.GET("/", technology.GetController().GetListTechnology)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		technology.GetController().GetDetailTechnology,
 	)
 	group.POST("/", technology.GetController().CreateTechnology)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionMasterDataManage),
 		technology.GetController().UpdateTechnology,
 	)
 }
@@ -1,11 +1,13 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/master-data/technology/model"
 	"be-sagara-hackathon/src/modules/master-data/technology/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 	"time"
 )
@@ -22,19 +24,23 @@ type TechnologyService interface {
 
 type TechnologyServiceImpl struct {
 	Repository repository.TechnologyRepository
+	Audit      ads.Recorder
 }
 
-func NewTechnologyService(repository repository.TechnologyRepository) TechnologyService {
-	return &TechnologyServiceImpl{Repository: repository}
+func NewTechnologyService(repository repository.TechnologyRepository, audit ads.Recorder) TechnologyService {
+	return &TechnologyServiceImpl{Repository: repository, Audit: audit}
 }
 
 func (service *TechnologyServiceImpl) CreateTechnology(ctx context.Context, req model.TechnologyRequest) (err error) {
-	if err = service.Repository.Save(model.Technology{
+	saved, err := service.Repository.Save(model.Technology{
 		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
 		Name:       req.Name,
-	}); err != nil {
+	})
+	if err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityTechnology, saved.ID, nil, saved)
 	return
 }
 
@@ -44,6 +50,7 @@ func (service *TechnologyServiceImpl) UpdateTechnology(ctx context.Context, req
 		return
 	}
 
+	before := technology
 	technology.Name = req.Name
 	technology.IsActive = req.IsActive
 	technology.UpdatedAt = time.Now()
@@ -51,6 +58,8 @@ func (service *TechnologyServiceImpl) UpdateTechnology(ctx context.Context, req
 	if err = service.Repository.Update(technology, id); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityTechnology, id, before, technology)
 	return
 }
 
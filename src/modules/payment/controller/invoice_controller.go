@@ -6,6 +6,7 @@ import (
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -15,6 +16,12 @@ type InvoiceController interface {
 	GetList(ctx *gin.Context)
 	GetDetail(ctx *gin.Context)
 	GetParticipantInvoice(ctx *gin.Context)
+	GetPDF(ctx *gin.Context)
+	Verify(ctx *gin.Context)
+	Refund(ctx *gin.Context)
+	Waive(ctx *gin.Context)
+	GetEntries(ctx *gin.Context)
+	Reopen(ctx *gin.Context)
 }
 
 type InvoiceControllerImpl struct {
@@ -125,3 +132,260 @@ func (controller *InvoiceControllerImpl) GetParticipantInvoice(ctx *gin.Context)
 
 	common.SendSuccess(ctx, http.StatusOK, "Get Participant's Invoice Success", data)
 }
+
+// GetPDF Download Invoice PDF godoc
+// @Tags Payments
+// @Summary Download Invoice or Receipt PDF
+// @Description Download the invoice, or the receipt of a paid invoice, as pdf
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param id path int true "Invoice Id"
+// @Param type query string false "invoice (default) or receipt"
+// @Failure 400 {object} src.BaseFailure
+// @Router /invoices/{id}/pdf [get]
+func (controller *InvoiceControllerImpl) GetPDF(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid invoice id", []string{err.Error()})
+		return
+	}
+
+	host, scheme := utils.GenerateSchemeAndHost(ctx)
+	verifyURL := fmt.Sprintf("%s://%s/api/v1/invoices/verify/", scheme, host)
+
+	pdf, filename, err := controller.Service.GetPDF(ctx, uint(id), ctx.DefaultQuery("type", service.InvoiceDocument), verifyURL)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrForbidden {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvoiceNotPaid || err == e.ErrInvalidDocumentType {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// Verify Verify Invoice godoc
+// @Tags Payments
+// @Summary Verify Invoice
+// @Description Check an invoice or receipt pdf against its verification code
+// @Produce json
+// @Param code path string true "Verification Code"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 404 {object} src.BaseFailure
+// @Router /invoices/verify/{code} [get]
+func (controller *InvoiceControllerImpl) Verify(ctx *gin.Context) {
+	data, err := controller.Service.Verify(ctx.Param("code"))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Verify Invoice Success", data)
+}
+
+// Refund Refund Invoice godoc
+// @Tags Payments
+// @Summary Refund Invoice
+// @Description Refund part or all of the paid amount of an invoice
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Invoice Id"
+// @Param body body model.InvoiceAdjustmentRequest true "Body Request"
+// @Success 201 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /invoices/{id}/refunds [post]
+func (controller *InvoiceControllerImpl) Refund(ctx *gin.Context) {
+	var request model.InvoiceAdjustmentRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		if err.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(err))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid invoice id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.Refund(ctx, uint(id), request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvalidAmount || err == e.ErrRefundExceedsPaid || err == e.ErrRefundExceedsPayment {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Refund Invoice Success", nil)
+}
+
+// Waive Waive Invoice godoc
+// @Tags Payments
+// @Summary Waive Invoice
+// @Description Waive part or all of the outstanding balance of an invoice
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Invoice Id"
+// @Param body body model.InvoiceAdjustmentRequest true "Body Request"
+// @Success 201 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /invoices/{id}/waivers [post]
+func (controller *InvoiceControllerImpl) Waive(ctx *gin.Context) {
+	var request model.InvoiceAdjustmentRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		if err.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(err))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid invoice id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.Waive(ctx, uint(id), request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvalidAmount || err == e.ErrAdjustmentExceedsBalance {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Waive Invoice Success", nil)
+}
+
+// GetEntries Get Invoice Entries godoc
+// @Tags Payments
+// @Summary Get Invoice Entries
+// @Description Get the ledger entries of an invoice
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Invoice Id"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /invoices/{id}/entries [get]
+func (controller *InvoiceControllerImpl) GetEntries(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid invoice id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetEntries(uint(id))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Invoice Entries Success", data)
+}
+
+// Reopen Reopen Expired Invoice godoc
+// @Tags Payments
+// @Summary Reopen Expired Invoice
+// @Description Give an expired invoice a new due date after the grace period
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Invoice Id"
+// @Param body body model.ReopenInvoiceRequest false "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Router /invoices/{id}/reopen [post]
+func (controller *InvoiceControllerImpl) Reopen(ctx *gin.Context) {
+	var request model.ReopenInvoiceRequest
+	// the body is optional, the default grace period applies without it
+	if err := ctx.ShouldBindJSON(&request); err != nil && err.Error() != "EOF" {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(err))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid invoice id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.Reopen(ctx, uint(id), request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvoiceNotExpired || err == e.ErrInvalidGracePeriod {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Reopen Invoice Success", nil)
+}
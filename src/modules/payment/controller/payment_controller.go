@@ -7,6 +7,7 @@ import (
 	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"github.com/gin-gonic/gin"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 )
@@ -17,6 +18,8 @@ type PaymentController interface {
 	GetList(ctx *gin.Context)
 	GetDetail(ctx *gin.Context)
 	GetManyByInvoiceID(ctx *gin.Context)
+	CreateAuto(ctx *gin.Context)
+	Webhook(ctx *gin.Context)
 }
 
 type PaymentControllerImpl struct {
@@ -64,7 +67,8 @@ func (controller *PaymentControllerImpl) Create(ctx *gin.Context) {
 		}
 
 		if err == e.ErrHaveUnprocessedPayment ||
-			err == e.ErrInvoiceIsPaid {
+			err == e.ErrInvoiceIsPaid ||
+			err == e.ErrInvoiceExpired {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -118,6 +122,17 @@ func (controller *PaymentControllerImpl) Update(ctx *gin.Context) {
 			return
 		}
 
+		// correcting a payment below what has been refunded of the invoice
+		if err == e.ErrRefundExceedsPaid {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrPaymentStatusChanged || err == e.ErrAutoPaymentNotSettled {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -212,3 +227,100 @@ func (controller *PaymentControllerImpl) GetManyByInvoiceID(ctx *gin.Context) {
 
 	common.SendSuccess(ctx, http.StatusOK, "Get Payments By Invoice ID Success", data)
 }
+
+// CreateAuto Create Auto Payment godoc
+// @Tags Payments
+// @Summary Create Auto Payment
+// @Description Create a virtual account or QR charge for an invoice through the payment gateway
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param body body model.CreateAutoPaymentRequest true "Body Request"
+// @Success 201 {object} src.BaseSuccess
+// @Success 400 {object} src.BaseFailure
+// @Router /payments/auto [post]
+func (controller *PaymentControllerImpl) CreateAuto(ctx *gin.Context) {
+	var request model.CreateAutoPaymentRequest
+	errorBinding := ctx.ShouldBindJSON(&request)
+	if errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	data, err := controller.Service.CreateAuto(ctx, request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrForbidden {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrHaveUnprocessedPayment ||
+			err == e.ErrInvoiceIsPaid ||
+			err == e.ErrInvoiceExpired ||
+			err == e.ErrPaymentMethodNotAuto {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Create Payment Success", data)
+}
+
+// Webhook Payment Gateway Callback godoc
+// @Tags Payments
+// @Summary Payment Gateway Callback
+// @Description Receive a signed transaction notification from the payment gateway
+// @Produce  json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 401 {object} src.BaseFailure
+// @Router /payments/webhook/{provider} [post]
+func (controller *PaymentControllerImpl) Webhook(ctx *gin.Context) {
+	payload, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.HandleNotification(ctx.Param("provider"), payload, ctx.GetHeader("X-Signature"))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvalidSignature {
+			common.SendError(ctx, http.StatusUnauthorized, "Unauthorized", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrTransactionMismatch {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Payment Notification Success", nil)
+}
@@ -1,10 +1,13 @@
 package payment
 
 import (
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/outbox"
 	"be-sagara-hackathon/src/modules/payment/controller"
 	"be-sagara-hackathon/src/modules/payment/repository"
 	"be-sagara-hackathon/src/modules/payment/service"
 	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/gateway"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +21,7 @@ var (
 	paymentRepository       repository.PaymentRepository
 	paymentService          service.PaymentService
 	paymentController       controller.PaymentController
+	invoiceScheduler        service.InvoiceScheduler
 )
 
 type Module interface {
@@ -34,19 +38,37 @@ func New(db *gorm.DB) Module {
 
 func (module ModuleImpl) InitModule() {
 	participantRepository := ur.NewParticipantRepository(module.DB)
+	auditRecorder := audit.GetRecorder()
 
 	paymentMethodRepository = repository.NewPaymentMethodRepository(module.DB)
-	paymentMethodService = service.NewPaymentMethod(paymentMethodRepository)
+	paymentMethodService = service.NewPaymentMethod(paymentMethodRepository, auditRecorder)
 	paymentMethodController = controller.NewPaymentMethodController(paymentMethodService)
 
+	paymentRepository = repository.NewPaymentRepository(module.DB)
+
 	invoiceRepository = repository.NewInvoiceRepository(module.DB)
-	invoiceService = service.NewInvoiceService(invoiceRepository)
+	invoiceService = service.NewInvoiceService(
+		invoiceRepository, repository.NewInvoiceEntryRepository(module.DB), paymentRepository, participantRepository, auditRecorder)
 	invoiceController = controller.NewInvoiceController(invoiceService)
 
-	paymentRepository = repository.NewPaymentRepository(module.DB)
 	paymentService = service.NewPaymentService(
-		paymentRepository, invoiceRepository, participantRepository)
+		paymentRepository, invoiceRepository, participantRepository, paymentMethodRepository, gateway.NewProvider(), auditRecorder)
 	paymentController = controller.NewPaymentController(paymentService)
+
+	invoiceScheduler = service.NewInvoiceScheduler(
+		invoiceRepository,
+		outbox.GetMailer(),
+		service.NewInvoiceSchedulerConfig(),
+		auditRecorder,
+	)
+	invoiceScheduler.Start()
+}
+
+// StopScheduler stops the invoice scheduler on shutdown, the run in progress is finished first
+func StopScheduler() {
+	if invoiceScheduler != nil {
+		invoiceScheduler.Stop()
+	}
 }
 
 func GetPaymentMethodController() controller.PaymentMethodController {
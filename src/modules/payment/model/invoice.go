@@ -2,23 +2,36 @@ package model
 
 import (
 	eve "be-sagara-hackathon/src/modules/event/model"
+	prm "be-sagara-hackathon/src/modules/promotion/model"
 	ue "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/helper"
 	"time"
 )
 
 type Invoice struct {
 	common.BaseEntity
-	EventID       uint           `gorm:"not null"`
-	Event         eve.Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
-	ParticipantID uint           `gorm:"not null"`
-	Participant   ue.Participant `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
-	InvoiceNumber string         `gorm:"type:varchar(50);uniqueIndex;not null"`
-	Amount        uint64         `gorm:"not null"`
-	Status        string         `gorm:"type:varchar(15)"`
-	PaidAmount    uint64         `gorm:"default:0"`
-	ApprovedAt    *time.Time     `gorm:"null"`
-	ApprovedBy    *string        `gorm:"null;type:varchar(36)"`
+	EventID        uint           `gorm:"not null"`
+	Event          eve.Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	ParticipantID  uint           `gorm:"not null"`
+	Participant    ue.Participant `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	InvoiceNumber  string         `gorm:"type:varchar(50);uniqueIndex;not null"`
+	FeeTierID      *uint          `gorm:"null"`
+	FeeTier        *prm.FeeTier   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-"`
+	VoucherID      *uint          `gorm:"null"`
+	Voucher        *prm.Voucher   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	BaseAmount     uint64         `gorm:"default:0"` //registration fee before the voucher
+	DiscountAmount uint64         `gorm:"default:0"` //taken off by the voucher
+	Amount         uint64         `gorm:"not null"`
+	Status         string         `gorm:"type:varchar(15)"`
+	PaidAmount     uint64         `gorm:"default:0"`
+	CreditAmount   uint64         `gorm:"default:0"` //discounts and waivers
+	ApprovedAt     *time.Time     `gorm:"null"`
+	ApprovedBy     *string        `gorm:"null;type:varchar(36)"`
+	DueDate        *time.Time     `gorm:"null"` //set when reopened, the event's payment due date applies until then
+	ExpiredAt      *time.Time     `gorm:"null"`
+	// VerificationCode is printed on the pdf so the document can be checked back against the api
+	VerificationCode *string `gorm:"null;type:varchar(20);uniqueIndex"`
 }
 
 type FilterInvoice struct {
@@ -52,8 +65,42 @@ type InvoiceFull struct {
 	ParticipantEmail string     `json:"participant_email"`
 	ParticipantPhone string     `json:"participant_phone"`
 	Status           string     `json:"status"`
+	BaseAmount       uint64     `json:"base_amount"`
+	DiscountAmount   uint64     `json:"discount_amount"`
+	VoucherCode      *string    `json:"voucher_code"`
 	Amount           uint64     `json:"amount"`
 	PaidAmount       uint64     `json:"paid_amount"`
+	CreditAmount     uint64     `json:"credit_amount"`
 	ApprovedAt       *time.Time `json:"approved_at"`
 	ApprovedBy       *string    `json:"approved_by"`
+	DueDate          time.Time  `json:"due_date"`
+	ExpiredAt        *time.Time `json:"expired_at"`
+	VerificationCode *string    `json:"-"`
+}
+
+type ReopenInvoiceRequest struct {
+	GracePeriod string `json:"grace_period" validate:"omitempty"` //e.g. 48h, defaults to INVOICE_GRACE_PERIOD
+}
+
+type InvoiceVerification struct {
+	InvoiceNumber   string     `json:"invoice_number"`
+	EventName       string     `json:"event_name"`
+	ParticipantName string     `json:"participant_name"`
+	Amount          uint64     `json:"amount"`
+	PaidAmount      uint64     `json:"paid_amount"`
+	Status          string     `json:"status"`
+	ApprovedAt      *time.Time `json:"approved_at"`
+}
+
+// Balance is what is left to pay after payments, discounts and waivers.
+func (invoice InvoiceFull) Balance() uint64 {
+	if invoice.PaidAmount+invoice.CreditAmount >= invoice.Amount {
+		return 0
+	}
+	return invoice.Amount - invoice.PaidAmount - invoice.CreditAmount
+}
+
+// DueAt is the moment the invoice becomes overdue. A date-only due date covers the whole day.
+func (invoice InvoiceFull) DueAt() time.Time {
+	return helper.EndOfDate(invoice.DueDate)
 }
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// InvoiceEntry is an append-only ledger line of an invoice. The actor is kept in CreatedBy.
+// Amounts are positive except for corrections, which can go both ways.
+type InvoiceEntry struct {
+	common.BaseEntity
+	InvoiceID uint     `gorm:"not null;index" json:"invoice_id"`
+	Invoice   Invoice  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	PaymentID *uint    `gorm:"null" json:"payment_id"`
+	Payment   *Payment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	Type      string   `gorm:"type:varchar(15);not null" json:"type"` //payment, refund, waiver, correction
+	Amount    int64    `gorm:"not null" json:"amount"`
+	Reason    *string  `gorm:"type:text;null" json:"reason"`
+}
+
+type InvoiceAdjustmentRequest struct {
+	Amount    uint64 `json:"amount" validate:"omitempty"` //waivers default to the outstanding balance
+	Reason    string `json:"reason" validate:"required"`
+	PaymentID *uint  `json:"payment_id" validate:"omitempty"`
+}
+
+type InvoiceEntryLite struct {
+	ID        uint      `json:"id"`
+	InvoiceID uint      `json:"invoice_id"`
+	PaymentID *uint     `json:"payment_id"`
+	Type      string    `json:"type"`
+	Amount    int64     `json:"amount"`
+	Reason    *string   `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
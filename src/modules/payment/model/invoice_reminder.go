@@ -0,0 +1,16 @@
+package model
+
+import (
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// InvoiceReminder marks that the reminder for Offset before the due date has been sent,
+// so every invoice gets each reminder once.
+type InvoiceReminder struct {
+	common.BaseEntity
+	InvoiceID uint      `gorm:"not null;uniqueIndex:idx_invoice_reminders_invoice_offset"`
+	Invoice   Invoice   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Offset    string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_invoice_reminders_invoice_offset"`
+	DueDate   time.Time `gorm:"not null"`
+}
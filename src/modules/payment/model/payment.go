@@ -12,7 +12,7 @@ type Payment struct {
 	PaymentType       string         `gorm:"not null"` //auto, manual
 	PaymentMethodID   *uint          `gorm:"null"`     //not null when payment type is manual
 	PaymentMethod     *PaymentMethod `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
-	Status            string         `gorm:"not null;default:created"` //created,proceed
+	Status            string         `gorm:"not null;default:created"` //created,proceed,failed
 	BankName          *string        `gorm:"type:varchar(10);null"`
 	BankAccountName   *string        `gorm:"type:varchar(255);null"`
 	BankAccountNumber *string        `gorm:"type:varchar(255);null"`
@@ -21,6 +21,13 @@ type Payment struct {
 	ProceedAt         *time.Time     `gorm:"null"`
 	ProceedBy         *string        `gorm:"type:varchar(255);null"`
 	Note              *string        `gorm:"type:text;null"`
+	// fields below are only filled for auto payments
+	Provider              *string    `gorm:"type:varchar(20);null;uniqueIndex:idx_payments_provider_transaction"`
+	ProviderTransactionID *string    `gorm:"type:varchar(100);null;uniqueIndex:idx_payments_provider_transaction"`
+	ProviderOrderID       *string    `gorm:"type:varchar(100);null"`
+	VANumber              *string    `gorm:"type:varchar(50);null"`
+	QRString              *string    `gorm:"type:text;null"`
+	ExpiresAt             *time.Time `gorm:"null"`
 }
 
 // CreatePaymentRequest Payment Manual
@@ -33,6 +40,21 @@ type CreatePaymentRequest struct {
 	Evidence        string `json:"evidence" validate:"required"`
 }
 
+type CreateAutoPaymentRequest struct {
+	InvoiceID       uint `json:"invoice_id" validate:"required"`
+	PaymentMethodID uint `json:"payment_method_id" validate:"required"`
+}
+
+type AutoPaymentResponse struct {
+	PaymentID     uint       `json:"payment_id"`
+	InvoiceNumber string     `json:"invoice_number"`
+	PaymentMethod string     `json:"payment_method"`
+	Amount        uint64     `json:"amount"`
+	VANumber      *string    `json:"va_number"`
+	QRString      *string    `json:"qr_string"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+}
+
 type UpdatePaymentRequest struct {
 	Amount uint64  `json:"amount" validate:"required"`
 	Note   *string `json:"note" validate:"omitempty"`
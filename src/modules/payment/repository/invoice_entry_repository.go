@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/payment/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type InvoiceEntryRepository interface {
+	AppendRefund(entry model.InvoiceEntry) error
+	AppendWaiver(entry model.InvoiceEntry) error
+	FindByInvoiceID(invoiceID uint) (entries []model.InvoiceEntryLite, err error)
+}
+
+type InvoiceEntryRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewInvoiceEntryRepository(db *gorm.DB) InvoiceEntryRepository {
+	return &InvoiceEntryRepositoryImpl{DB: db}
+}
+
+// AppendRefund saves the refund unless it is more than what has been paid, or than what is left
+// of its payment when it points to one. The limits are checked with the invoice locked,
+// so concurrent refunds can't return more than was paid together.
+func (repository *InvoiceEntryRepositoryImpl) AppendRefund(entry model.InvoiceEntry) error {
+	tx := repository.DB.Begin()
+	if _, err := lockInvoice(tx, entry.InvoiceID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	paid, _, err := sumInvoiceLedger(tx, entry.InvoiceID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var paymentLeft *int64
+	if entry.PaymentID != nil {
+		paymentPaid, refunded, errSum := sumPaymentLedger(tx, *entry.PaymentID)
+		if errSum != nil {
+			tx.Rollback()
+			return errSum
+		}
+		left := paymentPaid - refunded
+		paymentLeft = &left
+	}
+
+	if err = checkRefund(entry.Amount, paid, paymentLeft); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = appendInvoiceEntry(tx, entry); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// AppendWaiver saves the waiver unless it is more than the outstanding balance. A waiver without
+// an amount waives the whole balance. The balance is read with the invoice locked.
+func (repository *InvoiceEntryRepositoryImpl) AppendWaiver(entry model.InvoiceEntry) error {
+	tx := repository.DB.Begin()
+	invoice, err := lockInvoice(tx, entry.InvoiceID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	paid, credit, err := sumInvoiceLedger(tx, entry.InvoiceID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var balance int64
+	if int64(invoice.Amount) > paid+credit {
+		balance = int64(invoice.Amount) - paid - credit
+	}
+	if entry.Amount, err = waiverAmount(entry.Amount, balance); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = appendInvoiceEntry(tx, entry); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (repository *InvoiceEntryRepositoryImpl) FindByInvoiceID(invoiceID uint) (entries []model.InvoiceEntryLite, err error) {
+	err = repository.DB.Model(&model.InvoiceEntry{}).
+		Select("id, invoice_id, payment_id, type, amount, reason, created_at, created_by").
+		Where("invoice_id=?", invoiceID).
+		Order("id asc").
+		Find(&entries).Error
+	return
+}
+
+// checkRefund refuses a refund of more than has been paid, or than is left of its payment when paymentLeft is set.
+func checkRefund(amount, paid int64, paymentLeft *int64) error {
+	if amount <= 0 {
+		return e.ErrInvalidAmount
+	}
+	if amount > paid {
+		return e.ErrRefundExceedsPaid
+	}
+	if paymentLeft != nil && amount > *paymentLeft {
+		return e.ErrRefundExceedsPayment
+	}
+	return nil
+}
+
+// waiverAmount is the amount to waive out of balance, all of it when amount is zero.
+func waiverAmount(amount, balance int64) (int64, error) {
+	if amount == 0 {
+		amount = balance
+	}
+	if amount <= 0 {
+		return 0, e.ErrInvalidAmount
+	}
+	if amount > balance {
+		return 0, e.ErrAdjustmentExceedsBalance
+	}
+	return amount, nil
+}
+
+// lockInvoice reads the invoice and locks its row until tx ends.
+func lockInvoice(tx *gorm.DB, invoiceID uint) (invoice model.Invoice, err error) {
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id=?", invoiceID).First(&invoice).Error
+	if err == gorm.ErrRecordNotFound {
+		err = e.ErrDataNotFound
+	}
+	return
+}
+
+// sumInvoiceLedger returns what has been paid net of refunds and how much has been waived.
+func sumInvoiceLedger(tx *gorm.DB, invoiceID uint) (paid, credit int64, err error) {
+	var totals []struct {
+		Type   string
+		Amount int64
+	}
+	if err = tx.Model(&model.InvoiceEntry{}).
+		Select("type, SUM(amount) as amount").
+		Where("invoice_id=?", invoiceID).
+		Group("type").
+		Scan(&totals).Error; err != nil {
+		return
+	}
+
+	for _, v := range totals {
+		switch v.Type {
+		case constants.InvoiceEntryPayment, constants.InvoiceEntryCorrection:
+			paid += v.Amount
+		case constants.InvoiceEntryRefund:
+			paid -= v.Amount
+		case constants.InvoiceEntryWaiver:
+			credit += v.Amount
+		}
+	}
+	return
+}
+
+// sumPaymentLedger returns what a payment put on the ledger and how much of it has been refunded
+func sumPaymentLedger(tx *gorm.DB, paymentID uint) (paid, refunded int64, err error) {
+	var totals []struct {
+		Type   string
+		Amount int64
+	}
+	if err = tx.Model(&model.InvoiceEntry{}).
+		Select("type, SUM(amount) as amount").
+		Where("payment_id=? AND type IN ?", paymentID, []string{constants.InvoiceEntryPayment, constants.InvoiceEntryRefund}).
+		Group("type").
+		Scan(&totals).Error; err != nil {
+		return
+	}
+
+	for _, v := range totals {
+		if v.Type == constants.InvoiceEntryPayment {
+			paid = v.Amount
+		} else {
+			refunded = v.Amount
+		}
+	}
+	return
+}
+
+// appendInvoiceEntry saves the entry and refreshes the invoice from its ledger within tx.
+func appendInvoiceEntry(tx *gorm.DB, entry model.InvoiceEntry) error {
+	if err := tx.Omit("Invoice", "Payment").Create(&entry).Error; err != nil {
+		return err
+	}
+	return recomputeInvoice(tx, entry.InvoiceID, entry.CreatedBy)
+}
+
+// recomputeInvoice derives paid amount, credit and status of the invoice from its ledger and payments.
+// An invoice is paid once nothing is left to pay, processing while money came in or a payment waits
+// to be processed, and unpaid otherwise. The participant's payment status follows the invoice.
+// The invoice row is locked first so concurrent entries of the same invoice are summed one after another.
+func recomputeInvoice(tx *gorm.DB, invoiceID uint, actor string) error {
+	invoice, err := lockInvoice(tx, invoiceID)
+	if err != nil {
+		return err
+	}
+
+	paid, credit, err := sumInvoiceLedger(tx, invoiceID)
+	if err != nil {
+		return err
+	}
+	// refunds are checked before they are saved, a ledger that paid back more than came in is refused
+	if paid < 0 {
+		return e.ErrRefundExceedsPaid
+	}
+
+	var pending int64
+	if err = tx.Model(&model.Payment{}).
+		Where("invoice_id=? AND status=?", invoiceID, constants.PaymentStatusCreated).
+		Count(&pending).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"paid_amount":   paid,
+		"credit_amount": credit,
+		"updated_at":    now,
+		"updated_by":    actor,
+	}
+
+	switch {
+	case uint64(paid+credit) >= invoice.Amount:
+		updates["status"] = constants.InvoicePaid
+		if invoice.Status != constants.InvoicePaid {
+			updates["approved_at"] = now
+			updates["approved_by"] = actor
+		}
+	case paid > 0 || pending > 0:
+		updates["status"] = constants.InvoiceProcessing
+		updates["approved_at"] = nil
+		updates["approved_by"] = nil
+	default:
+		// an expired invoice stays expired until it is reopened
+		updates["status"] = constants.InvoiceUnpaid
+		if invoice.Status == constants.InvoiceExpired {
+			updates["status"] = constants.InvoiceExpired
+		}
+		updates["approved_at"] = nil
+		updates["approved_by"] = nil
+	}
+
+	if err := tx.Model(&model.Invoice{}).Where("id=?", invoiceID).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&um.Participant{}).Where("id=?", invoice.ParticipantID).Updates(map[string]interface{}{
+		"payment_status": updates["status"],
+		"updated_at":     now,
+		"updated_by":     actor,
+	}).Error
+}
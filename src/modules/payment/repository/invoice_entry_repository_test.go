@@ -0,0 +1,52 @@
+package repository
+
+import (
+	e "be-sagara-hackathon/src/utils/errors"
+	"testing"
+)
+
+func TestCheckRefund(t *testing.T) {
+	left := func(amount int64) *int64 { return &amount }
+	cases := []struct {
+		name        string
+		amount      int64
+		paid        int64
+		paymentLeft *int64
+		want        error
+	}{
+		{"within paid", 100, 300, nil, nil},
+		{"all of paid", 300, 300, nil, nil},
+		{"over paid", 301, 300, nil, e.ErrRefundExceedsPaid},
+		{"zero", 0, 300, nil, e.ErrInvalidAmount},
+		{"within payment", 60, 300, left(100), nil},
+		{"over payment", 150, 300, left(100), e.ErrRefundExceedsPayment},
+		{"over what is left of payment", 60, 300, left(40), e.ErrRefundExceedsPayment},
+		{"payment fully refunded", 1, 300, left(0), e.ErrRefundExceedsPayment},
+	}
+	for _, c := range cases {
+		if got := checkRefund(c.amount, c.paid, c.paymentLeft); got != c.want {
+			t.Errorf("%s: checkRefund = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestWaiverAmount(t *testing.T) {
+	cases := []struct {
+		name    string
+		amount  int64
+		balance int64
+		want    int64
+		wantErr error
+	}{
+		{"part of balance", 50, 200, 50, nil},
+		{"whole balance by default", 0, 200, 200, nil},
+		{"over balance", 201, 200, 0, e.ErrAdjustmentExceedsBalance},
+		{"nothing left to waive", 0, 0, 0, e.ErrInvalidAmount},
+	}
+	for _, c := range cases {
+		got, err := waiverAmount(c.amount, c.balance)
+		if got != c.want || err != c.wantErr {
+			t.Errorf("%s: waiverAmount = %d, %v, want %d, %v", c.name, got, err, c.want, c.wantErr)
+		}
+	}
+}
@@ -3,12 +3,12 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/payment/model"
 	"be-sagara-hackathon/src/utils"
-	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
-	"be-sagara-hackathon/src/utils/helper"
 	"database/sql"
 	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"math"
 	"strings"
 	"time"
@@ -16,10 +16,6 @@ import (
 
 type InvoiceRepository interface {
 	Save(invoice model.Invoice) error
-	Update(invoiceID uint, invoice model.Invoice) error
-	UpdatePaidAmount(invoiceID uint, invoice model.Invoice) error
-	UpdateStatus(invoiceID uint, status, updateBy string) error
-	UpdateApprove(invoiceID uint, invoice model.Invoice) error
 	FindAll(
 		filter model.FilterInvoice,
 		pg *utils.PaginateQueryOffset,
@@ -29,6 +25,12 @@ type InvoiceRepository interface {
 	FindManyByParticipantID(participantID uint) ([]model.InvoiceFull, error)
 	FindByParticipantIDAndEventID(participantID, eventID uint) (model.InvoiceFull, error)
 	FindByInvoiceNumber(invNumber string) (model.InvoiceFull, error)
+	FindByVerificationCode(code string) (model.InvoiceFull, error)
+	SetVerificationCode(invoiceID uint, code string) error
+	FindOutstandingDueBefore(t time.Time) ([]model.InvoiceFull, error)
+	SaveReminder(reminder model.InvoiceReminder) (saved bool, err error)
+	Expire(invoiceID uint) (expired bool, err error)
+	Reopen(invoiceID uint, dueDate time.Time, actor string) error
 }
 
 type InvoiceRepositoryImpl struct {
@@ -46,52 +48,6 @@ func (repository *InvoiceRepositoryImpl) Save(invoice model.Invoice) error {
 	return nil
 }
 
-func (repository *InvoiceRepositoryImpl) Update(invoiceID uint, invoice model.Invoice) error {
-	query := `
-		UPDATE invoices SET paid_amount=?, status=?, approved_at=?, approved_by=?, updated_by=?
-		WHERE id=?
-	`
-	if err := repository.DB.Exec(
-		query, invoice.PaidAmount, invoice.Status, invoice.ApprovedAt,
-		invoice.ApprovedBy, invoice.UpdatedBy, invoiceID,
-	).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
-func (repository *InvoiceRepositoryImpl) UpdatePaidAmount(invoiceID uint, invoice model.Invoice) error {
-	result := repository.DB.Where("id=?", invoiceID).Updates(&model.Invoice{
-		PaidAmount: invoice.PaidAmount,
-		BaseEntity: common.BaseEntity{
-			UpdatedBy: invoice.UpdatedBy,
-		},
-	})
-	return result.Error
-}
-
-func (repository *InvoiceRepositoryImpl) UpdateStatus(invoiceID uint, status, updateBy string) error {
-	result := repository.DB.Where("id=?", invoiceID).Updates(&model.Invoice{
-		Status: status,
-		BaseEntity: common.BaseEntity{
-			UpdatedBy: updateBy,
-		},
-	})
-	return result.Error
-}
-
-func (repository *InvoiceRepositoryImpl) UpdateApprove(invoiceID uint, invoice model.Invoice) error {
-	result := repository.DB.Where("id=?", invoiceID).Updates(&model.Invoice{
-		Status:     invoice.Status,
-		ApprovedBy: invoice.ApprovedBy,
-		ApprovedAt: helper.ReferTime(time.Now()),
-		BaseEntity: common.BaseEntity{
-			UpdatedBy: invoice.UpdatedBy,
-		},
-	})
-	return result.Error
-}
-
 func (repository *InvoiceRepositoryImpl) FindAll(
 	filter model.FilterInvoice,
 	pg *utils.PaginateQueryOffset,
@@ -177,11 +133,14 @@ func (repository *InvoiceRepositoryImpl) FindOne(invoiceID uint) (model.InvoiceF
 	query := `
 		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
 			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
-			inv.status, inv.amount, inv.paid_amount, inv.approved_at, inv.approved_by
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
 		FROM invoices inv
 		INNER JOIN participants p on p.id = inv.participant_id
 		INNER JOIN users u on u.id = p.user_id
 		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
 		WHERE inv.id = ? LIMIT 1
 	`
 	var invoice model.InvoiceFull
@@ -198,11 +157,14 @@ func (repository *InvoiceRepositoryImpl) FindManyByEventID(eventID uint) ([]mode
 	query := `
 		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
 			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
-			inv.status, inv.amount, inv.paid_amount, inv.approved_at, inv.approved_by
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
 		FROM invoices inv
 		INNER JOIN participants p on p.id = inv.participant_id
 		INNER JOIN users u on u.id = p.user_id
 		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
 		WHERE inv.event_id = ?
 	`
 	var invoices []model.InvoiceFull
@@ -216,11 +178,14 @@ func (repository *InvoiceRepositoryImpl) FindManyByParticipantID(participantID u
 	query := `
 		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
 			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
-			inv.status, inv.amount, inv.paid_amount, inv.approved_at, inv.approved_by
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
 		FROM invoices inv
 		INNER JOIN participants p on p.id = inv.participant_id
 		INNER JOIN users u on u.id = p.user_id
 		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
 		WHERE inv.participant_id = ?
 	`
 	var invoices []model.InvoiceFull
@@ -234,11 +199,14 @@ func (repository *InvoiceRepositoryImpl) FindByParticipantIDAndEventID(participa
 	query := `
 		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
 			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
-			inv.status, inv.amount, inv.paid_amount, inv.approved_at, inv.approved_by
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
 		FROM invoices inv
 		INNER JOIN participants p on p.id = inv.participant_id
 		INNER JOIN users u on u.id = p.user_id
 		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
 		WHERE inv.participant_id = ? AND inv.event_id = ?
 		LIMIT 1
 	`
@@ -256,11 +224,14 @@ func (repository *InvoiceRepositoryImpl) FindByInvoiceNumber(invNumber string) (
 	query := `
 		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
 			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
-			inv.status, inv.amount, inv.paid_amount, inv.approved_at, inv.approved_by
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
 		FROM invoices inv
 		INNER JOIN participants p on p.id = inv.participant_id
 		INNER JOIN users u on u.id = p.user_id
 		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
 		WHERE inv.invoice_number=? LIMIT 1
 	`
 	var invoice model.InvoiceFull
@@ -272,3 +243,135 @@ func (repository *InvoiceRepositoryImpl) FindByInvoiceNumber(invNumber string) (
 	}
 	return invoice, nil
 }
+
+func (repository *InvoiceRepositoryImpl) FindByVerificationCode(code string) (model.InvoiceFull, error) {
+	query := `
+		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
+			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
+		FROM invoices inv
+		INNER JOIN participants p on p.id = inv.participant_id
+		INNER JOIN users u on u.id = p.user_id
+		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
+		WHERE inv.verification_code=? AND inv.deleted_at IS NULL LIMIT 1
+	`
+	var invoice model.InvoiceFull
+	if err := repository.DB.Raw(query, code).Scan(&invoice).Error; err != nil {
+		return invoice, err
+	}
+	if invoice.ID == 0 {
+		return invoice, e.ErrDataNotFound
+	}
+	return invoice, nil
+}
+
+// SetVerificationCode only sets the code once, an invoice keeps the code printed on its first pdf.
+func (repository *InvoiceRepositoryImpl) SetVerificationCode(invoiceID uint, code string) error {
+	return repository.DB.Model(&model.Invoice{}).
+		Where("id=? AND verification_code IS NULL", invoiceID).
+		Update("verification_code", code).Error
+}
+
+// FindOutstandingDueBefore returns the unpaid and processing invoices whose due date is before t.
+func (repository *InvoiceRepositoryImpl) FindOutstandingDueBefore(t time.Time) ([]model.InvoiceFull, error) {
+	query := `
+		SELECT inv.id, inv.event_id, e.name as event_name, inv.invoice_number, inv.participant_id,
+			u.name as participant_name, u.email as participant_email, u.phone_number as participant_phone,
+			inv.status, inv.base_amount, inv.discount_amount, v.code as voucher_code, inv.amount, inv.paid_amount,
+			inv.credit_amount, inv.approved_at, inv.approved_by, inv.verification_code,
+			COALESCE(inv.due_date, e.payment_due_date) as due_date, inv.expired_at
+		FROM invoices inv
+		INNER JOIN participants p on p.id = inv.participant_id
+		INNER JOIN users u on u.id = p.user_id
+		INNER JOIN events e on e.id = inv.event_id
+		LEFT JOIN vouchers v on v.id = inv.voucher_id
+		WHERE inv.status IN (?) AND COALESCE(inv.due_date, e.payment_due_date) < ? AND inv.deleted_at IS NULL
+	`
+	var invoices []model.InvoiceFull
+	if err := repository.DB.Raw(
+		query, []string{constants.InvoiceUnpaid, constants.InvoiceProcessing}, t,
+	).Scan(&invoices).Error; err != nil {
+		return invoices, err
+	}
+	return invoices, nil
+}
+
+// SaveReminder reports false when the reminder has already been saved, e.g. by another instance.
+func (repository *InvoiceRepositoryImpl) SaveReminder(reminder model.InvoiceReminder) (saved bool, err error) {
+	result := repository.DB.Omit("Invoice").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&reminder)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Expire only expires an invoice that is still unpaid. The participant's payment status follows the invoice.
+func (repository *InvoiceRepositoryImpl) Expire(invoiceID uint) (expired bool, err error) {
+	now := time.Now()
+	tx := repository.DB.Begin()
+	result := tx.Model(&model.Invoice{}).
+		Where("id=? AND status=?", invoiceID, constants.InvoiceUnpaid).
+		Updates(map[string]interface{}{
+			"status":     constants.InvoiceExpired,
+			"expired_at": now,
+			"updated_at": now,
+			"updated_by": "system",
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err = tx.Exec(
+		"UPDATE participants SET payment_status=?, updated_at=?, updated_by=? WHERE id=(SELECT participant_id FROM invoices WHERE id=?)",
+		constants.InvoiceExpired, now, "system", invoiceID,
+	).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	tx.Commit()
+	return true, nil
+}
+
+// Reopen gives an expired invoice a new due date. Its reminders are sent again before the new due date.
+func (repository *InvoiceRepositoryImpl) Reopen(invoiceID uint, dueDate time.Time, actor string) error {
+	tx := repository.DB.Begin()
+	result := tx.Model(&model.Invoice{}).
+		Where("id=? AND status=?", invoiceID, constants.InvoiceExpired).
+		Updates(map[string]interface{}{
+			"status":     constants.InvoiceUnpaid,
+			"due_date":   dueDate,
+			"expired_at": nil,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return e.ErrInvoiceNotExpired
+	}
+
+	if err := tx.Unscoped().Where("invoice_id=?", invoiceID).Delete(&model.InvoiceReminder{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recomputeInvoice(tx, invoiceID, actor); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
@@ -3,17 +3,17 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/payment/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
 	"math"
 	"strings"
-	"time"
 )
 
 type PaymentMethodRepository interface {
-	Save(paymentMethod model.PaymentMethod) error
+	Save(paymentMethod model.PaymentMethod) (model.PaymentMethod, error)
 	Update(id uint, paymentMethod model.PaymentMethod) error
 	Delete(id uint, deletedBy string) error
 	FindAll(
@@ -31,11 +31,11 @@ func NewPaymentMethodRepository(db *gorm.DB) PaymentMethodRepository {
 	return &PaymentMethodRepositoryImpl{DB: db}
 }
 
-func (repository *PaymentMethodRepositoryImpl) Save(paymentMethod model.PaymentMethod) error {
+func (repository *PaymentMethodRepositoryImpl) Save(paymentMethod model.PaymentMethod) (model.PaymentMethod, error) {
 	if err := repository.DB.Create(&paymentMethod).Error; err != nil {
-		return err
+		return paymentMethod, err
 	}
-	return nil
+	return paymentMethod, nil
 }
 
 func (repository *PaymentMethodRepositoryImpl) Update(id uint, paymentMethod model.PaymentMethod) error {
@@ -48,13 +48,18 @@ func (repository *PaymentMethodRepositoryImpl) Update(id uint, paymentMethod mod
 }
 
 func (repository *PaymentMethodRepositoryImpl) Delete(paymentMethodId uint, deletedBy string) error {
-	if err := repository.DB.Model(&model.PaymentMethod{}).
-		Where("id=?", paymentMethodId).
-		Updates(map[string]interface{}{"is_active": false, "deleted_at": time.Now(), "deleted_by": deletedBy}).
-		Error; err != nil {
+	tx := repository.DB.Begin()
+	if err := tx.Model(&model.PaymentMethod{}).Where("id=?", paymentMethodId).Update("is_active", false).Error; err != nil {
+		tx.Rollback()
 		return err
 	}
-	return nil
+
+	if err := tx.Scopes(common.DeletedBy(deletedBy)).Delete(&model.PaymentMethod{}, paymentMethodId).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (repository *PaymentMethodRepositoryImpl) FindAll(
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"be-sagara-hackathon/src/modules/payment/model"
-	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common"
 	"be-sagara-hackathon/src/utils/constants"
@@ -12,11 +11,12 @@ import (
 	"gorm.io/gorm"
 	"math"
 	"strings"
+	"time"
 )
 
 type PaymentRepository interface {
 	Save(payment model.Payment) error
-	Update(paymentID uint, payment model.Payment) error
+	Update(paymentID uint, status string, payment model.Payment, entry *model.InvoiceEntry) error
 	FindAll(
 		filter model.FilterPayment,
 		pg *utils.PaginateQueryOffset,
@@ -24,6 +24,10 @@ type PaymentRepository interface {
 	FindOne(paymentID uint) (payment model.PaymentDetail, err error)
 	FindUnprocessedByInvoiceID(invID uint) (payment model.Payment, err error)
 	FindManyByInvoiceID(invID uint) (payments []model.PaymentLite, err error)
+	FindByProviderTransactionID(provider, transactionID string) (payment model.Payment, err error)
+	FindByProviderOrderID(provider, orderID string) (payment model.Payment, err error)
+	SettleAuto(payment model.Payment, amount uint64) error
+	FailAuto(payment model.Payment) error
 }
 
 type PaymentRepositoryImpl struct {
@@ -42,26 +46,7 @@ func (repository *PaymentRepositoryImpl) Save(payment model.Payment) error {
 		return err
 	}
 
-	if err := tx.Model(&model.Invoice{}).Where("id=?", payment.InvoiceID).Updates(&model.Invoice{
-		Status: constants.InvoiceProcessing,
-		BaseEntity: common.BaseEntity{
-			UpdatedAt: payment.UpdatedAt,
-			UpdatedBy: payment.UpdatedBy,
-		},
-	}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if err := tx.Model(&um.Participant{}).
-		Where("id=?", payment.Invoice.ParticipantID).
-		Updates(&um.Participant{
-			PaymentStatus: constants.InvoiceProcessing,
-			BaseEntity: common.BaseEntity{
-				UpdatedAt: payment.UpdatedAt,
-				UpdatedBy: payment.UpdatedBy,
-			},
-		}).Error; err != nil {
+	if err := recomputeInvoice(tx, payment.InvoiceID, payment.UpdatedBy); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -70,9 +55,11 @@ func (repository *PaymentRepositoryImpl) Save(payment model.Payment) error {
 	return nil
 }
 
-func (repository *PaymentRepositoryImpl) Update(paymentID uint, payment model.Payment) error {
+// Update processes the payment while it still has the status it was read with, the entry was picked
+// from that status. The entry, if any, is appended to the invoice's ledger.
+func (repository *PaymentRepositoryImpl) Update(paymentID uint, status string, payment model.Payment, entry *model.InvoiceEntry) error {
 	tx := repository.DB.Begin()
-	if err := tx.Model(&model.Payment{}).Where("id=?", paymentID).Updates(map[string]interface{}{
+	result := tx.Model(&model.Payment{}).Where("id=? AND status=?", paymentID, status).Updates(map[string]interface{}{
 		"amount":     payment.Amount,
 		"note":       payment.Note,
 		"status":     payment.Status,
@@ -80,32 +67,27 @@ func (repository *PaymentRepositoryImpl) Update(paymentID uint, payment model.Pa
 		"proceed_by": payment.ProceedBy,
 		"updated_at": payment.UpdatedAt,
 		"updated_by": payment.UpdatedBy,
-	}).Error; err != nil {
+	})
+	if result.Error != nil {
 		tx.Rollback()
-		return err
+		return result.Error
 	}
-
-	if err := tx.Model(&model.Invoice{}).Where("id=?", payment.InvoiceID).Updates(map[string]interface{}{
-		"paid_amount": payment.Invoice.PaidAmount,
-		"status":      payment.Invoice.Status,
-		"approved_at": payment.Invoice.ApprovedAt,
-		"approved_by": payment.Invoice.ApprovedBy,
-	}).Error; err != nil {
+	if result.RowsAffected == 0 {
 		tx.Rollback()
-		return err
+		return e.ErrPaymentStatusChanged
 	}
 
-	if err := tx.Model(&um.Participant{}).Where("id=?", payment.Invoice.ParticipantID).Updates(map[string]interface{}{
-		"payment_status": payment.Invoice.Status,
-		"updated_at":     payment.UpdatedAt,
-		"updated_by":     payment.UpdatedBy,
-	}).Error; err != nil {
+	if entry != nil {
+		if err := appendInvoiceEntry(tx, *entry); err != nil {
+			tx.Rollback()
+			return err
+		}
+	} else if err := recomputeInvoice(tx, payment.InvoiceID, payment.UpdatedBy); err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	tx.Commit()
-	return nil
+	return tx.Commit().Error
 }
 
 func (repository *PaymentRepositoryImpl) FindAll(
@@ -240,3 +222,98 @@ func (repository *PaymentRepositoryImpl) FindManyByInvoiceID(invID uint) (paymen
 	}
 	return
 }
+
+func (repository *PaymentRepositoryImpl) FindByProviderTransactionID(provider, transactionID string) (payment model.Payment, err error) {
+	if err = repository.DB.Preload("Invoice").
+		Where("provider=? AND provider_transaction_id=?", provider, transactionID).
+		First(&payment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return
+	}
+	return
+}
+
+func (repository *PaymentRepositoryImpl) FindByProviderOrderID(provider, orderID string) (payment model.Payment, err error) {
+	if err = repository.DB.Preload("Invoice").
+		Where("provider=? AND provider_order_id=?", provider, orderID).
+		First(&payment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return
+	}
+	return
+}
+
+// SettleAuto records a paid auto payment. The payment is only settled while it is still created,
+// so a repeated callback for the same transaction changes nothing.
+func (repository *PaymentRepositoryImpl) SettleAuto(payment model.Payment, amount uint64) error {
+	now := time.Now()
+	tx := repository.DB.Begin()
+	result := tx.Model(&model.Payment{}).
+		Where("id=? AND status=?", payment.ID, constants.PaymentStatusCreated).
+		Updates(map[string]interface{}{
+			"status":     constants.PaymentStatusProceed,
+			"amount":     amount,
+			"proceed_at": now,
+			"proceed_by": payment.Provider,
+			"updated_at": now,
+			"updated_by": payment.Provider,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return nil
+	}
+
+	if err := appendInvoiceEntry(tx, model.InvoiceEntry{
+		BaseEntity: common.BaseEntity{
+			CreatedBy: *payment.Provider,
+			UpdatedBy: *payment.Provider,
+		},
+		InvoiceID: payment.InvoiceID,
+		PaymentID: &payment.ID,
+		Type:      constants.InvoiceEntryPayment,
+		Amount:    int64(amount),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FailAuto marks an auto payment that was denied or expired, so the participant can pay again.
+func (repository *PaymentRepositoryImpl) FailAuto(payment model.Payment) error {
+	now := time.Now()
+	tx := repository.DB.Begin()
+	result := tx.Model(&model.Payment{}).
+		Where("id=? AND status=?", payment.ID, constants.PaymentStatusCreated).
+		Updates(map[string]interface{}{
+			"status":     constants.PaymentStatusFailed,
+			"proceed_at": now,
+			"proceed_by": payment.Provider,
+			"updated_at": now,
+			"updated_by": payment.Provider,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return nil
+	}
+
+	if err := recomputeInvoice(tx, payment.InvoiceID, *payment.Provider); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -9,12 +9,36 @@ import (
 
 func InvoiceRouter(group *gin.RouterGroup) {
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionInvoiceView),
 		payment.GetInvoiceController().GetList,
 	)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionInvoiceView),
 		payment.GetInvoiceController().GetDetail,
 	)
 	group.GET("/:id/payments", payment.GetPaymentController().GetManyByInvoiceID)
+	group.GET("/:id/pdf",
+		middlewares.Permission(constants.PermissionInvoiceDownload),
+		payment.GetInvoiceController().GetPDF,
+	)
+	group.GET("/:id/entries",
+		middlewares.Permission(constants.PermissionInvoiceView),
+		payment.GetInvoiceController().GetEntries,
+	)
+	group.POST("/:id/refunds",
+		middlewares.Permission(constants.PermissionInvoiceAdjust),
+		payment.GetInvoiceController().Refund,
+	)
+	group.POST("/:id/waivers",
+		middlewares.Permission(constants.PermissionInvoiceAdjust),
+		payment.GetInvoiceController().Waive,
+	)
+	group.POST("/:id/reopen",
+		middlewares.Permission(constants.PermissionInvoiceAdjust),
+		payment.GetInvoiceController().Reopen,
+	)
+}
+
+func InvoiceVerificationRouter(group *gin.RouterGroup) {
+	group.GET("/:code", payment.GetInvoiceController().Verify)
 }
@@ -9,15 +9,15 @@ import (
 
 func PaymentMethodRouter(group *gin.RouterGroup) {
 	group.POST("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionPaymentMethodManage),
 		payment.GetPaymentMethodController().Create,
 	)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionPaymentMethodManage),
 		payment.GetPaymentMethodController().Update,
 	)
 	group.DELETE("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionPaymentMethodManage),
 		payment.GetPaymentMethodController().Delete,
 	)
 	group.GET("/", payment.GetPaymentMethodController().GetList)
@@ -12,16 +12,24 @@ func PaymentRouter(group *gin.RouterGroup) {
 		middlewares.RolePermission(constants.UserParticipant),
 		payment.GetPaymentController().Create,
 	)
+	group.POST("/auto",
+		middlewares.RolePermission(constants.UserParticipant),
+		payment.GetPaymentController().CreateAuto,
+	)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionPaymentApprove),
 		payment.GetPaymentController().Update,
 	)
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionPaymentView),
 		payment.GetPaymentController().GetList,
 	)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionPaymentView),
 		payment.GetPaymentController().GetDetail,
 	)
 }
+
+func PaymentWebhookRouter(group *gin.RouterGroup) {
+	group.POST("/:provider", payment.GetPaymentController().Webhook)
+}
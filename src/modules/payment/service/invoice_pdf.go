@@ -0,0 +1,103 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/payment/model"
+	"be-sagara-hackathon/src/utils/helper"
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	InvoiceDocument = "invoice"
+	ReceiptDocument = "receipt"
+)
+
+// receiptNumber derives the receipt number from the invoice number, e.g. INV/X/22/1234 becomes RCP/X/22/1234.
+func receiptNumber(invoiceNumber string) string {
+	return strings.Replace(invoiceNumber, "INV", "RCP", 1)
+}
+
+func renderInvoicePDF(invoice model.InvoiceFull, document, verifyURL string) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+
+	title, number := "INVOICE", invoice.InvoiceNumber
+	if document == ReceiptDocument {
+		title, number = "RECEIPT", receiptNumber(invoice.InvoiceNumber)
+	}
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, "No. "+number, "", 1, "L", false, 0, "")
+	if document == ReceiptDocument {
+		pdf.CellFormat(0, 6, "Invoice No. "+invoice.InvoiceNumber, "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 6, "Date: "+time.Now().Format("02 January 2006"), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 6, "Billed To", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr(invoice.ParticipantName), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, invoice.ParticipantEmail, "", 1, "L", false, 0, "")
+	if invoice.ParticipantPhone != "" {
+		pdf.CellFormat(0, 6, invoice.ParticipantPhone, "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(120, 8, "Description", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(50, 8, "Amount", "1", 1, "R", true, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	if invoice.DiscountAmount > 0 {
+		pdf.CellFormat(120, 8, tr("Registration fee - "+invoice.EventName), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(50, 8, helper.FormatRupiah(invoice.BaseAmount), "1", 1, "R", false, 0, "")
+		pdf.CellFormat(120, 8, tr("Voucher "+helper.DereferString(invoice.VoucherCode)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(50, 8, "- "+helper.FormatRupiah(invoice.DiscountAmount), "1", 1, "R", false, 0, "")
+	} else {
+		pdf.CellFormat(120, 8, tr("Registration fee - "+invoice.EventName), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(50, 8, helper.FormatRupiah(invoice.Amount), "1", 1, "R", false, 0, "")
+	}
+
+	rows := [][2]string{{"Total", helper.FormatRupiah(invoice.Amount)}}
+	if invoice.CreditAmount > 0 {
+		rows = append(rows, [2]string{"Discount / Waiver", "- " + helper.FormatRupiah(invoice.CreditAmount)})
+	}
+	rows = append(rows,
+		[2]string{"Paid", helper.FormatRupiah(invoice.PaidAmount)},
+		[2]string{"Outstanding", helper.FormatRupiah(invoice.Balance())},
+	)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	for _, row := range rows {
+		pdf.CellFormat(120, 8, row[0], "", 0, "R", false, 0, "")
+		pdf.CellFormat(50, 8, row[1], "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, "Status: "+strings.ToUpper(invoice.Status), "", 1, "L", false, 0, "")
+	if document == ReceiptDocument && invoice.ApprovedAt != nil {
+		pdf.CellFormat(0, 6, "Paid on: "+invoice.ApprovedAt.Format("02 January 2006 15:04"), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, "Approved by: "+helper.DereferString(invoice.ApprovedBy), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.MultiCell(0, 5, "Verification code: "+helper.DereferString(invoice.VerificationCode)+
+		"\nThis document can be verified at "+verifyURL, "", "L", false)
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
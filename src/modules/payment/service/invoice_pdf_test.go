@@ -0,0 +1,110 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/payment/model"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func (repository *fakeInvoiceRepository) SetVerificationCode(invoiceID uint, code string) error {
+	if invoiceID == repository.invoice.ID {
+		repository.invoice.VerificationCode = &code
+	}
+	return nil
+}
+
+func (repository *fakeInvoiceRepository) FindByVerificationCode(code string) (model.InvoiceFull, error) {
+	if repository.invoice.VerificationCode == nil || *repository.invoice.VerificationCode != code {
+		return model.InvoiceFull{}, e.ErrDataNotFound
+	}
+	return repository.invoice, nil
+}
+
+func newPDFTest(status string) (*InvoiceServiceImpl, *fakeInvoiceRepository) {
+	invoices := &fakeInvoiceRepository{invoice: model.InvoiceFull{
+		ID:            1,
+		InvoiceNumber: "INV/HACK/22/0001",
+		ParticipantID: 2,
+		Amount:        300,
+		Status:        status,
+	}}
+	return &InvoiceServiceImpl{Repository: invoices, ParticipantRepo: fakeParticipantRepository{}}, invoices
+}
+
+func userContext(userID uint, role string) context.Context {
+	user := um.User{Email: "jane@example.com", UserRole: &um.UserRole{Name: role}}
+	user.ID = userID
+	return context.WithValue(context.Background(), "user", user)
+}
+
+func TestGetPDF(t *testing.T) {
+	service, invoices := newPDFTest(constants.InvoicePaid)
+
+	pdf, filename, err := service.GetPDF(userContext(1, constants.UserAdmin), 1, ReceiptDocument, "https://example.com/verify/")
+	if err != nil {
+		t.Fatalf("receipt returned %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Errorf("receipt isn't a PDF: %.20q", pdf)
+	}
+	if filename != "RCP-HACK-22-0001.pdf" {
+		t.Errorf("filename = %q, want RCP-HACK-22-0001.pdf", filename)
+	}
+
+	code := invoices.invoice.VerificationCode
+	if code == nil || *code != strings.ToUpper(*code) {
+		t.Fatalf("verification code = %v, want an upper case code", code)
+	}
+
+	if _, _, err = service.GetPDF(userContext(1, constants.UserAdmin), 1, InvoiceDocument, "https://example.com/verify/"); err != nil {
+		t.Fatalf("invoice returned %v", err)
+	}
+	if invoices.invoice.VerificationCode != code {
+		t.Error("a second download changed the verification code")
+	}
+
+	verification, err := service.Verify(strings.ToLower(*code))
+	if err != nil || verification.InvoiceNumber != "INV/HACK/22/0001" || verification.Status != constants.InvoicePaid {
+		t.Errorf("verify returned %+v, %v, want the paid invoice", verification, err)
+	}
+}
+
+func TestGetPDFRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		status   string
+		document string
+		want     error
+	}{
+		{name: "invoice of another participant", ctx: userContext(9, constants.UserParticipant), document: InvoiceDocument, want: e.ErrForbidden},
+		{name: "unknown document", ctx: userContext(1, constants.UserAdmin), document: "quote", want: e.ErrInvalidDocumentType},
+		{name: "receipt of an unpaid invoice", ctx: userContext(1, constants.UserAdmin), status: constants.InvoiceUnpaid, document: ReceiptDocument, want: e.ErrInvoiceNotPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, invoices := newPDFTest(tt.status)
+
+			if _, _, err := service.GetPDF(tt.ctx, 1, tt.document, "https://example.com/verify/"); err != tt.want {
+				t.Errorf("%s returned %v, want %v", tt.name, err, tt.want)
+			}
+			if invoices.invoice.VerificationCode != nil {
+				t.Errorf("%s got a verification code", tt.name)
+			}
+		})
+	}
+}
+
+func TestVerifyUnknownCode(t *testing.T) {
+	service, _ := newPDFTest(constants.InvoicePaid)
+
+	if _, err := service.Verify("NOPE"); err != e.ErrDataNotFound {
+		t.Errorf("verify of an unknown code returned %v, want %v", err, e.ErrDataNotFound)
+	}
+}
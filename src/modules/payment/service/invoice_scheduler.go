@@ -0,0 +1,196 @@
+package service
+
+import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/payment/model"
+	"be-sagara-hackathon/src/modules/payment/repository"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultSchedulerInterval = time.Hour
+
+type InvoiceScheduler interface {
+	Start()
+	Stop()
+	RunOnce(now time.Time)
+}
+
+type InvoiceSchedulerConfig struct {
+	Interval        time.Duration
+	ReminderOffsets []time.Duration
+}
+
+func NewInvoiceSchedulerConfig() InvoiceSchedulerConfig {
+	return InvoiceSchedulerConfig{
+		Interval:        helper.GetEnvDuration("INVOICE_SCHEDULER_INTERVAL", defaultSchedulerInterval),
+		ReminderOffsets: helper.GetEnvDurations("INVOICE_REMINDER_OFFSETS", []time.Duration{72 * time.Hour, 24 * time.Hour}),
+	}
+}
+
+type InvoiceSchedulerImpl struct {
+	Repository repository.InvoiceRepository
+	Mailer     email.Mailer
+	Config     InvoiceSchedulerConfig
+	Audit      ads.Recorder
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewInvoiceScheduler(
+	repository repository.InvoiceRepository,
+	mailer email.Mailer,
+	config InvoiceSchedulerConfig,
+	audit ads.Recorder,
+) InvoiceScheduler {
+	// time.NewTicker panics on a duration that isn't positive
+	if config.Interval <= 0 {
+		config.Interval = defaultSchedulerInterval
+	}
+
+	// the smallest offset first, it is the reminder that matters when several are due at once
+	sort.Slice(config.ReminderOffsets, func(i, j int) bool {
+		return config.ReminderOffsets[i] < config.ReminderOffsets[j]
+	})
+	return &InvoiceSchedulerImpl{
+		Repository: repository,
+		Mailer:     mailer,
+		Config:     config,
+		Audit:      audit,
+	}
+}
+
+func (scheduler *InvoiceSchedulerImpl) Start() {
+	scheduler.quit = make(chan struct{})
+	scheduler.wg.Add(1)
+	go func() {
+		defer scheduler.wg.Done()
+
+		ticker := time.NewTicker(scheduler.Config.Interval)
+		defer ticker.Stop()
+
+		for {
+			scheduler.RunOnce(time.Now())
+
+			select {
+			case <-scheduler.quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits until the run in progress is finished. It does nothing when the scheduler isn't running.
+func (scheduler *InvoiceSchedulerImpl) Stop() {
+	if scheduler.quit == nil {
+		return
+	}
+
+	close(scheduler.quit)
+	scheduler.wg.Wait()
+	scheduler.quit = nil
+}
+
+// RunOnce expires the unpaid invoices that are overdue at now and sends the reminders that are due.
+func (scheduler *InvoiceSchedulerImpl) RunOnce(now time.Time) {
+	var maxOffset time.Duration
+	if n := len(scheduler.Config.ReminderOffsets); n > 0 {
+		maxOffset = scheduler.Config.ReminderOffsets[n-1]
+	}
+
+	// date-only due dates are stored at midnight, the extra day covers them
+	invoices, err := scheduler.Repository.FindOutstandingDueBefore(now.Add(maxOffset).AddDate(0, 0, 1))
+	if err != nil {
+		log.Printf("invoice scheduler: failed to fetch outstanding invoices: %v", err)
+		return
+	}
+
+	for _, invoice := range invoices {
+		if !now.Before(invoice.DueAt()) {
+			scheduler.expire(invoice)
+			continue
+		}
+		scheduler.remind(invoice, now)
+	}
+}
+
+func (scheduler *InvoiceSchedulerImpl) expire(invoice model.InvoiceFull) {
+	if invoice.Status != constants.InvoiceUnpaid {
+		return
+	}
+	expired, err := scheduler.Repository.Expire(invoice.ID)
+	if err != nil {
+		log.Printf("invoice scheduler: failed to expire invoice %d: %v", invoice.ID, err)
+		return
+	}
+	if !expired {
+		return
+	}
+
+	after := invoice
+	after.Status = constants.InvoiceExpired
+	scheduler.Audit.Record(context.Background(), constants.AuditStatus, constants.AuditEntityInvoice, invoice.ID, invoice, after)
+}
+
+// remind sends the reminder of the smallest offset that is due, so a late run sends one reminder
+// instead of all the ones it missed.
+func (scheduler *InvoiceSchedulerImpl) remind(invoice model.InvoiceFull, now time.Time) {
+	dueAt := invoice.DueAt()
+	var offset *time.Duration
+	for k, v := range scheduler.Config.ReminderOffsets {
+		if !now.Before(dueAt.Add(-v)) {
+			offset = &scheduler.Config.ReminderOffsets[k]
+			break
+		}
+	}
+	if offset == nil {
+		return
+	}
+
+	saved, err := scheduler.Repository.SaveReminder(model.InvoiceReminder{
+		BaseEntity: common.BaseEntity{
+			CreatedAt: now,
+			CreatedBy: "system",
+			UpdatedAt: now,
+			UpdatedBy: "system",
+		},
+		InvoiceID: invoice.ID,
+		Offset:    offset.String(),
+		DueDate:   invoice.DueDate,
+	})
+	if err != nil {
+		log.Printf("invoice scheduler: failed to save reminder of invoice %d: %v", invoice.ID, err)
+		return
+	}
+	if !saved {
+		return
+	}
+
+	r := email.NewRequest([]string{invoice.ParticipantEmail}, constants.EmailSubjectInvoiceReminder, "")
+	if err := r.ParseTemplate(email.TemplateInvoiceReminder, email.InvoiceReminderTemplateData{
+		Title:         constants.EmailSubjectInvoiceReminder,
+		Name:          invoice.ParticipantName,
+		InvoiceNumber: invoice.InvoiceNumber,
+		EventName:     invoice.EventName,
+		Amount:        helper.FormatRupiah(invoice.Balance()),
+		DueDate:       invoice.DueDate.Format("02 January 2006"),
+		Link:          fmt.Sprintf("%s%s", os.Getenv("BASE_FE_URL"), os.Getenv("INVOICE_REDIRECT_URL")),
+	}); err != nil {
+		log.Printf("invoice scheduler: failed to parse reminder of invoice %d: %v", invoice.ID, err)
+		return
+	}
+	if err := scheduler.Mailer.Send(*r); err != nil {
+		log.Printf("invoice scheduler: failed to send reminder of invoice %d: %v", invoice.ID, err)
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/payment/model"
+	"be-sagara-hackathon/src/modules/payment/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/email"
+	"testing"
+	"time"
+)
+
+// fakeSchedulerRepository keeps the invoices and reminders the way the repository does: only an unpaid
+// invoice expires, a reminder is saved once per invoice and offset and reopening drops the reminders.
+type fakeSchedulerRepository struct {
+	repository.InvoiceRepository
+	invoices  []model.InvoiceFull
+	reminders map[uint]map[string]bool
+}
+
+func (repository *fakeSchedulerRepository) FindOutstandingDueBefore(t time.Time) ([]model.InvoiceFull, error) {
+	var invoices []model.InvoiceFull
+	for _, v := range repository.invoices {
+		if (v.Status == constants.InvoiceUnpaid || v.Status == constants.InvoiceProcessing) && v.DueDate.Before(t) {
+			invoices = append(invoices, v)
+		}
+	}
+	return invoices, nil
+}
+
+func (repository *fakeSchedulerRepository) SaveReminder(reminder model.InvoiceReminder) (bool, error) {
+	if repository.reminders == nil {
+		repository.reminders = map[uint]map[string]bool{}
+	}
+	if repository.reminders[reminder.InvoiceID] == nil {
+		repository.reminders[reminder.InvoiceID] = map[string]bool{}
+	}
+	if repository.reminders[reminder.InvoiceID][reminder.Offset] {
+		return false, nil
+	}
+	repository.reminders[reminder.InvoiceID][reminder.Offset] = true
+	return true, nil
+}
+
+func (repository *fakeSchedulerRepository) Expire(invoiceID uint) (bool, error) {
+	invoice := repository.find(invoiceID)
+	if invoice.Status != constants.InvoiceUnpaid {
+		return false, nil
+	}
+	invoice.Status = constants.InvoiceExpired
+	return true, nil
+}
+
+func (repository *fakeSchedulerRepository) Reopen(invoiceID uint, dueDate time.Time, actor string) error {
+	invoice := repository.find(invoiceID)
+	invoice.Status = constants.InvoiceUnpaid
+	invoice.DueDate = dueDate
+	delete(repository.reminders, invoiceID)
+	return nil
+}
+
+func (repository *fakeSchedulerRepository) find(invoiceID uint) *model.InvoiceFull {
+	for k := range repository.invoices {
+		if repository.invoices[k].ID == invoiceID {
+			return &repository.invoices[k]
+		}
+	}
+	return &model.InvoiceFull{}
+}
+
+type fakeReminderMailer struct {
+	sent []email.Request
+}
+
+func (mailer *fakeReminderMailer) Send(request email.Request) error {
+	mailer.sent = append(mailer.sent, request)
+	return nil
+}
+
+// the first run is at noon, date-only due dates are at midnight
+var schedulerNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func schedulerDate(days int) time.Time {
+	return time.Date(2026, 3, 10+days, 0, 0, 0, 0, time.UTC)
+}
+
+func newSchedulerTest(invoices ...model.InvoiceFull) (InvoiceScheduler, *fakeSchedulerRepository, *fakeReminderMailer) {
+	invoiceRepository := &fakeSchedulerRepository{invoices: invoices}
+	mailer := &fakeReminderMailer{}
+	scheduler := NewInvoiceScheduler(invoiceRepository, mailer, InvoiceSchedulerConfig{
+		Interval:        time.Hour,
+		ReminderOffsets: []time.Duration{24 * time.Hour, 72 * time.Hour},
+	}, fakeRecorder{})
+	return scheduler, invoiceRepository, mailer
+}
+
+func TestInvoiceSchedulerStopWhenNotRunning(t *testing.T) {
+	scheduler, _, _ := newSchedulerTest()
+
+	scheduler.Stop()
+	scheduler.Start()
+	scheduler.Stop()
+	scheduler.Stop()
+}
+
+func TestRunOnceExpiresOverdueInvoices(t *testing.T) {
+	scheduler, invoices, _ := newSchedulerTest(
+		model.InvoiceFull{ID: 1, Status: constants.InvoiceUnpaid, DueDate: schedulerDate(-1)},
+		model.InvoiceFull{ID: 2, Status: constants.InvoiceProcessing, DueDate: schedulerDate(-1)},
+		model.InvoiceFull{ID: 3, Status: constants.InvoiceUnpaid, DueDate: schedulerDate(0)},
+		model.InvoiceFull{ID: 4, Status: constants.InvoiceUnpaid, DueDate: schedulerNow.Add(-time.Minute)},
+	)
+
+	scheduler.RunOnce(schedulerNow)
+
+	want := map[uint]string{
+		1: constants.InvoiceExpired,
+		// a payment waiting for approval keeps the invoice open
+		2: constants.InvoiceProcessing,
+		// a date-only due date covers the whole day
+		3: constants.InvoiceUnpaid,
+		4: constants.InvoiceExpired,
+	}
+	for id, status := range want {
+		if got := invoices.find(id).Status; got != status {
+			t.Errorf("invoice %d status = %s, want %s", id, got, status)
+		}
+	}
+}
+
+func TestRunOnceSendsOneReminderPerWindow(t *testing.T) {
+	scheduler, _, mailer := newSchedulerTest(
+		model.InvoiceFull{ID: 1, Status: constants.InvoiceUnpaid, DueDate: schedulerDate(2), ParticipantEmail: "participant@example.com"},
+		model.InvoiceFull{ID: 2, Status: constants.InvoiceUnpaid, DueDate: schedulerDate(10)},
+	)
+
+	scheduler.RunOnce(schedulerNow)
+	if len(mailer.sent) != 1 || mailer.sent[0].To[0] != "participant@example.com" {
+		t.Fatalf("first run sent %d reminders, want the 72h reminder of invoice 1", len(mailer.sent))
+	}
+
+	scheduler.RunOnce(schedulerNow)
+	scheduler.RunOnce(schedulerNow.Add(12 * time.Hour))
+	if len(mailer.sent) != 1 {
+		t.Fatalf("runs within the same window sent %d reminders, want none", len(mailer.sent)-1)
+	}
+
+	scheduler.RunOnce(schedulerDate(2).Add(time.Hour))
+	scheduler.RunOnce(schedulerDate(2).Add(2 * time.Hour))
+	if len(mailer.sent) != 2 {
+		t.Errorf("runs within the 24h window sent %d reminders, want one", len(mailer.sent)-1)
+	}
+}
+
+func TestRunOnceSendsOnlyTheLatestMissedReminder(t *testing.T) {
+	scheduler, _, mailer := newSchedulerTest(
+		model.InvoiceFull{ID: 1, Status: constants.InvoiceUnpaid, DueDate: schedulerDate(0)},
+	)
+
+	scheduler.RunOnce(schedulerNow)
+	scheduler.RunOnce(schedulerNow.Add(time.Hour))
+	if len(mailer.sent) != 1 {
+		t.Errorf("a late run sent %d reminders, want one", len(mailer.sent))
+	}
+}
+
+func TestRunOnceKeepsReopenedInvoiceUntilNewDueDate(t *testing.T) {
+	scheduler, invoices, mailer := newSchedulerTest(
+		model.InvoiceFull{ID: 1, Status: constants.InvoiceUnpaid, DueDate: schedulerDate(-1)},
+	)
+
+	scheduler.RunOnce(schedulerNow)
+	if got := invoices.find(1).Status; got != constants.InvoiceExpired {
+		t.Fatalf("overdue invoice status = %s, want %s", got, constants.InvoiceExpired)
+	}
+
+	dueDate := schedulerNow.Add(48 * time.Hour)
+	if err := invoices.Reopen(1, dueDate, "admin@example.com"); err != nil {
+		t.Fatalf("reopen returned %v", err)
+	}
+
+	scheduler.RunOnce(schedulerNow.Add(time.Hour))
+	scheduler.RunOnce(dueDate.Add(-time.Minute))
+	if got := invoices.find(1).Status; got != constants.InvoiceUnpaid {
+		t.Fatalf("reopened invoice status = %s before its new due date, want %s", got, constants.InvoiceUnpaid)
+	}
+	if len(mailer.sent) != 2 {
+		t.Errorf("reopened invoice got %d reminders, want the 72h and 24h ones", len(mailer.sent))
+	}
+
+	scheduler.RunOnce(dueDate)
+	if got := invoices.find(1).Status; got != constants.InvoiceExpired {
+		t.Errorf("reopened invoice status = %s after its new due date, want %s", got, constants.InvoiceExpired)
+	}
+}
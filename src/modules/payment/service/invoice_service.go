@@ -1,9 +1,19 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/payment/model"
 	"be-sagara-hackathon/src/modules/payment/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"strings"
+	"time"
 )
 
 type InvoiceService interface {
@@ -13,14 +23,36 @@ type InvoiceService interface {
 	) (response model.ListInvoiceResponse, err error)
 	GetDetail(id uint) (invoice model.InvoiceFull, err error)
 	GetParticipantInvoice(participantID, eventID uint) (invoice model.InvoiceFull, err error)
+	GetPDF(ctx context.Context, id uint, document, verifyURL string) (pdf []byte, filename string, err error)
+	Verify(code string) (verification model.InvoiceVerification, err error)
+	Refund(ctx context.Context, id uint, request model.InvoiceAdjustmentRequest) error
+	Waive(ctx context.Context, id uint, request model.InvoiceAdjustmentRequest) error
+	GetEntries(id uint) (entries []model.InvoiceEntryLite, err error)
+	Reopen(ctx context.Context, id uint, request model.ReopenInvoiceRequest) error
 }
 
 type InvoiceServiceImpl struct {
-	Repository repository.InvoiceRepository
+	Repository      repository.InvoiceRepository
+	EntryRepo       repository.InvoiceEntryRepository
+	PaymentRepo     repository.PaymentRepository
+	ParticipantRepo ur.ParticipantRepository
+	Audit           ads.Recorder
 }
 
-func NewInvoiceService(repository repository.InvoiceRepository) InvoiceService {
-	return &InvoiceServiceImpl{Repository: repository}
+func NewInvoiceService(
+	repository repository.InvoiceRepository,
+	entryRepository repository.InvoiceEntryRepository,
+	paymentRepository repository.PaymentRepository,
+	participantRepository ur.ParticipantRepository,
+	audit ads.Recorder,
+) InvoiceService {
+	return &InvoiceServiceImpl{
+		Repository:      repository,
+		EntryRepo:       entryRepository,
+		PaymentRepo:     paymentRepository,
+		ParticipantRepo: participantRepository,
+		Audit:           audit,
+	}
 }
 
 func (service *InvoiceServiceImpl) GetList(
@@ -47,3 +79,191 @@ func (service *InvoiceServiceImpl) GetParticipantInvoice(participantID, eventID
 	}
 	return
 }
+
+// GetPDF renders the invoice, or the receipt once it is paid. verifyURL is printed on the document
+// followed by the invoice's verification code.
+func (service *InvoiceServiceImpl) GetPDF(
+	ctx context.Context,
+	id uint,
+	document, verifyURL string,
+) (pdf []byte, filename string, err error) {
+	authenticatedUser := ctx.Value("user").(um.User)
+
+	invoice, err := service.Repository.FindOne(id)
+	if err != nil {
+		return
+	}
+	if invoice.ID == 0 {
+		err = e.ErrDataNotFound
+		return
+	}
+
+	if authenticatedUser.UserRole.Name == constants.UserParticipant {
+		participant, errParticipant := service.ParticipantRepo.FindByID(invoice.ParticipantID)
+		if errParticipant != nil {
+			err = errParticipant
+			return
+		}
+		if participant.UserID != authenticatedUser.ID {
+			err = e.ErrForbidden
+			return
+		}
+	}
+
+	if document != InvoiceDocument && document != ReceiptDocument {
+		err = e.ErrInvalidDocumentType
+		return
+	}
+	if document == ReceiptDocument && invoice.Status != constants.InvoicePaid {
+		err = e.ErrInvoiceNotPaid
+		return
+	}
+
+	if invoice.VerificationCode == nil {
+		if err = service.Repository.SetVerificationCode(invoice.ID, strings.ToUpper(utils.GenerateSecureToken(8))); err != nil {
+			return
+		}
+		if invoice, err = service.Repository.FindOne(id); err != nil {
+			return
+		}
+	}
+
+	pdf, err = renderInvoicePDF(invoice, document, verifyURL+*invoice.VerificationCode)
+	if err != nil {
+		return
+	}
+
+	number := invoice.InvoiceNumber
+	if document == ReceiptDocument {
+		number = receiptNumber(number)
+	}
+	filename = strings.ReplaceAll(number, "/", "-") + ".pdf"
+	return
+}
+
+func (service *InvoiceServiceImpl) Verify(code string) (verification model.InvoiceVerification, err error) {
+	invoice, err := service.Repository.FindByVerificationCode(strings.ToUpper(code))
+	if err != nil {
+		return
+	}
+
+	verification = model.InvoiceVerification{
+		InvoiceNumber:   invoice.InvoiceNumber,
+		EventName:       invoice.EventName,
+		ParticipantName: invoice.ParticipantName,
+		Amount:          invoice.Amount,
+		PaidAmount:      invoice.PaidAmount,
+		Status:          invoice.Status,
+		ApprovedAt:      invoice.ApprovedAt,
+	}
+	return
+}
+
+// Refund gives back part or all of what has been paid. The refund can point to the payment it returns,
+// then it is capped at what is left of that payment.
+func (service *InvoiceServiceImpl) Refund(ctx context.Context, id uint, request model.InvoiceAdjustmentRequest) error {
+	invoice, err := service.findInvoice(id)
+	if err != nil {
+		return err
+	}
+
+	if request.Amount == 0 {
+		return e.ErrInvalidAmount
+	}
+
+	if request.PaymentID != nil {
+		payment, errPayment := service.PaymentRepo.FindOne(*request.PaymentID)
+		if errPayment != nil {
+			return errPayment
+		}
+		if payment.ID == 0 || payment.InvoiceID != invoice.ID {
+			return e.ErrDataNotFound
+		}
+	}
+
+	// the limits are checked by the repository while the invoice is locked
+	if err = service.EntryRepo.AppendRefund(model.InvoiceEntry{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		InvoiceID:  invoice.ID,
+		PaymentID:  request.PaymentID,
+		Type:       constants.InvoiceEntryRefund,
+		Amount:     int64(request.Amount),
+		Reason:     &request.Reason,
+	}); err != nil {
+		return err
+	}
+
+	service.recordAdjustment(ctx, constants.AuditRefund, invoice)
+	return nil
+}
+
+// Waive lets the participant off part of the outstanding balance, or all of it when no amount is given.
+func (service *InvoiceServiceImpl) Waive(ctx context.Context, id uint, request model.InvoiceAdjustmentRequest) error {
+	invoice, err := service.findInvoice(id)
+	if err != nil {
+		return err
+	}
+
+	if err = service.EntryRepo.AppendWaiver(model.InvoiceEntry{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		InvoiceID:  invoice.ID,
+		Type:       constants.InvoiceEntryWaiver,
+		Amount:     int64(request.Amount),
+		Reason:     &request.Reason,
+	}); err != nil {
+		return err
+	}
+
+	service.recordAdjustment(ctx, constants.AuditWaive, invoice)
+	return nil
+}
+
+func (service *InvoiceServiceImpl) GetEntries(id uint) (entries []model.InvoiceEntryLite, err error) {
+	if _, err = service.findInvoice(id); err != nil {
+		return
+	}
+	return service.EntryRepo.FindByInvoiceID(id)
+}
+
+// Reopen lets the participant pay an expired invoice until the grace period from now is over.
+func (service *InvoiceServiceImpl) Reopen(ctx context.Context, id uint, request model.ReopenInvoiceRequest) error {
+	authenticatedUser := ctx.Value("user").(um.User)
+
+	invoice, err := service.findInvoice(id)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != constants.InvoiceExpired {
+		return e.ErrInvoiceNotExpired
+	}
+
+	gracePeriod := helper.GetEnvDuration("INVOICE_GRACE_PERIOD", 72*time.Hour)
+	if request.GracePeriod != "" {
+		if gracePeriod, err = time.ParseDuration(request.GracePeriod); err != nil || gracePeriod <= 0 {
+			return e.ErrInvalidGracePeriod
+		}
+	}
+
+	if err = service.Repository.Reopen(invoice.ID, time.Now().Add(gracePeriod), authenticatedUser.Email); err != nil {
+		return err
+	}
+
+	service.recordAdjustment(ctx, constants.AuditReopen, invoice)
+	return nil
+}
+
+// recordAdjustment audits the invoice as it was before the adjustment and as it is now
+func (service *InvoiceServiceImpl) recordAdjustment(ctx context.Context, action string, before model.InvoiceFull) {
+	after, _ := service.Repository.FindOne(before.ID)
+	service.Audit.Record(ctx, action, constants.AuditEntityInvoice, before.ID, before, after)
+}
+
+func (service *InvoiceServiceImpl) findInvoice(id uint) (invoice model.InvoiceFull, err error) {
+	if invoice, err = service.Repository.FindOne(id); err != nil {
+		return
+	}
+	if invoice.ID == 0 {
+		err = e.ErrDataNotFound
+	}
+	return
+}
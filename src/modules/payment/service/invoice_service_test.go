@@ -0,0 +1,129 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/payment/model"
+	"be-sagara-hackathon/src/modules/payment/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+	"testing"
+)
+
+type fakeInvoiceRepository struct {
+	repository.InvoiceRepository
+	invoice model.InvoiceFull
+}
+
+func (repository *fakeInvoiceRepository) FindOne(id uint) (model.InvoiceFull, error) {
+	if id != repository.invoice.ID {
+		return model.InvoiceFull{}, nil
+	}
+	return repository.invoice, nil
+}
+
+type fakePaymentRepository struct {
+	repository.PaymentRepository
+	payment model.PaymentDetail
+}
+
+func (repository *fakePaymentRepository) FindOne(id uint) (model.PaymentDetail, error) {
+	if id != repository.payment.ID {
+		return model.PaymentDetail{}, nil
+	}
+	return repository.payment, nil
+}
+
+// fakeInvoiceEntryRepository keeps the appended entries, the limits are checked by the real repository
+type fakeInvoiceEntryRepository struct {
+	repository.InvoiceEntryRepository
+	entries []model.InvoiceEntry
+	err     error
+}
+
+func (repository *fakeInvoiceEntryRepository) AppendRefund(entry model.InvoiceEntry) error {
+	if repository.err != nil {
+		return repository.err
+	}
+	repository.entries = append(repository.entries, entry)
+	return nil
+}
+
+func (repository *fakeInvoiceEntryRepository) AppendWaiver(entry model.InvoiceEntry) error {
+	if repository.err != nil {
+		return repository.err
+	}
+	repository.entries = append(repository.entries, entry)
+	return nil
+}
+
+type fakeRecorder struct{}
+
+func (fakeRecorder) Record(context.Context, string, string, uint, interface{}, interface{}) {}
+
+func newAdjustmentTest(entries *fakeInvoiceEntryRepository) *InvoiceServiceImpl {
+	return &InvoiceServiceImpl{
+		Repository:  &fakeInvoiceRepository{invoice: model.InvoiceFull{ID: 1, Amount: 300, PaidAmount: 300}},
+		EntryRepo:   entries,
+		PaymentRepo: &fakePaymentRepository{payment: model.PaymentDetail{ID: 5, InvoiceID: 1, Amount: 100}},
+		Audit:       fakeRecorder{},
+	}
+}
+
+func TestRefund(t *testing.T) {
+	entries := &fakeInvoiceEntryRepository{}
+	service := newAdjustmentTest(entries)
+	ctx := context.WithValue(context.Background(), "user", um.User{Email: "admin@example.com"})
+	paymentID := uint(5)
+
+	if err := service.Refund(ctx, 1, model.InvoiceAdjustmentRequest{Amount: 60, Reason: "duplicate", PaymentID: &paymentID}); err != nil {
+		t.Fatalf("refund returned %v", err)
+	}
+	if len(entries.entries) != 1 {
+		t.Fatalf("refund appended %d entries, want 1", len(entries.entries))
+	}
+	entry := entries.entries[0]
+	if entry.Type != constants.InvoiceEntryRefund || entry.Amount != 60 || *entry.PaymentID != paymentID || entry.CreatedBy != "admin@example.com" {
+		t.Errorf("refund appended %+v", entry)
+	}
+}
+
+func TestRefundRejected(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "user", um.User{Email: "admin@example.com"})
+	otherPayment := uint(6)
+
+	entries := &fakeInvoiceEntryRepository{}
+	service := newAdjustmentTest(entries)
+	if err := service.Refund(ctx, 1, model.InvoiceAdjustmentRequest{Reason: "duplicate"}); err != e.ErrInvalidAmount {
+		t.Errorf("refund without an amount returned %v, want %v", err, e.ErrInvalidAmount)
+	}
+	if err := service.Refund(ctx, 2, model.InvoiceAdjustmentRequest{Amount: 1, Reason: "duplicate"}); err != e.ErrDataNotFound {
+		t.Errorf("refund of an unknown invoice returned %v, want %v", err, e.ErrDataNotFound)
+	}
+	if err := service.Refund(ctx, 1, model.InvoiceAdjustmentRequest{Amount: 1, Reason: "duplicate", PaymentID: &otherPayment}); err != e.ErrDataNotFound {
+		t.Errorf("refund of a payment of another invoice returned %v, want %v", err, e.ErrDataNotFound)
+	}
+	if len(entries.entries) != 0 {
+		t.Errorf("rejected refunds appended %d entries", len(entries.entries))
+	}
+
+	// the repository checks the limits with the invoice locked
+	service = newAdjustmentTest(&fakeInvoiceEntryRepository{err: e.ErrRefundExceedsPaid})
+	if err := service.Refund(ctx, 1, model.InvoiceAdjustmentRequest{Amount: 301, Reason: "duplicate"}); err != e.ErrRefundExceedsPaid {
+		t.Errorf("refund over the paid amount returned %v, want %v", err, e.ErrRefundExceedsPaid)
+	}
+}
+
+func TestWaiveWholeBalanceByDefault(t *testing.T) {
+	entries := &fakeInvoiceEntryRepository{}
+	service := newAdjustmentTest(entries)
+	ctx := context.WithValue(context.Background(), "user", um.User{Email: "admin@example.com"})
+
+	if err := service.Waive(ctx, 1, model.InvoiceAdjustmentRequest{Reason: "scholarship"}); err != nil {
+		t.Fatalf("waive returned %v", err)
+	}
+	// an amount of zero asks the repository to waive what is left
+	if len(entries.entries) != 1 || entries.entries[0].Type != constants.InvoiceEntryWaiver || entries.entries[0].Amount != 0 {
+		t.Errorf("waive appended %+v", entries.entries)
+	}
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/payment/model"
 	"be-sagara-hackathon/src/modules/payment/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
 	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
 	"context"
 )
 
@@ -22,23 +24,27 @@ type PaymentMethodService interface {
 
 type PaymentMethodServiceImpl struct {
 	Repository repository.PaymentMethodRepository
+	Audit      ads.Recorder
 }
 
-func NewPaymentMethod(repository repository.PaymentMethodRepository) PaymentMethodService {
-	return &PaymentMethodServiceImpl{Repository: repository}
+func NewPaymentMethod(repository repository.PaymentMethodRepository, audit ads.Recorder) PaymentMethodService {
+	return &PaymentMethodServiceImpl{Repository: repository, Audit: audit}
 }
 
 func (service *PaymentMethodServiceImpl) Create(ctx context.Context, request model.PaymentMethodRequest) error {
-	if err := service.Repository.Save(model.PaymentMethod{
+	method, err := service.Repository.Save(model.PaymentMethod{
 		BaseEntity:    builder.BuildBaseEntity(ctx, true, nil),
 		Name:          request.Name,
 		BankCode:      request.BankCode,
 		AccountNumber: request.AccountNumber,
 		AccountName:   request.AccountName,
 		IsActive:      true,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityPaymentMethod, method.ID, nil, method)
 	return nil
 }
 
@@ -58,19 +64,24 @@ func (service *PaymentMethodServiceImpl) Update(ctx context.Context, request mod
 	}); err != nil {
 		return err
 	}
+
+	updatedMethod, _ := service.Repository.FindByID(id)
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityPaymentMethod, id, method, updatedMethod)
 	return nil
 }
 
 func (service *PaymentMethodServiceImpl) Delete(ctx context.Context, id uint) error {
 	authenticatedUser := ctx.Value("user").(um.User)
-	if _, err := service.Repository.FindByID(id); err != nil {
+	method, err := service.Repository.FindByID(id)
+	if err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(id, authenticatedUser.Email); err != nil {
+	if err = service.Repository.Delete(id, authenticatedUser.Email); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityPaymentMethod, id, method, nil)
 	return nil
 }
 
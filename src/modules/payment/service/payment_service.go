@@ -1,6 +1,7 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/payment/model"
 	"be-sagara-hackathon/src/modules/payment/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
@@ -10,8 +11,10 @@ import (
 	"be-sagara-hackathon/src/utils/common/builder"
 	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/gateway"
 	"be-sagara-hackathon/src/utils/helper"
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -24,26 +27,44 @@ type PaymentService interface {
 	) (response model.ListPaymentResponse, err error)
 	GetDetail(paymentID uint) (payment model.PaymentDetail, err error)
 	GetManyByInvoiceID(invID uint) (payments []model.PaymentLite, err error)
+	CreateAuto(ctx context.Context, request model.CreateAutoPaymentRequest) (response model.AutoPaymentResponse, err error)
+	HandleNotification(provider string, payload []byte, signature string) error
 }
 
 type PaymentServiceImpl struct {
 	Repository      repository.PaymentRepository
 	InvoiceRepo     repository.InvoiceRepository
 	ParticipantRepo ur.ParticipantRepository
+	MethodRepo      repository.PaymentMethodRepository
+	Provider        gateway.Provider
+	Audit           ads.Recorder
 }
 
 func NewPaymentService(
 	paymentRepository repository.PaymentRepository,
 	invoiceRepository repository.InvoiceRepository,
 	participantRepository ur.ParticipantRepository,
+	methodRepository repository.PaymentMethodRepository,
+	provider gateway.Provider,
+	audit ads.Recorder,
 ) PaymentService {
 	return &PaymentServiceImpl{
 		Repository:      paymentRepository,
 		InvoiceRepo:     invoiceRepository,
 		ParticipantRepo: participantRepository,
+		MethodRepo:      methodRepository,
+		Provider:        provider,
+		Audit:           audit,
 	}
 }
 
+// autoPaymentMethods maps the payment methods that can be paid through the gateway to their charge.
+var autoPaymentMethods = map[string]gateway.ChargeRequest{
+	constants.PaymentMethodBcaVA: {Type: gateway.ChargeVirtualAccount, Bank: "bca"},
+	constants.PaymentMethodBriVA: {Type: gateway.ChargeVirtualAccount, Bank: "bri"},
+	constants.PaymentMethodQris:  {Type: gateway.ChargeQR},
+}
+
 func (service *PaymentServiceImpl) Create(ctx context.Context, request model.CreatePaymentRequest) error {
 	//check unprocessed payment
 	unprocessed, err := service.Repository.FindUnprocessedByInvoiceID(request.InvoiceID)
@@ -65,6 +86,9 @@ func (service *PaymentServiceImpl) Create(ctx context.Context, request model.Cre
 	if invoice.Status == constants.InvoicePaid {
 		return e.ErrInvoiceIsPaid
 	}
+	if invoice.Status == constants.InvoiceExpired {
+		return e.ErrInvoiceExpired
+	}
 
 	//get related participant
 	participant, err := service.ParticipantRepo.FindByID(invoice.ParticipantID)
@@ -102,49 +126,64 @@ func (service *PaymentServiceImpl) Update(ctx context.Context, paymentID uint, r
 		return err
 	}
 
-	//get related invoice
-	relatedInvoice, err := service.InvoiceRepo.FindOne(checkPayment.InvoiceID)
-	if err != nil {
-		return err
+	// an auto payment is settled by the provider's notification
+	if checkPayment.PaymentType == constants.PaymentTypeAuto && checkPayment.Status == constants.PaymentStatusCreated {
+		return e.ErrAutoPaymentNotSettled
 	}
 
-	// Update Invoice
-	amount := request.Amount - checkPayment.Amount
-	paidAmount := relatedInvoice.PaidAmount + amount
-	invUpdate := model.Invoice{PaidAmount: paidAmount, Status: constants.InvoiceProcessing}
-	if invUpdate.PaidAmount >= relatedInvoice.Amount {
-		invUpdate.Status = constants.InvoicePaid
-		invUpdate.ApprovedAt = helper.ReferTime(time.Now())
-		invUpdate.ApprovedBy = &authenticatedUser.Email
+	// the first approval records the payment, later ones correct the approved amount
+	var entry *model.InvoiceEntry
+	if checkPayment.Status != constants.PaymentStatusProceed {
+		entry = &model.InvoiceEntry{
+			Type:   constants.InvoiceEntryPayment,
+			Amount: int64(request.Amount),
+		}
+	} else if request.Amount != checkPayment.Amount {
+		entry = &model.InvoiceEntry{
+			Type:   constants.InvoiceEntryCorrection,
+			Amount: int64(request.Amount) - int64(checkPayment.Amount),
+			Reason: request.Note,
+		}
 	}
-
-	if relatedInvoice.Status == constants.InvoicePaid &&
-		invUpdate.PaidAmount != relatedInvoice.Amount {
-		invUpdate.Status = constants.InvoiceProcessing
-		invUpdate.ApprovedAt = nil
-		invUpdate.ApprovedBy = nil
+	if entry != nil {
+		entry.BaseEntity = builder.BuildBaseEntity(ctx, true, nil)
+		entry.InvoiceID = checkPayment.InvoiceID
+		entry.PaymentID = &checkPayment.ID
 	}
 
-	invUpdate.ParticipantID = checkPayment.ParticipantID
-	if err = service.Repository.Update(paymentID, model.Payment{
+	status := constants.PaymentStatusProceed
+	if err = service.Repository.Update(paymentID, checkPayment.Status, model.Payment{
 		BaseEntity: common.BaseEntity{
 			UpdatedAt: time.Now(),
 			UpdatedBy: authenticatedUser.Email,
 		},
-		Status:    constants.PaymentStatusProceed,
+		Status:    status,
 		Amount:    request.Amount,
 		Note:      request.Note,
 		ProceedAt: helper.ReferTime(time.Now()),
 		ProceedBy: &authenticatedUser.Email,
 		InvoiceID: checkPayment.InvoiceID,
-		Invoice:   invUpdate,
-	}); err != nil {
+	}, entry); err != nil {
 		return err
 	}
 
+	updatedPayment, _ := service.Repository.FindOne(paymentID)
+	service.Audit.Record(ctx, paymentAuditAction(status), constants.AuditEntityPayment, paymentID, checkPayment, updatedPayment)
 	return nil
 }
 
+// paymentAuditAction is the audit action of setting a payment to status
+func paymentAuditAction(status string) string {
+	switch status {
+	case constants.PaymentStatusProceed:
+		return constants.AuditApprove
+	case constants.PaymentStatusFailed:
+		return constants.AuditReject
+	default:
+		return constants.AuditStatus
+	}
+}
+
 func (service *PaymentServiceImpl) GetList(
 	filter model.FilterPayment,
 	pg *utils.PaginateQueryOffset,
@@ -170,3 +209,159 @@ func (service *PaymentServiceImpl) GetManyByInvoiceID(invID uint) (payments []mo
 	}
 	return
 }
+
+func (service *PaymentServiceImpl) CreateAuto(
+	ctx context.Context,
+	request model.CreateAutoPaymentRequest,
+) (response model.AutoPaymentResponse, err error) {
+	authenticatedUser := ctx.Value("user").(um.User)
+
+	//check unprocessed payment
+	unprocessed, err := service.Repository.FindUnprocessedByInvoiceID(request.InvoiceID)
+	if err != nil && err != e.ErrDataNotFound {
+		return
+	}
+
+	if unprocessed.ID != 0 {
+		err = e.ErrHaveUnprocessedPayment
+		return
+	}
+
+	// get related invoice
+	invoice, err := service.InvoiceRepo.FindOne(request.InvoiceID)
+	if err != nil {
+		return
+	}
+	if invoice.ID == 0 {
+		err = e.ErrDataNotFound
+		return
+	}
+
+	//check invoice status
+	if invoice.Status == constants.InvoicePaid {
+		err = e.ErrInvoiceIsPaid
+		return
+	}
+	if invoice.Status == constants.InvoiceExpired {
+		err = e.ErrInvoiceExpired
+		return
+	}
+
+	//get related participant
+	participant, err := service.ParticipantRepo.FindByID(invoice.ParticipantID)
+	if err != nil {
+		return
+	}
+	if participant.UserID != authenticatedUser.ID {
+		err = e.ErrForbidden
+		return
+	}
+
+	method, err := service.MethodRepo.FindByID(request.PaymentMethodID)
+	if err != nil {
+		return
+	}
+
+	charge, ok := autoPaymentMethods[method.Name]
+	if !ok || !method.IsActive {
+		err = e.ErrPaymentMethodNotAuto
+		return
+	}
+
+	charge.OrderID = fmt.Sprintf("%s-%d", invoice.InvoiceNumber, time.Now().Unix())
+	charge.Amount = invoice.Balance()
+	charge.ExpireIn = helper.GetEnvDuration("PAYMENT_EXPIRY", 24*time.Hour)
+	charge.Customer = gateway.Customer{
+		Name:  invoice.ParticipantName,
+		Email: invoice.ParticipantEmail,
+		Phone: invoice.ParticipantPhone,
+	}
+
+	result, err := service.Provider.CreateCharge(charge)
+	if err != nil {
+		return
+	}
+
+	payment := model.Payment{
+		BaseEntity:            builder.BuildBaseEntity(ctx, true, nil),
+		InvoiceID:             invoice.ID,
+		Invoice:               model.Invoice{ParticipantID: participant.ID},
+		PaymentType:           constants.PaymentTypeAuto,
+		PaymentMethodID:       &method.ID,
+		Amount:                charge.Amount,
+		Provider:              helper.ReferString(service.Provider.Name()),
+		ProviderTransactionID: &result.TransactionID,
+		ProviderOrderID:       &charge.OrderID,
+		ExpiresAt:             result.ExpiresAt,
+	}
+	if result.VANumber != "" {
+		payment.VANumber = &result.VANumber
+	}
+	if result.QRString != "" {
+		payment.QRString = &result.QRString
+	}
+
+	if err = service.Repository.Save(payment); err != nil {
+		return
+	}
+
+	saved, err := service.Repository.FindByProviderTransactionID(service.Provider.Name(), result.TransactionID)
+	if err != nil {
+		return
+	}
+
+	response = model.AutoPaymentResponse{
+		PaymentID:     saved.ID,
+		InvoiceNumber: invoice.InvoiceNumber,
+		PaymentMethod: method.Name,
+		Amount:        charge.Amount,
+		VANumber:      payment.VANumber,
+		QRString:      payment.QRString,
+		ExpiresAt:     payment.ExpiresAt,
+	}
+	return
+}
+
+// HandleNotification applies a gateway callback. Callbacks can arrive more than once,
+// only the first one for a transaction changes the payment and its invoice.
+// The payment is found by the order id, which every provider signs, and the transaction
+// has to be the one the charge was created with.
+func (service *PaymentServiceImpl) HandleNotification(provider string, payload []byte, signature string) error {
+	if provider != service.Provider.Name() {
+		return e.ErrDataNotFound
+	}
+
+	notification, err := service.Provider.ParseNotification(payload, signature)
+	if err != nil {
+		if err == gateway.ErrInvalidSignature {
+			return e.ErrInvalidSignature
+		}
+		return err
+	}
+
+	payment, err := service.Repository.FindByProviderOrderID(provider, notification.OrderID)
+	if err != nil {
+		return err
+	}
+	if payment.ProviderTransactionID == nil || *payment.ProviderTransactionID != notification.TransactionID {
+		return e.ErrTransactionMismatch
+	}
+
+	switch notification.Status {
+	case gateway.TransactionPaid:
+		err = service.Repository.SettleAuto(payment, notification.Amount)
+	case gateway.TransactionFailed:
+		err = service.Repository.FailAuto(payment)
+	}
+	if err != nil {
+		return err
+	}
+
+	// a repeated notification leaves the payment as it was and is not recorded again
+	updatedPayment, _ := service.Repository.FindOne(payment.ID)
+	if updatedPayment.Status != payment.Status {
+		service.Audit.Record(context.Background(), paymentAuditAction(updatedPayment.Status),
+			constants.AuditEntityPayment, payment.ID, payment, updatedPayment)
+	}
+	return nil
+}
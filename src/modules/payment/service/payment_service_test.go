@@ -0,0 +1,270 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/payment/model"
+	"be-sagara-hackathon/src/modules/payment/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	ur "be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/gateway"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestPaymentAuditAction(t *testing.T) {
+	cases := map[string]string{
+		constants.PaymentStatusProceed: constants.AuditApprove,
+		constants.PaymentStatusFailed:  constants.AuditReject,
+		constants.PaymentStatusCreated: constants.AuditStatus,
+	}
+	for status, want := range cases {
+		if got := paymentAuditAction(status); got != want {
+			t.Errorf("paymentAuditAction(%s) = %s, want %s", status, got, want)
+		}
+	}
+}
+
+// fakeAutoPaymentRepository keeps one auto payment and its invoice, settling the way the
+// repository does: only a created payment changes and the invoice is paid once it is covered.
+type fakeAutoPaymentRepository struct {
+	repository.PaymentRepository
+	payment model.Payment
+	settled int
+	failed  int
+}
+
+func (repository *fakeAutoPaymentRepository) FindByProviderOrderID(provider, orderID string) (model.Payment, error) {
+	if provider != *repository.payment.Provider || orderID != *repository.payment.ProviderOrderID {
+		return model.Payment{}, e.ErrDataNotFound
+	}
+	return repository.payment, nil
+}
+
+func (repository *fakeAutoPaymentRepository) FindOne(paymentID uint) (model.PaymentDetail, error) {
+	if paymentID != repository.payment.ID {
+		return model.PaymentDetail{}, e.ErrDataNotFound
+	}
+	return model.PaymentDetail{ID: repository.payment.ID, Status: repository.payment.Status}, nil
+}
+
+func (repository *fakeAutoPaymentRepository) SettleAuto(payment model.Payment, amount uint64) error {
+	if repository.payment.Status != constants.PaymentStatusCreated {
+		return nil
+	}
+	repository.settled++
+	repository.payment.Status = constants.PaymentStatusProceed
+	repository.payment.Amount = amount
+	repository.payment.Invoice.PaidAmount += amount
+	if repository.payment.Invoice.PaidAmount >= repository.payment.Invoice.Amount {
+		repository.payment.Invoice.Status = constants.InvoicePaid
+	}
+	return nil
+}
+
+func (repository *fakeAutoPaymentRepository) FailAuto(payment model.Payment) error {
+	if repository.payment.Status != constants.PaymentStatusCreated {
+		return nil
+	}
+	repository.failed++
+	repository.payment.Status = constants.PaymentStatusFailed
+	return nil
+}
+
+func newNotificationTest() (*PaymentServiceImpl, *fakeAutoPaymentRepository, *gateway.FakeProvider) {
+	provider := gateway.NewFakeProvider("test-secret")
+	payments := &fakeAutoPaymentRepository{payment: model.Payment{
+		BaseEntity:            common.BaseEntity{ID: 7},
+		InvoiceID:             3,
+		Invoice:               model.Invoice{Amount: 150000, Status: constants.InvoiceProcessing},
+		PaymentType:           constants.PaymentTypeAuto,
+		Status:                constants.PaymentStatusCreated,
+		Amount:                150000,
+		Provider:              helper.ReferString(gateway.ProviderFake),
+		ProviderTransactionID: helper.ReferString("fake-1"),
+		ProviderOrderID:       helper.ReferString("INV-1-1700000000"),
+	}}
+	service := &PaymentServiceImpl{Repository: payments, Provider: provider, Audit: fakeRecorder{}}
+	return service, payments, provider
+}
+
+func notify(service *PaymentServiceImpl, provider *gateway.FakeProvider, transactionID, status string) error {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"transaction_id": transactionID,
+		"order_id":       "INV-1-1700000000",
+		"status":         status,
+		"amount":         150000,
+	})
+	return service.HandleNotification(gateway.ProviderFake, payload, provider.Sign(payload))
+}
+
+func TestHandleNotificationRejectsWrongSignature(t *testing.T) {
+	service, payments, _ := newNotificationTest()
+	forged := gateway.NewFakeProvider("other-secret")
+
+	if err := notify(service, forged, "fake-1", gateway.TransactionPaid); err != e.ErrInvalidSignature {
+		t.Fatalf("notification signed with another secret returned %v, want %v", err, e.ErrInvalidSignature)
+	}
+	if payments.settled != 0 {
+		t.Errorf("notification with a wrong signature settled the payment")
+	}
+}
+
+func TestHandleNotificationRejectsOtherTransaction(t *testing.T) {
+	service, payments, provider := newNotificationTest()
+
+	if err := notify(service, provider, "fake-2", gateway.TransactionPaid); err != e.ErrTransactionMismatch {
+		t.Fatalf("notification of another transaction returned %v, want %v", err, e.ErrTransactionMismatch)
+	}
+	if payments.settled != 0 {
+		t.Errorf("notification of another transaction settled the payment")
+	}
+}
+
+func TestHandleNotificationPaid(t *testing.T) {
+	service, payments, provider := newNotificationTest()
+
+	if err := notify(service, provider, "fake-1", gateway.TransactionPaid); err != nil {
+		t.Fatalf("paid notification returned %v", err)
+	}
+	if payments.payment.Status != constants.PaymentStatusProceed {
+		t.Errorf("payment status = %s, want %s", payments.payment.Status, constants.PaymentStatusProceed)
+	}
+	if payments.payment.Invoice.Status != constants.InvoicePaid {
+		t.Errorf("invoice status = %s, want %s", payments.payment.Invoice.Status, constants.InvoicePaid)
+	}
+
+	if err := notify(service, provider, "fake-1", gateway.TransactionPaid); err != nil {
+		t.Fatalf("repeated paid notification returned %v", err)
+	}
+	if payments.settled != 1 || payments.payment.Invoice.PaidAmount != 150000 {
+		t.Errorf("repeated paid notification settled %d times for %d, want once for 150000",
+			payments.settled, payments.payment.Invoice.PaidAmount)
+	}
+}
+
+func TestHandleNotificationFailed(t *testing.T) {
+	service, payments, provider := newNotificationTest()
+
+	if err := notify(service, provider, "fake-1", gateway.TransactionFailed); err != nil {
+		t.Fatalf("failed notification returned %v", err)
+	}
+	if payments.failed != 1 || payments.payment.Status != constants.PaymentStatusFailed {
+		t.Errorf("payment status = %s, want %s", payments.payment.Status, constants.PaymentStatusFailed)
+	}
+	if payments.settled != 0 || payments.payment.Invoice.Status == constants.InvoicePaid {
+		t.Errorf("failed notification paid the invoice")
+	}
+
+	if err := notify(service, provider, "fake-1", gateway.TransactionPaid); err != nil {
+		t.Fatalf("paid notification after the failure returned %v", err)
+	}
+	if payments.settled != 0 {
+		t.Errorf("paid notification settled a failed payment")
+	}
+}
+
+type fakeParticipantRepository struct {
+	ur.ParticipantRepository
+}
+
+func (fakeParticipantRepository) FindByID(id uint) (participant um.Participant, err error) {
+	participant.ID = id
+	return
+}
+
+// fakeManualPaymentRepository keeps one payment, updating it only while it has the status it was read with
+type fakeManualPaymentRepository struct {
+	repository.PaymentRepository
+	payment model.PaymentDetail
+	entries []model.InvoiceEntry
+}
+
+func (repository *fakeManualPaymentRepository) FindOne(paymentID uint) (model.PaymentDetail, error) {
+	if paymentID != repository.payment.ID {
+		return model.PaymentDetail{}, e.ErrDataNotFound
+	}
+	return repository.payment, nil
+}
+
+func (repository *fakeManualPaymentRepository) Update(paymentID uint, status string, payment model.Payment, entry *model.InvoiceEntry) error {
+	if status != repository.payment.Status {
+		return e.ErrPaymentStatusChanged
+	}
+	repository.payment.Status = payment.Status
+	repository.payment.Amount = payment.Amount
+	if entry != nil {
+		repository.entries = append(repository.entries, *entry)
+	}
+	return nil
+}
+
+func newUpdateTest(paymentType string) (*PaymentServiceImpl, *fakeManualPaymentRepository, context.Context) {
+	payments := &fakeManualPaymentRepository{payment: model.PaymentDetail{
+		ID:          7,
+		InvoiceID:   3,
+		PaymentType: paymentType,
+		Status:      constants.PaymentStatusCreated,
+	}}
+	service := &PaymentServiceImpl{Repository: payments, ParticipantRepo: fakeParticipantRepository{}, Audit: fakeRecorder{}}
+	ctx := context.WithValue(context.Background(), "user", um.User{Email: "admin@example.com"})
+	return service, payments, ctx
+}
+
+func TestUpdatePaymentApprovesThenCorrects(t *testing.T) {
+	service, payments, ctx := newUpdateTest(constants.PaymentTypeManual)
+
+	if err := service.Update(ctx, 7, model.UpdatePaymentRequest{Amount: 100000}); err != nil {
+		t.Fatalf("approval returned %v", err)
+	}
+	if err := service.Update(ctx, 7, model.UpdatePaymentRequest{Amount: 150000}); err != nil {
+		t.Fatalf("correction returned %v", err)
+	}
+
+	if len(payments.entries) != 2 {
+		t.Fatalf("got %d entries, want a payment and a correction", len(payments.entries))
+	}
+	if v := payments.entries[0]; v.Type != constants.InvoiceEntryPayment || v.Amount != 100000 {
+		t.Errorf("first entry = %s of %d, want a payment of 100000", v.Type, v.Amount)
+	}
+	if v := payments.entries[1]; v.Type != constants.InvoiceEntryCorrection || v.Amount != 50000 {
+		t.Errorf("second entry = %s of %d, want a correction of 50000", v.Type, v.Amount)
+	}
+}
+
+func TestUpdatePaymentChangedMeanwhile(t *testing.T) {
+	service, payments, ctx := newUpdateTest(constants.PaymentTypeManual)
+	service.Repository = &changingPaymentRepository{payments}
+
+	if err := service.Update(ctx, 7, model.UpdatePaymentRequest{Amount: 100000}); err != e.ErrPaymentStatusChanged {
+		t.Fatalf("approval of a payment approved meanwhile returned %v, want %v", err, e.ErrPaymentStatusChanged)
+	}
+	if len(payments.entries) != 0 {
+		t.Errorf("approval of a payment approved meanwhile added %d entries", len(payments.entries))
+	}
+}
+
+// changingPaymentRepository approves the payment right after it is read, like a second admin would
+type changingPaymentRepository struct {
+	*fakeManualPaymentRepository
+}
+
+func (repository *changingPaymentRepository) FindOne(paymentID uint) (model.PaymentDetail, error) {
+	payment, err := repository.fakeManualPaymentRepository.FindOne(paymentID)
+	repository.payment.Status = constants.PaymentStatusProceed
+	return payment, err
+}
+
+func TestUpdatePaymentRejectsUnsettledAuto(t *testing.T) {
+	service, payments, ctx := newUpdateTest(constants.PaymentTypeAuto)
+
+	if err := service.Update(ctx, 7, model.UpdatePaymentRequest{Amount: 100000}); err != e.ErrAutoPaymentNotSettled {
+		t.Fatalf("approval of a created auto payment returned %v, want %v", err, e.ErrAutoPaymentNotSettled)
+	}
+	if payments.payment.Status != constants.PaymentStatusCreated {
+		t.Errorf("payment status = %s, want it left %s", payments.payment.Status, constants.PaymentStatusCreated)
+	}
+}
@@ -62,7 +62,10 @@ func (controller *ProjectAssessmentControllerImpl) Create(ctx *gin.Context) {
 			return
 		}
 
-		if err == e.ErrProjectStatusShouldBeSubmitted {
+		if err == e.ErrProjectStatusShouldBeSubmitted || err == e.ErrOutsideTimelinePhase ||
+			err == e.ErrLeaderboardFrozen || err == e.ErrInvalidAssessmentCriteria ||
+			err == e.ErrDuplicateAssessmentCriteria || err == e.ErrScoreOutOfRange ||
+			err == e.ErrIncompleteAssessment || err == e.ErrJudgeConflict {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -81,13 +84,18 @@ func (controller *ProjectAssessmentControllerImpl) GetByProjectID(ctx *gin.Conte
 		return
 	}
 
-	data, err := controller.Service.GetByProjectID(uint(projectID))
+	data, err := controller.Service.GetByProjectID(ctx, uint(projectID))
 	if err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -54,12 +54,12 @@ func (controller *ProjectControllerImpl) Create(ctx *gin.Context) {
 			return
 		}
 
-		if err == e.ErrForbidden {
+		if err == e.ErrForbidden || err == e.ErrNotEventStaff {
 			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
 			return
 		}
 
-		if err == e.ErrEventNotRunning || err == e.ErrTeamAlreadyHasProject {
+		if err == e.ErrEventNotRunning || err == e.ErrOutsideTimelinePhase || err == e.ErrTeamAlreadyHasProject {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -104,12 +104,12 @@ func (controller *ProjectControllerImpl) Update(ctx *gin.Context) {
 			return
 		}
 
-		if err == e.ErrForbidden {
+		if err == e.ErrForbidden || err == e.ErrNotEventStaff {
 			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
 			return
 		}
 
-		if err == e.ErrEventNotRunning || err == e.ErrProjectStatusShouldBeDraft {
+		if err == e.ErrEventNotRunning || err == e.ErrOutsideTimelinePhase || err == e.ErrProjectStatusShouldBeDraft {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -146,6 +146,11 @@ func (controller *ProjectControllerImpl) UpdateStatus(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -196,7 +201,7 @@ func (controller *ProjectControllerImpl) GetAll(ctx *gin.Context) {
 		Search:    ctx.Query("q"),
 	}
 
-	data, err := controller.Service.GetAll(filter, pg)
+	data, err := controller.Service.GetAll(ctx, filter, pg)
 	if err != nil {
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
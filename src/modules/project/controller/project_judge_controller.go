@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/project/model"
+	"be-sagara-hackathon/src/modules/project/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ProjectJudgeController interface {
+	AutoAssign(ctx *gin.Context)
+	Assign(ctx *gin.Context)
+	Unassign(ctx *gin.Context)
+	GetByProjectID(ctx *gin.Context)
+	DeclareConflict(ctx *gin.Context)
+	RemoveConflict(ctx *gin.Context)
+	GetConflictsByProjectID(ctx *gin.Context)
+}
+
+type ProjectJudgeControllerImpl struct {
+	Service service.ProjectJudgeService
+}
+
+func NewProjectJudgeController(judgeService service.ProjectJudgeService) ProjectJudgeController {
+	return &ProjectJudgeControllerImpl{Service: judgeService}
+}
+
+func (controller *ProjectJudgeControllerImpl) AutoAssign(ctx *gin.Context) {
+	var request model.AutoAssignJudgeRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		if err.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(err))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	eventID, err := strconv.Atoi(ctx.Param("event_id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid event id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.AutoAssign(ctx, uint(eventID), request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Assign Judges Success", data)
+}
+
+func (controller *ProjectJudgeControllerImpl) Assign(ctx *gin.Context) {
+	var request model.ProjectJudgeRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		if err.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(err))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	projectID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid project id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.Assign(ctx, uint(projectID), request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventJudge || err == e.ErrJudgeConflict || err == e.ErrJudgeAlreadyAssigned {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Assign Project Judge Success", nil)
+}
+
+func (controller *ProjectJudgeControllerImpl) Unassign(ctx *gin.Context) {
+	projectID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid project id", []string{err.Error()})
+		return
+	}
+
+	judgeID, err := strconv.Atoi(ctx.Param("judge_id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid judge id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.Unassign(ctx, uint(projectID), uint(judgeID))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Unassign Project Judge Success", nil)
+}
+
+func (controller *ProjectJudgeControllerImpl) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid project id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetByProjectID(ctx, uint(projectID))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Project Judges Success", data)
+}
+
+func (controller *ProjectJudgeControllerImpl) DeclareConflict(ctx *gin.Context) {
+	var request model.JudgeConflictRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		if err.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(err))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	projectID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid project id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.DeclareConflict(ctx, uint(projectID), request)
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrJudgeIDRequired || err == e.ErrNotEventJudge || err == e.ErrConflictAlreadyDeclared {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Declare Conflict of Interest Success", nil)
+}
+
+func (controller *ProjectJudgeControllerImpl) RemoveConflict(ctx *gin.Context) {
+	projectID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid project id", []string{err.Error()})
+		return
+	}
+
+	conflictID, err := strconv.Atoi(ctx.Param("conflict_id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid conflict id", []string{err.Error()})
+		return
+	}
+
+	err = controller.Service.RemoveConflict(ctx, uint(projectID), uint(conflictID))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Remove Conflict of Interest Success", nil)
+}
+
+func (controller *ProjectJudgeControllerImpl) GetConflictsByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid project id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetConflictsByProjectID(ctx, uint(projectID))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Conflicts of Interest Success", data)
+}
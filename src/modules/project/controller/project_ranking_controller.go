@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/project/service"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ProjectRankingController interface {
+	GetLeaderboard(ctx *gin.Context)
+	Freeze(ctx *gin.Context)
+}
+
+type ProjectRankingControllerImpl struct {
+	Service service.ProjectRankingService
+}
+
+func NewProjectRankingController(rankingService service.ProjectRankingService) ProjectRankingController {
+	return &ProjectRankingControllerImpl{Service: rankingService}
+}
+
+func (controller *ProjectRankingControllerImpl) GetLeaderboard(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Param("event_id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid event id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetLeaderboard(ctx, uint(eventID))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Leaderboard Success", data)
+}
+
+func (controller *ProjectRankingControllerImpl) Freeze(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Param("event_id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid event id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.Freeze(ctx, uint(eventID))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrLeaderboardFrozen || err == e.ErrNoProjectToRank {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Freeze Leaderboard Success", data)
+}
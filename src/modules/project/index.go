@@ -2,10 +2,13 @@ package project
 
 import (
 	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/project/controller"
 	"be-sagara-hackathon/src/modules/project/repository"
 	"be-sagara-hackathon/src/modules/project/service"
 	tm "be-sagara-hackathon/src/modules/team/repository"
+	ur "be-sagara-hackathon/src/modules/user/repository"
 
 	"gorm.io/gorm"
 )
@@ -18,6 +21,15 @@ var (
 	projectAssessmentRepository repository.ProjectAssessmentRepository
 	projectAssessmentService    service.ProjectAssessmentService
 	projectAssessmentController controller.ProjectAssessmentController
+
+	projectResultRepository  repository.ProjectResultRepository
+	projectRankingService    service.ProjectRankingService
+	projectRankingController controller.ProjectRankingController
+
+	projectJudgeRepository  repository.ProjectJudgeRepository
+	judgeConflictRepository repository.JudgeConflictRepository
+	projectJudgeService     service.ProjectJudgeService
+	projectJudgeController  controller.ProjectJudgeController
 )
 
 type Module interface {
@@ -38,6 +50,12 @@ func (module ModuleImpl) InitModule() {
 	eventRepository := eve.NewEventRepository(module.DB)
 	eventJudgeRepository := eve.NewEventJudgeRepository(module.DB)
 	criteriaRepository := eve.NewEventAssessmentCriteriaRepository(module.DB)
+	timelineGuard := evs.NewEventTimelineGuard(eve.NewEventTimelineRepository(module.DB))
+	eventScope := evs.NewEventScope(eve.NewEventStaffRepository(module.DB), ur.NewPermissionRepository(module.DB))
+	auditRecorder := audit.GetRecorder()
+
+	projectJudgeRepository = repository.NewProjectJudgeRepository(module.DB)
+	judgeConflictRepository = repository.NewJudgeConflictRepository(module.DB)
 
 	projectRepository = repository.NewProjectRepository(module.DB)
 	projectService = service.NewProjectService(
@@ -45,15 +63,45 @@ func (module ModuleImpl) InitModule() {
 		teamRepository,
 		teamMemberRepository,
 		eventRepository,
+		timelineGuard,
+		eventScope,
+		auditRecorder,
 	)
 	projectController = controller.NewProjectController(projectService)
 
+	projectResultRepository = repository.NewProjectResultRepository(module.DB)
+	projectRankingService = service.NewProjectRankingService(
+		projectResultRepository,
+		projectRepository,
+		eventRepository,
+		eventScope,
+		auditRecorder,
+	)
+	projectRankingController = controller.NewProjectRankingController(projectRankingService)
+
+	projectJudgeService = service.NewProjectJudgeService(
+		projectJudgeRepository,
+		judgeConflictRepository,
+		projectRepository,
+		eventRepository,
+		eventJudgeRepository,
+		eventScope,
+		auditRecorder,
+	)
+	projectJudgeController = controller.NewProjectJudgeController(projectJudgeService)
+
 	projectAssessmentRepository = repository.NewProjectAssessmentRepository(module.DB)
 	projectAssessmentService = service.NewProjectAssessmentService(
 		projectAssessmentRepository,
 		projectRepository,
 		criteriaRepository,
 		eventJudgeRepository,
+		timelineGuard,
+		projectResultRepository,
+		projectJudgeRepository,
+		judgeConflictRepository,
+		eventScope,
+		auditRecorder,
 	)
 	projectAssessmentController = controller.NewProjectAssessmentController(projectAssessmentService)
 }
@@ -65,3 +113,11 @@ func GetProjectController() controller.ProjectController {
 func GetProjectAssessmentController() controller.ProjectAssessmentController {
 	return projectAssessmentController
 }
+
+func GetProjectRankingController() controller.ProjectRankingController {
+	return projectRankingController
+}
+
+func GetProjectJudgeController() controller.ProjectJudgeController {
+	return projectJudgeController
+}
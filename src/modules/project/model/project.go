@@ -77,10 +77,12 @@ type UpdateProjectModel struct {
 
 type FilterProject struct {
 	EventID   uint
+	EventIDs  []uint
 	TeamID    uint
 	CreatedAt string
 	Status    string
 	Search    string //project name
+	JudgeID   uint   //only projects the judge may score
 }
 
 type ProjectLite struct {
@@ -8,11 +8,11 @@ import (
 
 type ProjectAssessment struct {
 	common.BaseEntity
-	JudgeID    uint                        `gorm:"not null"`
+	JudgeID    uint                        `gorm:"not null;uniqueIndex:idx_project_assessments_judge_project_criteria"`
 	Judge      um.User                     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"judge"`
-	ProjectID  uint                        `gorm:"not null"`
+	ProjectID  uint                        `gorm:"not null;uniqueIndex:idx_project_assessments_judge_project_criteria"`
 	Project    Project                     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"-"`
-	CriteriaID uint                        `gorm:"not null"`
+	CriteriaID uint                        `gorm:"not null;uniqueIndex:idx_project_assessments_judge_project_criteria"`
 	Criteria   evm.EventAssessmentCriteria `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"criteria"`
 	Score      uint                        `gorm:"not null"`
 }
@@ -23,7 +23,7 @@ type ProjectAssessmentRequest struct {
 }
 
 type CreateBatchProjectAssessmentRequest struct {
-	Assessments []ProjectAssessmentRequest `json:"assessments"`
+	Assessments []ProjectAssessmentRequest `json:"assessments" validate:"required,min=1,dive"`
 }
 
 type GetByProjectIDResponse struct {
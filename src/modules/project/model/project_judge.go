@@ -0,0 +1,60 @@
+package model
+
+import (
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+type ProjectJudge struct {
+	common.BaseEntity
+	ProjectID uint    `gorm:"not null;uniqueIndex:idx_project_judges_project_judge" json:"project_id"`
+	Project   Project `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	JudgeID   uint    `gorm:"not null;uniqueIndex:idx_project_judges_project_judge" json:"judge_id"`
+	Judge     um.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"-"`
+	IsManual  bool    `gorm:"not null;default:false" json:"is_manual"` //manual assignments are kept on auto assignment
+}
+
+type JudgeConflict struct {
+	common.BaseEntity
+	ProjectID uint    `gorm:"not null;uniqueIndex:idx_judge_conflicts_project_judge" json:"project_id"`
+	Project   Project `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	JudgeID   uint    `gorm:"not null;uniqueIndex:idx_judge_conflicts_project_judge" json:"judge_id"`
+	Judge     um.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"-"`
+	Reason    string  `gorm:"type:text;not null" json:"reason"`
+}
+
+type AutoAssignJudgeRequest struct {
+	JudgesPerProject uint `json:"judges_per_project" validate:"required,min=1"`
+}
+
+type ProjectJudgeRequest struct {
+	JudgeID uint `json:"judge_id" validate:"required"`
+}
+
+type JudgeConflictRequest struct {
+	JudgeID uint   `json:"judge_id" validate:"omitempty"` //required when declared by an admin
+	Reason  string `json:"reason" validate:"required"`
+}
+
+type ProjectJudgeLite struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"project_id"`
+	JudgeID   uint   `json:"judge_id"`
+	JudgeName string `json:"judge_name"`
+	IsManual  bool   `json:"is_manual"`
+}
+
+type JudgeConflictLite struct {
+	ID        uint      `json:"id"`
+	ProjectID uint      `json:"project_id"`
+	JudgeID   uint      `json:"judge_id"`
+	JudgeName string    `json:"judge_name"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AutoAssignJudgeResponse struct {
+	TotalAssigned      int    `json:"total_assigned"`
+	UnfilledProjectIDs []uint `json:"unfilled_project_ids"` //projects that got less judges than requested
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+// ProjectResult is a row of a frozen (official) leaderboard.
+type ProjectResult struct {
+	common.BaseEntity
+	EventID    uint      `gorm:"not null;uniqueIndex:idx_project_results_event_project" json:"event_id"`
+	Event      evm.Event `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"-"`
+	ProjectID  uint      `gorm:"not null;uniqueIndex:idx_project_results_event_project" json:"project_id"`
+	Project    Project   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"-"`
+	Rank       uint      `gorm:"not null" json:"rank"`
+	FinalScore float64   `gorm:"not null" json:"final_score"`
+	JudgeCount uint      `gorm:"not null" json:"judge_count"`
+	Breakdown  string    `gorm:"type:text;not null" json:"-"` //json of []CriteriaScore
+	FrozenAt   time.Time `gorm:"not null" json:"frozen_at"`
+}
+
+// ScoredAssessment is a single judge's score joined with the criteria it was given for.
+type ScoredAssessment struct {
+	ProjectID     uint
+	ProjectName   string
+	TeamID        uint
+	JudgeID       uint
+	CriteriaID    uint
+	Criteria      string
+	PercentageVal uint
+	ScoreStart    uint
+	ScoreEnd      uint
+	Score         uint
+}
+
+type CriteriaScore struct {
+	CriteriaID      uint    `json:"criteria_id"`
+	Criteria        string  `json:"criteria"`
+	PercentageVal   uint    `json:"percentage_val"`
+	AverageScore    float64 `json:"average_score"`
+	NormalizedScore float64 `json:"normalized_score"`
+	WeightedScore   float64 `json:"weighted_score"`
+}
+
+type ProjectRanking struct {
+	Rank        uint            `json:"rank"`
+	ProjectID   uint            `json:"project_id"`
+	ProjectName string          `json:"project_name"`
+	TeamID      uint            `json:"team_id"`
+	FinalScore  float64         `json:"final_score"`
+	JudgeCount  uint            `json:"judge_count"`
+	IsTie       bool            `json:"is_tie"`
+	Breakdown   []CriteriaScore `json:"breakdown"`
+}
+
+type LeaderboardResponse struct {
+	EventID    uint             `json:"event_id"`
+	IsOfficial bool             `json:"is_official"`
+	FrozenAt   *time.Time       `json:"frozen_at"`
+	Rankings   []ProjectRanking `json:"rankings"`
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/project/model"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+)
+
+type JudgeConflictRepository interface {
+	Save(conflict model.JudgeConflict) (model.JudgeConflict, error)
+	Delete(id uint) error
+	FindOne(id uint) (conflict model.JudgeConflict, err error)
+	FindByProjectID(projectID uint) (conflicts []model.JudgeConflictLite, err error)
+	FindByEventID(eventID uint) (conflicts []model.JudgeConflict, err error)
+	IsConflicted(projectID, judgeID uint) (bool, error)
+}
+
+type JudgeConflictRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewJudgeConflictRepository(db *gorm.DB) JudgeConflictRepository {
+	return &JudgeConflictRepositoryImpl{DB: db}
+}
+
+// Save records the conflict and drops the judge's assignment to the project, if any.
+func (repository *JudgeConflictRepositoryImpl) Save(conflict model.JudgeConflict) (model.JudgeConflict, error) {
+	tx := repository.DB.Begin()
+	if err := tx.Create(&conflict).Error; err != nil {
+		tx.Rollback()
+		return conflict, err
+	}
+
+	if err := tx.Unscoped().Where("project_id=? AND judge_id=?", conflict.ProjectID, conflict.JudgeID).
+		Delete(&model.ProjectJudge{}).Error; err != nil {
+		tx.Rollback()
+		return conflict, err
+	}
+
+	tx.Commit()
+	return conflict, nil
+}
+
+func (repository *JudgeConflictRepositoryImpl) Delete(id uint) error {
+	if err := repository.DB.Unscoped().Delete(&model.JudgeConflict{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *JudgeConflictRepositoryImpl) FindOne(id uint) (conflict model.JudgeConflict, err error) {
+	if err = repository.DB.Where("id=?", id).First(&conflict).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return
+	}
+	return
+}
+
+func (repository *JudgeConflictRepositoryImpl) FindByProjectID(projectID uint) (conflicts []model.JudgeConflictLite, err error) {
+	err = repository.DB.Table("judge_conflicts jc").
+		Select("jc.id, jc.project_id, jc.judge_id, u.name as judge_name, jc.reason, jc.created_at").
+		Joins("inner join users u on u.id = jc.judge_id").
+		Where("jc.project_id=?", projectID).
+		Order("jc.id asc").
+		Find(&conflicts).Error
+	return
+}
+
+func (repository *JudgeConflictRepositoryImpl) FindByEventID(eventID uint) (conflicts []model.JudgeConflict, err error) {
+	err = repository.DB.Joins("inner join projects p on p.id = judge_conflicts.project_id").
+		Where("p.event_id=?", eventID).
+		Find(&conflicts).Error
+	return
+}
+
+func (repository *JudgeConflictRepositoryImpl) IsConflicted(projectID, judgeID uint) (bool, error) {
+	var total int64
+	if err := repository.DB.Model(&model.JudgeConflict{}).
+		Where("project_id=? AND judge_id=?", projectID, judgeID).
+		Count(&total).Error; err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
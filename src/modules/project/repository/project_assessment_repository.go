@@ -4,11 +4,12 @@ import (
 	"be-sagara-hackathon/src/modules/project/model"
 	"be-sagara-hackathon/src/utils/constants"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProjectAssessmentRepository interface {
 	Create(assessment model.ProjectAssessment) error
-	CreateBatch(assessments []model.ProjectAssessment) error
+	UpsertBatch(assessments []model.ProjectAssessment, requiredJudges []uint) error
 	FindByProjectID(projectID uint) (assessments []model.ProjectAssessment, err error)
 	FindByProjectIDAndJudgeID(projectID, judgeID uint) (assessment []model.ProjectAssessment, err error)
 }
@@ -28,22 +29,51 @@ func (repository *ProjectAssessmentRepositoryImpl) Create(assessment model.Proje
 	return nil
 }
 
-func (repository *ProjectAssessmentRepositoryImpl) CreateBatch(assessments []model.ProjectAssessment) error {
+// UpsertBatch saves a judge's scores for a project, replacing the scores the judge gave before,
+// deleted ones included. The batch must hold every active criteria of the event. The project is
+// marked as assessed once every one of requiredJudges has scored all of those criteria.
+func (repository *ProjectAssessmentRepositoryImpl) UpsertBatch(assessments []model.ProjectAssessment, requiredJudges []uint) error {
+	var criteriaIDs []uint
+	for _, v := range assessments {
+		criteriaIDs = append(criteriaIDs, v.CriteriaID)
+	}
+	projectID := assessments[0].ProjectID
+
 	tx := repository.DB.Begin()
-	if err := tx.Create(&assessments).Error; err != nil {
+	if err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "judge_id"}, {Name: "project_id"}, {Name: "criteria_id"}},
+		DoUpdates: append(
+			clause.AssignmentColumns([]string{"score", "updated_at", "updated_by"}),
+			clause.Assignments(map[string]interface{}{"deleted_at": nil, "deleted_by": nil})...,
+		),
+	}).Create(&assessments).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	if err := tx.Model(&model.Project{}).
-		Where("id=?", assessments[0].ProjectID).
-		Update("status", constants.ProjectStatusAssessed).Error; err != nil {
-		tx.Rollback()
-		return err
+	// scores of judges who are no longer required, e.g. after declaring a conflict, are left out
+	var completedJudges []uint
+	if len(requiredJudges) > 0 {
+		if err := tx.Model(&model.ProjectAssessment{}).
+			Where("project_id=? AND criteria_id IN ? AND judge_id IN ?", projectID, criteriaIDs, requiredJudges).
+			Group("judge_id").
+			Having("COUNT(DISTINCT criteria_id) = ?", len(criteriaIDs)).
+			Pluck("judge_id", &completedJudges).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
-	tx.Commit()
-	return nil
+	if len(completedJudges) >= len(requiredJudges) {
+		if err := tx.Model(&model.Project{}).
+			Where("id=? AND status=?", projectID, constants.ProjectStatusSubmitted).
+			Update("status", constants.ProjectStatusAssessed).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
 }
 
 func (repository *ProjectAssessmentRepositoryImpl) FindByProjectID(projectID uint) (assessments []model.ProjectAssessment, err error) {
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/project/model"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// fakeConnPool lets a DryRun database begin and commit transactions without a server
+type fakeConnPool struct {
+	gorm.ConnPool
+}
+
+func (pool *fakeConnPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return pool, nil
+}
+
+func (*fakeConnPool) Commit() error {
+	return nil
+}
+
+func (*fakeConnPool) Rollback() error {
+	return nil
+}
+
+// upsertBatchStatements returns the statements UpsertBatch sends, the judges who scored every
+// criteria are read as completedJudges
+func upsertBatchStatements(t *testing.T, requiredJudges, completedJudges []uint) []string {
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: &fakeConnPool{}, SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var statements []string
+	capture := func(tx *gorm.DB) {
+		if judges, ok := tx.Statement.Dest.(*[]uint); ok {
+			*judges = completedJudges
+		}
+		statements = append(statements, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	}
+	for _, err = range []error{
+		db.Callback().Create().After("gorm:create").Register("test:capture", capture),
+		db.Callback().Query().After("gorm:query").Register("test:capture", capture),
+		db.Callback().Update().After("gorm:update").Register("test:capture", capture),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var assessments []model.ProjectAssessment
+	for _, criteriaID := range []uint{11, 12} {
+		assessments = append(assessments, model.ProjectAssessment{JudgeID: 3, ProjectID: 5, CriteriaID: criteriaID, Score: 7})
+	}
+	if err = NewProjectAssessmentRepository(db).UpsertBatch(assessments, requiredJudges); err != nil {
+		t.Fatalf("UpsertBatch returned %v", err)
+	}
+	return statements
+}
+
+func TestUpsertBatchRestoresDeletedScores(t *testing.T) {
+	statements := upsertBatchStatements(t, []uint{3}, []uint{3})
+
+	upsert := statements[0]
+	if !strings.Contains(upsert, "ON DUPLICATE KEY UPDATE") || !strings.Contains(upsert, "`score`=VALUES(`score`)") ||
+		!strings.Contains(upsert, "`deleted_at`=NULL") || !strings.Contains(upsert, "`deleted_by`=NULL") {
+		t.Errorf("upsert doesn't replace the judge's scores, deleted ones included:\n%s", upsert)
+	}
+}
+
+func TestUpsertBatchMarksAssessed(t *testing.T) {
+	const assess = "UPDATE `projects` SET `status`='assessed'"
+	tests := []struct {
+		name            string
+		requiredJudges  []uint
+		completedJudges []uint
+		assessed        bool
+	}{
+		{name: "every required judge done", requiredJudges: []uint{3, 4}, completedJudges: []uint{3, 4}, assessed: true},
+		{name: "a required judge left", requiredJudges: []uint{3, 4}, completedJudges: []uint{3}},
+		{name: "no required judges", assessed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := upsertBatchStatements(t, tt.requiredJudges, tt.completedJudges)
+
+			last := statements[len(statements)-1]
+			if got := strings.HasPrefix(last, assess); got != tt.assessed {
+				t.Fatalf("project marked as assessed = %v, want %v:\n%s", got, tt.assessed, strings.Join(statements, "\n"))
+			}
+			if tt.assessed && !strings.Contains(last, "WHERE (id=5 AND status='submitted')") {
+				t.Errorf("only a submitted project should be marked as assessed:\n%s", last)
+			}
+		})
+	}
+}
+
+func TestUpsertBatchCountsOnlyRequiredJudges(t *testing.T) {
+	statements := upsertBatchStatements(t, []uint{3, 4}, nil)
+
+	want := "WHERE (project_id=5 AND criteria_id IN (11,12) AND judge_id IN (3,4)) " +
+		"AND `project_assessments`.`deleted_at` IS NULL GROUP BY `judge_id` HAVING COUNT(DISTINCT criteria_id) = 2"
+	if len(statements) < 2 || !strings.Contains(statements[1], want) {
+		t.Errorf("completed judges aren't counted over the required judges and the batch's criteria:\n%s",
+			strings.Join(statements, "\n"))
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/project/model"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+)
+
+type ProjectJudgeRepository interface {
+	Save(assignment model.ProjectJudge) error
+	Delete(projectID, judgeID uint) error
+	FindByProjectID(projectID uint) (assignments []model.ProjectJudgeLite, err error)
+	FindByEventID(eventID uint) (assignments []model.ProjectJudge, err error)
+	FindOneByProjectIDAndJudgeID(projectID, judgeID uint) (assignment model.ProjectJudge, err error)
+	HasAssignmentByEventID(eventID uint) (bool, error)
+	ReplaceAutoAssignments(eventID uint, assignments []model.ProjectJudge) error
+}
+
+type ProjectJudgeRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewProjectJudgeRepository(db *gorm.DB) ProjectJudgeRepository {
+	return &ProjectJudgeRepositoryImpl{DB: db}
+}
+
+func (repository *ProjectJudgeRepositoryImpl) Save(assignment model.ProjectJudge) error {
+	if err := repository.DB.Create(&assignment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *ProjectJudgeRepositoryImpl) Delete(projectID, judgeID uint) error {
+	if err := repository.DB.Unscoped().Where("project_id=? AND judge_id=?", projectID, judgeID).
+		Delete(&model.ProjectJudge{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *ProjectJudgeRepositoryImpl) FindByProjectID(projectID uint) (assignments []model.ProjectJudgeLite, err error) {
+	err = repository.DB.Table("project_judges pj").
+		Select("pj.id, pj.project_id, pj.judge_id, u.name as judge_name, pj.is_manual").
+		Joins("inner join users u on u.id = pj.judge_id").
+		Where("pj.project_id=?", projectID).
+		Order("pj.id asc").
+		Find(&assignments).Error
+	return
+}
+
+func (repository *ProjectJudgeRepositoryImpl) FindByEventID(eventID uint) (assignments []model.ProjectJudge, err error) {
+	err = repository.DB.Joins("inner join projects p on p.id = project_judges.project_id").
+		Where("p.event_id=?", eventID).
+		Find(&assignments).Error
+	return
+}
+
+func (repository *ProjectJudgeRepositoryImpl) FindOneByProjectIDAndJudgeID(projectID, judgeID uint) (assignment model.ProjectJudge, err error) {
+	if err = repository.DB.Where("project_id=? AND judge_id=?", projectID, judgeID).
+		First(&assignment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return
+	}
+	return
+}
+
+// HasAssignmentByEventID tells whether judges of the event are limited to the projects assigned to them.
+func (repository *ProjectJudgeRepositoryImpl) HasAssignmentByEventID(eventID uint) (bool, error) {
+	var total int64
+	if err := repository.DB.Model(&model.ProjectJudge{}).
+		Joins("inner join projects p on p.id = project_judges.project_id").
+		Where("p.event_id=?", eventID).
+		Count(&total).Error; err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// ReplaceAutoAssignments removes the event's automatic assignments and saves the new ones.
+// Manual assignments are left untouched.
+func (repository *ProjectJudgeRepositoryImpl) ReplaceAutoAssignments(eventID uint, assignments []model.ProjectJudge) error {
+	tx := repository.DB.Begin()
+	if err := tx.Unscoped().Where("is_manual = ? AND project_id IN (?)", false,
+		tx.Table("projects").Select("id").Where("event_id=?", eventID)).
+		Delete(&model.ProjectJudge{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(assignments) > 0 {
+		if err := tx.Create(&assignments).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	tx.Commit()
+	return nil
+}
@@ -20,6 +20,7 @@ type ProjectRepository interface {
 		filter model.FilterProject,
 		pg *utils.PaginateQueryOffset,
 	) (projects []model.ProjectLite, totalData, totalPage int64, err error)
+	FindManyByEventIDAndStatus(eventID uint, statuses []string) (projects []model.ProjectLite, err error)
 	FindByEventIDAndTeamID(eventID, teamID uint) (project model.Project, err error)
 }
 
@@ -74,21 +75,21 @@ func (repository *ProjectRepositoryImpl) Update(id uint, req model.UpdateProject
 	}
 
 	if len(req.RemovedBuiltWith) > 0 {
-		if err := tx.Delete(&req.RemovedBuiltWith).Error; err != nil {
+		if err := tx.Unscoped().Delete(&req.RemovedBuiltWith).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
 	}
 
 	if len(req.RemovedSiteLinks) > 0 {
-		if err := tx.Delete(&model.ProjectSiteLink{}, req.RemovedSiteLinks).Error; err != nil {
+		if err := tx.Unscoped().Delete(&model.ProjectSiteLink{}, req.RemovedSiteLinks).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
 	}
 
 	if len(req.RemovedImages) > 0 {
-		if err := tx.Delete(&model.ProjectImage{}, req.RemovedImages).Error; err != nil {
+		if err := tx.Unscoped().Delete(&model.ProjectImage{}, req.RemovedImages).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -205,6 +206,22 @@ func BuildFilter(filter model.FilterProject) (where []string, whereVal []interfa
 		whereVal = append(whereVal, filter.EventID)
 	}
 
+	if len(filter.EventIDs) > 0 {
+		where = append(where, "event_id IN ?")
+		whereVal = append(whereVal, filter.EventIDs)
+	}
+
+	if filter.JudgeID != 0 {
+		where = append(where, "id NOT IN (SELECT project_id FROM judge_conflicts WHERE judge_id = ?)")
+		whereVal = append(whereVal, filter.JudgeID)
+
+		// events without any assignment are open to all of their judges
+		where = append(where, `(id IN (SELECT project_id FROM project_judges WHERE judge_id = ?) OR NOT EXISTS (
+			SELECT 1 FROM project_judges pj INNER JOIN projects ap ON ap.id = pj.project_id
+			WHERE ap.event_id = projects.event_id))`)
+		whereVal = append(whereVal, filter.JudgeID)
+	}
+
 	return
 }
 
@@ -218,3 +235,12 @@ func (repository *ProjectRepositoryImpl) FindByEventIDAndTeamID(eventID, teamID
 	}
 	return
 }
+
+func (repository *ProjectRepositoryImpl) FindManyByEventIDAndStatus(eventID uint, statuses []string) (projects []model.ProjectLite, err error) {
+	err = repository.DB.Table("projects").
+		Select(`id, event_id, team_id, name, status, created_at`).
+		Where("event_id=? AND status IN ? AND deleted_at IS NULL", eventID, statuses).
+		Order("id asc").
+		Find(&projects).Error
+	return
+}
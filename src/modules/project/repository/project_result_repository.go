@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/project/model"
+	"gorm.io/gorm"
+)
+
+type ProjectResultRepository interface {
+	FindScoredAssessments(eventID uint) (assessments []model.ScoredAssessment, err error)
+	FindByEventID(eventID uint) (results []model.ProjectResult, err error)
+	IsFrozen(eventID uint) (bool, error)
+	CreateBatch(results []model.ProjectResult) error
+}
+
+type ProjectResultRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewProjectResultRepository(db *gorm.DB) ProjectResultRepository {
+	return &ProjectResultRepositoryImpl{DB: db}
+}
+
+// FindScoredAssessments returns every score given to the event's projects on its active criteria.
+// Only scores of the event's current judges count, and once the event assigns judges only those of the
+// assigned judges. Scores of judges who declared a conflict of interest with the project are left out.
+func (repository *ProjectResultRepositoryImpl) FindScoredAssessments(eventID uint) (assessments []model.ScoredAssessment, err error) {
+	err = repository.DB.Table("project_assessments pa").
+		Select(`pa.project_id, p.name as project_name, p.team_id, pa.judge_id, pa.criteria_id, c.criteria,
+			c.percentage_val, c.score_start, c.score_end, pa.score`).
+		Joins("inner join projects p on p.id = pa.project_id").
+		Joins("inner join event_assessment_criteria c on c.id = pa.criteria_id").
+		Where("p.event_id = ? AND c.is_active = ? AND pa.deleted_at IS NULL AND p.deleted_at IS NULL AND c.deleted_at IS NULL",
+			eventID, true).
+		Where(`EXISTS (SELECT 1 FROM event_judges ej
+			WHERE ej.event_id = p.event_id AND ej.judge_id = pa.judge_id AND ej.deleted_at IS NULL)`).
+		Where(`NOT EXISTS (SELECT 1 FROM project_judges apj INNER JOIN projects ap ON ap.id = apj.project_id
+			WHERE ap.event_id = p.event_id AND apj.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM project_judges pj
+			WHERE pj.project_id = pa.project_id AND pj.judge_id = pa.judge_id AND pj.deleted_at IS NULL)`).
+		Where("NOT EXISTS (SELECT 1 FROM judge_conflicts jc WHERE jc.project_id = pa.project_id AND jc.judge_id = pa.judge_id)").
+		Order("pa.project_id ASC, c.id ASC").
+		Find(&assessments).Error
+	return
+}
+
+func (repository *ProjectResultRepositoryImpl) FindByEventID(eventID uint) (results []model.ProjectResult, err error) {
+	err = repository.DB.Preload("Project").
+		Where("event_id = ? AND deleted_at IS NULL", eventID).
+		Order("`rank` ASC, project_id ASC").
+		Find(&results).Error
+	return
+}
+
+func (repository *ProjectResultRepositoryImpl) IsFrozen(eventID uint) (bool, error) {
+	var total int64
+	if err := repository.DB.Model(&model.ProjectResult{}).
+		Where("event_id = ? AND deleted_at IS NULL", eventID).
+		Count(&total).Error; err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+func (repository *ProjectResultRepositoryImpl) CreateBatch(results []model.ProjectResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	tx := repository.DB.Begin()
+	if err := tx.Create(&results).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// scoredAssessmentsQuery returns the query FindScoredAssessments sends, built without a database
+func scoredAssessmentsQuery(t *testing.T) string {
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var query string
+	if err = db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		query = strings.Join(strings.Fields(tx.Statement.SQL.String()), " ")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = NewProjectResultRepository(db).FindScoredAssessments(1); err != nil {
+		t.Fatalf("FindScoredAssessments returned %v", err)
+	}
+	return query
+}
+
+func TestFindScoredAssessmentsCountsOnlyCurrentJudges(t *testing.T) {
+	query := scoredAssessmentsQuery(t)
+
+	want := "EXISTS (SELECT 1 FROM event_judges ej WHERE ej.event_id = p.event_id AND ej.judge_id = pa.judge_id AND ej.deleted_at IS NULL)"
+	if !strings.Contains(query, want) {
+		t.Errorf("query doesn't leave out judges removed from the event:\n%s", query)
+	}
+}
+
+func TestFindScoredAssessmentsCountsOnlyAssignedJudges(t *testing.T) {
+	query := scoredAssessmentsQuery(t)
+
+	want := "(NOT EXISTS (SELECT 1 FROM project_judges apj INNER JOIN projects ap ON ap.id = apj.project_id " +
+		"WHERE ap.event_id = p.event_id AND apj.deleted_at IS NULL) " +
+		"OR EXISTS (SELECT 1 FROM project_judges pj WHERE pj.project_id = pa.project_id AND pj.judge_id = pa.judge_id AND pj.deleted_at IS NULL))"
+	if !strings.Contains(query, want) {
+		t.Errorf("query doesn't limit an event with assignments to the assigned judges:\n%s", query)
+	}
+}
+
+func TestFindScoredAssessmentsLeavesOutConflicts(t *testing.T) {
+	query := scoredAssessmentsQuery(t)
+
+	want := "NOT EXISTS (SELECT 1 FROM judge_conflicts jc WHERE jc.project_id = pa.project_id AND jc.judge_id = pa.judge_id)"
+	if !strings.Contains(query, want) {
+		t.Errorf("query doesn't leave out conflicted judges:\n%s", query)
+	}
+}
@@ -14,7 +14,7 @@ func ProjectRouter(group *gin.RouterGroup) {
 		project.GetProjectController().Create,
 	)
 	group.POST("/:id/assessments",
-		middlewares.RolePermission(constants.UserJudge),
+		middlewares.Permission(constants.PermissionProjectAssess),
 		project.GetProjectAssessmentController().Create,
 	)
 	group.PUT("/:id",
@@ -22,20 +22,56 @@ func ProjectRouter(group *gin.RouterGroup) {
 		project.GetProjectController().Update,
 	)
 	group.PUT("/:id/status/:status",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionProjectManage),
 		project.GetProjectController().UpdateStatus,
 	)
+	group.POST("/assignments/:event_id",
+		middlewares.Permission(constants.PermissionProjectManage),
+		project.GetProjectJudgeController().AutoAssign,
+	)
+	group.POST("/:id/judges",
+		middlewares.Permission(constants.PermissionProjectManage),
+		project.GetProjectJudgeController().Assign,
+	)
+	group.DELETE("/:id/judges/:judge_id",
+		middlewares.Permission(constants.PermissionProjectManage),
+		project.GetProjectJudgeController().Unassign,
+	)
+	group.GET("/:id/judges",
+		middlewares.Permission(constants.PermissionProjectManage),
+		project.GetProjectJudgeController().GetByProjectID,
+	)
+	group.POST("/:id/conflicts",
+		middlewares.Permission(constants.PermissionProjectManage, constants.PermissionProjectConflictDeclare),
+		project.GetProjectJudgeController().DeclareConflict,
+	)
+	group.DELETE("/:id/conflicts/:conflict_id",
+		middlewares.Permission(constants.PermissionProjectManage),
+		project.GetProjectJudgeController().RemoveConflict,
+	)
+	group.GET("/:id/conflicts",
+		middlewares.Permission(constants.PermissionProjectManage),
+		project.GetProjectJudgeController().GetConflictsByProjectID,
+	)
+	group.POST("/leaderboards/:event_id/freeze",
+		middlewares.Permission(constants.PermissionLeaderboardFreeze),
+		project.GetProjectRankingController().Freeze,
+	)
+	group.GET("/leaderboards/:event_id",
+		middlewares.Permission(constants.PermissionLeaderboardView),
+		project.GetProjectRankingController().GetLeaderboard,
+	)
 	group.GET("/:id", project.GetProjectController().GetDetail)
 	group.GET("/:id/assessments",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionProjectManage),
 		project.GetProjectAssessmentController().GetByProjectID,
 	)
 	group.GET("/:id/assessments/judge",
-		middlewares.RolePermission(constants.UserJudge),
+		middlewares.Permission(constants.PermissionProjectAssess),
 		project.GetProjectAssessmentController().GetByJudgeAndProjectID,
 	)
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin, constants.UserJudge),
+		middlewares.Permission(constants.PermissionProjectView),
 		project.GetProjectController().GetAll,
 	)
 }
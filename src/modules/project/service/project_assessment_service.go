@@ -1,7 +1,10 @@
 package service
 
 import (
+	evm "be-sagara-hackathon/src/modules/event/model"
 	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/project/model"
 	"be-sagara-hackathon/src/modules/project/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
@@ -9,11 +12,12 @@ import (
 	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"context"
+	"fmt"
 )
 
 type ProjectAssessmentService interface {
 	CreateBatch(ctx context.Context, projectID uint, request model.CreateBatchProjectAssessmentRequest) error
-	GetByProjectID(projectID uint) (assessments []model.GetByProjectIDResponse, err error)
+	GetByProjectID(ctx context.Context, projectID uint) (assessments []model.GetByProjectIDResponse, err error)
 	GetByJudgeAndProjectID(ctx context.Context, projectID uint) (assessments []model.ProjectAssessment, err error)
 }
 
@@ -22,6 +26,12 @@ type ProjectAssessmentServiceImpl struct {
 	ProjectRepo    repository.ProjectRepository
 	CriteriaRepo   eve.EventAssessmentCriteriaRepository
 	EventJudgeRepo eve.EventJudgeRepository
+	TimelineGuard  evs.EventTimelineGuard
+	ResultRepo     repository.ProjectResultRepository
+	JudgeRepo      repository.ProjectJudgeRepository
+	ConflictRepo   repository.JudgeConflictRepository
+	Scope          evs.EventScope
+	Audit          ads.Recorder
 }
 
 func NewProjectAssessmentService(
@@ -29,12 +39,24 @@ func NewProjectAssessmentService(
 	projectRepo repository.ProjectRepository,
 	criteriaRepo eve.EventAssessmentCriteriaRepository,
 	eventJudgeRepo eve.EventJudgeRepository,
+	timelineGuard evs.EventTimelineGuard,
+	resultRepo repository.ProjectResultRepository,
+	judgeRepo repository.ProjectJudgeRepository,
+	conflictRepo repository.JudgeConflictRepository,
+	scope evs.EventScope,
+	audit ads.Recorder,
 ) ProjectAssessmentService {
 	return &ProjectAssessmentServiceImpl{
 		Repository:     repo,
 		ProjectRepo:    projectRepo,
 		CriteriaRepo:   criteriaRepo,
 		EventJudgeRepo: eventJudgeRepo,
+		TimelineGuard:  timelineGuard,
+		ResultRepo:     resultRepo,
+		JudgeRepo:      judgeRepo,
+		ConflictRepo:   conflictRepo,
+		Scope:          scope,
+		Audit:          audit,
 	}
 }
 
@@ -45,7 +67,7 @@ func (service *ProjectAssessmentServiceImpl) CreateBatch(ctx context.Context, pr
 		return err
 	}
 
-	if project.Status != constants.ProjectStatusSubmitted {
+	if project.Status != constants.ProjectStatusSubmitted && project.Status != constants.ProjectStatusAssessed {
 		return e.ErrProjectStatusShouldBeSubmitted
 	}
 
@@ -56,10 +78,47 @@ func (service *ProjectAssessmentServiceImpl) CreateBatch(ctx context.Context, pr
 		return e.ErrForbidden
 	}
 
+	if err = service.checkAssignment(project, authenticatedUser.ID); err != nil {
+		return err
+	}
+
+	if err = service.TimelineGuard.CheckPhase(project.EventID, constants.TimelinePhaseJudging); err != nil {
+		return err
+	}
+
+	frozen, err := service.ResultRepo.IsFrozen(project.EventID)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		return e.ErrLeaderboardFrozen
+	}
+
+	activeCriteria, err := service.CriteriaRepo.FindActiveByEventID(project.EventID)
+	if err != nil {
+		return err
+	}
+
+	criteriaMap := map[uint]evm.EventAssessmentCriteria{}
+	for k, v := range activeCriteria {
+		criteriaMap[v.ID] = activeCriteria[k]
+	}
+
 	var data []model.ProjectAssessment
+	scored := map[uint]bool{}
 	for k, v := range request.Assessments {
-		if _, err = service.CriteriaRepo.FindOne(v.CriteriaID); err != nil {
-			return err
+		criteria, ok := criteriaMap[v.CriteriaID]
+		if !ok {
+			return e.ErrInvalidAssessmentCriteria
+		}
+
+		if scored[v.CriteriaID] {
+			return e.ErrDuplicateAssessmentCriteria
+		}
+		scored[v.CriteriaID] = true
+
+		if v.Score < criteria.ScoreStart || v.Score > criteria.ScoreEnd {
+			return e.ErrScoreOutOfRange
 		}
 
 		data = append(data, model.ProjectAssessment{
@@ -71,13 +130,126 @@ func (service *ProjectAssessmentServiceImpl) CreateBatch(ctx context.Context, pr
 		})
 	}
 
-	if err = service.Repository.CreateBatch(data); err != nil {
+	if len(scored) != len(activeCriteria) {
+		return e.ErrIncompleteAssessment
+	}
+
+	requiredJudges, err := service.findRequiredJudges(project)
+	if err != nil {
+		return err
+	}
+
+	previous, err := service.Repository.FindByProjectIDAndJudgeID(projectID, authenticatedUser.ID)
+	if err != nil {
 		return err
 	}
+
+	if err = service.Repository.UpsertBatch(data, requiredJudges); err != nil {
+		return err
+	}
+
+	var before interface{}
+	if len(previous) > 0 {
+		before = scoresByCriteria(previous)
+	}
+	service.Audit.Record(ctx, constants.AuditAssess, constants.AuditEntityProject, projectID, before, scoresByCriteria(data))
 	return nil
 }
 
-func (service *ProjectAssessmentServiceImpl) GetByProjectID(projectID uint) (assessments []model.GetByProjectIDResponse, err error) {
+// scoresByCriteria flattens a judge's assessments of a project for the audit log
+func scoresByCriteria(assessments []model.ProjectAssessment) map[string]uint {
+	scores := map[string]uint{}
+	for _, v := range assessments {
+		scores[fmt.Sprintf("criteria_%d", v.CriteriaID)] = v.Score
+	}
+	return scores
+}
+
+// checkAssignment blocks judges with a conflict of interest. Once judges have been assigned to the
+// event's projects, a judge can only score the projects assigned to them.
+func (service *ProjectAssessmentServiceImpl) checkAssignment(project model.Project, judgeID uint) error {
+	conflicted, err := service.ConflictRepo.IsConflicted(project.ID, judgeID)
+	if err != nil {
+		return err
+	}
+	if conflicted {
+		return e.ErrJudgeConflict
+	}
+
+	hasAssignment, err := service.JudgeRepo.HasAssignmentByEventID(project.EventID)
+	if err != nil || !hasAssignment {
+		return err
+	}
+
+	_, err = service.JudgeRepo.FindOneByProjectIDAndJudgeID(project.ID, judgeID)
+	if err != nil && err == e.ErrDataNotFound {
+		return e.ErrForbidden
+	}
+	return err
+}
+
+// findRequiredJudges returns the judges who have to finish before the project is assessed:
+// the assigned judges, or every judge of the event when nobody is assigned. Judges who left the event
+// or declared a conflict are not required, and their scores don't count towards the project either.
+func (service *ProjectAssessmentServiceImpl) findRequiredJudges(project model.Project) ([]uint, error) {
+	eventJudges, err := service.EventJudgeRepo.FindManyByEventID(project.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []uint
+	for _, v := range eventJudges {
+		candidates = append(candidates, v.JudgeID)
+	}
+
+	hasAssignment, err := service.JudgeRepo.HasAssignmentByEventID(project.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasAssignment {
+		assignments, err := service.JudgeRepo.FindByProjectID(project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		assigned := map[uint]bool{}
+		for _, v := range assignments {
+			assigned[v.JudgeID] = true
+		}
+
+		var kept []uint
+		for _, judgeID := range candidates {
+			if assigned[judgeID] {
+				kept = append(kept, judgeID)
+			}
+		}
+		candidates = kept
+	}
+
+	var required []uint
+	for _, judgeID := range candidates {
+		conflicted, err := service.ConflictRepo.IsConflicted(project.ID, judgeID)
+		if err != nil {
+			return nil, err
+		}
+		if !conflicted {
+			required = append(required, judgeID)
+		}
+	}
+	return required, nil
+}
+
+func (service *ProjectAssessmentServiceImpl) GetByProjectID(ctx context.Context, projectID uint) (assessments []model.GetByProjectIDResponse, err error) {
+	project, err := service.ProjectRepo.FindOne(projectID)
+	if err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, project.EventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+
 	data, err := service.Repository.FindByProjectID(projectID)
 	if err != nil {
 		return
@@ -0,0 +1,246 @@
+package service
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/project/model"
+	"be-sagara-hackathon/src/modules/project/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"reflect"
+	"testing"
+)
+
+type fakeAssessedProjectRepository struct {
+	repository.ProjectRepository
+	project model.Project
+}
+
+func (repository *fakeAssessedProjectRepository) FindOne(id uint) (model.Project, error) {
+	if id != repository.project.ID {
+		return model.Project{}, e.ErrDataNotFound
+	}
+	return repository.project, nil
+}
+
+type fakeCriteriaRepository struct {
+	eve.EventAssessmentCriteriaRepository
+	criteria []evm.EventAssessmentCriteria
+}
+
+func (repository *fakeCriteriaRepository) FindActiveByEventID(uint) ([]evm.EventAssessmentCriteria, error) {
+	return repository.criteria, nil
+}
+
+// fakeEventJudgeRepository knows the judges of the project's event
+type fakeEventJudgeRepository struct {
+	eve.EventJudgeRepository
+	judges []uint
+}
+
+func (repository *fakeEventJudgeRepository) FindOneByJudgeIDAndEventID(judgeID, eventID uint) (judge evm.EventJudge, err error) {
+	if !helper.UintInSlice(judgeID, repository.judges) {
+		return judge, e.ErrDataNotFound
+	}
+	judge.JudgeID, judge.EventID = judgeID, eventID
+	return
+}
+
+func (repository *fakeEventJudgeRepository) FindManyByEventID(eventID uint) (judges []evm.EventJudgeLite, err error) {
+	for _, judgeID := range repository.judges {
+		judges = append(judges, evm.EventJudgeLite{EventID: eventID, JudgeID: judgeID})
+	}
+	return
+}
+
+type fakeTimelineGuard struct {
+	evs.EventTimelineGuard
+	err error
+}
+
+func (guard fakeTimelineGuard) CheckPhase(uint, string) error {
+	return guard.err
+}
+
+func (repository *fakeProjectResultRepository) IsFrozen(uint) (bool, error) {
+	return false, nil
+}
+
+// fakeProjectJudgeRepository holds the assignments of the project, the event has none when it's empty
+type fakeProjectJudgeRepository struct {
+	repository.ProjectJudgeRepository
+	assigned []uint
+}
+
+func (repository *fakeProjectJudgeRepository) HasAssignmentByEventID(uint) (bool, error) {
+	return len(repository.assigned) > 0, nil
+}
+
+func (repository *fakeProjectJudgeRepository) FindByProjectID(projectID uint) (assignments []model.ProjectJudgeLite, err error) {
+	for _, judgeID := range repository.assigned {
+		assignments = append(assignments, model.ProjectJudgeLite{ProjectID: projectID, JudgeID: judgeID})
+	}
+	return
+}
+
+func (repository *fakeProjectJudgeRepository) FindOneByProjectIDAndJudgeID(projectID, judgeID uint) (assignment model.ProjectJudge, err error) {
+	if !helper.UintInSlice(judgeID, repository.assigned) {
+		return assignment, e.ErrDataNotFound
+	}
+	assignment.ProjectID, assignment.JudgeID = projectID, judgeID
+	return
+}
+
+type fakeJudgeConflictRepository struct {
+	repository.JudgeConflictRepository
+	conflicted []uint
+}
+
+func (repository *fakeJudgeConflictRepository) IsConflicted(_, judgeID uint) (bool, error) {
+	return helper.UintInSlice(judgeID, repository.conflicted), nil
+}
+
+// fakeProjectAssessmentRepository keeps the last batch and the judges it was saved for
+type fakeProjectAssessmentRepository struct {
+	repository.ProjectAssessmentRepository
+	saved          []model.ProjectAssessment
+	requiredJudges []uint
+}
+
+func (repository *fakeProjectAssessmentRepository) FindByProjectIDAndJudgeID(uint, uint) ([]model.ProjectAssessment, error) {
+	return nil, nil
+}
+
+func (repository *fakeProjectAssessmentRepository) UpsertBatch(assessments []model.ProjectAssessment, requiredJudges []uint) error {
+	repository.saved, repository.requiredJudges = assessments, requiredJudges
+	return nil
+}
+
+type fakeRecorder struct{}
+
+func (fakeRecorder) Record(context.Context, string, string, uint, interface{}, interface{}) {}
+
+func newTestAssessmentService() (*ProjectAssessmentServiceImpl, *fakeProjectAssessmentRepository) {
+	project := model.Project{EventID: 1, Status: constants.ProjectStatusSubmitted}
+	project.ID = 5
+
+	var criteria []evm.EventAssessmentCriteria
+	for _, id := range []uint{11, 12} {
+		c := evm.EventAssessmentCriteria{EventID: 1, ScoreStart: 1, ScoreEnd: 10, IsActive: true}
+		c.ID = id
+		criteria = append(criteria, c)
+	}
+
+	assessments := &fakeProjectAssessmentRepository{}
+	return &ProjectAssessmentServiceImpl{
+		Repository:     assessments,
+		ProjectRepo:    &fakeAssessedProjectRepository{project: project},
+		CriteriaRepo:   &fakeCriteriaRepository{criteria: criteria},
+		EventJudgeRepo: &fakeEventJudgeRepository{judges: []uint{3, 4, 6}},
+		TimelineGuard:  fakeTimelineGuard{},
+		ResultRepo:     &fakeProjectResultRepository{},
+		JudgeRepo:      &fakeProjectJudgeRepository{},
+		ConflictRepo:   &fakeJudgeConflictRepository{conflicted: []uint{6}},
+		Audit:          fakeRecorder{},
+	}, assessments
+}
+
+func judgeContext(judgeID uint) context.Context {
+	judge := um.User{Email: "judge@example.com"}
+	judge.ID = judgeID
+	return context.WithValue(context.Background(), "user", judge)
+}
+
+func scores(scores ...uint) model.CreateBatchProjectAssessmentRequest {
+	var request model.CreateBatchProjectAssessmentRequest
+	for i := 0; i+1 < len(scores); i += 2 {
+		request.Assessments = append(request.Assessments, model.ProjectAssessmentRequest{CriteriaID: scores[i], Score: scores[i+1]})
+	}
+	return request
+}
+
+func TestCreateBatch(t *testing.T) {
+	service, assessments := newTestAssessmentService()
+
+	if err := service.CreateBatch(judgeContext(3), 5, scores(11, 7, 12, 10)); err != nil {
+		t.Fatalf("batch returned %v", err)
+	}
+	if len(assessments.saved) != 2 || assessments.saved[0].JudgeID != 3 || assessments.saved[1].Score != 10 {
+		t.Errorf("saved %+v, want the 2 scores of judge 3", assessments.saved)
+	}
+	if want := []uint{3, 4}; !reflect.DeepEqual(assessments.requiredJudges, want) {
+		t.Errorf("required judges = %v, want the event judges without a conflict %v", assessments.requiredJudges, want)
+	}
+}
+
+func TestCreateBatchAssignedJudges(t *testing.T) {
+	service, assessments := newTestAssessmentService()
+	service.JudgeRepo = &fakeProjectJudgeRepository{assigned: []uint{4, 6, 9}}
+
+	if err := service.CreateBatch(judgeContext(3), 5, scores(11, 7, 12, 10)); err != e.ErrForbidden {
+		t.Errorf("batch of an unassigned judge returned %v, want %v", err, e.ErrForbidden)
+	}
+
+	if err := service.CreateBatch(judgeContext(4), 5, scores(11, 7, 12, 10)); err != nil {
+		t.Fatalf("batch of an assigned judge returned %v", err)
+	}
+	if want := []uint{4}; !reflect.DeepEqual(assessments.requiredJudges, want) {
+		t.Errorf("required judges = %v, want the assigned event judges without a conflict %v", assessments.requiredJudges, want)
+	}
+}
+
+func TestCreateBatchRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		judgeID uint
+		request model.CreateBatchProjectAssessmentRequest
+		setup   func(service *ProjectAssessmentServiceImpl)
+		want    error
+	}{
+		{name: "criteria of another event", judgeID: 3, request: scores(11, 7, 13, 7), want: e.ErrInvalidAssessmentCriteria},
+		{name: "criteria scored twice", judgeID: 3, request: scores(11, 7, 11, 8), want: e.ErrDuplicateAssessmentCriteria},
+		{name: "score above the range", judgeID: 3, request: scores(11, 7, 12, 11), want: e.ErrScoreOutOfRange},
+		{name: "score below the range", judgeID: 3, request: scores(11, 0, 12, 7), want: e.ErrScoreOutOfRange},
+		{name: "criteria left out", judgeID: 3, request: scores(11, 7), want: e.ErrIncompleteAssessment},
+		{name: "judge of another event", judgeID: 8, request: scores(11, 7, 12, 7), want: e.ErrForbidden},
+		{name: "conflicted judge", judgeID: 6, request: scores(11, 7, 12, 7), want: e.ErrJudgeConflict},
+		{
+			name:    "project not submitted",
+			judgeID: 3,
+			request: scores(11, 7, 12, 7),
+			setup: func(service *ProjectAssessmentServiceImpl) {
+				service.ProjectRepo.(*fakeAssessedProjectRepository).project.Status = constants.ProjectStatusDraft
+			},
+			want: e.ErrProjectStatusShouldBeSubmitted,
+		},
+		{
+			name:    "judging closed",
+			judgeID: 3,
+			request: scores(11, 7, 12, 7),
+			setup: func(service *ProjectAssessmentServiceImpl) {
+				service.TimelineGuard = fakeTimelineGuard{err: e.ErrOutsideTimelinePhase}
+			},
+			want: e.ErrOutsideTimelinePhase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, assessments := newTestAssessmentService()
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			if err := service.CreateBatch(judgeContext(tt.judgeID), 5, tt.request); err != tt.want {
+				t.Errorf("batch with %s returned %v, want %v", tt.name, err, tt.want)
+			}
+			if assessments.saved != nil {
+				t.Errorf("batch with %s saved %+v", tt.name, assessments.saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/project/model"
+	"be-sagara-hackathon/src/modules/project/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+	"sort"
+)
+
+type ProjectJudgeService interface {
+	AutoAssign(
+		ctx context.Context,
+		eventID uint,
+		request model.AutoAssignJudgeRequest,
+	) (response model.AutoAssignJudgeResponse, err error)
+	Assign(ctx context.Context, projectID uint, request model.ProjectJudgeRequest) error
+	Unassign(ctx context.Context, projectID, judgeID uint) error
+	GetByProjectID(ctx context.Context, projectID uint) (assignments []model.ProjectJudgeLite, err error)
+	DeclareConflict(ctx context.Context, projectID uint, request model.JudgeConflictRequest) error
+	RemoveConflict(ctx context.Context, projectID, conflictID uint) error
+	GetConflictsByProjectID(ctx context.Context, projectID uint) (conflicts []model.JudgeConflictLite, err error)
+}
+
+type ProjectJudgeServiceImpl struct {
+	Repository     repository.ProjectJudgeRepository
+	ConflictRepo   repository.JudgeConflictRepository
+	ProjectRepo    repository.ProjectRepository
+	EventRepo      eve.EventRepository
+	EventJudgeRepo eve.EventJudgeRepository
+	Scope          evs.EventScope
+	Audit          ads.Recorder
+}
+
+func NewProjectJudgeService(
+	repo repository.ProjectJudgeRepository,
+	conflictRepo repository.JudgeConflictRepository,
+	projectRepo repository.ProjectRepository,
+	eventRepo eve.EventRepository,
+	eventJudgeRepo eve.EventJudgeRepository,
+	scope evs.EventScope,
+	audit ads.Recorder,
+) ProjectJudgeService {
+	return &ProjectJudgeServiceImpl{
+		Repository:     repo,
+		ConflictRepo:   conflictRepo,
+		ProjectRepo:    projectRepo,
+		EventRepo:      eventRepo,
+		EventJudgeRepo: eventJudgeRepo,
+		Scope:          scope,
+		Audit:          audit,
+	}
+}
+
+// AutoAssign gives every submitted project JudgesPerProject judges, always picking the judges with the
+// fewest projects so far. Manual assignments are kept and count towards both the project and the judge.
+func (service *ProjectJudgeServiceImpl) AutoAssign(
+	ctx context.Context,
+	eventID uint,
+	request model.AutoAssignJudgeRequest,
+) (response model.AutoAssignJudgeResponse, err error) {
+	if _, err = service.EventRepo.FindOne(eventID); err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+
+	projects, err := service.ProjectRepo.FindManyByEventIDAndStatus(eventID, []string{
+		constants.ProjectStatusSubmitted,
+		constants.ProjectStatusAssessed,
+	})
+	if err != nil {
+		return
+	}
+
+	judges, err := service.EventJudgeRepo.FindManyByEventID(eventID)
+	if err != nil {
+		return
+	}
+
+	existing, err := service.Repository.FindByEventID(eventID)
+	if err != nil {
+		return
+	}
+
+	conflicts, err := service.ConflictRepo.FindByEventID(eventID)
+	if err != nil {
+		return
+	}
+
+	load := map[uint]int{}
+	assigned := map[uint]map[uint]bool{}
+	blocked := map[uint]map[uint]bool{}
+	for _, v := range judges {
+		load[v.JudgeID] = 0
+	}
+	for _, v := range existing {
+		if !v.IsManual {
+			continue
+		}
+		load[v.JudgeID]++
+		if assigned[v.ProjectID] == nil {
+			assigned[v.ProjectID] = map[uint]bool{}
+		}
+		assigned[v.ProjectID][v.JudgeID] = true
+	}
+	for _, v := range conflicts {
+		if blocked[v.ProjectID] == nil {
+			blocked[v.ProjectID] = map[uint]bool{}
+		}
+		blocked[v.ProjectID][v.JudgeID] = true
+	}
+
+	response.UnfilledProjectIDs = []uint{}
+	var assignments []model.ProjectJudge
+	for _, project := range projects {
+		var candidates []uint
+		for _, v := range judges {
+			if !assigned[project.ID][v.JudgeID] && !blocked[project.ID][v.JudgeID] {
+				candidates = append(candidates, v.JudgeID)
+			}
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if load[candidates[i]] != load[candidates[j]] {
+				return load[candidates[i]] < load[candidates[j]]
+			}
+			return candidates[i] < candidates[j]
+		})
+
+		needed := int(request.JudgesPerProject) - len(assigned[project.ID])
+		for _, judgeID := range candidates {
+			if needed <= 0 {
+				break
+			}
+
+			assignments = append(assignments, model.ProjectJudge{
+				BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+				ProjectID:  project.ID,
+				JudgeID:    judgeID,
+			})
+			load[judgeID]++
+			needed--
+		}
+
+		if needed > 0 {
+			response.UnfilledProjectIDs = append(response.UnfilledProjectIDs, project.ID)
+		}
+	}
+
+	if err = service.Repository.ReplaceAutoAssignments(eventID, assignments); err != nil {
+		return
+	}
+
+	response.TotalAssigned = len(assignments)
+	service.Audit.Record(ctx, constants.AuditAssign, constants.AuditEntityEvent, eventID, nil, map[string]interface{}{
+		"judges_per_project": request.JudgesPerProject,
+		"total_assigned":     response.TotalAssigned,
+		"unfilled_projects":  len(response.UnfilledProjectIDs),
+	})
+	return
+}
+
+func (service *ProjectJudgeServiceImpl) Assign(ctx context.Context, projectID uint, request model.ProjectJudgeRequest) error {
+	project, err := service.ProjectRepo.FindOne(projectID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Scope.Check(ctx, project.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
+	if err = service.checkEventJudge(request.JudgeID, project.EventID); err != nil {
+		return err
+	}
+
+	conflicted, err := service.ConflictRepo.IsConflicted(projectID, request.JudgeID)
+	if err != nil {
+		return err
+	}
+	if conflicted {
+		return e.ErrJudgeConflict
+	}
+
+	_, err = service.Repository.FindOneByProjectIDAndJudgeID(projectID, request.JudgeID)
+	if err != nil && err != e.ErrDataNotFound {
+		return err
+	}
+	if err == nil {
+		return e.ErrJudgeAlreadyAssigned
+	}
+
+	if err = service.Repository.Save(model.ProjectJudge{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		ProjectID:  projectID,
+		JudgeID:    request.JudgeID,
+		IsManual:   true,
+	}); err != nil {
+		return err
+	}
+
+	assignment, _ := service.Repository.FindOneByProjectIDAndJudgeID(projectID, request.JudgeID)
+	service.Audit.Record(ctx, constants.AuditAssign, constants.AuditEntityProjectJudge, assignment.ID, nil, assignment)
+	return nil
+}
+
+func (service *ProjectJudgeServiceImpl) Unassign(ctx context.Context, projectID, judgeID uint) error {
+	if err := service.checkProjectScope(ctx, projectID); err != nil {
+		return err
+	}
+
+	assignment, err := service.Repository.FindOneByProjectIDAndJudgeID(projectID, judgeID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Repository.Delete(projectID, judgeID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditUnassign, constants.AuditEntityProjectJudge, assignment.ID, assignment, nil)
+	return nil
+}
+
+func (service *ProjectJudgeServiceImpl) GetByProjectID(ctx context.Context, projectID uint) (assignments []model.ProjectJudgeLite, err error) {
+	if err = service.checkProjectScope(ctx, projectID); err != nil {
+		return
+	}
+	return service.Repository.FindByProjectID(projectID)
+}
+
+// DeclareConflict records a conflict of interest. Judges declare it for themselves,
+// admins have to tell which judge it is for.
+func (service *ProjectJudgeServiceImpl) DeclareConflict(ctx context.Context, projectID uint, request model.JudgeConflictRequest) error {
+	authenticatedUser := ctx.Value("user").(um.User)
+	if authenticatedUser.UserRole.Name == constants.UserJudge {
+		request.JudgeID = authenticatedUser.ID
+	}
+	if request.JudgeID == 0 {
+		return e.ErrJudgeIDRequired
+	}
+
+	project, err := service.ProjectRepo.FindOne(projectID)
+	if err != nil {
+		return err
+	}
+
+	if authenticatedUser.UserRole.Name != constants.UserJudge {
+		if err = service.Scope.Check(ctx, project.EventID, constants.EventStaffOrganizer); err != nil {
+			return err
+		}
+	}
+
+	if err = service.checkEventJudge(request.JudgeID, project.EventID); err != nil {
+		return err
+	}
+
+	conflicted, err := service.ConflictRepo.IsConflicted(projectID, request.JudgeID)
+	if err != nil {
+		return err
+	}
+	if conflicted {
+		return e.ErrConflictAlreadyDeclared
+	}
+
+	conflict, err := service.ConflictRepo.Save(model.JudgeConflict{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		ProjectID:  projectID,
+		JudgeID:    request.JudgeID,
+		Reason:     request.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityJudgeConflict, conflict.ID, nil, conflict)
+	return nil
+}
+
+func (service *ProjectJudgeServiceImpl) RemoveConflict(ctx context.Context, projectID, conflictID uint) error {
+	if err := service.checkProjectScope(ctx, projectID); err != nil {
+		return err
+	}
+
+	conflict, err := service.ConflictRepo.FindOne(conflictID)
+	if err != nil {
+		return err
+	}
+	if conflict.ProjectID != projectID {
+		return e.ErrDataNotFound
+	}
+
+	if err = service.ConflictRepo.Delete(conflictID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityJudgeConflict, conflictID, conflict, nil)
+	return nil
+}
+
+func (service *ProjectJudgeServiceImpl) GetConflictsByProjectID(ctx context.Context, projectID uint) (conflicts []model.JudgeConflictLite, err error) {
+	if err = service.checkProjectScope(ctx, projectID); err != nil {
+		return
+	}
+	return service.ConflictRepo.FindByProjectID(projectID)
+}
+
+func (service *ProjectJudgeServiceImpl) checkEventJudge(judgeID, eventID uint) error {
+	_, err := service.EventJudgeRepo.FindOneByJudgeIDAndEventID(judgeID, eventID)
+	if err != nil && err == e.ErrDataNotFound {
+		return e.ErrNotEventJudge
+	}
+	return err
+}
+
+// checkProjectScope makes sure the project exists and belongs to an event the user organizes
+func (service *ProjectJudgeServiceImpl) checkProjectScope(ctx context.Context, projectID uint) error {
+	project, err := service.ProjectRepo.FindOne(projectID)
+	if err != nil {
+		return err
+	}
+	return service.Scope.Check(ctx, project.EventID, constants.EventStaffOrganizer)
+}
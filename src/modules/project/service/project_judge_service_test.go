@@ -0,0 +1,216 @@
+package service
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/project/model"
+	"be-sagara-hackathon/src/modules/project/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+	"reflect"
+	"testing"
+)
+
+type fakeEventRepository struct {
+	eve.EventRepository
+}
+
+func (fakeEventRepository) FindOne(eventID uint) (event evm.Event, err error) {
+	event.ID = eventID
+	return
+}
+
+// fakeJudgedProjectRepository holds the submitted projects of the event
+type fakeJudgedProjectRepository struct {
+	repository.ProjectRepository
+	projects []model.ProjectLite
+}
+
+func (repository *fakeJudgedProjectRepository) FindManyByEventIDAndStatus(uint, []string) ([]model.ProjectLite, error) {
+	return repository.projects, nil
+}
+
+func (repository *fakeJudgedProjectRepository) FindOne(id uint) (project model.Project, err error) {
+	for _, v := range repository.projects {
+		if v.ID == id {
+			project.ID, project.EventID = v.ID, v.EventID
+			return
+		}
+	}
+	return project, e.ErrDataNotFound
+}
+
+type fakeEventScope struct {
+	evs.EventScope
+}
+
+func (fakeEventScope) Check(context.Context, uint, ...string) error {
+	return nil
+}
+
+// fakeAssignmentRepository keeps the assignments of the event
+type fakeAssignmentRepository struct {
+	repository.ProjectJudgeRepository
+	assignments []model.ProjectJudge
+}
+
+func (repository *fakeAssignmentRepository) FindByEventID(uint) ([]model.ProjectJudge, error) {
+	return repository.assignments, nil
+}
+
+func (repository *fakeAssignmentRepository) ReplaceAutoAssignments(_ uint, assignments []model.ProjectJudge) error {
+	kept := assignments
+	for _, v := range repository.assignments {
+		if v.IsManual {
+			kept = append(kept, v)
+		}
+	}
+	repository.assignments = kept
+	return nil
+}
+
+func (repository *fakeAssignmentRepository) FindOneByProjectIDAndJudgeID(projectID, judgeID uint) (model.ProjectJudge, error) {
+	for _, v := range repository.assignments {
+		if v.ProjectID == projectID && v.JudgeID == judgeID {
+			return v, nil
+		}
+	}
+	return model.ProjectJudge{}, e.ErrDataNotFound
+}
+
+func (repository *fakeAssignmentRepository) Save(assignment model.ProjectJudge) error {
+	repository.assignments = append(repository.assignments, assignment)
+	return nil
+}
+
+// fakeConflictRepository keeps the conflicts of the event
+type fakeConflictRepository struct {
+	repository.JudgeConflictRepository
+	conflicts []model.JudgeConflict
+}
+
+func (repository *fakeConflictRepository) FindByEventID(uint) ([]model.JudgeConflict, error) {
+	return repository.conflicts, nil
+}
+
+func (repository *fakeConflictRepository) IsConflicted(projectID, judgeID uint) (bool, error) {
+	for _, v := range repository.conflicts {
+		if v.ProjectID == projectID && v.JudgeID == judgeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (repository *fakeConflictRepository) Save(conflict model.JudgeConflict) (model.JudgeConflict, error) {
+	repository.conflicts = append(repository.conflicts, conflict)
+	return conflict, nil
+}
+
+// newTestProjectJudgeService sets up an event with judges 3, 4 and 6 and the submitted projects 1, 2 and 3.
+// Judge 6 is assigned to project 1 by hand, judge 4 has a conflict with project 2 and judges 3 and 4 with project 3.
+func newTestProjectJudgeService() (*ProjectJudgeServiceImpl, *fakeAssignmentRepository, *fakeConflictRepository) {
+	var projects []model.ProjectLite
+	for _, id := range []uint{1, 2, 3} {
+		projects = append(projects, model.ProjectLite{ID: id, EventID: 1})
+	}
+	assignments := &fakeAssignmentRepository{assignments: []model.ProjectJudge{
+		{ProjectID: 1, JudgeID: 6, IsManual: true},
+		{ProjectID: 2, JudgeID: 3},
+	}}
+	conflicts := &fakeConflictRepository{conflicts: []model.JudgeConflict{
+		{ProjectID: 2, JudgeID: 4},
+		{ProjectID: 3, JudgeID: 3},
+		{ProjectID: 3, JudgeID: 4},
+	}}
+
+	return &ProjectJudgeServiceImpl{
+		Repository:     assignments,
+		ConflictRepo:   conflicts,
+		ProjectRepo:    &fakeJudgedProjectRepository{projects: projects},
+		EventRepo:      fakeEventRepository{},
+		EventJudgeRepo: &fakeEventJudgeRepository{judges: []uint{3, 4, 6}},
+		Scope:          fakeEventScope{},
+		Audit:          fakeRecorder{},
+	}, assignments, conflicts
+}
+
+func adminContext() context.Context {
+	admin := um.User{Email: "admin@example.com", UserRole: &um.UserRole{Name: constants.UserAdmin}}
+	admin.ID = 1
+	return context.WithValue(context.Background(), "user", admin)
+}
+
+func TestAutoAssign(t *testing.T) {
+	service, assignments, _ := newTestProjectJudgeService()
+
+	response, err := service.AutoAssign(adminContext(), 1, model.AutoAssignJudgeRequest{JudgesPerProject: 2})
+	if err != nil {
+		t.Fatalf("auto assign returned %v", err)
+	}
+
+	// judge 6 starts with the manual assignment, so judge 3 is the least busy for project 1
+	var got [][2]uint
+	for _, v := range assignments.assignments {
+		got = append(got, [2]uint{v.ProjectID, v.JudgeID})
+	}
+	want := [][2]uint{{1, 3}, {2, 3}, {2, 6}, {3, 6}, {1, 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("assignments = %v, want %v", got, want)
+	}
+	if response.TotalAssigned != 4 || !reflect.DeepEqual(response.UnfilledProjectIDs, []uint{3}) {
+		t.Errorf("response = %+v, want 4 assigned and project 3 unfilled", response)
+	}
+}
+
+func TestAssign(t *testing.T) {
+	tests := []struct {
+		name    string
+		judgeID uint
+		want    error
+	}{
+		{name: "judge of the event", judgeID: 6},
+		{name: "judge of another event", judgeID: 8, want: e.ErrNotEventJudge},
+		{name: "conflicted judge", judgeID: 4, want: e.ErrJudgeConflict},
+		{name: "judge already assigned", judgeID: 3, want: e.ErrJudgeAlreadyAssigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, assignments, _ := newTestProjectJudgeService()
+
+			err := service.Assign(adminContext(), 2, model.ProjectJudgeRequest{JudgeID: tt.judgeID})
+			if err != tt.want {
+				t.Fatalf("assigning a %s returned %v, want %v", tt.name, err, tt.want)
+			}
+			if assigned := len(assignments.assignments) == 3; assigned != (tt.want == nil) {
+				t.Errorf("assignments = %+v after assigning a %s", assignments.assignments, tt.name)
+			}
+		})
+	}
+}
+
+func TestDeclareConflict(t *testing.T) {
+	service, _, conflicts := newTestProjectJudgeService()
+	judge := um.User{Email: "judge@example.com", UserRole: &um.UserRole{Name: constants.UserJudge}}
+	judge.ID = 6
+	ctx := context.WithValue(context.Background(), "user", judge)
+
+	if err := service.DeclareConflict(ctx, 2, model.JudgeConflictRequest{JudgeID: 3, Reason: "mentored the team"}); err != nil {
+		t.Fatalf("declare returned %v", err)
+	}
+	if last := conflicts.conflicts[len(conflicts.conflicts)-1]; last.ProjectID != 2 || last.JudgeID != 6 {
+		t.Errorf("declared %+v, want the conflict of judge 6 themselves", last)
+	}
+
+	if err := service.DeclareConflict(ctx, 2, model.JudgeConflictRequest{Reason: "again"}); err != e.ErrConflictAlreadyDeclared {
+		t.Errorf("declaring twice returned %v, want %v", err, e.ErrConflictAlreadyDeclared)
+	}
+
+	if err := service.DeclareConflict(adminContext(), 2, model.JudgeConflictRequest{Reason: "no judge"}); err != e.ErrJudgeIDRequired {
+		t.Errorf("declaring as admin without a judge returned %v, want %v", err, e.ErrJudgeIDRequired)
+	}
+}
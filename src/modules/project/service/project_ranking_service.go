@@ -0,0 +1,280 @@
+package service
+
+import (
+	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/project/model"
+	"be-sagara-hackathon/src/modules/project/repository"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+type ProjectRankingService interface {
+	GetLeaderboard(ctx context.Context, eventID uint) (response model.LeaderboardResponse, err error)
+	Freeze(ctx context.Context, eventID uint) (response model.LeaderboardResponse, err error)
+}
+
+type ProjectRankingServiceImpl struct {
+	Repository  repository.ProjectResultRepository
+	ProjectRepo repository.ProjectRepository
+	EventRepo   eve.EventRepository
+	Scope       evs.EventScope
+	Audit       ads.Recorder
+}
+
+func NewProjectRankingService(
+	repo repository.ProjectResultRepository,
+	projectRepo repository.ProjectRepository,
+	eventRepo eve.EventRepository,
+	scope evs.EventScope,
+	audit ads.Recorder,
+) ProjectRankingService {
+	return &ProjectRankingServiceImpl{
+		Repository:  repo,
+		ProjectRepo: projectRepo,
+		EventRepo:   eventRepo,
+		Scope:       scope,
+		Audit:       audit,
+	}
+}
+
+// GetLeaderboard returns the official result when the leaderboard has been frozen,
+// otherwise the ranking is calculated from the current assessments.
+func (service *ProjectRankingServiceImpl) GetLeaderboard(ctx context.Context, eventID uint) (response model.LeaderboardResponse, err error) {
+	if _, err = service.EventRepo.FindOne(eventID); err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+
+	response.EventID = eventID
+	results, err := service.Repository.FindByEventID(eventID)
+	if err != nil {
+		return
+	}
+
+	if len(results) > 0 {
+		response.IsOfficial = true
+		response.FrozenAt = &results[0].FrozenAt
+		response.Rankings, err = toRankings(results)
+		return
+	}
+
+	response.Rankings, err = service.calculate(eventID)
+	return
+}
+
+func (service *ProjectRankingServiceImpl) Freeze(ctx context.Context, eventID uint) (response model.LeaderboardResponse, err error) {
+	if _, err = service.EventRepo.FindOne(eventID); err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, eventID, constants.EventStaffOrganizer); err != nil {
+		return
+	}
+
+	frozen, err := service.Repository.IsFrozen(eventID)
+	if err != nil {
+		return
+	}
+	if frozen {
+		err = e.ErrLeaderboardFrozen
+		return
+	}
+
+	rankings, err := service.calculate(eventID)
+	if err != nil {
+		return
+	}
+	if len(rankings) == 0 {
+		err = e.ErrNoProjectToRank
+		return
+	}
+
+	frozenAt := time.Now()
+	var results []model.ProjectResult
+	for _, v := range rankings {
+		breakdown, errMarshal := json.Marshal(v.Breakdown)
+		if errMarshal != nil {
+			err = errMarshal
+			return
+		}
+
+		results = append(results, model.ProjectResult{
+			BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+			EventID:    eventID,
+			ProjectID:  v.ProjectID,
+			Rank:       v.Rank,
+			FinalScore: v.FinalScore,
+			JudgeCount: v.JudgeCount,
+			Breakdown:  string(breakdown),
+			FrozenAt:   frozenAt,
+		})
+	}
+
+	if err = service.Repository.CreateBatch(results); err != nil {
+		return
+	}
+
+	response = model.LeaderboardResponse{
+		EventID:    eventID,
+		IsOfficial: true,
+		FrozenAt:   &frozenAt,
+		Rankings:   rankings,
+	}
+	service.Audit.Record(ctx, constants.AuditFreeze, constants.AuditEntityLeaderboard, eventID, nil, map[string]interface{}{
+		"frozen_at":      frozenAt,
+		"total_projects": len(results),
+	})
+	return
+}
+
+// calculate ranks the event's projects. Every score is normalised to 0..1 within its criteria's range
+// and averaged across the judges who scored it, then weighted by the criteria's percentage.
+// The final score is the sum of the weighted scores, so it ranges 0..100 when the percentages add up to 100.
+func (service *ProjectRankingServiceImpl) calculate(eventID uint) (rankings []model.ProjectRanking, err error) {
+	projects, err := service.ProjectRepo.FindManyByEventIDAndStatus(eventID, []string{
+		constants.ProjectStatusSubmitted,
+		constants.ProjectStatusAssessed,
+	})
+	if err != nil {
+		return
+	}
+
+	assessments, err := service.Repository.FindScoredAssessments(eventID)
+	if err != nil {
+		return
+	}
+
+	type criteriaTotal struct {
+		score      model.CriteriaScore
+		scoreSum   float64
+		normSum    float64
+		judgeCount int
+	}
+
+	rankings = []model.ProjectRanking{}
+	index := map[uint]int{}
+	for _, v := range projects {
+		index[v.ID] = len(rankings)
+		rankings = append(rankings, model.ProjectRanking{
+			ProjectID:   v.ID,
+			ProjectName: v.Name,
+			TeamID:      v.TeamID,
+			Breakdown:   []model.CriteriaScore{},
+		})
+	}
+
+	totals := map[uint][]*criteriaTotal{}
+	judges := map[uint]map[uint]bool{}
+	for _, v := range assessments {
+		// projects left out of the ranking, e.g. disqualified ones, keep their assessments but aren't ranked
+		if _, ok := index[v.ProjectID]; !ok {
+			continue
+		}
+
+		var total *criteriaTotal
+		for _, t := range totals[v.ProjectID] {
+			if t.score.CriteriaID == v.CriteriaID {
+				total = t
+				break
+			}
+		}
+		if total == nil {
+			total = &criteriaTotal{score: model.CriteriaScore{
+				CriteriaID:    v.CriteriaID,
+				Criteria:      v.Criteria,
+				PercentageVal: v.PercentageVal,
+			}}
+			totals[v.ProjectID] = append(totals[v.ProjectID], total)
+		}
+
+		total.scoreSum += float64(v.Score)
+		total.normSum += normalizeScore(v.Score, v.ScoreStart, v.ScoreEnd)
+		total.judgeCount++
+
+		if judges[v.ProjectID] == nil {
+			judges[v.ProjectID] = map[uint]bool{}
+		}
+		judges[v.ProjectID][v.JudgeID] = true
+	}
+
+	for k, v := range rankings {
+		var finalScore float64
+		for _, t := range totals[v.ProjectID] {
+			count := float64(t.judgeCount)
+			t.score.AverageScore = roundScore(t.scoreSum / count)
+			t.score.NormalizedScore = roundScore(t.normSum / count)
+			t.score.WeightedScore = roundScore(t.normSum / count * float64(t.score.PercentageVal))
+			finalScore += t.normSum / count * float64(t.score.PercentageVal)
+			rankings[k].Breakdown = append(rankings[k].Breakdown, t.score)
+		}
+		rankings[k].FinalScore = roundScore(finalScore)
+		rankings[k].JudgeCount = uint(len(judges[v.ProjectID]))
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if rankings[i].FinalScore != rankings[j].FinalScore {
+			return rankings[i].FinalScore > rankings[j].FinalScore
+		}
+		return rankings[i].ProjectID < rankings[j].ProjectID
+	})
+	assignRanks(rankings)
+
+	return
+}
+
+// assignRanks uses standard competition ranking, e.g. 1, 2, 2, 4, and flags the tied projects.
+func assignRanks(rankings []model.ProjectRanking) {
+	for k := range rankings {
+		if k > 0 && rankings[k].FinalScore == rankings[k-1].FinalScore {
+			rankings[k].Rank = rankings[k-1].Rank
+			rankings[k].IsTie = true
+			rankings[k-1].IsTie = true
+			continue
+		}
+		rankings[k].Rank = uint(k + 1)
+	}
+}
+
+func normalizeScore(score, start, end uint) float64 {
+	if end <= start {
+		return 0
+	}
+
+	normalized := (float64(score) - float64(start)) / (float64(end) - float64(start))
+	return math.Max(0, math.Min(1, normalized))
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
+
+func toRankings(results []model.ProjectResult) (rankings []model.ProjectRanking, err error) {
+	for k, v := range results {
+		rankings = append(rankings, model.ProjectRanking{
+			Rank:        v.Rank,
+			ProjectID:   v.ProjectID,
+			ProjectName: v.Project.Name,
+			TeamID:      v.Project.TeamID,
+			FinalScore:  v.FinalScore,
+			JudgeCount:  v.JudgeCount,
+			IsTie: (k > 0 && results[k-1].Rank == v.Rank) ||
+				(k < len(results)-1 && results[k+1].Rank == v.Rank),
+		})
+
+		if err = json.Unmarshal([]byte(v.Breakdown), &rankings[k].Breakdown); err != nil {
+			return
+		}
+	}
+	return
+}
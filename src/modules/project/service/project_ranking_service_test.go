@@ -0,0 +1,222 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/project/model"
+	"be-sagara-hackathon/src/modules/project/repository"
+	"testing"
+)
+
+type fakeProjectRepository struct {
+	repository.ProjectRepository
+	projects []model.ProjectLite
+}
+
+func (repository *fakeProjectRepository) FindManyByEventIDAndStatus(eventID uint, statuses []string) ([]model.ProjectLite, error) {
+	return repository.projects, nil
+}
+
+type fakeProjectResultRepository struct {
+	repository.ProjectResultRepository
+	assessments []model.ScoredAssessment
+}
+
+func (repository *fakeProjectResultRepository) FindScoredAssessments(eventID uint) ([]model.ScoredAssessment, error) {
+	return repository.assessments, nil
+}
+
+// score is what judge gave project for criteria, which weighs percentage and is scored start..end
+func score(project, judge, criteria, percentage, start, end, value uint) model.ScoredAssessment {
+	return model.ScoredAssessment{
+		ProjectID:     project,
+		JudgeID:       judge,
+		CriteriaID:    criteria,
+		PercentageVal: percentage,
+		ScoreStart:    start,
+		ScoreEnd:      end,
+		Score:         value,
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	type ranked struct {
+		projectID  uint
+		rank       uint
+		finalScore float64
+		judgeCount uint
+		isTie      bool
+	}
+
+	tests := []struct {
+		name        string
+		projects    []uint
+		assessments []model.ScoredAssessment
+		want        []ranked
+	}{
+		{
+			name:     "weights criteria of different score ranges",
+			projects: []uint{1, 2},
+			assessments: []model.ScoredAssessment{
+				score(1, 10, 100, 60, 1, 10, 10),
+				score(1, 10, 101, 40, 0, 100, 50),
+				score(2, 10, 100, 60, 1, 10, 1),
+				score(2, 10, 101, 40, 0, 100, 100),
+			},
+			want: []ranked{
+				{projectID: 1, rank: 1, finalScore: 80, judgeCount: 1},
+				{projectID: 2, rank: 2, finalScore: 40, judgeCount: 1},
+			},
+		},
+		{
+			name:     "averages the judges",
+			projects: []uint{1},
+			assessments: []model.ScoredAssessment{
+				score(1, 10, 100, 100, 0, 10, 4),
+				score(1, 11, 100, 100, 0, 10, 8),
+			},
+			want: []ranked{
+				{projectID: 1, rank: 1, finalScore: 60, judgeCount: 2},
+			},
+		},
+		{
+			name:     "ties share the rank and skip the next",
+			projects: []uint{1, 2, 3},
+			assessments: []model.ScoredAssessment{
+				score(1, 10, 100, 100, 0, 10, 5),
+				score(2, 10, 100, 100, 0, 10, 7),
+				score(3, 10, 100, 100, 0, 10, 7),
+			},
+			want: []ranked{
+				{projectID: 2, rank: 1, finalScore: 70, judgeCount: 1, isTie: true},
+				{projectID: 3, rank: 1, finalScore: 70, judgeCount: 1, isTie: true},
+				{projectID: 1, rank: 3, finalScore: 50, judgeCount: 1},
+			},
+		},
+		{
+			name:     "projects without scores are ranked last",
+			projects: []uint{1, 2, 3},
+			assessments: []model.ScoredAssessment{
+				score(2, 10, 100, 100, 0, 10, 3),
+				// a project left out of the ranking
+				score(4, 10, 100, 100, 0, 10, 10),
+			},
+			want: []ranked{
+				{projectID: 2, rank: 1, finalScore: 30, judgeCount: 1},
+				{projectID: 1, rank: 2, finalScore: 0, judgeCount: 0, isTie: true},
+				{projectID: 3, rank: 2, finalScore: 0, judgeCount: 0, isTie: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := &fakeProjectRepository{}
+			for _, id := range tt.projects {
+				projects.projects = append(projects.projects, model.ProjectLite{ID: id})
+			}
+			service := &ProjectRankingServiceImpl{
+				Repository:  &fakeProjectResultRepository{assessments: tt.assessments},
+				ProjectRepo: projects,
+			}
+
+			rankings, err := service.calculate(1)
+			if err != nil {
+				t.Fatalf("calculate returned %v", err)
+			}
+			if len(rankings) != len(tt.want) {
+				t.Fatalf("got %d rankings, want %d", len(rankings), len(tt.want))
+			}
+			for k, want := range tt.want {
+				got := ranked{
+					projectID:  rankings[k].ProjectID,
+					rank:       rankings[k].Rank,
+					finalScore: rankings[k].FinalScore,
+					judgeCount: rankings[k].JudgeCount,
+					isTie:      rankings[k].IsTie,
+				}
+				if got != want {
+					t.Errorf("ranking %d = %+v, want %+v", k, got, want)
+				}
+				if rankings[k].JudgeCount == 0 && len(rankings[k].Breakdown) != 0 {
+					t.Errorf("project %d without scores has a breakdown", rankings[k].ProjectID)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateBreakdown(t *testing.T) {
+	service := &ProjectRankingServiceImpl{
+		Repository: &fakeProjectResultRepository{assessments: []model.ScoredAssessment{
+			score(1, 10, 100, 40, 1, 5, 2),
+			score(1, 11, 100, 40, 1, 5, 5),
+		}},
+		ProjectRepo: &fakeProjectRepository{projects: []model.ProjectLite{{ID: 1}}},
+	}
+
+	rankings, err := service.calculate(1)
+	if err != nil {
+		t.Fatalf("calculate returned %v", err)
+	}
+
+	got := rankings[0].Breakdown
+	want := model.CriteriaScore{CriteriaID: 100, PercentageVal: 40, AverageScore: 3.5, NormalizedScore: 0.63, WeightedScore: 25}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("breakdown = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		name              string
+		score, start, end uint
+		want              float64
+	}{
+		{name: "start of the range", score: 1, start: 1, end: 5, want: 0},
+		{name: "end of the range", score: 5, start: 1, end: 5, want: 1},
+		{name: "within the range", score: 50, start: 0, end: 100, want: 0.5},
+		{name: "below the range", score: 0, start: 1, end: 10, want: 0},
+		{name: "above the range", score: 11, start: 1, end: 10, want: 1},
+		{name: "empty range", score: 3, start: 3, end: 3, want: 0},
+		{name: "inverted range", score: 3, start: 5, end: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeScore(tt.score, tt.start, tt.end); got != tt.want {
+				t.Errorf("normalizeScore(%d, %d, %d) = %v, want %v", tt.score, tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignRanks(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []float64
+		ranks  []uint
+		ties   []bool
+	}{
+		{name: "no projects"},
+		{name: "one project", scores: []float64{50}, ranks: []uint{1}, ties: []bool{false}},
+		{name: "no ties", scores: []float64{90, 80, 70}, ranks: []uint{1, 2, 3}, ties: []bool{false, false, false}},
+		{name: "tie at the top", scores: []float64{90, 90, 80}, ranks: []uint{1, 1, 3}, ties: []bool{true, true, false}},
+		{name: "tie in the middle", scores: []float64{90, 80, 80, 70}, ranks: []uint{1, 2, 2, 4}, ties: []bool{false, true, true, false}},
+		{name: "everyone tied", scores: []float64{0, 0, 0}, ranks: []uint{1, 1, 1}, ties: []bool{true, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankings := make([]model.ProjectRanking, len(tt.scores))
+			for k, v := range tt.scores {
+				rankings[k].FinalScore = v
+			}
+
+			assignRanks(rankings)
+			for k, v := range rankings {
+				if v.Rank != tt.ranks[k] || v.IsTie != tt.ties[k] {
+					t.Errorf("project %d ranked %d (tie %v), want %d (tie %v)", k, v.Rank, v.IsTie, tt.ranks[k], tt.ties[k])
+				}
+			}
+		})
+	}
+}
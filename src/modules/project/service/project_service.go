@@ -2,6 +2,8 @@ package service
 
 import (
 	eve "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/project/model"
 	"be-sagara-hackathon/src/modules/project/repository"
 	tm "be-sagara-hackathon/src/modules/team/repository"
@@ -21,6 +23,7 @@ type ProjectService interface {
 	UpdateStatus(ctx context.Context, id uint, status string) error
 	GetDetail(ctx context.Context, id uint) (project model.Project, err error)
 	GetAll(
+		ctx context.Context,
 		filter model.FilterProject,
 		pg *utils.PaginateQueryOffset,
 	) (response model.ListProjectResponse, err error)
@@ -31,6 +34,9 @@ type ProjectServiceImpl struct {
 	TeamRepo       tm.TeamRepository
 	TeamMemberRepo tm.TeamMemberRepository
 	EventRepo      eve.EventRepository
+	TimelineGuard  evs.EventTimelineGuard
+	Scope          evs.EventScope
+	Audit          ads.Recorder
 }
 
 func NewProjectService(
@@ -38,12 +44,18 @@ func NewProjectService(
 	teamRepo tm.TeamRepository,
 	teamMemberRepo tm.TeamMemberRepository,
 	eventRepo eve.EventRepository,
+	timelineGuard evs.EventTimelineGuard,
+	scope evs.EventScope,
+	audit ads.Recorder,
 ) ProjectService {
 	return &ProjectServiceImpl{
 		Repository:     repository,
 		TeamRepo:       teamRepo,
 		TeamMemberRepo: teamMemberRepo,
 		EventRepo:      eventRepo,
+		TimelineGuard:  timelineGuard,
+		Scope:          scope,
+		Audit:          audit,
 	}
 }
 
@@ -58,7 +70,9 @@ func (service *ProjectServiceImpl) Create(ctx context.Context, request model.Cre
 		return e.ErrEventNotRunning
 	}
 
-	//TODO: check timeline
+	if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseSubmission); err != nil {
+		return err
+	}
 
 	team, err := service.TeamRepo.FindOne(request.TeamID)
 	if err != nil {
@@ -137,14 +151,21 @@ func (service *ProjectServiceImpl) Update(ctx context.Context, id uint, request
 		return e.ErrEventNotRunning
 	}
 
-	//TODO: check timeline
+	if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseSubmission); err != nil {
+		return err
+	}
 
 	if project.Status != constants.ProjectStatusDraft {
 		return e.ErrProjectStatusShouldBeDraft
 	}
 
-	if project.Team.ParticipantID != authenticatedUser.Participant.ID {
-		return e.ErrForbidden
+	// participants may only edit their own team's project, staff only the projects of their events
+	if authenticatedUser.UserRole.Name == constants.UserParticipant {
+		if project.Team.ParticipantID != authenticatedUser.Participant.ID {
+			return e.ErrForbidden
+		}
+	} else if err = service.Scope.Check(ctx, project.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
 	}
 
 	project.Name = request.Name
@@ -215,6 +236,10 @@ func (service *ProjectServiceImpl) UpdateStatus(ctx context.Context, id uint, st
 		return err
 	}
 
+	if err = service.Scope.Check(ctx, project.EventID, constants.EventStaffOrganizer); err != nil {
+		return err
+	}
+
 	event, err := service.EventRepo.FindOne(project.EventID)
 	if err != nil {
 		return err
@@ -224,6 +249,7 @@ func (service *ProjectServiceImpl) UpdateStatus(ctx context.Context, id uint, st
 		return e.ErrEventNotRunning
 	}
 
+	before := project
 	project.Status = status
 	project.UpdatedAt = time.Now()
 	project.UpdatedBy = authenticatedUser.Email
@@ -231,6 +257,7 @@ func (service *ProjectServiceImpl) UpdateStatus(ctx context.Context, id uint, st
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditStatus, constants.AuditEntityProject, id, before, project)
 	return nil
 }
 
@@ -248,15 +275,36 @@ func (service *ProjectServiceImpl) GetDetail(ctx context.Context, id uint) (proj
 			err = e.ErrForbidden
 			return
 		}
+		return
 	}
 
+	err = service.Scope.Check(ctx, project.EventID, constants.EventStaffRoles...)
 	return
 }
 
 func (service *ProjectServiceImpl) GetAll(
+	ctx context.Context,
 	filter model.FilterProject,
 	pg *utils.PaginateQueryOffset,
 ) (response model.ListProjectResponse, err error) {
+	authenticatedUser := ctx.Value("user").(um.User)
+	roles := []string{constants.EventStaffOrganizer}
+	if authenticatedUser.UserRole.Name == constants.UserJudge {
+		filter.JudgeID = authenticatedUser.ID
+		roles = []string{constants.EventStaffJudge}
+	}
+
+	eventIDs, all, err := service.Scope.EventIDs(ctx, roles...)
+	if err != nil {
+		return
+	}
+	if !all {
+		if len(eventIDs) == 0 {
+			return
+		}
+		filter.EventIDs = eventIDs
+	}
+
 	response.Projects, response.TotalItem, response.TotalPage, err = service.Repository.FindAll(filter, pg)
 	if err != nil {
 		return
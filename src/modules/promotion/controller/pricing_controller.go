@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/promotion/service"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PricingController interface {
+	GetQuote(ctx *gin.Context)
+}
+
+type PricingControllerImpl struct {
+	Service service.PricingService
+}
+
+func NewPricingController(service service.PricingService) PricingController {
+	return &PricingControllerImpl{Service: service}
+}
+
+func (controller *PricingControllerImpl) GetQuote(ctx *gin.Context) {
+	data, err := controller.Service.QuoteLatest(ctx.Query("voucher_code"))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrVoucherInvalid || err == e.ErrVoucherUsedUp {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Registration Fee Success", data)
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/modules/promotion/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type VoucherController interface {
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	GetList(ctx *gin.Context)
+	GetDetail(ctx *gin.Context)
+}
+
+type VoucherControllerImpl struct {
+	Service service.VoucherService
+}
+
+func NewVoucherController(service service.VoucherService) VoucherController {
+	return &VoucherControllerImpl{Service: service}
+}
+
+func (controller *VoucherControllerImpl) Create(ctx *gin.Context) {
+	var request model.VoucherRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	request.Action = "create"
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	if err := controller.Service.Create(ctx, request); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvalidDateRange || err == e.ErrInvalidDiscountValue || err == e.ErrVoucherCodeAlreadyExists {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusCreated, "Create Voucher Success", nil)
+}
+
+func (controller *VoucherControllerImpl) Update(ctx *gin.Context) {
+	var request model.VoucherRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	request.Action = "update"
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid voucher id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Update(ctx, request, uint(id)); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrInvalidDateRange || err == e.ErrInvalidDiscountValue || err == e.ErrVoucherCodeAlreadyExists {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Update Voucher Success", nil)
+}
+
+func (controller *VoucherControllerImpl) Delete(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid voucher id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Delete(ctx, uint(id)); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Delete Voucher Success", nil)
+}
+
+func (controller *VoucherControllerImpl) GetList(ctx *gin.Context) {
+	eventID, err := strconv.Atoi(ctx.Query("event"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{"Invalid event"})
+		return
+	}
+	filter := model.FilterVoucher{
+		EventID: uint(eventID),
+	}
+	data, err := controller.Service.GetList(filter)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Voucher Success", data)
+}
+
+func (controller *VoucherControllerImpl) GetDetail(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid voucher id", []string{err.Error()})
+		return
+	}
+
+	data, err := controller.Service.GetDetail(uint(id))
+	if err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get Detail Voucher Success", data)
+}
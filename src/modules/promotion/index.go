@@ -0,0 +1,61 @@
+package promotion
+
+import (
+	er "be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/promotion/controller"
+	"be-sagara-hackathon/src/modules/promotion/repository"
+	"be-sagara-hackathon/src/modules/promotion/service"
+
+	"gorm.io/gorm"
+)
+
+var (
+	pricingService    service.PricingService
+	voucherController controller.VoucherController
+	feeTierController controller.FeeTierController
+	pricingController controller.PricingController
+)
+
+type PromotionModule interface {
+	InitModule()
+}
+
+type PromotionModuleImpl struct {
+	DB *gorm.DB
+}
+
+func New(database *gorm.DB) PromotionModule {
+	return &PromotionModuleImpl{DB: database}
+}
+
+func (module *PromotionModuleImpl) InitModule() {
+	eventRepository := er.NewEventRepository(module.DB)
+	voucherRepository := repository.NewVoucherRepository(module.DB)
+	feeTierRepository := repository.NewFeeTierRepository(module.DB)
+
+	voucherService := service.NewVoucherService(voucherRepository, eventRepository, audit.GetRecorder())
+	voucherController = controller.NewVoucherController(voucherService)
+
+	feeTierService := service.NewFeeTierService(feeTierRepository, eventRepository, audit.GetRecorder())
+	feeTierController = controller.NewFeeTierController(feeTierService)
+
+	pricingService = service.NewPricingService(voucherRepository, feeTierRepository, eventRepository)
+	pricingController = controller.NewPricingController(pricingService)
+}
+
+func GetVoucherController() controller.VoucherController {
+	return voucherController
+}
+
+func GetFeeTierController() controller.FeeTierController {
+	return feeTierController
+}
+
+func GetPricingController() controller.PricingController {
+	return pricingController
+}
+
+func GetPricingService() service.PricingService {
+	return pricingService
+}
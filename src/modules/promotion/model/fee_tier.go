@@ -0,0 +1,36 @@
+package model
+
+import (
+	eve "be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/helper"
+	"time"
+)
+
+// FeeTier overrides the event's registration fee between StartDate and EndDate, e.g. an early-bird window.
+type FeeTier struct {
+	common.BaseEntity
+	EventID   uint      `gorm:"not null;index" json:"event_id"`
+	Event     eve.Event `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	Amount    uint64    `gorm:"not null;default:0" json:"amount"`
+	StartDate time.Time `gorm:"not null" json:"start_date"`
+	EndDate   time.Time `gorm:"not null" json:"end_date"`
+}
+
+type FeeTierRequest struct {
+	Action    string `json:"-"`
+	EventID   uint   `json:"event_id" validate:"required_if=Action create"`
+	Name      string `json:"name" validate:"required,max=100"`
+	Amount    uint64 `json:"amount"`
+	StartDate string `json:"start_date" validate:"required"`
+	EndDate   string `json:"end_date" validate:"required"`
+}
+
+type FilterFeeTier struct {
+	EventID uint
+}
+
+func (tier FeeTier) IsOpenAt(t time.Time) bool {
+	return helper.IsOpenAt(tier.StartDate, tier.EndDate, t)
+}
@@ -0,0 +1,12 @@
+package model
+
+// Price is the registration fee a participant pays for an event.
+type Price struct {
+	FeeTierID      *uint  `json:"fee_tier_id"`
+	FeeTierName    string `json:"fee_tier_name"`
+	VoucherID      *uint  `json:"voucher_id"`
+	VoucherCode    string `json:"voucher_code"`
+	BaseAmount     uint64 `json:"base_amount"`
+	DiscountAmount uint64 `json:"discount_amount"`
+	Amount         uint64 `json:"amount"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	eve "be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/utils/common"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/helper"
+	"time"
+)
+
+type Voucher struct {
+	common.BaseEntity
+	EventID       uint      `gorm:"not null;index" json:"event_id"`
+	Event         eve.Event `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Code          string    `gorm:"type:varchar(50);not null" json:"code"`
+	Description   string    `gorm:"type:text" json:"description"`
+	DiscountType  string    `gorm:"type:varchar(15);not null" json:"discount_type"` //percentage, fixed
+	DiscountValue uint64    `gorm:"not null" json:"discount_value"`
+	MaxUsage      uint      `gorm:"not null;default:0" json:"max_usage"` //0 means unlimited
+	UsedCount     uint      `gorm:"not null;default:0" json:"used_count"`
+	StartDate     time.Time `gorm:"not null" json:"start_date"`
+	EndDate       time.Time `gorm:"not null" json:"end_date"`
+	IsActive      bool      `gorm:"not null;default:true" json:"is_active"`
+}
+
+type VoucherRequest struct {
+	Action        string `json:"-"`
+	EventID       uint   `json:"event_id" validate:"required_if=Action create"`
+	Code          string `json:"code" validate:"required,max=50"`
+	Description   string `json:"description"`
+	DiscountType  string `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue uint64 `json:"discount_value" validate:"required"`
+	MaxUsage      uint   `json:"max_usage"`
+	StartDate     string `json:"start_date" validate:"required"`
+	EndDate       string `json:"end_date" validate:"required"`
+	IsActive      *bool  `json:"is_active"` //left out, a new voucher is active and an updated one keeps its status
+}
+
+type FilterVoucher struct {
+	EventID uint
+}
+
+// IsAvailableAt reports whether the voucher can still be redeemed at t.
+func (voucher Voucher) IsAvailableAt(t time.Time) bool {
+	if !voucher.IsActive || !helper.IsOpenAt(voucher.StartDate, voucher.EndDate, t) {
+		return false
+	}
+	return voucher.MaxUsage == 0 || voucher.UsedCount < voucher.MaxUsage
+}
+
+// Discount returns how much the voucher takes off amount. It never exceeds amount.
+func (voucher Voucher) Discount(amount uint64) uint64 {
+	discount := voucher.DiscountValue
+	if voucher.DiscountType == constants.DiscountPercentage {
+		discount = amount * voucher.DiscountValue / 100
+	}
+	if discount > amount {
+		return amount
+	}
+	return discount
+}
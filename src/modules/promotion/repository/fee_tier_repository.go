@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+)
+
+type FeeTierRepository interface {
+	Save(tier model.FeeTier) (model.FeeTier, error)
+	Update(tierID uint, tier model.FeeTier) error
+	Delete(tierID uint, deletedBy string) error
+	FindAll(filter model.FilterFeeTier) ([]model.FeeTier, error)
+	FindOne(tierID uint) (model.FeeTier, error)
+}
+
+type FeeTierRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewFeeTierRepository(db *gorm.DB) FeeTierRepository {
+	return &FeeTierRepositoryImpl{DB: db}
+}
+
+func (repository *FeeTierRepositoryImpl) Save(tier model.FeeTier) (model.FeeTier, error) {
+	if err := repository.DB.Omit("Event").Create(&tier).Error; err != nil {
+		return tier, err
+	}
+	return tier, nil
+}
+
+func (repository *FeeTierRepositoryImpl) Update(tierID uint, tier model.FeeTier) error {
+	if err := repository.DB.Select("*").Omit("Event").Where("id=?", tierID).Updates(&tier).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *FeeTierRepositoryImpl) Delete(tierID uint, deletedBy string) error {
+	if err := repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.FeeTier{}, tierID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *FeeTierRepositoryImpl) FindAll(filter model.FilterFeeTier) ([]model.FeeTier, error) {
+	var tiers []model.FeeTier
+	if err := repository.DB.Where("event_id=?", filter.EventID).
+		Order("start_date asc").
+		Find(&tiers).Error; err != nil {
+		return tiers, err
+	}
+	return tiers, nil
+}
+
+func (repository *FeeTierRepositoryImpl) FindOne(tierID uint) (model.FeeTier, error) {
+	var tier model.FeeTier
+	if err := repository.DB.Where("id=?", tierID).First(&tier).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return tier, err
+	}
+	return tier, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+type VoucherRepository interface {
+	Save(voucher model.Voucher) (model.Voucher, error)
+	Update(voucherID uint, voucher model.Voucher) error
+	Delete(voucherID uint, deletedBy string) error
+	FindAll(filter model.FilterVoucher) ([]model.Voucher, error)
+	FindOne(voucherID uint) (model.Voucher, error)
+	FindByEventIDAndCode(eventID uint, code string) (model.Voucher, error)
+}
+
+type VoucherRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewVoucherRepository(db *gorm.DB) VoucherRepository {
+	return &VoucherRepositoryImpl{DB: db}
+}
+
+func (repository *VoucherRepositoryImpl) Save(voucher model.Voucher) (model.Voucher, error) {
+	// gorm writes the column default in place of false, an inactive voucher is switched off after it is created
+	isActive := voucher.IsActive
+	tx := repository.DB.Begin()
+	if err := tx.Omit("Event").Create(&voucher).Error; err != nil {
+		tx.Rollback()
+		return voucher, translateVoucherError(err)
+	}
+
+	if !isActive {
+		if err := tx.Model(&voucher).Update("is_active", false).Error; err != nil {
+			tx.Rollback()
+			return voucher, err
+		}
+	}
+	return voucher, tx.Commit().Error
+}
+
+func (repository *VoucherRepositoryImpl) Update(voucherID uint, voucher model.Voucher) error {
+	if err := repository.DB.Select("*").Omit("Event", "UsedCount").
+		Where("id=?", voucherID).Updates(&voucher).Error; err != nil {
+		return translateVoucherError(err)
+	}
+	return nil
+}
+
+func (repository *VoucherRepositoryImpl) Delete(voucherID uint, deletedBy string) error {
+	if err := repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.Voucher{}, voucherID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (repository *VoucherRepositoryImpl) FindAll(filter model.FilterVoucher) ([]model.Voucher, error) {
+	var vouchers []model.Voucher
+	if err := repository.DB.Where("event_id=?", filter.EventID).
+		Order("start_date asc").
+		Find(&vouchers).Error; err != nil {
+		return vouchers, err
+	}
+	return vouchers, nil
+}
+
+func (repository *VoucherRepositoryImpl) FindOne(voucherID uint) (model.Voucher, error) {
+	var voucher model.Voucher
+	if err := repository.DB.Where("id=?", voucherID).First(&voucher).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return voucher, err
+	}
+	return voucher, nil
+}
+
+func (repository *VoucherRepositoryImpl) FindByEventIDAndCode(eventID uint, code string) (model.Voucher, error) {
+	var voucher model.Voucher
+	if err := repository.DB.Where("event_id=? AND code=?", eventID, code).First(&voucher).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return voucher, err
+	}
+	return voucher, nil
+}
+
+// RedeemVoucher counts one usage of the voucher within tx, the same transaction that creates the invoice.
+// The usage limit is checked by the update itself so concurrent registrations can not go over it.
+func RedeemVoucher(tx *gorm.DB, voucherID uint) error {
+	result := tx.Model(&model.Voucher{}).
+		Where("id=? AND (max_usage=0 OR used_count<max_usage)", voucherID).
+		Update("used_count", gorm.Expr("used_count+1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrVoucherUsedUp
+	}
+	return nil
+}
+
+func translateVoucherError(err error) error {
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == 1062 {
+		return e.ErrVoucherCodeAlreadyExists
+	}
+	return err
+}
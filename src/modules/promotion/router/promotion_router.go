@@ -0,0 +1,72 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/promotion"
+	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/ratelimit"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PromotionRouter(group *gin.RouterGroup) {
+	/// Voucher Routes ///
+	vc := group.Group("/vouchers")
+	{
+		vc.POST("/",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetVoucherController().Create,
+		)
+		vc.PUT("/:id",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetVoucherController().Update,
+		)
+		vc.DELETE("/:id",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetVoucherController().Delete,
+		)
+		vc.GET("/",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetVoucherController().GetList,
+		)
+		vc.GET("/:id",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetVoucherController().GetDetail,
+		)
+	}
+
+	/// Fee Tier Routes ///
+	ft := group.Group("/fee-tiers")
+	{
+		ft.POST("/",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetFeeTierController().Create,
+		)
+		ft.PUT("/:id",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetFeeTierController().Update,
+		)
+		ft.DELETE("/:id",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetFeeTierController().Delete,
+		)
+		ft.GET("/",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetFeeTierController().GetList,
+		)
+		ft.GET("/:id",
+			middlewares.Permission(constants.PermissionPromotionManage),
+			promotion.GetFeeTierController().GetDetail,
+		)
+	}
+}
+
+func PricingRouter(group *gin.RouterGroup) {
+	// the quote is public, the limit keeps it from being used to guess voucher codes
+	quotePerIP := ratelimit.RuleFromEnv("RATE_LIMIT_PRICE_QUOTE_IP", ratelimit.Rule{Limit: 30, Window: time.Minute})
+	group.GET("/",
+		middlewares.RateLimit("price-quote", quotePerIP, ratelimit.Rule{}, nil),
+		promotion.GetPricingController().GetQuote,
+	)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/modules/promotion/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	"context"
+)
+
+type FeeTierService interface {
+	Create(ctx context.Context, request model.FeeTierRequest) error
+	Update(ctx context.Context, request model.FeeTierRequest, tierID uint) error
+	Delete(ctx context.Context, tierID uint) error
+	GetList(filter model.FilterFeeTier) ([]model.FeeTier, error)
+	GetDetail(tierID uint) (model.FeeTier, error)
+}
+
+type FeeTierServiceImpl struct {
+	Repository      repository.FeeTierRepository
+	EventRepository evr.EventRepository
+	Audit           ads.Recorder
+}
+
+func NewFeeTierService(
+	repository repository.FeeTierRepository,
+	eventRepository evr.EventRepository,
+	audit ads.Recorder,
+) FeeTierService {
+	return &FeeTierServiceImpl{Repository: repository, EventRepository: eventRepository, Audit: audit}
+}
+
+func (service *FeeTierServiceImpl) Create(ctx context.Context, request model.FeeTierRequest) error {
+	if _, err := service.EventRepository.FindOne(request.EventID); err != nil {
+		return err
+	}
+
+	startDate, endDate, err := parseDateRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+
+	tier, err := service.Repository.Save(model.FeeTier{
+		BaseEntity: builder.BuildBaseEntity(ctx, true, nil),
+		EventID:    request.EventID,
+		Name:       request.Name,
+		Amount:     request.Amount,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityFeeTier, tier.ID, nil, tier)
+	return nil
+}
+
+func (service *FeeTierServiceImpl) Update(ctx context.Context, request model.FeeTierRequest, tierID uint) error {
+	existing, err := service.Repository.FindOne(tierID)
+	if err != nil {
+		return err
+	}
+
+	startDate, endDate, err := parseDateRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Repository.Update(tierID, model.FeeTier{
+		BaseEntity: builder.BuildBaseEntity(ctx, false, &existing.BaseEntity),
+		EventID:    existing.EventID,
+		Name:       request.Name,
+		Amount:     request.Amount,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	}); err != nil {
+		return err
+	}
+
+	updated, _ := service.Repository.FindOne(tierID)
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityFeeTier, tierID, existing, updated)
+	return nil
+}
+
+func (service *FeeTierServiceImpl) Delete(ctx context.Context, tierID uint) error {
+	existing, err := service.Repository.FindOne(tierID)
+	if err != nil {
+		return err
+	}
+
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(tierID, authUser.Email); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityFeeTier, tierID, existing, nil)
+	return nil
+}
+
+func (service *FeeTierServiceImpl) GetList(filter model.FilterFeeTier) ([]model.FeeTier, error) {
+	return service.Repository.FindAll(filter)
+}
+
+func (service *FeeTierServiceImpl) GetDetail(tierID uint) (model.FeeTier, error) {
+	return service.Repository.FindOne(tierID)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/modules/promotion/repository"
+	e "be-sagara-hackathon/src/utils/errors"
+	"strings"
+	"time"
+)
+
+type PricingService interface {
+	Quote(event evm.Event, voucherCode string) (price model.Price, err error)
+	QuoteLatest(voucherCode string) (price model.Price, err error)
+}
+
+type PricingServiceImpl struct {
+	VoucherRepo     repository.VoucherRepository
+	FeeTierRepo     repository.FeeTierRepository
+	EventRepository evr.EventRepository
+}
+
+func NewPricingService(
+	voucherRepo repository.VoucherRepository,
+	feeTierRepo repository.FeeTierRepository,
+	eventRepository evr.EventRepository,
+) PricingService {
+	return &PricingServiceImpl{
+		VoucherRepo:     voucherRepo,
+		FeeTierRepo:     feeTierRepo,
+		EventRepository: eventRepository,
+	}
+}
+
+// Quote prices the registration of event right now. The fee tier that is open replaces the event's
+// registration fee (the one that started last wins when tiers overlap), then the voucher, if any, is taken off.
+func (service *PricingServiceImpl) Quote(event evm.Event, voucherCode string) (price model.Price, err error) {
+	now := time.Now()
+	price.BaseAmount = event.RegFee
+
+	tiers, err := service.FeeTierRepo.FindAll(model.FilterFeeTier{EventID: event.ID})
+	if err != nil {
+		return
+	}
+	var current *model.FeeTier
+	for k, v := range tiers {
+		if v.IsOpenAt(now) && (current == nil || v.StartDate.After(current.StartDate)) {
+			current = &tiers[k]
+		}
+	}
+	if current != nil {
+		price.FeeTierID = &current.ID
+		price.FeeTierName = current.Name
+		price.BaseAmount = current.Amount
+	}
+
+	voucherCode = strings.ToUpper(strings.TrimSpace(voucherCode))
+	if voucherCode != "" {
+		voucher, errVoucher := service.VoucherRepo.FindByEventIDAndCode(event.ID, voucherCode)
+		if errVoucher != nil {
+			err = errVoucher
+			if err == e.ErrDataNotFound {
+				err = e.ErrVoucherInvalid
+			}
+			return
+		}
+		if !voucher.IsAvailableAt(now) {
+			err = e.ErrVoucherInvalid
+			if voucher.MaxUsage > 0 && voucher.UsedCount >= voucher.MaxUsage {
+				err = e.ErrVoucherUsedUp
+			}
+			return
+		}
+
+		price.VoucherID = &voucher.ID
+		price.VoucherCode = voucher.Code
+		price.DiscountAmount = voucher.Discount(price.BaseAmount)
+	}
+
+	price.Amount = price.BaseAmount - price.DiscountAmount
+	return
+}
+
+// QuoteLatest prices the registration of the latest event, so participants can check a voucher before registering.
+func (service *PricingServiceImpl) QuoteLatest(voucherCode string) (price model.Price, err error) {
+	event, err := service.EventRepository.FindLatest()
+	if err != nil {
+		return
+	}
+	return service.Quote(event, voucherCode)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	evm "be-sagara-hackathon/src/modules/event/model"
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/modules/promotion/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"testing"
+	"time"
+)
+
+type fakeFeeTierRepository struct {
+	repository.FeeTierRepository
+	tiers []model.FeeTier
+}
+
+func (repository *fakeFeeTierRepository) FindAll(model.FilterFeeTier) ([]model.FeeTier, error) {
+	return repository.tiers, nil
+}
+
+type fakeVoucherRepository struct {
+	repository.VoucherRepository
+	vouchers []model.Voucher
+}
+
+func (repository *fakeVoucherRepository) FindByEventIDAndCode(eventID uint, code string) (model.Voucher, error) {
+	for _, v := range repository.vouchers {
+		if v.EventID == eventID && v.Code == code {
+			return v, nil
+		}
+	}
+	return model.Voucher{}, e.ErrDataNotFound
+}
+
+func feeTier(id uint, amount uint64, start, end time.Time) model.FeeTier {
+	tier := model.FeeTier{EventID: 1, Name: "tier", Amount: amount, StartDate: start, EndDate: end}
+	tier.ID = id
+	return tier
+}
+
+func voucher(code, discountType string, value uint64) model.Voucher {
+	now := time.Now()
+	return model.Voucher{
+		EventID:       1,
+		Code:          code,
+		DiscountType:  discountType,
+		DiscountValue: value,
+		StartDate:     now.Add(-time.Hour),
+		EndDate:       now.Add(time.Hour),
+		IsActive:      true,
+	}
+}
+
+func newTestPricingService(tiers ...model.FeeTier) *PricingServiceImpl {
+	usedUp := voucher("USEDUP", constants.DiscountFixed, 50)
+	usedUp.MaxUsage, usedUp.UsedCount = 2, 2
+	inactive := voucher("INACTIVE", constants.DiscountFixed, 50)
+	inactive.IsActive = false
+	expired := voucher("EXPIRED", constants.DiscountFixed, 50)
+	expired.EndDate = time.Now().Add(-time.Minute)
+
+	return &PricingServiceImpl{
+		FeeTierRepo: &fakeFeeTierRepository{tiers: tiers},
+		VoucherRepo: &fakeVoucherRepository{vouchers: []model.Voucher{
+			voucher("HALF", constants.DiscountPercentage, 50),
+			voucher("BIGGIFT", constants.DiscountFixed, 1000),
+			usedUp, inactive, expired,
+		}},
+	}
+}
+
+func TestQuoteFeeTier(t *testing.T) {
+	now := time.Now()
+	event := evm.Event{RegFee: 300}
+	event.ID = 1
+
+	tests := []struct {
+		name  string
+		tiers []model.FeeTier
+		want  uint64
+	}{
+		{name: "no tier", want: 300},
+		{name: "open tier", tiers: []model.FeeTier{feeTier(1, 200, now.Add(-time.Hour), now.Add(time.Hour))}, want: 200},
+		{name: "ended tier", tiers: []model.FeeTier{feeTier(1, 200, now.Add(-2*time.Hour), now.Add(-time.Hour))}, want: 300},
+		{
+			name: "overlapping tiers",
+			tiers: []model.FeeTier{
+				feeTier(1, 200, now.Add(-2*time.Hour), now.Add(time.Hour)),
+				feeTier(2, 250, now.Add(-time.Hour), now.Add(time.Hour)),
+			},
+			want: 250,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := newTestPricingService(tt.tiers...).Quote(event, "")
+			if err != nil {
+				t.Fatalf("quote returned %v", err)
+			}
+			if price.BaseAmount != tt.want || price.Amount != tt.want {
+				t.Errorf("quote with %s = %+v, want %d", tt.name, price, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteVoucher(t *testing.T) {
+	now := time.Now()
+	event := evm.Event{RegFee: 300}
+	event.ID = 1
+	service := newTestPricingService(feeTier(1, 200, now.Add(-time.Hour), now.Add(time.Hour)))
+
+	tests := []struct {
+		code     string
+		discount uint64
+		want     error
+	}{
+		{code: " half ", discount: 100},
+		{code: "BIGGIFT", discount: 200},
+		{code: "NOPE", want: e.ErrVoucherInvalid},
+		{code: "USEDUP", want: e.ErrVoucherUsedUp},
+		{code: "INACTIVE", want: e.ErrVoucherInvalid},
+		{code: "EXPIRED", want: e.ErrVoucherInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			price, err := service.Quote(event, tt.code)
+			if err != tt.want {
+				t.Fatalf("quote with %q returned %v, want %v", tt.code, err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if price.VoucherID == nil || price.DiscountAmount != tt.discount || price.Amount != 200-tt.discount {
+				t.Errorf("quote with %q = %+v, want %d off the tier amount", tt.code, price, tt.discount)
+			}
+		})
+	}
+}
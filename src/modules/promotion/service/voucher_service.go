@@ -0,0 +1,153 @@
+package service
+
+import (
+	evr "be-sagara-hackathon/src/modules/event/repository"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/promotion/model"
+	"be-sagara-hackathon/src/modules/promotion/repository"
+	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/common/builder"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"strings"
+	"time"
+)
+
+type VoucherService interface {
+	Create(ctx context.Context, request model.VoucherRequest) error
+	Update(ctx context.Context, request model.VoucherRequest, voucherID uint) error
+	Delete(ctx context.Context, voucherID uint) error
+	GetList(filter model.FilterVoucher) ([]model.Voucher, error)
+	GetDetail(voucherID uint) (model.Voucher, error)
+}
+
+type VoucherServiceImpl struct {
+	Repository      repository.VoucherRepository
+	EventRepository evr.EventRepository
+	Audit           ads.Recorder
+}
+
+func NewVoucherService(
+	repository repository.VoucherRepository,
+	eventRepository evr.EventRepository,
+	audit ads.Recorder,
+) VoucherService {
+	return &VoucherServiceImpl{Repository: repository, EventRepository: eventRepository, Audit: audit}
+}
+
+func (service *VoucherServiceImpl) Create(ctx context.Context, request model.VoucherRequest) error {
+	if _, err := service.EventRepository.FindOne(request.EventID); err != nil {
+		return err
+	}
+
+	startDate, endDate, err := parseDateRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+	if err = validateDiscount(request.DiscountType, request.DiscountValue); err != nil {
+		return err
+	}
+
+	voucher, err := service.Repository.Save(model.Voucher{
+		BaseEntity:    builder.BuildBaseEntity(ctx, true, nil),
+		EventID:       request.EventID,
+		Code:          strings.ToUpper(strings.TrimSpace(request.Code)),
+		Description:   request.Description,
+		DiscountType:  request.DiscountType,
+		DiscountValue: request.DiscountValue,
+		MaxUsage:      request.MaxUsage,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		IsActive:      request.IsActive == nil || *request.IsActive,
+	})
+	if err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityVoucher, voucher.ID, nil, voucher)
+	return nil
+}
+
+func (service *VoucherServiceImpl) Update(ctx context.Context, request model.VoucherRequest, voucherID uint) error {
+	existing, err := service.Repository.FindOne(voucherID)
+	if err != nil {
+		return err
+	}
+
+	startDate, endDate, err := parseDateRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+	if err = validateDiscount(request.DiscountType, request.DiscountValue); err != nil {
+		return err
+	}
+
+	isActive := existing.IsActive
+	if request.IsActive != nil {
+		isActive = *request.IsActive
+	}
+
+	if err = service.Repository.Update(voucherID, model.Voucher{
+		BaseEntity:    builder.BuildBaseEntity(ctx, false, &existing.BaseEntity),
+		EventID:       existing.EventID,
+		Code:          strings.ToUpper(strings.TrimSpace(request.Code)),
+		Description:   request.Description,
+		DiscountType:  request.DiscountType,
+		DiscountValue: request.DiscountValue,
+		MaxUsage:      request.MaxUsage,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		IsActive:      isActive,
+	}); err != nil {
+		return err
+	}
+
+	updated, _ := service.Repository.FindOne(voucherID)
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityVoucher, voucherID, existing, updated)
+	return nil
+}
+
+func (service *VoucherServiceImpl) Delete(ctx context.Context, voucherID uint) error {
+	existing, err := service.Repository.FindOne(voucherID)
+	if err != nil {
+		return err
+	}
+
+	authUser := ctx.Value("user").(um.User)
+	if err = service.Repository.Delete(voucherID, authUser.Email); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityVoucher, voucherID, existing, nil)
+	return nil
+}
+
+func (service *VoucherServiceImpl) GetList(filter model.FilterVoucher) ([]model.Voucher, error) {
+	return service.Repository.FindAll(filter)
+}
+
+func (service *VoucherServiceImpl) GetDetail(voucherID uint) (model.Voucher, error) {
+	return service.Repository.FindOne(voucherID)
+}
+
+func validateDiscount(discountType string, value uint64) error {
+	if discountType == constants.DiscountPercentage && (value == 0 || value > 100) {
+		return e.ErrInvalidDiscountValue
+	}
+	return nil
+}
+
+func parseDateRange(start, end string) (startDate, endDate time.Time, err error) {
+	if startDate, err = helper.ParseDateStringToTime(start); err != nil {
+		return
+	}
+	if endDate, err = helper.ParseDateStringToTime(end); err != nil {
+		return
+	}
+	if endDate.Before(startDate) {
+		err = e.ErrInvalidDateRange
+	}
+	return
+}
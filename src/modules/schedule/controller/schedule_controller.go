@@ -49,7 +49,7 @@ func (controller *ScheduleControllerImpl) CreateSchedule(ctx *gin.Context) {
 
 	data, err := controller.Service.CreateSchedule(ctx, request)
 	if err != nil {
-		if err == e.ErrEventNotRunning || err == e.ErrScheduleDateNotValid {
+		if err == e.ErrEventNotRunning || err == e.ErrScheduleDateNotValid || err == e.ErrNotEventMentor {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -59,6 +59,11 @@ func (controller *ScheduleControllerImpl) CreateSchedule(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -84,13 +89,18 @@ func (controller *ScheduleControllerImpl) CreateScheduleTeam(ctx *gin.Context) {
 		return
 	}
 
-	err := controller.Service.CreateScheduleTeam(request)
+	err := controller.Service.CreateScheduleTeam(ctx, request)
 	if err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -124,7 +134,7 @@ func (controller *ScheduleControllerImpl) UpdateSchedule(ctx *gin.Context) {
 
 	err = controller.Service.UpdateSchedule(ctx, uint(id), request)
 	if err != nil {
-		if err == e.ErrScheduleDateNotValid {
+		if err == e.ErrScheduleDateNotValid || err == e.ErrNotEventMentor {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request Error", []string{err.Error()})
 			return
 		}
@@ -134,6 +144,11 @@ func (controller *ScheduleControllerImpl) UpdateSchedule(ctx *gin.Context) {
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -148,13 +163,18 @@ func (controller *ScheduleControllerImpl) DeleteSchedule(ctx *gin.Context) {
 		return
 	}
 
-	err = controller.Service.DeleteSchedule(uint(id))
+	err = controller.Service.DeleteSchedule(ctx, uint(id))
 	if err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -175,13 +195,18 @@ func (controller *ScheduleControllerImpl) DeleteScheduleTeam(ctx *gin.Context) {
 		return
 	}
 
-	err = controller.Service.DeleteScheduleTeam(uint(id), uint(teamID))
+	err = controller.Service.DeleteScheduleTeam(ctx, uint(id), uint(teamID))
 	if err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
@@ -219,13 +244,18 @@ func (controller *ScheduleControllerImpl) GetDetailSchedule(ctx *gin.Context) {
 		return
 	}
 
-	data, err := controller.Service.GetDetailSchedule(uint(id))
+	data, err := controller.Service.GetDetailSchedule(ctx, uint(id))
 	if err != nil {
 		if err == e.ErrDataNotFound {
 			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
 			return
 		}
 
+		if err == e.ErrNotEventStaff {
+			common.SendError(ctx, http.StatusForbidden, "Forbidden", []string{err.Error()})
+			return
+		}
+
 		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
 		return
 	}
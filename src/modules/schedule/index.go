@@ -2,6 +2,8 @@ package schedule
 
 import (
 	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/schedule/controller"
 	"be-sagara-hackathon/src/modules/schedule/repository"
 	"be-sagara-hackathon/src/modules/schedule/service"
@@ -32,8 +34,11 @@ func (module *ScheduleModuleImpl) InitModule() {
 	eventRepository := evr.NewEventRepository(module.DB)
 	userRepository := ur.NewUserRepository(module.DB)
 	teamRepository := tr.NewTeamRepository(module.DB)
+	eventStaffRepository := evr.NewEventStaffRepository(module.DB)
+	eventScope := evs.NewEventScope(eventStaffRepository, ur.NewPermissionRepository(module.DB))
 	scheduleRepository = repository.NewScheduleRepository(module.DB)
-	scheduleService = service.NewScheduleService(scheduleRepository, eventRepository, userRepository, teamRepository)
+	scheduleService = service.NewScheduleService(
+		scheduleRepository, eventRepository, userRepository, teamRepository, eventStaffRepository, eventScope, audit.GetRecorder())
 	scheduleController = controller.NewScheduleController(scheduleService)
 }
 
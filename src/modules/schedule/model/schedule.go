@@ -31,6 +31,7 @@ type ScheduleRequest struct {
 
 type FilterSchedule struct {
 	EventID  uint
+	EventIDs []uint
 	HeldOn   string
 	Search   string // title, mentor name
 	MentorID uint
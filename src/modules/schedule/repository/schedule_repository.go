@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/schedule/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"database/sql"
 	"fmt"
@@ -15,7 +16,7 @@ type ScheduleRepository interface {
 	Save(req model.Schedule) (schedule model.Schedule, err error)
 	SaveScheduleTeam(req model.ScheduleTeam) (err error)
 	Update(id uint, req model.Schedule) (err error)
-	Delete(id uint) (err error)
+	Delete(id uint, deletedBy string) (err error)
 	DeleteScheduleTeam(id, teamID uint) (err error)
 	Find(
 		filter model.FilterSchedule,
@@ -56,14 +57,14 @@ func (repository *ScheduleRepositoryImpl) Update(id uint, req model.Schedule) (e
 	return
 }
 
-func (repository *ScheduleRepositoryImpl) Delete(id uint) (err error) {
+func (repository *ScheduleRepositoryImpl) Delete(id uint, deletedBy string) (err error) {
 	tx := repository.DB.Begin()
-	if err = tx.Delete(&model.Schedule{}, "id=?", id).Error; err != nil {
+	if err = tx.Scopes(common.DeletedBy(deletedBy)).Delete(&model.Schedule{}, "id=?", id).Error; err != nil {
 		tx.Rollback()
 		return
 	}
 
-	if err = tx.Delete(&model.ScheduleTeam{}, "schedule_id=?", id).Error; err != nil {
+	if err = tx.Unscoped().Delete(&model.ScheduleTeam{}, "schedule_id=?", id).Error; err != nil {
 		tx.Rollback()
 		return
 	}
@@ -74,7 +75,7 @@ func (repository *ScheduleRepositoryImpl) Delete(id uint) (err error) {
 
 func (repository *ScheduleRepositoryImpl) DeleteScheduleTeam(id, teamID uint) (err error) {
 	tx := repository.DB.Begin()
-	if err = tx.Delete(&model.ScheduleTeam{}, "schedule_id=? AND team_id=?", id, teamID).Error; err != nil {
+	if err = tx.Unscoped().Delete(&model.ScheduleTeam{}, "schedule_id=? AND team_id=?", id, teamID).Error; err != nil {
 		tx.Rollback()
 		return
 	}
@@ -97,6 +98,7 @@ func (repository *ScheduleRepositoryImpl) Find(
 	if err = repository.DB.Table("schedules as s").
 		Select("s.id, s.event_id, s.title, s.held_on, u.name as mentor_name").
 		Joins("inner join users u on u.id = s.mentor_id").
+		Where("s.deleted_at IS NULL").
 		Order(fmt.Sprintf("%s %s", pg.Order.Field, pg.Order.By)).
 		Limit(pg.Limit).Offset(pg.Offset).
 		Where(buildWhereQuery, whereVals...).
@@ -133,6 +135,7 @@ func (repository *ScheduleRepositoryImpl) getTotalSchedule(filter *model.FilterS
 
 	if err = repository.DB.Table("schedules as s").
 		Joins("inner join users u on u.id = s.mentor_id").
+		Where("s.deleted_at IS NULL").
 		Where(buildWhereQuery, whereVals...).
 		Count(&totalData).Error; err != nil {
 		return 0, err
@@ -152,6 +155,11 @@ func BuildFilter(filter model.FilterSchedule) (where []string, whereVal []interf
 		whereVal = append(whereVal, sql.Named("event", filter.EventID))
 	}
 
+	if len(filter.EventIDs) > 0 {
+		where = append(where, "s.event_id IN @events")
+		whereVal = append(whereVal, sql.Named("events", filter.EventIDs))
+	}
+
 	if filter.HeldOn != "" {
 		where = append(where, "s.held_on = @held")
 		whereVal = append(whereVal, sql.Named("held", filter.HeldOn))
@@ -172,7 +180,7 @@ func (repository *ScheduleRepositoryImpl) FindDetail(id uint) (schedule model.Sc
 			u.avatar as mentor_avatar`).
 		Joins("inner join users u on u.id = s.mentor_id").
 		Joins("inner join occupations o on o.id = u.occupation_id").
-		Where("s.id=?", id).
+		Where("s.id=? AND s.deleted_at IS NULL", id).
 		First(&schedule).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			err = e.ErrDataNotFound
@@ -196,7 +204,7 @@ func (repository *ScheduleRepositoryImpl) FindByEventIDAndTeamID(eventID, teamID
 	if err = repository.DB.Table("schedules as s").
 		Select(`s.id, s.title, s.held_on`).
 		Joins("inner join schedule_teams st on st.schedule_id = s.id").
-		Where("s.event_id=? AND st.team_id=?", eventID, teamID).
+		Where("s.event_id=? AND st.team_id=? AND s.deleted_at IS NULL", eventID, teamID).
 		Find(&schedules).Error; err != nil {
 		return
 	}
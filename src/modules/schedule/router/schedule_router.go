@@ -9,28 +9,31 @@ import (
 
 func ScheduleRouter(group *gin.RouterGroup) {
 	group.POST("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionScheduleManage),
 		schedule.GetController().CreateSchedule,
 	)
 	group.POST("/teams",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionScheduleManage),
 		schedule.GetController().CreateScheduleTeam,
 	)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionScheduleManage),
 		schedule.GetController().UpdateSchedule,
 	)
 	group.DELETE("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionScheduleManage),
 		schedule.GetController().DeleteSchedule,
 	)
 	group.DELETE("/:id/teams/:team_id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionScheduleManage),
 		schedule.GetController().DeleteScheduleTeam,
 	)
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin, constants.UserMentor),
+		middlewares.Permission(constants.PermissionScheduleView),
 		schedule.GetController().GetListSchedule,
 	)
-	group.GET("/:id", schedule.GetController().GetDetailSchedule)
+	group.GET("/:id",
+		middlewares.Permission(constants.PermissionScheduleView),
+		schedule.GetController().GetDetailSchedule,
+	)
 }
@@ -2,6 +2,8 @@ package service
 
 import (
 	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/schedule/model"
 	"be-sagara-hackathon/src/modules/schedule/repository"
 	tr "be-sagara-hackathon/src/modules/team/repository"
@@ -18,16 +20,16 @@ import (
 
 type ScheduleService interface {
 	CreateSchedule(ctx context.Context, req model.ScheduleRequest) (schedule model.Schedule, err error)
-	CreateScheduleTeam(req model.ScheduleTeam) (err error)
+	CreateScheduleTeam(ctx context.Context, req model.ScheduleTeam) (err error)
 	UpdateSchedule(ctx context.Context, id uint, req model.ScheduleRequest) (err error)
-	DeleteSchedule(id uint) (err error)
-	DeleteScheduleTeam(id, teamID uint) (err error)
+	DeleteSchedule(ctx context.Context, id uint) (err error)
+	DeleteScheduleTeam(ctx context.Context, id, teamID uint) (err error)
 	GetListSchedule(
 		ctx context.Context,
 		filter model.FilterSchedule,
 		pg *utils.PaginateQueryOffset,
 	) (response model.ListScheduleResponse, err error)
-	GetDetailSchedule(id uint) (schedule model.ScheduleDetail, err error)
+	GetDetailSchedule(ctx context.Context, id uint) (schedule model.ScheduleDetail, err error)
 }
 
 type ScheduleServiceImpl struct {
@@ -35,6 +37,9 @@ type ScheduleServiceImpl struct {
 	EventRepo  evr.EventRepository
 	UserRepo   ur.UserRepository
 	TeamRepo   tr.TeamRepository
+	StaffRepo  evr.EventStaffRepository
+	Scope      evs.EventScope
+	Audit      ads.Recorder
 }
 
 func NewScheduleService(
@@ -42,8 +47,19 @@ func NewScheduleService(
 	eventRepo evr.EventRepository,
 	userRepo ur.UserRepository,
 	teamRepo tr.TeamRepository,
+	staffRepo evr.EventStaffRepository,
+	scope evs.EventScope,
+	audit ads.Recorder,
 ) ScheduleService {
-	return &ScheduleServiceImpl{Repository: repository, EventRepo: eventRepo, UserRepo: userRepo, TeamRepo: teamRepo}
+	return &ScheduleServiceImpl{
+		Repository: repository,
+		EventRepo:  eventRepo,
+		UserRepo:   userRepo,
+		TeamRepo:   teamRepo,
+		StaffRepo:  staffRepo,
+		Scope:      scope,
+		Audit:      audit,
+	}
 }
 
 func (service *ScheduleServiceImpl) CreateSchedule(ctx context.Context, req model.ScheduleRequest) (schedule model.Schedule, err error) {
@@ -57,13 +73,11 @@ func (service *ScheduleServiceImpl) CreateSchedule(ctx context.Context, req mode
 		return
 	}
 
-	mentor, err := service.UserRepo.FindByID(req.MentorID)
-	if err != nil {
+	if err = service.Scope.Check(ctx, req.EventID, constants.EventStaffOrganizer); err != nil {
 		return
 	}
 
-	if mentor.UserRole.Name != constants.UserMentor {
-		err = e.ErrDataNotFound
+	if err = service.checkEventMentor(req.EventID, req.MentorID); err != nil {
 		return
 	}
 
@@ -86,11 +100,18 @@ func (service *ScheduleServiceImpl) CreateSchedule(ctx context.Context, req mode
 	}); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntitySchedule, schedule.ID, nil, schedule)
 	return
 }
 
-func (service *ScheduleServiceImpl) CreateScheduleTeam(req model.ScheduleTeam) (err error) {
-	if _, err = service.Repository.FindOne(req.ScheduleID); err != nil {
+func (service *ScheduleServiceImpl) CreateScheduleTeam(ctx context.Context, req model.ScheduleTeam) (err error) {
+	schedule, err := service.Repository.FindOne(req.ScheduleID)
+	if err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, schedule.EventID, constants.EventStaffOrganizer); err != nil {
 		return
 	}
 
@@ -111,13 +132,11 @@ func (service *ScheduleServiceImpl) UpdateSchedule(ctx context.Context, id uint,
 		return
 	}
 
-	mentor, err := service.UserRepo.FindByID(req.MentorID)
-	if err != nil {
+	if err = service.Scope.Check(ctx, schedule.EventID, constants.EventStaffOrganizer); err != nil {
 		return
 	}
 
-	if mentor.UserRole.Name != constants.UserMentor {
-		err = e.ErrDataNotFound
+	if err = service.checkEventMentor(schedule.EventID, req.MentorID); err != nil {
 		return
 	}
 
@@ -126,7 +145,7 @@ func (service *ScheduleServiceImpl) UpdateSchedule(ctx context.Context, id uint,
 		return
 	}
 
-	event, err := service.EventRepo.FindOne(req.EventID)
+	event, err := service.EventRepo.FindOne(schedule.EventID)
 	if err != nil {
 		return
 	}
@@ -136,6 +155,7 @@ func (service *ScheduleServiceImpl) UpdateSchedule(ctx context.Context, id uint,
 		return
 	}
 
+	before := schedule
 	schedule.MentorID = req.MentorID
 	schedule.Title = req.Title
 	schedule.HeldOn = heldOn
@@ -144,22 +164,36 @@ func (service *ScheduleServiceImpl) UpdateSchedule(ctx context.Context, id uint,
 	if err = service.Repository.Update(id, schedule); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntitySchedule, id, before, schedule)
 	return
 }
 
-func (service *ScheduleServiceImpl) DeleteSchedule(id uint) (err error) {
-	if _, err = service.Repository.FindOne(id); err != nil {
+func (service *ScheduleServiceImpl) DeleteSchedule(ctx context.Context, id uint) (err error) {
+	schedule, err := service.Repository.FindOne(id)
+	if err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, schedule.EventID, constants.EventStaffOrganizer); err != nil {
 		return
 	}
 
-	if err = service.Repository.Delete(id); err != nil {
+	if err = service.Repository.Delete(id, ctx.Value("user").(um.User).Email); err != nil {
 		return
 	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntitySchedule, id, schedule, nil)
 	return
 }
 
-func (service *ScheduleServiceImpl) DeleteScheduleTeam(id, teamID uint) (err error) {
-	if _, err = service.Repository.FindOne(id); err != nil {
+func (service *ScheduleServiceImpl) DeleteScheduleTeam(ctx context.Context, id, teamID uint) (err error) {
+	schedule, err := service.Repository.FindOne(id)
+	if err != nil {
+		return
+	}
+
+	if err = service.Scope.Check(ctx, schedule.EventID, constants.EventStaffOrganizer); err != nil {
 		return
 	}
 
@@ -177,6 +211,18 @@ func (service *ScheduleServiceImpl) GetListSchedule(
 	authenticatedUser := ctx.Value("user").(um.User)
 	if authenticatedUser.UserRole.Name == constants.UserMentor {
 		filter.MentorID = authenticatedUser.ID
+	} else {
+		eventIDs, all, errScope := service.Scope.EventIDs(ctx, constants.EventStaffOrganizer)
+		if errScope != nil {
+			err = errScope
+			return
+		}
+		if !all {
+			if len(eventIDs) == 0 {
+				return
+			}
+			filter.EventIDs = eventIDs
+		}
 	}
 
 	response.Schedules, response.TotalItem, response.TotalPage, err = service.Repository.Find(filter, pg)
@@ -186,9 +232,31 @@ func (service *ScheduleServiceImpl) GetListSchedule(
 	return
 }
 
-func (service *ScheduleServiceImpl) GetDetailSchedule(id uint) (schedule model.ScheduleDetail, err error) {
+func (service *ScheduleServiceImpl) GetDetailSchedule(ctx context.Context, id uint) (schedule model.ScheduleDetail, err error) {
 	if schedule, err = service.Repository.FindDetail(id); err != nil {
 		return
 	}
+
+	role := constants.EventStaffOrganizer
+	if ctx.Value("user").(um.User).UserRole.Name == constants.UserMentor {
+		role = constants.EventStaffMentor
+	}
+	err = service.Scope.Check(ctx, schedule.EventID, role)
 	return
 }
+
+// checkEventMentor makes sure the mentor is on the event's staff as a mentor
+func (service *ScheduleServiceImpl) checkEventMentor(eventID, mentorID uint) error {
+	if _, err := service.UserRepo.FindByID(mentorID); err != nil {
+		return err
+	}
+
+	ok, err := service.StaffRepo.HasRole(eventID, mentorID, []string{constants.EventStaffMentor})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.ErrNotEventMentor
+	}
+	return nil
+}
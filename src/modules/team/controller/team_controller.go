@@ -66,7 +66,8 @@ func (controller *TeamControllerImpl) Create(ctx *gin.Context) {
 		}
 
 		if err == e.ErrEventNotRunning || err == e.ErrNotCompleteProfile || err == e.ErrHasTeam ||
-			err == e.ErrTeamCodeAlreadyExists || err == e.ErrTeamNameAlreadyExists {
+			err == e.ErrTeamCodeAlreadyExists || err == e.ErrTeamNameAlreadyExists ||
+			err == e.ErrOutsideTimelinePhase {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -62,7 +62,7 @@ func (controller *TeamInvitationControllerImpl) Create(ctx *gin.Context) {
 
 		if err == e.ErrEventNotRunning || err == e.ErrHasTeam || err == e.ErrParticipantHasBeenInvited ||
 			err == e.ErrRegistrationNotCompleted || err == e.ErrPaymentNotPaid || err == e.ErrTeamIsFull ||
-			err == e.ErrParticipantRequestedToJoinTeam {
+			err == e.ErrParticipantRequestedToJoinTeam || err == e.ErrOutsideTimelinePhase {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -160,7 +160,8 @@ func (controller *TeamInvitationControllerImpl) UpdateStatus(ctx *gin.Context) {
 			return
 		}
 
-		if err == e.ErrInvitationHasBeenProceed || err == e.ErrHasTeam || err == e.ErrTeamIsFull {
+		if err == e.ErrInvitationHasBeenProceed || err == e.ErrHasTeam || err == e.ErrTeamIsFull ||
+			err == e.ErrOutsideTimelinePhase {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -61,7 +61,8 @@ func (controller *TeamRequestControllerImpl) Create(ctx *gin.Context) {
 
 		if err == e.ErrEventNotRunning || err == e.ErrHasTeam || err == e.ErrParticipantHasBeenInvited ||
 			err == e.ErrRegistrationNotCompleted || err == e.ErrPaymentNotPaid || err == e.ErrTeamIsFull ||
-			err == e.ErrParticipantRequestedToJoinTeam || err == e.ErrCannotRequestToJoinYourTeam {
+			err == e.ErrParticipantRequestedToJoinTeam || err == e.ErrCannotRequestToJoinYourTeam ||
+			err == e.ErrOutsideTimelinePhase {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -216,7 +217,8 @@ func (controller *TeamRequestControllerImpl) UpdateStatus(ctx *gin.Context) {
 			return
 		}
 
-		if err == e.ErrTeamReqHasBeenProceed || err == e.ErrHasTeam || err == e.ErrTeamIsFull {
+		if err == e.ErrTeamReqHasBeenProceed || err == e.ErrHasTeam || err == e.ErrTeamIsFull ||
+			err == e.ErrOutsideTimelinePhase {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -2,6 +2,9 @@ package team
 
 import (
 	ever "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	"be-sagara-hackathon/src/modules/general/audit"
+	"be-sagara-hackathon/src/modules/general/outbox"
 	"be-sagara-hackathon/src/modules/team/controller"
 	"be-sagara-hackathon/src/modules/team/repository"
 	"be-sagara-hackathon/src/modules/team/service"
@@ -47,6 +50,8 @@ func (module ModuleImpl) InitModule() {
 	teamInvitationRepository = repository.NewTeamInvitationRepository(module.DB)
 	teamRequestRepository = repository.NewTeamRequestRepository(module.DB)
 	teamRepository = repository.NewTeamRepository(module.DB)
+	mailer := outbox.GetMailer()
+	timelineGuard := evs.NewEventTimelineGuard(ever.NewEventTimelineRepository(module.DB))
 
 	teamService = service.NewTeamService(
 		teamRepository,
@@ -56,6 +61,8 @@ func (module ModuleImpl) InitModule() {
 		participantRepository,
 		eventRepository,
 		eventParticipantRepository,
+		timelineGuard,
+		audit.GetRecorder(),
 	)
 	teamController = controller.NewTeamController(teamService)
 
@@ -66,6 +73,9 @@ func (module ModuleImpl) InitModule() {
 		teamRequestRepository,
 		participantRepository,
 		eventRepository,
+		mailer,
+		timelineGuard,
+		audit.GetRecorder(),
 	)
 	teamInvitationController = controller.NewTeamInvitationController(teamInvitationService)
 
@@ -76,10 +86,13 @@ func (module ModuleImpl) InitModule() {
 		teamInvitationRepository,
 		participantRepository,
 		eventRepository,
+		mailer,
+		timelineGuard,
+		audit.GetRecorder(),
 	)
 	teamRequestController = controller.NewTeamRequestController(teamRequestService)
 
-	teamMemberService = service.NewTeamMemberService(teamMemberRepository)
+	teamMemberService = service.NewTeamMemberService(teamMemberRepository, audit.GetRecorder())
 	teamMemberController = controller.NewTeamMemberController(teamMemberService)
 }
 
@@ -77,7 +77,7 @@ func (repository *TeamInvitationRepositoryImpl) Update(id uint, invitation model
 }
 
 func (repository *TeamInvitationRepositoryImpl) Delete(id uint) error {
-	if err := repository.DB.Delete(&model.TeamInvitation{}, id).Error; err != nil {
+	if err := repository.DB.Unscoped().Delete(&model.TeamInvitation{}, id).Error; err != nil {
 		return err
 	}
 	return nil
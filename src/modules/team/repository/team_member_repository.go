@@ -42,7 +42,7 @@ func (repository *TeamMemberRepositoryImpl) SaveBatch(members []model.TeamMember
 }
 
 func (repository *TeamMemberRepositoryImpl) Delete(id uint) error {
-	if err := repository.DB.Delete(&model.TeamMember{}, id).Error; err != nil {
+	if err := repository.DB.Unscoped().Delete(&model.TeamMember{}, id).Error; err != nil {
 		return err
 	}
 	return nil
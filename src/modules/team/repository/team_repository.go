@@ -3,6 +3,7 @@ package repository
 import (
 	"be-sagara-hackathon/src/modules/team/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"database/sql"
 	"errors"
@@ -11,7 +12,6 @@ import (
 	"gorm.io/gorm"
 	"math"
 	"strings"
-	"time"
 )
 
 type TeamRepository interface {
@@ -84,10 +84,7 @@ func (repository *TeamRepositoryImpl) Update(id uint, team model.Team) error {
 }
 
 func (repository *TeamRepositoryImpl) Delete(id uint, deletedBy string) error {
-	if err := repository.DB.Model(&model.Team{}).Where("id=?", id).Updates(map[string]interface{}{
-		"deleted_at": time.Now(),
-		"deleted_by": deletedBy,
-	}).Error; err != nil {
+	if err := repository.DB.Scopes(common.DeletedBy(deletedBy)).Delete(&model.Team{}, id).Error; err != nil {
 		return err
 	}
 	return nil
@@ -60,7 +60,7 @@ func (repository *TeamRequestRepositoryImpl) Update(id uint, request model.TeamR
 }
 
 func (repository *TeamRequestRepositoryImpl) Delete(requestId uint) error {
-	if err := repository.DB.Delete(&model.TeamRequest{}, requestId).Error; err != nil {
+	if err := repository.DB.Unscoped().Delete(&model.TeamRequest{}, requestId).Error; err != nil {
 		return err
 	}
 	return nil
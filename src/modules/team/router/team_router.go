@@ -17,19 +17,19 @@ func TeamRouter(group *gin.RouterGroup) {
 		team.GetTeamController().Update,
 	)
 	group.PUT("/:id/status",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionTeamManage),
 		team.GetTeamController().UpdateStatus,
 	)
 	group.DELETE("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionTeamManage),
 		team.GetTeamController().Delete,
 	)
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin, constants.UserMentor),
+		middlewares.Permission(constants.PermissionTeamList),
 		team.GetTeamController().GetAll,
 	)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionTeamView),
 		team.GetTeamController().GetDetail,
 	)
 	group.GET("/:id/detail",
@@ -37,7 +37,7 @@ func TeamRouter(group *gin.RouterGroup) {
 		team.GetTeamController().GetDetail2,
 	)
 	group.GET("/:id/members",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin, constants.UserParticipant),
+		middlewares.Permission(constants.PermissionTeamMemberView),
 		team.GetTeamController().GetMembers,
 	)
 	group.GET("/:id/invitations",
@@ -2,6 +2,8 @@ package service
 
 import (
 	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/team/model"
 	"be-sagara-hackathon/src/modules/team/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
@@ -15,6 +17,7 @@ import (
 	"be-sagara-hackathon/src/utils/helper"
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"time"
 )
@@ -40,6 +43,9 @@ type TeamInvitationServiceImpl struct {
 	TeamRequestRepo repository.TeamRequestRepository
 	ParticipantRepo ur.ParticipantRepository
 	EventRepo       evr.EventRepository
+	Mailer          email.Mailer
+	TimelineGuard   evs.EventTimelineGuard
+	Audit           ads.Recorder
 }
 
 func NewTeamInvitationService(
@@ -49,6 +55,9 @@ func NewTeamInvitationService(
 	teamRequestRepo repository.TeamRequestRepository,
 	participantRepo ur.ParticipantRepository,
 	eventRepo evr.EventRepository,
+	mailer email.Mailer,
+	timelineGuard evs.EventTimelineGuard,
+	audit ads.Recorder,
 ) TeamInvitationService {
 	return &TeamInvitationServiceImpl{
 		Repository:      repository,
@@ -57,6 +66,9 @@ func NewTeamInvitationService(
 		TeamRequestRepo: teamRequestRepo,
 		ParticipantRepo: participantRepo,
 		EventRepo:       eventRepo,
+		Mailer:          mailer,
+		TimelineGuard:   timelineGuard,
+		Audit:           audit,
 	}
 }
 
@@ -70,6 +82,10 @@ func (service *TeamInvitationServiceImpl) Create(ctx context.Context, request mo
 		return e.ErrEventNotRunning
 	}
 
+	if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseTeamFormation); err != nil {
+		return err
+	}
+
 	team, err := service.TeamRepo.FindByIDAndEventID(request.TeamID, request.EventID)
 	if err != nil {
 		return err
@@ -99,7 +115,7 @@ func (service *TeamInvitationServiceImpl) Create(ctx context.Context, request mo
 	if err != nil && err != e.ErrDataNotFound {
 		return err
 	}
-	if member.ID != 0 && member.Team.DeletedAt == nil && member.Team.IsActive {
+	if member.ID != 0 && member.Team.IsActive {
 		err = e.ErrHasTeam
 		return err
 	}
@@ -144,9 +160,6 @@ func (service *TeamInvitationServiceImpl) Create(ctx context.Context, request mo
 		return err
 	}
 
-	//TODO:
-	// - send email team invitation [OK]
-	// - use goroutine/task queue
 	templateData := email.TeamInvitationTemplateData{
 		Title:       constants.EmailSubjectTeamInvitation,
 		InvitedName: invitedParticipant.User.Name,
@@ -156,15 +169,15 @@ func (service *TeamInvitationServiceImpl) Create(ctx context.Context, request mo
 	}
 
 	r := email.NewRequest([]string{invitedParticipant.User.Email}, constants.EmailSubjectTeamInvitation, "")
-	if err = r.ParseTemplate("./src/utils/email/template_email_team_invitation.html", templateData); err == nil {
-		ok, err := r.SendEmail()
-		if !ok || err != nil {
-			return e.ErrFailedSendEmail
-		}
-	} else {
+	if err = r.ParseTemplate(email.TemplateTeamInvitation, templateData); err != nil {
 		return e.ErrFailedParseEmailTemplate
 	}
 
+	// the invitation is saved already and can still be seen from the app
+	if err = service.Mailer.Send(*r); err != nil {
+		log.Printf("failed to queue team invitation email for %s: %v", invitedParticipant.User.Email, err)
+	}
+
 	return nil
 }
 
@@ -227,7 +240,7 @@ func (service *TeamInvitationServiceImpl) UpdateStatus(ctx context.Context, code
 	if err != nil && err != e.ErrDataNotFound {
 		return err
 	}
-	if member.ID != 0 && member.Team.DeletedAt == nil && member.Team.IsActive {
+	if member.ID != 0 && member.Team.IsActive {
 		err = e.ErrHasTeam
 		return err
 	}
@@ -239,6 +252,10 @@ func (service *TeamInvitationServiceImpl) UpdateStatus(ctx context.Context, code
 			return err2
 		}
 
+		if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseTeamFormation); err != nil {
+			return err
+		}
+
 		if team.NumOfMember >= event.TeamMaxMember {
 			return e.ErrTeamIsFull
 		}
@@ -264,9 +281,20 @@ func (service *TeamInvitationServiceImpl) UpdateStatus(ctx context.Context, code
 		return err
 	}
 
+	updated := invitation
+	updated.Status, updated.ProceedAt = updateInvitation.Status, updateInvitation.ProceedAt
+	service.Audit.Record(ctx, invitationAuditAction(request.Status), constants.AuditEntityTeamInvitation, invitation.ID, invitation, updated)
 	return nil
 }
 
+// invitationAuditAction is the audit action of answering an invitation or a join request with status
+func invitationAuditAction(status string) string {
+	if status == constants.InvitationOrRequestStatusAccepted {
+		return constants.AuditApprove
+	}
+	return constants.AuditReject
+}
+
 func (service *TeamInvitationServiceImpl) Delete(ctx context.Context, id uint) error {
 	authenticatedUser := ctx.Value("user").(um.User)
 	invitation, err := service.Repository.FindOne(id)
@@ -1,8 +1,10 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/team/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"context"
 )
@@ -14,15 +16,18 @@ type TeamMemberService interface {
 type TeamMemberServiceImpl struct {
 	Repository repository.TeamMemberRepository
 	//TeamRepository repository.TeamRepository
+	Audit ads.Recorder
 }
 
 func NewTeamMemberService(
 	repository repository.TeamMemberRepository,
 	// teamRepository repository.TeamRepository,
+	audit ads.Recorder,
 ) TeamMemberService {
 	return &TeamMemberServiceImpl{
 		Repository: repository,
 		//TeamRepository: teamRepository,
+		Audit: audit,
 	}
 }
 
@@ -46,5 +51,6 @@ func (service *TeamMemberServiceImpl) Delete(ctx context.Context, id uint) error
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityTeamMember, id, teamMember, nil)
 	return nil
 }
@@ -2,6 +2,8 @@ package service
 
 import (
 	evr "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/team/model"
 	"be-sagara-hackathon/src/modules/team/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
@@ -15,6 +17,7 @@ import (
 	"be-sagara-hackathon/src/utils/helper"
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"time"
 )
@@ -35,6 +38,9 @@ type TeamRequestServiceImpl struct {
 	TeamInvitationRepo repository.TeamInvitationRepository
 	ParticipantRepo    ur.ParticipantRepository
 	EventRepo          evr.EventRepository
+	Mailer             email.Mailer
+	TimelineGuard      evs.EventTimelineGuard
+	Audit              ads.Recorder
 }
 
 func NewTeamRequestService(
@@ -44,6 +50,9 @@ func NewTeamRequestService(
 	teamInvitationRepo repository.TeamInvitationRepository,
 	participantRepo ur.ParticipantRepository,
 	eventRepo evr.EventRepository,
+	mailer email.Mailer,
+	timelineGuard evs.EventTimelineGuard,
+	audit ads.Recorder,
 ) TeamRequestService {
 	return &TeamRequestServiceImpl{
 		Repository:         repository,
@@ -52,6 +61,9 @@ func NewTeamRequestService(
 		TeamInvitationRepo: teamInvitationRepo,
 		ParticipantRepo:    participantRepo,
 		EventRepo:          eventRepo,
+		Mailer:             mailer,
+		TimelineGuard:      timelineGuard,
+		Audit:              audit,
 	}
 }
 
@@ -73,6 +85,10 @@ func (service *TeamRequestServiceImpl) Create(ctx context.Context, request model
 		return e.ErrEventNotRunning
 	}
 
+	if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseTeamFormation); err != nil {
+		return err
+	}
+
 	team, err := service.TeamRepo.FindByIDAndEventID(request.TeamID, request.EventID)
 	if err != nil {
 		return err
@@ -90,7 +106,7 @@ func (service *TeamRequestServiceImpl) Create(ctx context.Context, request model
 	if err != nil && err != e.ErrDataNotFound {
 		return err
 	}
-	if member.ID != 0 && member.Team.DeletedAt == nil && member.Team.IsActive {
+	if member.ID != 0 && member.Team.IsActive {
 		err = e.ErrHasTeam
 		return err
 	}
@@ -133,9 +149,6 @@ func (service *TeamRequestServiceImpl) Create(ctx context.Context, request model
 		return err
 	}
 
-	//TODO:
-	// - send email team request [OK]
-	// - use goroutine/task queue
 	templateData := email.TeamRequestTemplateData{
 		Title:           constants.EmailSubjectTeamRequest,
 		TeamCreatorName: team.ParticipantName,
@@ -145,15 +158,15 @@ func (service *TeamRequestServiceImpl) Create(ctx context.Context, request model
 	}
 
 	r := email.NewRequest([]string{team.ParticipantEmail}, constants.EmailSubjectTeamRequest, "")
-	if err = r.ParseTemplate("./src/utils/email/template_email_team_request.html", templateData); err == nil {
-		ok, err := r.SendEmail()
-		if !ok || err != nil {
-			return e.ErrFailedSendEmail
-		}
-	} else {
+	if err = r.ParseTemplate(email.TemplateTeamRequest, templateData); err != nil {
 		return e.ErrFailedParseEmailTemplate
 	}
 
+	// the request is saved already and can still be seen from the app
+	if err = service.Mailer.Send(*r); err != nil {
+		log.Printf("failed to queue team request email for %s: %v", team.ParticipantEmail, err)
+	}
+
 	return nil
 }
 
@@ -212,7 +225,7 @@ func (service *TeamRequestServiceImpl) UpdateStatus(ctx context.Context, code st
 	if err != nil && err != e.ErrDataNotFound {
 		return err
 	}
-	if member.ID != 0 && member.Team.DeletedAt == nil && member.Team.IsActive {
+	if member.ID != 0 && member.Team.IsActive {
 		err = e.ErrHasTeam
 		return err
 	}
@@ -224,6 +237,10 @@ func (service *TeamRequestServiceImpl) UpdateStatus(ctx context.Context, code st
 			return err2
 		}
 
+		if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseTeamFormation); err != nil {
+			return err
+		}
+
 		if team.NumOfMember >= event.TeamMaxMember {
 			return e.ErrTeamIsFull
 		}
@@ -250,6 +267,10 @@ func (service *TeamRequestServiceImpl) UpdateStatus(ctx context.Context, code st
 		return err
 	}
 
+	updated := teamReq
+	updated.Status, updated.ProceedAt = updateTeamReq.Status, updateTeamReq.ProceedAt
+	updated.ProceedBy = updateTeamReq.ProceedBy
+	service.Audit.Record(ctx, invitationAuditAction(request.Status), constants.AuditEntityTeamRequest, teamReq.ID, teamReq, updated)
 	return nil
 }
 
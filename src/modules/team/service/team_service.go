@@ -2,6 +2,8 @@ package service
 
 import (
 	ever "be-sagara-hackathon/src/modules/event/repository"
+	evs "be-sagara-hackathon/src/modules/event/service"
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/team/model"
 	"be-sagara-hackathon/src/modules/team/repository"
 	um "be-sagara-hackathon/src/modules/user/model"
@@ -44,6 +46,8 @@ type TeamServiceImpl struct {
 	ParticipantRepo      ur.ParticipantRepository
 	EventRepo            ever.EventRepository
 	EventParticipantRepo ever.EventParticipantRepository
+	TimelineGuard        evs.EventTimelineGuard
+	Audit                ads.Recorder
 }
 
 func NewTeamService(
@@ -54,6 +58,8 @@ func NewTeamService(
 	participantRepository ur.ParticipantRepository,
 	eventRepository ever.EventRepository,
 	eventParticipantRepo ever.EventParticipantRepository,
+	timelineGuard evs.EventTimelineGuard,
+	audit ads.Recorder,
 ) TeamService {
 	return &TeamServiceImpl{
 		Repository:           teamRepository,
@@ -63,6 +69,8 @@ func NewTeamService(
 		ParticipantRepo:      participantRepository,
 		EventRepo:            eventRepository,
 		EventParticipantRepo: eventParticipantRepo,
+		TimelineGuard:        timelineGuard,
+		Audit:                audit,
 	}
 }
 
@@ -80,6 +88,10 @@ func (service *TeamServiceImpl) Create(ctx context.Context, request model.Create
 		return
 	}
 
+	if err = service.TimelineGuard.CheckPhase(event.ID, constants.TimelinePhaseTeamFormation); err != nil {
+		return
+	}
+
 	//get participant (creator)
 	participant, err := service.ParticipantRepo.FindByEmail(authenticatedUser.Email)
 	if err != nil {
@@ -101,7 +113,7 @@ func (service *TeamServiceImpl) Create(ctx context.Context, request model.Create
 	if err != nil && err != e.ErrDataNotFound {
 		return
 	}
-	if member.ID != 0 && member.Team.DeletedAt == nil && member.Team.IsActive {
+	if member.ID != 0 && member.Team.IsActive {
 		err = e.ErrHasTeam
 		return
 	}
@@ -157,24 +169,30 @@ func (service *TeamServiceImpl) UpdateStatus(ctx context.Context, id uint, reque
 		return err
 	}
 
+	before := team
 	team.IsActive = request.IsActive
 	team.UpdatedBy = authenticatedUser.Email
 	team.UpdatedAt = time.Now()
 	if err = service.Repository.Update(id, team); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditStatus, constants.AuditEntityTeam, id, before, team)
 	return nil
 }
 
 func (service *TeamServiceImpl) Delete(ctx context.Context, id uint) error {
 	authenticatedUser := ctx.Value("user").(um.User)
-	if _, err := service.Repository.FindOne(id); err != nil {
+	team, err := service.Repository.FindOne(id)
+	if err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(id, authenticatedUser.Email); err != nil {
+	if err = service.Repository.Delete(id, authenticatedUser.Email); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityTeam, id, team, nil)
 	return nil
 }
 
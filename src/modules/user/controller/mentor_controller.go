@@ -58,7 +58,7 @@ func (controller *MentorControllerImpl) Create(ctx *gin.Context) {
 
 	err := controller.Service.Create(ctx, request)
 	if err != nil {
-		if err == e.ErrEmailAlreadyExists || err == e.ErrPhoneNumberAlreadyExists {
+		if err == e.ErrEmailAlreadyExists || err == e.ErrPhoneNumberAlreadyExists || utils.IsPasswordPolicyError(err) {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
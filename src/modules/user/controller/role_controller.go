@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/service"
+	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type RoleController interface {
+	GetList(ctx *gin.Context)
+	GetPermissions(ctx *gin.Context)
+	UpdatePermissions(ctx *gin.Context)
+}
+
+type RoleControllerImpl struct {
+	Service service.RoleService
+}
+
+func NewRoleController(service service.RoleService) RoleController {
+	return &RoleControllerImpl{Service: service}
+}
+
+// GetList Get Roles godoc
+// @Tags Role
+// @Summary Get Roles
+// @Description Get every role with the permissions it grants
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Router /roles [get]
+func (controller *RoleControllerImpl) GetList(ctx *gin.Context) {
+	data, err := controller.Service.GetList()
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Role Success", data)
+}
+
+// GetPermissions Get Permissions godoc
+// @Tags Role
+// @Summary Get Permissions
+// @Description Get every permission that can be granted to a role
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Router /roles/permissions [get]
+func (controller *RoleControllerImpl) GetPermissions(ctx *gin.Context) {
+	data, err := controller.Service.GetPermissions()
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Permission Success", data)
+}
+
+// UpdatePermissions Update Role Permissions godoc
+// @Tags Role
+// @Summary Update Role Permissions
+// @Description Replace the permissions of a role
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path int true "Role ID"
+// @Param body body model.UpdateRolePermissionsRequest true "Body Request"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 400 {object} src.BaseFailure
+// @Failure 404 {object} src.BaseFailure
+// @Router /roles/{id}/permissions [put]
+func (controller *RoleControllerImpl) UpdatePermissions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid Id", []string{err.Error()})
+		return
+	}
+
+	var request model.UpdateRolePermissionsRequest
+	if errorBinding := ctx.ShouldBindJSON(&request); errorBinding != nil {
+		if errorBinding.Error() == "EOF" {
+			common.SendError(ctx, http.StatusBadRequest, "Body is empty", []string{"Body required"})
+			return
+		}
+
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", utils.SplitError(errorBinding))
+		return
+	}
+
+	// Validate request body
+	if errs := utils.NewCustomValidator().ValidateStruct(request); errs != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid request", errs)
+		return
+	}
+
+	if err = controller.Service.UpdatePermissions(ctx, uint(id), request); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+
+		if err == e.ErrUnknownPermission || err == e.ErrRoleManageLockout {
+			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
+			return
+		}
+
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Update Role Permissions Success", nil)
+}
@@ -59,7 +59,7 @@ func (controller *UserControllerImpl) CreateUser(ctx *gin.Context) {
 	}
 
 	if err := controller.Service.CreateUser(ctx, request); err != nil {
-		if err == e.ErrEmailAlreadyExists || err == e.ErrPhoneNumberAlreadyExists {
+		if err == e.ErrEmailAlreadyExists || err == e.ErrPhoneNumberAlreadyExists || utils.IsPasswordPolicyError(err) {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
@@ -202,7 +202,8 @@ func (controller *UserControllerImpl) ChangePassword(ctx *gin.Context) {
 
 	err := controller.Service.ChangePassword(ctx, request)
 	if err != nil {
-		if err == e.ErrConfirmPasswordNotSame || err == e.ErrWrongOldPassword || err == e.ErrWrongAuthMethod {
+		if err == e.ErrConfirmPasswordNotSame || err == e.ErrWrongOldPassword || err == e.ErrWrongAuthMethod ||
+			utils.IsPasswordPolicyError(err) {
 			common.SendError(ctx, http.StatusBadRequest, "Bad Request", []string{err.Error()})
 			return
 		}
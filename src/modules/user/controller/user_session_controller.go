@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"be-sagara-hackathon/src/modules/user/service"
+	"be-sagara-hackathon/src/utils/common"
+	e "be-sagara-hackathon/src/utils/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type UserSessionController interface {
+	GetList(ctx *gin.Context)
+	Revoke(ctx *gin.Context)
+	RevokeAll(ctx *gin.Context)
+	ForceLogout(ctx *gin.Context)
+}
+
+type UserSessionControllerImpl struct {
+	Service service.UserSessionService
+}
+
+func NewUserSessionController(service service.UserSessionService) UserSessionController {
+	return &UserSessionControllerImpl{Service: service}
+}
+
+// GetList Get User Sessions godoc
+// @Tags User
+// @Summary Get Active Sessions
+// @Description Get the devices the authenticated user is logged in on
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Router /users/profile/sessions [get]
+func (controller *UserSessionControllerImpl) GetList(ctx *gin.Context) {
+	data, err := controller.Service.GetList(ctx)
+	if err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Get List Session Success", data)
+}
+
+// Revoke Revoke User Session godoc
+// @Tags User
+// @Summary Revoke Session
+// @Description Log the authenticated user out of one device
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path int true "Session ID"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 404 {object} src.BaseFailure
+// @Router /users/profile/sessions/{id} [delete]
+func (controller *UserSessionControllerImpl) Revoke(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid Id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.Revoke(ctx, uint(id)); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Revoke Session Success", nil)
+}
+
+// RevokeAll Revoke All User Sessions godoc
+// @Tags User
+// @Summary Log Out Everywhere
+// @Description Log the authenticated user out of every device, including the current one
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} src.BaseSuccess
+// @Router /users/profile/sessions [delete]
+func (controller *UserSessionControllerImpl) RevokeAll(ctx *gin.Context) {
+	if err := controller.Service.RevokeAll(ctx); err != nil {
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Revoke All Session Success", nil)
+}
+
+// ForceLogout Force Logout User godoc
+// @Tags User
+// @Summary Force Logout User
+// @Description End every session of a user
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} src.BaseSuccess
+// @Failure 404 {object} src.BaseFailure
+// @Router /users/{id}/logout [post]
+func (controller *UserSessionControllerImpl) ForceLogout(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, http.StatusBadRequest, "Invalid Id", []string{err.Error()})
+		return
+	}
+
+	if err = controller.Service.ForceLogout(ctx, uint(id)); err != nil {
+		if err == e.ErrDataNotFound {
+			common.SendError(ctx, http.StatusNotFound, "Not Found Error", []string{err.Error()})
+			return
+		}
+		common.SendError(ctx, http.StatusInternalServerError, "Internal Server Error", []string{err.Error()})
+		return
+	}
+
+	common.SendSuccess(ctx, http.StatusOK, "Force Logout User Success", nil)
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"be-sagara-hackathon/src/modules/general/audit"
 	"be-sagara-hackathon/src/modules/user/controller"
 	"be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/modules/user/service"
@@ -20,6 +21,10 @@ var (
 	mentorController      controller.MentorController
 	judgeService          service.JudgeService
 	judgeController       controller.JudgeController
+	sessionRepository     repository.UserSessionRepository
+	sessionController     controller.UserSessionController
+	permissionRepository  repository.PermissionRepository
+	roleController        controller.RoleController
 )
 
 type Module interface {
@@ -35,17 +40,24 @@ func New(database *gorm.DB) Module {
 }
 
 func (module ModuleImpl) InitModule() {
+	auditRecorder := audit.GetRecorder()
 	userRoleRepository = repository.NewUserRoleRepository(module.DB)
 	userRepository = repository.NewUserRepository(module.DB)
-	userService = service.NewUserService(userRepository)
+	userService = service.NewUserService(userRepository, auditRecorder)
 	userController = controller.NewUserController(userService)
 	participantRepository = repository.NewParticipantRepository(module.DB)
-	participantService = service.NewParticipantService(participantRepository, userRepository, userRoleRepository)
+	participantService = service.NewParticipantService(participantRepository, userRepository, userRoleRepository, auditRecorder)
 	participantController = controller.NewParticipantController(participantService)
-	mentorService = service.NewMentorService(userRepository, userRoleRepository)
+	mentorService = service.NewMentorService(userRepository, userRoleRepository, auditRecorder)
 	mentorController = controller.NewMentorController(mentorService)
-	judgeService = service.NewJudgeService(userRepository, userRoleRepository)
+	judgeService = service.NewJudgeService(userRepository, userRoleRepository, auditRecorder)
 	judgeController = controller.NewJudgeController(judgeService)
+	sessionRepository = repository.NewUserSessionRepository(module.DB)
+	sessionController = controller.NewUserSessionController(
+		service.NewUserSessionService(sessionRepository, userRepository, auditRecorder),
+	)
+	permissionRepository = repository.NewPermissionRepository(module.DB)
+	roleController = controller.NewRoleController(service.NewRoleService(permissionRepository, auditRecorder))
 }
 
 func GetUserController() controller.UserController {
@@ -67,3 +79,19 @@ func GetMentorController() controller.MentorController {
 func GetJudgeController() controller.JudgeController {
 	return judgeController
 }
+
+func GetUserSessionRepository() repository.UserSessionRepository {
+	return sessionRepository
+}
+
+func GetUserSessionController() controller.UserSessionController {
+	return sessionController
+}
+
+func GetPermissionRepository() repository.PermissionRepository {
+	return permissionRepository
+}
+
+func GetRoleController() controller.RoleController {
+	return roleController
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"be-sagara-hackathon/src/utils/common"
+	"time"
+)
+
+type Permission struct {
+	common.BaseEntity
+	Name        string `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Description string `gorm:"type:varchar(255)" json:"description"`
+}
+
+// RolePermission grants a permission to every user of a role
+type RolePermission struct {
+	UserRoleID   uint       `gorm:"primaryKey"`
+	UserRole     UserRole   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	PermissionID uint       `gorm:"primaryKey"`
+	Permission   Permission `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt    time.Time  `gorm:"not null;autoCreateTime"`
+	CreatedBy    string     `gorm:"type:varchar(36);null;default:NULL"`
+}
+
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"required"`
+}
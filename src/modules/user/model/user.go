@@ -3,6 +3,7 @@ package model
 import (
 	ocm "be-sagara-hackathon/src/modules/master-data/occupation/model"
 	"be-sagara-hackathon/src/utils/common"
+	"time"
 )
 
 type User struct {
@@ -21,6 +22,8 @@ type User struct {
 	Occupation   *ocm.Occupation `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"occupation"`
 	Institution  *string         `gorm:"type:varchar(255)" json:"institution"`
 	Participant  *Participant    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	// TokensRevokedAt invalidates every access token issued before it
+	TokensRevokedAt *time.Time `gorm:"null" json:"-"`
 }
 
 type UserRole struct {
@@ -3,9 +3,9 @@ package repository
 import (
 	evm "be-sagara-hackathon/src/modules/event/model"
 	pym "be-sagara-hackathon/src/modules/payment/model"
+	prr "be-sagara-hackathon/src/modules/promotion/repository"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
-	"be-sagara-hackathon/src/utils/constants"
 	e "be-sagara-hackathon/src/utils/errors"
 	"database/sql"
 	"fmt"
@@ -69,7 +69,7 @@ func (repository *ParticipantRepositoryImpl) Update(req model.UpdateParticipant)
 	}
 
 	if len(req.RemovedSkills) > 0 {
-		err = tx.Delete(&model.ParticipantSkill{}, "participant_id=? AND skill_id in (?)", req.ID, req.RemovedSkills).Error
+		err = tx.Unscoped().Delete(&model.ParticipantSkill{}, "participant_id=? AND skill_id in (?)", req.ID, req.RemovedSkills).Error
 		if err != nil {
 			tx.Rollback()
 			return
@@ -90,7 +90,7 @@ func (repository *ParticipantRepositoryImpl) UpdateRegistrationStatus(
 
 	updateModel := map[string]interface{}{"is_registered": isRegistered}
 	if invoice != nil {
-		updateModel["payment_status"] = constants.InvoiceUnpaid
+		updateModel["payment_status"] = invoice.Status
 	}
 
 	if err = tx.Model(&model.Participant{}).
@@ -112,6 +112,13 @@ func (repository *ParticipantRepositoryImpl) UpdateRegistrationStatus(
 			tx.Rollback()
 			return
 		}
+
+		if invoice.VoucherID != nil {
+			if err = prr.RedeemVoucher(tx, *invoice.VoucherID); err != nil {
+				tx.Rollback()
+				return
+			}
+		}
 	}
 
 	tx.Commit()
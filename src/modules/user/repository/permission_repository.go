@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	e "be-sagara-hackathon/src/utils/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PermissionRepository interface {
+	FindAll() ([]model.Permission, error)
+	FindByNames(names []string) ([]model.Permission, error)
+	FindRoles() ([]model.UserRole, error)
+	FindRoleByID(roleID uint) (model.UserRole, error)
+	FindNamesByRoleID(roleID uint) ([]string, error)
+	HasAny(roleID uint, names []string) (bool, error)
+	ReplaceRolePermissions(roleID uint, permissionIDs []uint, actor string) error
+}
+
+type PermissionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) PermissionRepository {
+	return &PermissionRepositoryImpl{DB: db}
+}
+
+func (repository *PermissionRepositoryImpl) FindAll() (permissions []model.Permission, err error) {
+	err = repository.DB.Order("name asc").Find(&permissions).Error
+	return
+}
+
+func (repository *PermissionRepositoryImpl) FindByNames(names []string) (permissions []model.Permission, err error) {
+	err = repository.DB.Where("name IN ?", names).Find(&permissions).Error
+	return
+}
+
+func (repository *PermissionRepositoryImpl) FindRoles() (roles []model.UserRole, err error) {
+	err = repository.DB.Order("id asc").Find(&roles).Error
+	return
+}
+
+func (repository *PermissionRepositoryImpl) FindRoleByID(roleID uint) (role model.UserRole, err error) {
+	if err = repository.DB.Where("id=?", roleID).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+	}
+	return
+}
+
+func (repository *PermissionRepositoryImpl) FindNamesByRoleID(roleID uint) (names []string, err error) {
+	err = repository.DB.Model(&model.Permission{}).
+		Joins("JOIN role_permissions rp ON rp.permission_id = permissions.id").
+		Where("rp.user_role_id = ?", roleID).
+		Order("permissions.name asc").
+		Pluck("permissions.name", &names).Error
+	return
+}
+
+func (repository *PermissionRepositoryImpl) HasAny(roleID uint, names []string) (bool, error) {
+	var total int64
+	if err := repository.DB.Model(&model.RolePermission{}).
+		Joins("JOIN permissions p ON p.id = role_permissions.permission_id").
+		Where("role_permissions.user_role_id = ? AND p.name IN ?", roleID, names).
+		Count(&total).Error; err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+func (repository *PermissionRepositoryImpl) ReplaceRolePermissions(roleID uint, permissionIDs []uint, actor string) error {
+	tx := repository.DB.Begin()
+	if err := tx.Where("user_role_id=?", roleID).Delete(&model.RolePermission{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var grants []model.RolePermission
+	for _, id := range permissionIDs {
+		grants = append(grants, model.RolePermission{
+			UserRoleID:   roleID,
+			PermissionID: id,
+			CreatedAt:    time.Now(),
+			CreatedBy:    actor,
+		})
+	}
+	if len(grants) > 0 {
+		if err := tx.Omit("UserRole", "Permission").Create(&grants).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
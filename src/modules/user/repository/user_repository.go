@@ -4,6 +4,7 @@ import (
 	aum "be-sagara-hackathon/src/modules/auth/model"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/utils"
+	"be-sagara-hackathon/src/utils/common"
 	e "be-sagara-hackathon/src/utils/errors"
 	"database/sql"
 	"errors"
@@ -59,7 +60,7 @@ func (repository *UserRepositoryImpl) Save(user model.User) error {
 }
 
 func (repository *UserRepositoryImpl) Update(userID uint, user model.User) error {
-	if err := repository.DB.Model(&model.User{}).Select("*").
+	if err := repository.DB.Model(&model.User{}).Select("*").Omit("tokens_revoked_at").
 		Where("id = ?", userID).
 		Updates(&user).
 		Error; err != nil {
@@ -75,6 +76,10 @@ func (repository *UserRepositoryImpl) Update(userID uint, user model.User) error
 		}
 		return err
 	}
+
+	if !user.IsActive {
+		return revokeUserTokens(repository.DB, userID)
+	}
 	return nil
 }
 
@@ -84,12 +89,39 @@ func (repository *UserRepositoryImpl) UpdateByEmail(user model.User) error {
 }
 
 func (repository *UserRepositoryImpl) UpdateStatus(userId uint, isActive bool) error {
-	result := repository.DB.Model(&model.User{}).Where("id=?", userId).Update("is_active", isActive)
-	return result.Error
+	tx := repository.DB.Begin()
+	if err := tx.Model(&model.User{}).Where("id=?", userId).Update("is_active", isActive).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if !isActive {
+		if err := revokeUserTokens(tx, userId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	tx.Commit()
+	return nil
 }
 
 func (repository *UserRepositoryImpl) UpdatePassword(userId uint, password, verifCode string) error {
 	tx := repository.DB.Begin()
+	// the code is used up first so two requests with the same code can't both go through
+	if len(verifCode) > 0 {
+		result := tx.Unscoped().Where("code=? AND user_id=?", verifCode, userId).
+			Delete(&aum.VerificationCode{})
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return e.ErrInvalidVerificationCode
+		}
+	}
+
 	if err := tx.Model(&model.User{}).
 		Where("id=?", userId).
 		Update("password", password).Error; err != nil {
@@ -97,12 +129,9 @@ func (repository *UserRepositoryImpl) UpdatePassword(userId uint, password, veri
 		return err
 	}
 
-	if len(verifCode) > 0 {
-		if err := tx.Where("code=?", verifCode).
-			Delete(&aum.VerificationCode{}).Error; err != nil {
-			tx.Rollback()
-			return err
-		}
+	if err := revokeUserTokens(tx, userId); err != nil {
+		tx.Rollback()
+		return err
 	}
 
 	tx.Commit()
@@ -110,15 +139,41 @@ func (repository *UserRepositoryImpl) UpdatePassword(userId uint, password, veri
 }
 
 func (repository *UserRepositoryImpl) Delete(userID uint, deletedBy string) error {
-	if err := repository.DB.Model(&model.User{}).
-		Where("id=?", userID).
-		Updates(map[string]interface{}{"deleted_at": time.Now(), "deleted_by": deletedBy}).
-		Error; err != nil {
+	tx := repository.DB.Begin()
+	if err := tx.Scopes(common.DeletedBy(deletedBy)).Delete(&model.User{}, userID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := revokeUserTokens(tx, userID); err != nil {
+		tx.Rollback()
 		return err
 	}
+
+	tx.Commit()
 	return nil
 }
 
+// revokeUserTokens invalidates every access token issued so far and ends every session of the user.
+func revokeUserTokens(tx *gorm.DB, userID uint) error {
+	now := time.Now()
+	if err := tx.Model(&model.User{}).
+		Where("id=?", userID).
+		Update("tokens_revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.UserSession{}).
+		Where("user_id=? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&aum.RefreshToken{}).
+		Where("user_id=? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+}
+
 func (repository *UserRepositoryImpl) Find(
 	filter model.FilterUser,
 	pg *utils.PaginateQueryOffset,
@@ -227,7 +282,7 @@ func (repository *UserRepositoryImpl) FindByEmail(email string) (model.User, err
 	var user model.User
 
 	result := repository.DB.
-		Where("email = ? AND deleted_by is null", email).
+		Where("email = ?", email).
 		Preload("UserRole").
 		Preload("Participant").
 		Preload("Participant.Skills").
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	aum "be-sagara-hackathon/src/modules/auth/model"
+	"be-sagara-hackathon/src/modules/user/model"
+	e "be-sagara-hackathon/src/utils/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type UserSessionRepository interface {
+	FindByID(sessionID uint) (model.UserSession, error)
+	FindActiveByUserID(userID uint) ([]model.UserSession, error)
+	Touch(sessionID uint, seenAt time.Time) error
+	Revoke(sessionID uint) error
+	RevokeAll(userID uint) error
+}
+
+type UserSessionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) UserSessionRepository {
+	return &UserSessionRepositoryImpl{DB: db}
+}
+
+func (repository *UserSessionRepositoryImpl) FindByID(sessionID uint) (model.UserSession, error) {
+	var session model.UserSession
+	if err := repository.DB.Where("id=?", sessionID).First(&session).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			err = e.ErrDataNotFound
+		}
+		return session, err
+	}
+	return session, nil
+}
+
+func (repository *UserSessionRepositoryImpl) FindActiveByUserID(userID uint) ([]model.UserSession, error) {
+	var sessions []model.UserSession
+	if err := repository.DB.
+		Where("user_id=? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at desc").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (repository *UserSessionRepositoryImpl) Touch(sessionID uint, seenAt time.Time) error {
+	return repository.DB.Model(&model.UserSession{}).
+		Where("id=?", sessionID).
+		Update("last_seen_at", seenAt).Error
+}
+
+func (repository *UserSessionRepositoryImpl) Revoke(sessionID uint) error {
+	tx := repository.DB.Begin()
+	if err := revokeSession(tx, sessionID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (repository *UserSessionRepositoryImpl) RevokeAll(userID uint) error {
+	tx := repository.DB.Begin()
+	if err := revokeUserTokens(tx, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+// revokeSession ends the session and every refresh token issued for it
+func revokeSession(tx *gorm.DB, sessionID uint) error {
+	now := time.Now()
+	if err := tx.Model(&model.UserSession{}).
+		Where("id=? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&aum.RefreshToken{}).
+		Where("session_id=? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", now).Error
+}
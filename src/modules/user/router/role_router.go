@@ -0,0 +1,17 @@
+package router
+
+import (
+	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/user"
+	"be-sagara-hackathon/src/utils/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RoleRouter(group *gin.RouterGroup) {
+	group.Use(middlewares.Permission(constants.PermissionRoleManage))
+
+	group.GET("/", user.GetRoleController().GetList)
+	group.GET("/permissions", user.GetRoleController().GetPermissions)
+	group.PUT("/:id/permissions", user.GetRoleController().UpdatePermissions)
+}
@@ -2,9 +2,12 @@ package router
 
 import (
 	"be-sagara-hackathon/src/middlewares"
+	"be-sagara-hackathon/src/modules/auth"
 	"be-sagara-hackathon/src/modules/payment"
 	"be-sagara-hackathon/src/modules/user"
 	"be-sagara-hackathon/src/utils/constants"
+	"be-sagara-hackathon/src/utils/ratelimit"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,37 +15,60 @@ import (
 func UserRouter(group *gin.RouterGroup) {
 	/// User Routes ///
 	group.POST("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionUserManage),
 		user.GetUserController().CreateUser,
 	)
 	group.PUT("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionUserManage),
 		user.GetUserController().UpdateUser,
 	)
 	group.DELETE("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionUserManage),
 		user.GetUserController().DeleteUser,
 	)
 	group.GET("/",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionUserManage),
 		user.GetUserController().GetList,
 	)
 	group.GET("/:id",
-		middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+		middlewares.Permission(constants.PermissionUserManage),
 		user.GetUserController().GetDetail,
 	)
 	group.GET("/profile", user.GetUserController().GetUserProfile)
 	group.PUT("/change-password", user.GetUserController().ChangePassword)
+	group.GET("/profile/sessions", user.GetUserSessionController().GetList)
+	group.DELETE("/profile/sessions", user.GetUserSessionController().RevokeAll)
+	group.DELETE("/profile/sessions/:id", user.GetUserSessionController().Revoke)
+	group.POST("/:id/logout",
+		middlewares.Permission(constants.PermissionSessionRevoke),
+		user.GetUserSessionController().ForceLogout,
+	)
+
+	/// Two Factor Routes ///
+	codePerIP := ratelimit.RuleFromEnv("RATE_LIMIT_LOGIN_IP", ratelimit.Rule{Limit: 20, Window: time.Minute})
+	codePerAccount := ratelimit.RuleFromEnv("RATE_LIMIT_LOGIN_ACCOUNT", ratelimit.Rule{Limit: 10, Window: time.Minute})
+	twoFactor := group.Group("/profile/2fa")
+	{
+		twoFactor.Use(
+			middlewares.Permission(constants.PermissionTwoFactorManage),
+			middlewares.RateLimit("profile-2fa", codePerIP, codePerAccount, middlewares.AccountFromUser),
+		)
+		twoFactor.GET("/", auth.GetTwoFactorController().GetStatus)
+		twoFactor.POST("/setup", auth.GetTwoFactorController().Setup)
+		twoFactor.POST("/enable", auth.GetTwoFactorController().Enable)
+		twoFactor.POST("/disable", auth.GetTwoFactorController().Disable)
+		twoFactor.POST("/recovery-codes", auth.GetTwoFactorController().RegenerateRecoveryCodes)
+	}
 
 	/// Participant Routes ///
 	participant := group.Group("/participants")
 	{
 		participant.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionParticipantView),
 			user.GetParticipantController().GetList,
 		)
 		participant.GET("/detail/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionParticipantView),
 			user.GetParticipantController().GetDetail,
 		)
 		participant.GET("/profile",
@@ -87,23 +113,23 @@ func UserRouter(group *gin.RouterGroup) {
 	mentor := group.Group("/mentors")
 	{
 		mentor.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetMentorController().Create,
 		)
 		mentor.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetMentorController().Update,
 		)
 		mentor.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetMentorController().Delete,
 		)
 		mentor.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetMentorController().GetList,
 		)
 		mentor.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetMentorController().GetDetail,
 		)
 	}
@@ -112,23 +138,23 @@ func UserRouter(group *gin.RouterGroup) {
 	judge := group.Group("/judges")
 	{
 		judge.POST("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetJudgeController().Create,
 		)
 		judge.PUT("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetJudgeController().Update,
 		)
 		judge.DELETE("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetJudgeController().Delete,
 		)
 		judge.GET("/",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetJudgeController().GetList,
 		)
 		judge.GET("/:id",
-			middlewares.RolePermission(constants.UserSuperadmin, constants.UserAdmin),
+			middlewares.Permission(constants.PermissionUserManage),
 			user.GetJudgeController().GetDetail,
 		)
 	}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
@@ -24,15 +25,18 @@ type JudgeService interface {
 type JudgeServiceImpl struct {
 	Repository     repository.UserRepository
 	RoleRepository repository.UserRoleRepository
+	Audit          ads.Recorder
 }
 
 func NewJudgeService(
 	repository repository.UserRepository,
 	roleRepository repository.UserRoleRepository,
+	audit ads.Recorder,
 ) JudgeService {
 	return &JudgeServiceImpl{
 		Repository:     repository,
 		RoleRepository: roleRepository,
+		Audit:          audit,
 	}
 }
 
@@ -43,6 +47,10 @@ func (service *JudgeServiceImpl) Create(ctx context.Context, request model.Creat
 		return err
 	}
 
+	if err := utils.ValidatePassword(request.Password); err != nil {
+		return err
+	}
+
 	hashed, err := utils.HashPassword(request.Password)
 	if err != nil {
 		return err
@@ -63,6 +71,9 @@ func (service *JudgeServiceImpl) Create(ctx context.Context, request model.Creat
 	}); err != nil {
 		return err
 	}
+
+	judge, _ := service.Repository.FindByEmail(request.Email)
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityUser, judge.ID, nil, judge)
 	return nil
 }
 
@@ -72,6 +83,7 @@ func (service *JudgeServiceImpl) Update(ctx context.Context, request model.Updat
 		return err
 	}
 
+	before := judge
 	judge.Name = request.Name
 	judge.Email = request.Email
 	judge.PhoneNumber = &request.PhoneNumber
@@ -84,18 +96,23 @@ func (service *JudgeServiceImpl) Update(ctx context.Context, request model.Updat
 	if err = service.Repository.Update(id, judge); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, userAuditAction(before, judge), constants.AuditEntityUser, id, before, judge)
 	return nil
 }
 
 func (service *JudgeServiceImpl) Delete(ctx context.Context, id uint) error {
 	authenticatedUser := ctx.Value("user").(model.User)
-	if _, err := service.Repository.FindByID(id); err != nil {
+	judge, err := service.Repository.FindByID(id)
+	if err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(id, authenticatedUser.Email); err != nil {
+	if err = service.Repository.Delete(id, authenticatedUser.Email); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityUser, id, judge, nil)
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
@@ -24,15 +25,18 @@ type MentorService interface {
 type MentorServiceImpl struct {
 	Repository     repository.UserRepository
 	RoleRepository repository.UserRoleRepository
+	Audit          ads.Recorder
 }
 
 func NewMentorService(
 	repository repository.UserRepository,
 	roleRepository repository.UserRoleRepository,
+	audit ads.Recorder,
 ) MentorService {
 	return &MentorServiceImpl{
 		Repository:     repository,
 		RoleRepository: roleRepository,
+		Audit:          audit,
 	}
 }
 
@@ -43,6 +47,10 @@ func (service *MentorServiceImpl) Create(ctx context.Context, request model.Crea
 		return err
 	}
 
+	if err := utils.ValidatePassword(request.Password); err != nil {
+		return err
+	}
+
 	hashed, err := utils.HashPassword(request.Password)
 	if err != nil {
 		return err
@@ -63,6 +71,9 @@ func (service *MentorServiceImpl) Create(ctx context.Context, request model.Crea
 	}); err != nil {
 		return err
 	}
+
+	mentor, _ := service.Repository.FindByEmail(request.Email)
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityUser, mentor.ID, nil, mentor)
 	return nil
 }
 
@@ -72,6 +83,7 @@ func (service *MentorServiceImpl) Update(ctx context.Context, request model.Upda
 		return err
 	}
 
+	before := mentor
 	mentor.Name = request.Name
 	mentor.Email = request.Email
 	mentor.PhoneNumber = &request.PhoneNumber
@@ -84,18 +96,23 @@ func (service *MentorServiceImpl) Update(ctx context.Context, request model.Upda
 	if err = service.Repository.Update(id, mentor); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, userAuditAction(before, mentor), constants.AuditEntityUser, id, before, mentor)
 	return nil
 }
 
 func (service *MentorServiceImpl) Delete(ctx context.Context, id uint) error {
 	authenticatedUser := ctx.Value("user").(model.User)
-	if _, err := service.Repository.FindByID(id); err != nil {
+	mentor, err := service.Repository.FindByID(id)
+	if err != nil {
 		return err
 	}
 
-	if err := service.Repository.Delete(id, authenticatedUser.Email); err != nil {
+	if err = service.Repository.Delete(id, authenticatedUser.Email); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityUser, id, mentor, nil)
 	return nil
 }
 
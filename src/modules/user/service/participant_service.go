@@ -1,6 +1,7 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
@@ -34,17 +35,20 @@ type ParticipantServiceImpl struct {
 	Repository     repository.ParticipantRepository
 	UserRepo       repository.UserRepository
 	RoleRepository repository.UserRoleRepository
+	Audit          ads.Recorder
 }
 
 func NewParticipantService(
 	repository repository.ParticipantRepository,
 	userRepo repository.UserRepository,
 	roleRepo repository.UserRoleRepository,
+	audit ads.Recorder,
 ) ParticipantService {
 	return &ParticipantServiceImpl{
 		Repository:     repository,
 		UserRepo:       userRepo,
 		RoleRepository: roleRepo,
+		Audit:          audit,
 	}
 }
 
@@ -62,6 +66,7 @@ func (service *ParticipantServiceImpl) UpdateFull(ctx context.Context, request m
 	if err != nil {
 		return participant, err
 	}
+	before := copyParticipant(participant)
 
 	participant.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.BaseEntity)
 	participant.User.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.User.BaseEntity)
@@ -120,6 +125,8 @@ func (service *ParticipantServiceImpl) UpdateFull(ctx context.Context, request m
 		return participant, err
 	}
 
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityParticipant, participant.ID, before, participant)
+
 	if request.Action == "register" {
 		if err = service.CompleteRegistration(ctx); err != nil {
 			return participant, err
@@ -136,6 +143,7 @@ func (service *ParticipantServiceImpl) UpdateProfileAndLocation(ctx context.Cont
 	if err != nil {
 		return participant, err
 	}
+	before := copyParticipant(participant)
 
 	participant.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.BaseEntity)
 	participant.User.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.User.BaseEntity)
@@ -167,6 +175,8 @@ func (service *ParticipantServiceImpl) UpdateProfileAndLocation(ctx context.Cont
 	}); err != nil {
 		return participant, err
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityParticipant, participant.ID, before, participant)
 	return participant, nil
 }
 
@@ -176,6 +186,7 @@ func (service *ParticipantServiceImpl) UpdateEducation(ctx context.Context, requ
 	if err != nil {
 		return participant, err
 	}
+	before := copyParticipant(participant)
 
 	participant.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.BaseEntity)
 	participant.User.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.User.BaseEntity)
@@ -189,6 +200,8 @@ func (service *ParticipantServiceImpl) UpdateEducation(ctx context.Context, requ
 	}); err != nil {
 		return participant, err
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityParticipant, participant.ID, before, participant)
 	return participant, nil
 }
 
@@ -198,6 +211,7 @@ func (service *ParticipantServiceImpl) UpdatePreference(ctx context.Context, req
 	if err != nil {
 		return participant, err
 	}
+	before := copyParticipant(participant)
 
 	participant.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.BaseEntity)
 	participant.User.BaseEntity = builder.BuildBaseEntity(ctx, false, &participant.User.BaseEntity)
@@ -228,6 +242,8 @@ func (service *ParticipantServiceImpl) UpdatePreference(ctx context.Context, req
 	}); err != nil {
 		return participant, err
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityParticipant, participant.ID, before, participant)
 	return participant, nil
 }
 
@@ -237,12 +253,15 @@ func (service *ParticipantServiceImpl) UpdateAccount(ctx context.Context, reques
 	if err != nil {
 		return participant, err
 	}
+	before := copyParticipant(participant)
 
 	participant.User.PhoneNumber = &request.PhoneNumber
 	participant.User.Username = &request.Username
 	if err = service.UserRepo.Update(participant.UserID, *participant.User); err != nil {
 		return participant, err
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityParticipant, participant.ID, before, participant)
 	return participant, nil
 }
 
@@ -252,6 +271,7 @@ func (service *ParticipantServiceImpl) CompleteRegistration(ctx context.Context)
 	if err != nil {
 		return err
 	}
+	before := copyParticipant(participant)
 
 	isComplete := isRegistrationCompleted(participant)
 	if !isComplete {
@@ -267,9 +287,20 @@ func (service *ParticipantServiceImpl) CompleteRegistration(ctx context.Context)
 	}); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityParticipant, participant.ID, before, participant)
 	return nil
 }
 
+// copyParticipant keeps the participant as it was for the audit log, the updates change its user in place
+func copyParticipant(participant model.Participant) model.Participant {
+	if participant.User != nil {
+		user := *participant.User
+		participant.User = &user
+	}
+	return participant
+}
+
 func isRegistrationCompleted(participant model.Participant) bool {
 	if participant.User.PhoneNumber == nil || participant.Birthdate == nil || participant.Gender == nil || participant.Address == nil ||
 		participant.ProvinceID == nil || (participant.ProvinceID != nil && *participant.ProvinceID == 0) ||
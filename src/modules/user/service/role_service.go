@@ -0,0 +1,114 @@
+package service
+
+import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"sort"
+	"strings"
+)
+
+type RoleService interface {
+	GetList() (roles []model.RoleResponse, err error)
+	GetPermissions() (permissions []model.Permission, err error)
+	UpdatePermissions(ctx context.Context, roleID uint, request model.UpdateRolePermissionsRequest) error
+}
+
+type RoleServiceImpl struct {
+	PermissionRepo repository.PermissionRepository
+	Audit          ads.Recorder
+}
+
+func NewRoleService(permissionRepo repository.PermissionRepository, audit ads.Recorder) RoleService {
+	return &RoleServiceImpl{PermissionRepo: permissionRepo, Audit: audit}
+}
+
+func (service *RoleServiceImpl) GetList() (roles []model.RoleResponse, err error) {
+	result, err := service.PermissionRepo.FindRoles()
+	if err != nil {
+		return
+	}
+
+	roles = []model.RoleResponse{}
+	for _, v := range result {
+		names, err := service.PermissionRepo.FindNamesByRoleID(v.ID)
+		if err != nil {
+			return nil, err
+		}
+		if names == nil {
+			names = []string{}
+		}
+		roles = append(roles, model.RoleResponse{ID: v.ID, Name: v.Name, Permissions: names})
+	}
+	return
+}
+
+func (service *RoleServiceImpl) GetPermissions() ([]model.Permission, error) {
+	return service.PermissionRepo.FindAll()
+}
+
+// UpdatePermissions replaces the permissions of a role. It takes effect on the next request of its users.
+func (service *RoleServiceImpl) UpdatePermissions(
+	ctx context.Context,
+	roleID uint,
+	request model.UpdateRolePermissionsRequest,
+) error {
+	user := ctx.Value("user").(model.User)
+	if _, err := service.PermissionRepo.FindRoleByID(roleID); err != nil {
+		return err
+	}
+
+	// nobody can lock themselves, and possibly everyone, out of editing roles
+	if user.UserRoleID == roleID && !helper.StringInSlice(constants.PermissionRoleManage, request.Permissions) {
+		return e.ErrRoleManageLockout
+	}
+
+	permissions, err := service.PermissionRepo.FindByNames(request.Permissions)
+	if err != nil {
+		return err
+	}
+
+	var ids []uint
+	for _, name := range request.Permissions {
+		found := false
+		for _, p := range permissions {
+			if p.Name == name {
+				found = true
+				if !helper.UintInSlice(p.ID, ids) {
+					ids = append(ids, p.ID)
+				}
+				break
+			}
+		}
+		if !found {
+			return e.ErrUnknownPermission
+		}
+	}
+
+	previous, err := service.PermissionRepo.FindNamesByRoleID(roleID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.PermissionRepo.ReplaceRolePermissions(roleID, ids, user.Email); err != nil {
+		return err
+	}
+
+	current, err := service.PermissionRepo.FindNamesByRoleID(roleID)
+	if err != nil {
+		return err
+	}
+	service.Audit.Record(ctx, constants.AuditUpdate, constants.AuditEntityRole, roleID,
+		permissionList(previous), permissionList(current))
+	return nil
+}
+
+// permissionList puts a role's permissions in one sorted field, so the audit log can compare them
+func permissionList(names []string) map[string]string {
+	sort.Strings(names)
+	return map[string]string{"permissions": strings.Join(names, ",")}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	"context"
+	"testing"
+)
+
+// fakePermissionRepository knows the permissions and the grants of every role by id
+type fakePermissionRepository struct {
+	repository.PermissionRepository
+	permissions []model.Permission
+	grants      map[uint][]uint
+}
+
+func (repository *fakePermissionRepository) FindRoleByID(roleID uint) (role model.UserRole, err error) {
+	if _, ok := repository.grants[roleID]; !ok {
+		return role, e.ErrDataNotFound
+	}
+	role.ID = roleID
+	return
+}
+
+func (repository *fakePermissionRepository) FindByNames(names []string) (permissions []model.Permission, err error) {
+	for _, permission := range repository.permissions {
+		if helper.StringInSlice(permission.Name, names) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return
+}
+
+func (repository *fakePermissionRepository) FindNamesByRoleID(roleID uint) (names []string, err error) {
+	for _, permission := range repository.permissions {
+		if helper.UintInSlice(permission.ID, repository.grants[roleID]) {
+			names = append(names, permission.Name)
+		}
+	}
+	return
+}
+
+func (repository *fakePermissionRepository) ReplaceRolePermissions(roleID uint, permissionIDs []uint, _ string) error {
+	repository.grants[roleID] = permissionIDs
+	return nil
+}
+
+func newTestRoleService() (*RoleServiceImpl, *fakePermissionRepository, context.Context) {
+	var permissions []model.Permission
+	for i, name := range []string{constants.PermissionRoleManage, constants.PermissionEventManageAll} {
+		permission := model.Permission{Name: name}
+		permission.ID = uint(i + 1)
+		permissions = append(permissions, permission)
+	}
+	repository := &fakePermissionRepository{
+		permissions: permissions,
+		grants:      map[uint][]uint{1: {1, 2}, 2: {2}},
+	}
+
+	user := model.User{Email: "admin@example.com", UserRoleID: 1}
+	ctx := context.WithValue(context.Background(), "user", user)
+	return &RoleServiceImpl{PermissionRepo: repository, Audit: &fakeRecorder{}}, repository, ctx
+}
+
+func TestUpdatePermissions(t *testing.T) {
+	service, repository, ctx := newTestRoleService()
+
+	request := model.UpdateRolePermissionsRequest{
+		Permissions: []string{constants.PermissionRoleManage, constants.PermissionRoleManage},
+	}
+	if err := service.UpdatePermissions(ctx, 2, request); err != nil {
+		t.Fatalf("update returned %v", err)
+	}
+	if grants := repository.grants[2]; len(grants) != 1 || grants[0] != 1 {
+		t.Errorf("grants of role 2 = %v, want [1]", grants)
+	}
+	if records := service.Audit.(*fakeRecorder).records; len(records) != 1 || records[0] != "role update" {
+		t.Errorf("audit records = %v, want the role update", records)
+	}
+}
+
+func TestUpdatePermissionsRejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		roleID      uint
+		permissions []string
+		want        error
+	}{
+		{name: "own role without role manage", roleID: 1, permissions: []string{constants.PermissionEventManageAll}, want: e.ErrRoleManageLockout},
+		{name: "unknown permission", roleID: 2, permissions: []string{"event:fly"}, want: e.ErrUnknownPermission},
+		{name: "unknown role", roleID: 9, permissions: []string{constants.PermissionEventManageAll}, want: e.ErrDataNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository, ctx := newTestRoleService()
+
+			err := service.UpdatePermissions(ctx, tt.roleID, model.UpdateRolePermissionsRequest{Permissions: tt.permissions})
+			if err != tt.want {
+				t.Errorf("update with %s returned %v, want %v", tt.name, err, tt.want)
+			}
+			if len(repository.grants[1]) != 2 || len(repository.grants[2]) != 1 {
+				t.Errorf("grants = %v, want them unchanged", repository.grants)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
 	"be-sagara-hackathon/src/modules/user/model"
 	"be-sagara-hackathon/src/modules/user/repository"
 	"be-sagara-hackathon/src/utils"
@@ -28,15 +29,21 @@ type UserService interface {
 
 type UserServiceImpl struct {
 	Repository repository.UserRepository
+	Audit      ads.Recorder
 }
 
-func NewUserService(userRepository repository.UserRepository) UserService {
+func NewUserService(userRepository repository.UserRepository, audit ads.Recorder) UserService {
 	return &UserServiceImpl{
 		Repository: userRepository,
+		Audit:      audit,
 	}
 }
 
 func (service *UserServiceImpl) CreateUser(ctx context.Context, request model.CreateUserRequest) error {
+	if err := utils.ValidatePassword(request.Password); err != nil {
+		return err
+	}
+
 	hashed, err := utils.HashPassword(request.Password)
 	if err != nil {
 		return err
@@ -54,6 +61,9 @@ func (service *UserServiceImpl) CreateUser(ctx context.Context, request model.Cr
 	}); err != nil {
 		return err
 	}
+
+	user, _ := service.Repository.FindByEmail(request.Email)
+	service.Audit.Record(ctx, constants.AuditCreate, constants.AuditEntityUser, user.ID, nil, user)
 	return nil
 }
 
@@ -63,6 +73,7 @@ func (service *UserServiceImpl) UpdateUser(ctx context.Context, request model.Up
 		return err
 	}
 
+	before := user
 	user.Name = request.Name
 	user.Email = request.Email
 	user.PhoneNumber = &request.PhoneNumber
@@ -72,22 +83,34 @@ func (service *UserServiceImpl) UpdateUser(ctx context.Context, request model.Up
 	if err = service.Repository.Update(id, user); err != nil {
 		return err
 	}
+
+	service.Audit.Record(ctx, userAuditAction(before, user), constants.AuditEntityUser, id, before, user)
 	return nil
 }
 
 func (service *UserServiceImpl) DeleteUser(ctx context.Context, id uint) error {
-	if _, err := service.Repository.FindByID(id); err != nil {
+	user, err := service.Repository.FindByID(id)
+	if err != nil {
 		return err
 	}
 
 	deleteBy := ctx.Value("user").(model.User).Email
-	if err := service.Repository.Delete(id, deleteBy); err != nil {
+	if err = service.Repository.Delete(id, deleteBy); err != nil {
 		return err
 	}
 
+	service.Audit.Record(ctx, constants.AuditDelete, constants.AuditEntityUser, id, user, nil)
 	return nil
 }
 
+// userAuditAction tells (de)activating an account apart from editing it
+func userAuditAction(before, after model.User) string {
+	if before.IsActive != after.IsActive {
+		return constants.AuditStatus
+	}
+	return constants.AuditUpdate
+}
+
 func (service *UserServiceImpl) GetList(
 	ctx context.Context,
 	filter model.FilterUser,
@@ -141,6 +164,9 @@ func (service *UserServiceImpl) ChangePassword(ctx context.Context, request mode
 	if request.NewPassword != request.ConfirmNewPassword {
 		return e.ErrConfirmPasswordNotSame
 	}
+	if err = utils.ValidatePassword(request.NewPassword); err != nil {
+		return
+	}
 
 	if user.AuthType != constants.AuthTypeRegular {
 		return e.ErrWrongAuthMethod
@@ -0,0 +1,97 @@
+package service
+
+import (
+	ads "be-sagara-hackathon/src/modules/general/audit/service"
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/repository"
+	"be-sagara-hackathon/src/utils/constants"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+)
+
+type UserSessionService interface {
+	GetList(ctx context.Context) (sessions []model.UserSessionResponse, err error)
+	Revoke(ctx context.Context, sessionID uint) error
+	RevokeAll(ctx context.Context) error
+	ForceLogout(ctx context.Context, userID uint) error
+}
+
+type UserSessionServiceImpl struct {
+	Repository repository.UserSessionRepository
+	UserRepo   repository.UserRepository
+	Audit      ads.Recorder
+}
+
+func NewUserSessionService(
+	repository repository.UserSessionRepository,
+	userRepo repository.UserRepository,
+	audit ads.Recorder,
+) UserSessionService {
+	return &UserSessionServiceImpl{Repository: repository, UserRepo: userRepo, Audit: audit}
+}
+
+func (service *UserSessionServiceImpl) GetList(ctx context.Context) (sessions []model.UserSessionResponse, err error) {
+	user := ctx.Value("user").(model.User)
+	currentID, _ := ctx.Value("sessionID").(uint)
+
+	result, err := service.Repository.FindActiveByUserID(user.ID)
+	if err != nil {
+		return
+	}
+
+	sessions = []model.UserSessionResponse{}
+	for _, v := range result {
+		sessions = append(sessions, model.UserSessionResponse{
+			ID:         v.ID,
+			UserAgent:  v.UserAgent,
+			IPAddress:  v.IPAddress,
+			CreatedAt:  v.CreatedAt,
+			LastSeenAt: v.LastSeenAt,
+			Current:    v.ID == currentID,
+		})
+	}
+	return
+}
+
+func (service *UserSessionServiceImpl) Revoke(ctx context.Context, sessionID uint) error {
+	user := ctx.Value("user").(model.User)
+	session, err := service.Repository.FindByID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	// another user's session is reported as missing so ids can't be probed
+	if session.UserID != user.ID {
+		return e.ErrDataNotFound
+	}
+	if err = service.Repository.Revoke(session.ID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditRevoke, constants.AuditEntitySession, session.ID, session, nil)
+	return nil
+}
+
+// RevokeAll logs the user out everywhere, including the current session
+func (service *UserSessionServiceImpl) RevokeAll(ctx context.Context) error {
+	user := ctx.Value("user").(model.User)
+	if err := service.Repository.RevokeAll(user.ID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditLogoutAll, constants.AuditEntityUser, user.ID, nil, nil)
+	return nil
+}
+
+func (service *UserSessionServiceImpl) ForceLogout(ctx context.Context, userID uint) error {
+	if _, err := service.UserRepo.FindByID(userID); err != nil {
+		return err
+	}
+
+	if err := service.Repository.RevokeAll(userID); err != nil {
+		return err
+	}
+
+	service.Audit.Record(ctx, constants.AuditLogoutAll, constants.AuditEntityUser, userID, nil, nil)
+	return nil
+}
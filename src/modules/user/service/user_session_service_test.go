@@ -0,0 +1,105 @@
+package service
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/modules/user/repository"
+	e "be-sagara-hackathon/src/utils/errors"
+	"context"
+	"testing"
+)
+
+type fakeUserSessionRepository struct {
+	repository.UserSessionRepository
+	sessions []model.UserSession
+	revoked  []uint
+}
+
+func (repository *fakeUserSessionRepository) FindByID(sessionID uint) (model.UserSession, error) {
+	for _, session := range repository.sessions {
+		if session.ID == sessionID {
+			return session, nil
+		}
+	}
+	return model.UserSession{}, e.ErrDataNotFound
+}
+
+func (repository *fakeUserSessionRepository) FindActiveByUserID(userID uint) (sessions []model.UserSession, err error) {
+	for _, session := range repository.sessions {
+		if session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+	return
+}
+
+func (repository *fakeUserSessionRepository) Revoke(sessionID uint) error {
+	repository.revoked = append(repository.revoked, sessionID)
+	return nil
+}
+
+// fakeRecorder keeps the action and entity type of every record
+type fakeRecorder struct {
+	records []string
+}
+
+func (recorder *fakeRecorder) Record(_ context.Context, action, entityType string, _ uint, _, _ interface{}) {
+	recorder.records = append(recorder.records, entityType+" "+action)
+}
+
+func newTestSessionService() (*UserSessionServiceImpl, *fakeUserSessionRepository, context.Context) {
+	user := model.User{Email: "jane@example.com"}
+	user.ID = 3
+
+	var sessions []model.UserSession
+	for id, userID := range map[uint]uint{7: 3, 8: 3, 9: 4} {
+		session := model.UserSession{UserID: userID}
+		session.ID = id
+		sessions = append(sessions, session)
+	}
+	repository := &fakeUserSessionRepository{sessions: sessions}
+
+	ctx := context.WithValue(context.WithValue(context.Background(), "user", user), "sessionID", uint(7))
+	return &UserSessionServiceImpl{Repository: repository, Audit: &fakeRecorder{}}, repository, ctx
+}
+
+func TestUserSessionList(t *testing.T) {
+	service, _, ctx := newTestSessionService()
+
+	sessions, err := service.GetList(ctx)
+	if err != nil {
+		t.Fatalf("list returned %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("list returned %d sessions, want the 2 of the user", len(sessions))
+	}
+	for _, session := range sessions {
+		if session.Current != (session.ID == 7) {
+			t.Errorf("session %d current = %v, want only session 7 current", session.ID, session.Current)
+		}
+	}
+}
+
+func TestUserSessionRevoke(t *testing.T) {
+	service, repository, ctx := newTestSessionService()
+
+	if err := service.Revoke(ctx, 8); err != nil {
+		t.Fatalf("revoke returned %v", err)
+	}
+	if len(repository.revoked) != 1 || repository.revoked[0] != 8 {
+		t.Errorf("revoked sessions = %v, want [8]", repository.revoked)
+	}
+	if records := service.Audit.(*fakeRecorder).records; len(records) != 1 || records[0] != "session revoke" {
+		t.Errorf("audit records = %v, want the session revoke", records)
+	}
+}
+
+func TestUserSessionRevokeOtherUser(t *testing.T) {
+	service, repository, ctx := newTestSessionService()
+
+	if err := service.Revoke(ctx, 9); err != e.ErrDataNotFound {
+		t.Errorf("revoke of another user's session returned %v, want %v", err, e.ErrDataNotFound)
+	}
+	if len(repository.revoked) != 0 {
+		t.Errorf("revoked sessions = %v, want none", repository.revoked)
+	}
+}
@@ -47,6 +47,7 @@ func SeedsAll() []Seed {
 					{Name: constants.UserCompany},
 					{Name: constants.UserMentor},
 					{Name: constants.UserJudge},
+					{Name: constants.UserOrganizer},
 				}
 				for _, role := range roles {
 					err := SeederRole(db, role)
@@ -57,6 +58,14 @@ func SeedsAll() []Seed {
 				return nil
 			},
 		},
+		{
+			Name: "SeederPermission",
+			Run:  SeederPermission,
+		},
+		{
+			Name: "SeederRolePermission",
+			Run:  SeederRolePermission,
+		},
 		{
 			Name: "SeederUser",
 			Run: func(db *gorm.DB) error {
@@ -0,0 +1,76 @@
+package seeder
+
+import (
+	"be-sagara-hackathon/src/modules/user/model"
+	"be-sagara-hackathon/src/utils/constants"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func SeederPermission(db *gorm.DB) error {
+	var permissions []model.Permission
+	for name, description := range constants.Permissions {
+		permission := model.Permission{Name: name, Description: description}
+		permission.CreatedBy, permission.UpdatedBy = "system", "system"
+		permissions = append(permissions, permission)
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description"}),
+	}).Create(&permissions).Error
+}
+
+// SeederRolePermission grants each role the default permissions it is missing. A default is only granted when
+// the permission was added after the role's grants were last saved, so permissions an admin took away from
+// a role aren't granted again. Grants the seeder made that are no longer a default are taken back, grants
+// saved by an admin are left alone. Running it again changes nothing.
+func SeederRolePermission(db *gorm.DB) error {
+	for roleName, names := range constants.DefaultRolePermissions {
+		var role model.UserRole
+		if err := db.Where("name=?", roleName).First(&role).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return err
+		}
+
+		var lastGrant *time.Time
+		if err := db.Model(&model.RolePermission{}).
+			Select("MAX(created_at)").
+			Where("user_role_id=?", role.ID).
+			Scan(&lastGrant).Error; err != nil {
+			return err
+		}
+
+		if err := db.Where("user_role_id = ? AND created_by = ?", role.ID, "system").
+			Where("permission_id NOT IN (?)", db.Model(&model.Permission{}).Select("id").Where("name IN ?", names)).
+			Delete(&model.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		query := db.Where("name IN ?", names)
+		if lastGrant != nil {
+			query = query.Where("created_at > ?", *lastGrant)
+		}
+		var permissions []model.Permission
+		if err := query.Find(&permissions).Error; err != nil {
+			return err
+		}
+
+		var grants []model.RolePermission
+		for _, p := range permissions {
+			grants = append(grants, model.RolePermission{UserRoleID: role.ID, PermissionID: p.ID, CreatedBy: "system"})
+		}
+		if len(grants) == 0 {
+			continue
+		}
+		if err := db.Omit("UserRole", "Permission").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&grants).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package common
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const deletedByKey = "soft_delete:deleted_by"
+
+// DeletedAt works like gorm.DeletedAt, every query skips deleted rows unless Unscoped is used,
+// but a delete also fills deleted_by with the value given through the DeletedBy scope.
+type DeletedAt sql.NullTime
+
+// DeletedBy is the scope that tells a soft delete who deleted the rows
+func DeletedBy(deletedBy string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Set(deletedByKey, deletedBy)
+	}
+}
+
+// Scan implements the Scanner interface.
+func (n *DeletedAt) Scan(value interface{}) error {
+	return (*sql.NullTime)(n).Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (n DeletedAt) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time, nil
+}
+
+func (n DeletedAt) MarshalJSON() ([]byte, error) {
+	if n.Valid {
+		return json.Marshal(n.Time)
+	}
+	return json.Marshal(nil)
+}
+
+func (n *DeletedAt) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Time)
+	if err == nil {
+		n.Valid = true
+	}
+	return err
+}
+
+func (DeletedAt) QueryClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{gorm.SoftDeleteQueryClause{Field: f}}
+}
+
+func (DeletedAt) UpdateClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{gorm.SoftDeleteUpdateClause{Field: f}}
+}
+
+func (DeletedAt) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{softDeleteClause{Field: f}}
+}
+
+// softDeleteClause is gorm.SoftDeleteDeleteClause setting deleted_by along with deleted_at.
+// Both have to go in one SET clause, a second one would replace the first.
+type softDeleteClause struct {
+	Field *schema.Field
+}
+
+func (sd softDeleteClause) Name() string {
+	return ""
+}
+
+func (sd softDeleteClause) Build(clause.Builder) {
+}
+
+func (sd softDeleteClause) MergeClause(*clause.Clause) {
+}
+
+func (sd softDeleteClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() > 0 || stmt.Statement.Unscoped {
+		return
+	}
+
+	curTime := stmt.DB.NowFunc()
+	set := clause.Set{{Column: clause.Column{Name: sd.Field.DBName}, Value: curTime}}
+	stmt.SetColumn(sd.Field.DBName, curTime, true)
+
+	if deletedBy, ok := stmt.Settings.Load(deletedByKey); ok && stmt.Schema != nil {
+		if field := stmt.Schema.LookUpField("deleted_by"); field != nil {
+			set = append(set, clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: deletedBy})
+			stmt.SetColumn(field.DBName, deletedBy, true)
+		}
+	}
+	stmt.AddClause(set)
+
+	if stmt.Schema != nil {
+		_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+
+		if len(values) > 0 {
+			stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+		}
+
+		if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
+			_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmt.Schema.PrimaryFields)
+			column, values = schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+
+			if len(values) > 0 {
+				stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+			}
+		}
+	}
+
+	gorm.SoftDeleteQueryClause{Field: sd.Field}.ModifyStatement(stmt)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
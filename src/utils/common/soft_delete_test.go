@@ -0,0 +1,82 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type softDeleted struct {
+	BaseEntity
+	Name string
+}
+
+// dryRun returns the statements query sends, built without a database
+func dryRun(t *testing.T, query func(db *gorm.DB)) []string {
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var statements []string
+	if err = db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	query(db)
+	return statements
+}
+
+func TestSoftDeleteScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(db *gorm.DB)
+		want  string
+		skips bool
+	}{
+		{
+			name:  "model query",
+			query: func(db *gorm.DB) { db.Where("name = ?", "a").Find(&[]softDeleted{}) },
+			want:  "`soft_deleteds`.`deleted_at` IS NULL",
+			skips: true,
+		},
+		{
+			name:  "model table",
+			query: func(db *gorm.DB) { db.Model(&softDeleted{}).Where("id = ?", 1).Find(&[]softDeleted{}) },
+			want:  "`soft_deleteds`.`deleted_at` IS NULL",
+			skips: true,
+		},
+		{
+			name:  "unscoped",
+			query: func(db *gorm.DB) { db.Unscoped().Find(&[]softDeleted{}) },
+			want:  "deleted_at",
+			skips: false,
+		},
+		{
+			name: "raw table",
+			query: func(db *gorm.DB) {
+				var rows []struct{ ID uint }
+				db.Table("soft_deleteds sd").Select("sd.id").Find(&rows)
+			},
+			want:  "deleted_at",
+			skips: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := dryRun(t, tt.query)
+			if len(statements) != 1 {
+				t.Fatalf("got %d statements, want 1", len(statements))
+			}
+			if got := strings.Contains(statements[0], tt.want); got != tt.skips {
+				t.Errorf("%s skips deleted rows = %v, want %v:\n%s", tt.name, got, tt.skips, statements[0])
+			}
+		})
+	}
+}
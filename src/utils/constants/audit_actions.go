@@ -0,0 +1,63 @@
+package constants
+
+// Actions written to the audit log
+const (
+	AuditCreate    = "create"
+	AuditUpdate    = "update"
+	AuditDelete    = "delete"
+	AuditStatus    = "status"
+	AuditApprove   = "approve"
+	AuditReject    = "reject"
+	AuditRefund    = "refund"
+	AuditWaive     = "waive"
+	AuditReopen    = "reopen"
+	AuditAssign    = "assign"
+	AuditUnassign  = "unassign"
+	AuditAssess    = "assess"
+	AuditFreeze    = "freeze"
+	AuditLogoutAll = "logout_all"
+	AuditRevoke    = "revoke"
+	AuditRestore   = "restore"
+	AuditImport    = "import"
+	AuditGenerate  = "generate"
+	AuditResend    = "resend"
+)
+
+// Entity types written to the audit log
+const (
+	AuditEntityUser                = "user"
+	AuditEntityTwoFactor           = "two_factor"
+	AuditEntitySession             = "session"
+	AuditEntityParticipant         = "participant"
+	AuditEntityRole                = "role"
+	AuditEntityEvent               = "event"
+	AuditEntityEventStaff          = "event_staff"
+	AuditEntityPayment             = "payment"
+	AuditEntityPaymentMethod       = "payment_method"
+	AuditEntityInvoice             = "invoice"
+	AuditEntityTeam                = "team"
+	AuditEntityProject             = "project"
+	AuditEntityProjectJudge        = "project_judge"
+	AuditEntityJudgeConflict       = "judge_conflict"
+	AuditEntityLeaderboard         = "leaderboard"
+	AuditEntitySchedule            = "schedule"
+	AuditEntityVoucher             = "voucher"
+	AuditEntityFeeTier             = "fee_tier"
+	AuditEntityCertificate         = "certificate"
+	AuditEntityCertificateTemplate = "certificate_template"
+	AuditEntityEventTimeline       = "event_timeline"
+	AuditEntityEventRule           = "event_rule"
+	AuditEntityEventFaq            = "event_faq"
+	AuditEntityEventCompany        = "event_company"
+	AuditEntityAssessmentCriteria  = "assessment_criteria"
+	AuditEntityEventMentor         = "event_mentor"
+	AuditEntityEventJudge          = "event_judge"
+	AuditEntityOccupation          = "occupation"
+	AuditEntitySkill               = "skill"
+	AuditEntitySpeciality          = "speciality"
+	AuditEntityTechnology          = "technology"
+	AuditEntityTeamMember          = "team_member"
+	AuditEntityTeamInvitation      = "team_invitation"
+	AuditEntityTeamRequest         = "team_request"
+	AuditEntityEmail               = "email"
+)
@@ -4,4 +4,5 @@ const (
 	AuthTypeRegular  = "regular"
 	AuthTypeGoogle   = "google"
 	AuthTypeLinkedin = "linkedin"
+	AuthTypeGithub   = "github"
 )
@@ -0,0 +1,12 @@
+package constants
+
+// Certificate template types, winners are participants whose project ranked within the template's max rank
+const (
+	CertificateParticipant = "participant"
+	CertificateWinner      = "winner"
+	CertificateMentor      = "mentor"
+	CertificateJudge       = "judge"
+)
+
+// CertificatePlaceholders can be written as {{name}} in the title and body of a certificate template
+var CertificatePlaceholders = []string{"name", "event", "team", "project", "rank", "role"}
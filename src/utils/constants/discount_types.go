@@ -0,0 +1,6 @@
+package constants
+
+const (
+	DiscountPercentage = "percentage"
+	DiscountFixed      = "fixed"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	EmailOutboxPending    = "pending"
+	EmailOutboxProcessing = "processing"
+	EmailOutboxSent       = "sent"
+	EmailOutboxFailed     = "failed"
+)
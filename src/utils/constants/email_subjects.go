@@ -1,8 +1,10 @@
 package constants
 
 const (
-	EmailSubjectVerifyEmail    = "Email Verification"
-	EmailSubjectResetPassword  = "Reset Password"
-	EmailSubjectTeamInvitation = "Team Invitation"
-	EmailSubjectTeamRequest    = "Request Join Team"
+	EmailSubjectVerifyEmail     = "Email Verification"
+	EmailSubjectResetPassword   = "Reset Password"
+	EmailSubjectSetPassword     = "Set Your Password"
+	EmailSubjectTeamInvitation  = "Team Invitation"
+	EmailSubjectTeamRequest     = "Request Join Team"
+	EmailSubjectInvoiceReminder = "Payment Reminder"
 )
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	EventStaffOrganizer = "organizer"
+	EventStaffMentor    = "mentor"
+	EventStaffJudge     = "judge"
+)
+
+var EventStaffRoles = []string{EventStaffOrganizer, EventStaffMentor, EventStaffJudge}
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	InvoiceEntryPayment    = "payment"
+	InvoiceEntryRefund     = "refund"
+	InvoiceEntryWaiver     = "waiver"
+	InvoiceEntryCorrection = "correction"
+)
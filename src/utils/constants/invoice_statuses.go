@@ -4,9 +4,11 @@ const (
 	InvoiceUnpaid     = "unpaid"
 	InvoiceProcessing = "processing"
 	InvoicePaid       = "paid"
+	InvoiceExpired    = "expired"
 )
 
 const (
 	PaymentStatusCreated = "created"
 	PaymentStatusProceed = "proceed"
+	PaymentStatusFailed  = "failed"
 )
@@ -5,6 +5,7 @@ const (
 	PaymentMethodBcaVA = "BCA VA"
 	PaymentMethodBri   = "BRI"
 	PaymentMethodBriVA = "BRI VA"
+	PaymentMethodQris  = "QRIS"
 )
 
 const (
@@ -0,0 +1,136 @@
+package constants
+
+// Permissions are assigned to roles in the role_permissions table and checked by middlewares.Permission.
+// Routes that act on the caller's own participant profile keep checking the participant role instead.
+// Without PermissionEventManageAll, event related permissions only apply to the events the user is staff of.
+const (
+	PermissionEventManage            = "event.manage"
+	PermissionEventManageAll         = "event.manage_all"
+	PermissionAssessmentCriteriaView = "assessment_criteria.view"
+	PermissionUserManage             = "user.manage"
+	PermissionParticipantView        = "participant.view"
+	PermissionSessionRevoke          = "session.revoke"
+	PermissionTwoFactorManage        = "two_factor.manage"
+	PermissionRoleManage             = "role.manage"
+	PermissionPaymentView            = "payment.view"
+	PermissionPaymentApprove         = "payment.approve"
+	PermissionPaymentMethodManage    = "payment_method.manage"
+	PermissionInvoiceView            = "invoice.view"
+	PermissionInvoiceDownload        = "invoice.download"
+	PermissionInvoiceAdjust          = "invoice.adjust"
+	PermissionProjectView            = "project.view"
+	PermissionProjectManage          = "project.manage"
+	PermissionProjectAssess          = "project.assess"
+	PermissionProjectConflictDeclare = "project.conflict_declare"
+	PermissionLeaderboardView        = "leaderboard.view"
+	PermissionLeaderboardFreeze      = "leaderboard.freeze"
+	PermissionTeamList               = "team.list"
+	PermissionTeamView               = "team.view"
+	PermissionTeamManage             = "team.manage"
+	PermissionTeamMemberView         = "team.member_view"
+	PermissionScheduleView           = "schedule.view"
+	PermissionScheduleManage         = "schedule.manage"
+	PermissionMasterDataManage       = "master_data.manage"
+	PermissionPromotionManage        = "promotion.manage"
+	PermissionEmailManage            = "email.manage"
+	PermissionAuditView              = "audit.view"
+	PermissionTrashManage            = "trash.manage"
+	PermissionUserImport             = "user.import"
+	PermissionDataExport             = "data.export"
+	PermissionAnalyticsView          = "analytics.view"
+	PermissionCertificateManage      = "certificate.manage"
+)
+
+// Permissions lists every permission with its description
+var Permissions = map[string]string{
+	PermissionEventManage:            "Manage events, timelines, rules, FAQs, companies, mentors, judges and assessment criteria",
+	PermissionEventManageAll:         "Manage every event, not only the ones the user is an organizer of",
+	PermissionAssessmentCriteriaView: "View the assessment criteria of events",
+	PermissionUserManage:             "Manage users, mentors and judges",
+	PermissionParticipantView:        "View and search participants",
+	PermissionSessionRevoke:          "Log any user out of every session",
+	PermissionTwoFactorManage:        "Set up two factor authentication for the own account",
+	PermissionRoleManage:             "Edit the permissions of roles",
+	PermissionPaymentView:            "View payments",
+	PermissionPaymentApprove:         "Approve or reject payments",
+	PermissionPaymentMethodManage:    "Manage payment methods",
+	PermissionInvoiceView:            "View invoices and their ledger",
+	PermissionInvoiceDownload:        "Download invoice and receipt PDFs",
+	PermissionInvoiceAdjust:          "Refund, waive and reopen invoices",
+	PermissionProjectView:            "View projects",
+	PermissionProjectManage:          "Change project status, assign judges and resolve conflicts of interest",
+	PermissionProjectAssess:          "Assess assigned projects",
+	PermissionProjectConflictDeclare: "Declare a conflict of interest on a project",
+	PermissionLeaderboardView:        "View leaderboards",
+	PermissionLeaderboardFreeze:      "Freeze leaderboards",
+	PermissionTeamList:               "List teams",
+	PermissionTeamView:               "View the details of teams",
+	PermissionTeamManage:             "Change team status and delete teams",
+	PermissionTeamMemberView:         "View team members",
+	PermissionScheduleView:           "View schedules",
+	PermissionScheduleManage:         "Manage schedules",
+	PermissionMasterDataManage:       "Manage specialities, occupations, skills and technologies",
+	PermissionPromotionManage:        "Manage vouchers and fee tiers",
+	PermissionEmailManage:            "View and resend outgoing emails",
+	PermissionAuditView:              "View and export the audit log",
+	PermissionTrashManage:            "List and restore deleted events, teams, projects and users",
+	PermissionUserImport:             "Import mentors, judges and participants from CSV or XLSX files",
+	PermissionDataExport:             "Export the participants, teams, projects and payments of events as CSV or XLSX",
+	PermissionAnalyticsView:          "View the registration, payment, team, project and judging numbers of events",
+	PermissionCertificateManage:      "Manage certificate templates and generate the certificates of finished events",
+}
+
+// DefaultRolePermissions is what the seeder grants a role, permissions added later are granted on the next migration
+var DefaultRolePermissions = map[string][]string{
+	UserSuperadmin: staffPermissions(true),
+	UserAdmin:      staffPermissions(false),
+	UserHR: {
+		PermissionTwoFactorManage,
+	},
+	UserParticipant: {
+		PermissionInvoiceDownload,
+		PermissionTeamMemberView,
+	},
+	UserMentor: {
+		PermissionTeamList,
+		PermissionScheduleView,
+		PermissionTwoFactorManage,
+	},
+	UserOrganizer: {
+		PermissionEventManage,
+		PermissionParticipantView,
+		PermissionProjectView,
+		PermissionProjectManage,
+		PermissionLeaderboardView,
+		PermissionLeaderboardFreeze,
+		PermissionTeamList,
+		PermissionTeamView,
+		PermissionTeamMemberView,
+		PermissionScheduleView,
+		PermissionScheduleManage,
+		PermissionDataExport,
+		PermissionAnalyticsView,
+		PermissionCertificateManage,
+		PermissionTwoFactorManage,
+	},
+	UserJudge: {
+		PermissionAssessmentCriteriaView,
+		PermissionProjectView,
+		PermissionProjectAssess,
+		PermissionProjectConflictDeclare,
+		PermissionTwoFactorManage,
+	},
+}
+
+// staffPermissions is everything but assessing, which is the judges' job. Editing roles is only
+// included for the superadmin.
+func staffPermissions(withRoles bool) []string {
+	var names []string
+	for name := range Permissions {
+		if name == PermissionProjectAssess || (name == PermissionRoleManage && !withRoles) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
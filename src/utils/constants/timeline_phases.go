@@ -0,0 +1,9 @@
+package constants
+
+const (
+	TimelinePhaseRegistration  = "registration"
+	TimelinePhaseTeamFormation = "team-formation"
+	TimelinePhaseSubmission    = "submission"
+	TimelinePhaseJudging       = "judging"
+	TimelinePhaseAnnouncement  = "announcement"
+)
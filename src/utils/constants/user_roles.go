@@ -8,4 +8,8 @@ const (
 	UserCompany     = "Company"
 	UserMentor      = "Mentor"
 	UserJudge       = "Judge"
+	UserOrganizer   = "Organizer"
 )
+
+// TwoFactorRequiredRoles can't log in without a second factor
+var TwoFactorRequiredRoles = []string{UserSuperadmin, UserAdmin}
@@ -3,4 +3,6 @@ package constants
 const (
 	VerifCodeEmail         = "verify-email"
 	VerifCodeResetPassword = "reset-password"
+	// VerifCodeSetPassword is sent to imported users, it works like a reset-password code
+	VerifCodeSetPassword = "set-password"
 )
@@ -0,0 +1,48 @@
+package email
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// FileMailer writes every email as an .eml file into Dir instead of sending it. It is meant for local development.
+type FileMailer struct {
+	Dir    string
+	Sender string
+}
+
+func NewFileMailer(dir, sender string) Mailer {
+	return &FileMailer{Dir: dir, Sender: sender}
+}
+
+func (mailer *FileMailer) Send(request Request) error {
+	if err := os.MkdirAll(mailer.Dir, 0755); err != nil {
+		return err
+	}
+
+	sender := request.From
+	if sender == "" {
+		sender = mailer.Sender
+	}
+
+	message := gomail.NewMessage()
+	message.SetHeader("From", sender)
+	message.SetHeader("To", request.To...)
+	message.SetHeader("Subject", request.Subject)
+	message.SetDateHeader("Date", time.Now())
+	message.SetBody("text/html", request.Body)
+
+	fileName := fmt.Sprintf("%s-%d.eml", time.Now().Format("20060102-150405"), time.Now().UnixNano())
+	file, err := os.Create(filepath.Join(mailer.Dir, fileName))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = message.WriteTo(file)
+	return err
+}
@@ -0,0 +1,68 @@
+package email
+
+import (
+	"os"
+	"strconv"
+
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	MailDriverSMTP   = "smtp"
+	MailDriverFile   = "file"
+	MailDriverMemory = "memory"
+)
+
+// Mailer delivers an already rendered email request.
+type Mailer interface {
+	Send(request Request) error
+}
+
+// NewMailer picks the implementation from MAIL_DRIVER (smtp, file or memory). It defaults to smtp.
+func NewMailer() Mailer {
+	switch os.Getenv("MAIL_DRIVER") {
+	case MailDriverFile:
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = "./tmp/mails"
+		}
+		return NewFileMailer(dir, os.Getenv("SMTP_SENDER_EMAIL"))
+	case MailDriverMemory:
+		return NewMemoryMailer()
+	default:
+		return NewSMTPMailer()
+	}
+}
+
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Sender   string
+	Password string
+}
+
+func NewSMTPMailer() Mailer {
+	port, _ := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	return &SMTPMailer{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     port,
+		Sender:   os.Getenv("SMTP_SENDER_EMAIL"),
+		Password: os.Getenv("SMTP_SENDER_PASSWORD"),
+	}
+}
+
+func (mailer *SMTPMailer) Send(request Request) error {
+	sender := request.From
+	if sender == "" {
+		sender = mailer.Sender
+	}
+
+	message := gomail.NewMessage()
+	message.SetHeader("From", sender)
+	message.SetHeader("To", request.To...)
+	message.SetHeader("Subject", request.Subject)
+	message.SetBody("text/html", request.Body)
+
+	dialer := gomail.NewDialer(mailer.Host, mailer.Port, mailer.Sender, mailer.Password)
+	return dialer.DialAndSend(message)
+}
@@ -0,0 +1,88 @@
+package email
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewMailer(t *testing.T) {
+	tests := []struct {
+		driver string
+		want   string
+	}{
+		{driver: MailDriverFile, want: "*email.FileMailer"},
+		{driver: MailDriverMemory, want: "*email.MemoryMailer"},
+		{driver: MailDriverSMTP, want: "*email.SMTPMailer"},
+		{driver: "", want: "*email.SMTPMailer"},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("MAIL_DRIVER", tt.driver)
+
+		if got := fmt.Sprintf("%T", NewMailer()); got != tt.want {
+			t.Errorf("driver %q made a %s, want a %s", tt.driver, got, tt.want)
+		}
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mails")
+	mailer := NewFileMailer(dir, "noreply@example.com")
+
+	if err := mailer.Send(Request{To: []string{"jane@example.com"}, Subject: "Welcome", Body: "<p>Hi Jane</p>"}); err != nil {
+		t.Fatalf("send returned %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("found %v, %v, want one .eml file", files, err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"From: noreply@example.com", "To: jane@example.com", "Subject: Welcome", "Hi Jane"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("email doesn't contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestMemoryMailer(t *testing.T) {
+	mailer := NewMemoryMailer()
+	for _, subject := range []string{"first", "second"} {
+		if err := mailer.Send(Request{To: []string{"jane@example.com"}, Subject: subject}); err != nil {
+			t.Fatalf("send returned %v", err)
+		}
+	}
+
+	messages := mailer.Messages()
+	if len(messages) != 2 || messages[0].Subject != "first" || messages[1].Subject != "second" {
+		t.Errorf("messages = %+v, want first and second in order", messages)
+	}
+
+	messages[0].Subject = "changed"
+	if mailer.Messages()[0].Subject != "first" {
+		t.Error("changing the returned messages changed the captured ones")
+	}
+
+	mailer.Reset()
+	if len(mailer.Messages()) != 0 {
+		t.Error("reset kept the captured messages")
+	}
+}
+
+func TestRender(t *testing.T) {
+	for _, name := range []string{TemplateVerificationCode, TemplateTeamInvitation, TemplateTeamRequest, TemplateInvoiceReminder} {
+		if _, err := Render(name, nil); err != nil {
+			t.Errorf("render of %s returned %v", name, err)
+		}
+	}
+
+	if _, err := Render("newsletter", nil); err == nil {
+		t.Error("render of an unknown template should fail")
+	}
+}
@@ -0,0 +1,35 @@
+package email
+
+import "sync"
+
+// MemoryMailer keeps the sent emails in memory so tests can assert on them.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Request
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (mailer *MemoryMailer) Send(request Request) error {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	mailer.messages = append(mailer.messages, request)
+	return nil
+}
+
+// Messages returns a copy of the captured emails in the order they were sent.
+func (mailer *MemoryMailer) Messages() []Request {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	messages := make([]Request, len(mailer.messages))
+	copy(messages, mailer.messages)
+	return messages
+}
+
+func (mailer *MemoryMailer) Reset() {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	mailer.messages = nil
+}
@@ -1,13 +1,5 @@
 package email
 
-import (
-	"bytes"
-	"gopkg.in/gomail.v2"
-	"html/template"
-	"os"
-	"strconv"
-)
-
 type TemplateData struct {
 	Name        string
 	Link        string
@@ -16,6 +8,16 @@ type TemplateData struct {
 	Type        string
 }
 
+type InvoiceReminderTemplateData struct {
+	Title         string
+	Name          string
+	InvoiceNumber string
+	EventName     string
+	Amount        string
+	DueDate       string
+	Link          string
+}
+
 type TeamInvitationTemplateData struct {
 	Title       string
 	InvitedName string
@@ -47,39 +49,12 @@ func NewRequest(to []string, subject, body string) *Request {
 	}
 }
 
-func (r *Request) ParseTemplate(templateFileName string, data interface{}) error {
-	t, err := template.ParseFiles(templateFileName)
+// ParseTemplate renders one of the registered templates (see template.go) into the request body.
+func (r *Request) ParseTemplate(templateName string, data interface{}) error {
+	body, err := Render(templateName, data)
 	if err != nil {
 		return err
 	}
-	buf := new(bytes.Buffer)
-	if err = t.Execute(buf, data); err != nil {
-		return err
-	}
-	r.Body = buf.String()
+	r.Body = body
 	return nil
 }
-
-func (r *Request) SendEmail() (bool, error) {
-	mailer := gomail.NewMessage()
-	mailer.SetHeader("From", os.Getenv("SMTP_SENDER_EMAIL"))
-	mailer.SetHeader("To", r.To...)
-	mailer.SetHeader("Subject", r.Subject)
-	mailer.SetBody("text/html", r.Body)
-
-	SmtpPort, _ := strconv.Atoi(os.Getenv("SMTP_PORT"))
-
-	dialer := gomail.NewDialer(
-		os.Getenv("SMTP_HOST"),
-		SmtpPort,
-		os.Getenv("SMTP_SENDER_EMAIL"),
-		os.Getenv("SMTP_SENDER_PASSWORD"),
-	)
-
-	err := dialer.DialAndSend(mailer)
-	if err != nil {
-		return false, err
-	}
-
-	return true, nil
-}
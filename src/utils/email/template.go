@@ -0,0 +1,34 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+)
+
+const (
+	TemplateVerificationCode = "verification_code"
+	TemplateTeamInvitation   = "team_invitation"
+	TemplateTeamRequest      = "team_request"
+	TemplateInvoiceReminder  = "invoice_reminder"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.html"))
+
+// Render executes the template registered under the given name, e.g. TemplateTeamInvitation.
+func Render(templateName string, data interface{}) (string, error) {
+	t := templates.Lookup(templateName + ".html")
+	if t == nil {
+		return "", fmt.Errorf("email template %q is not found", templateName)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
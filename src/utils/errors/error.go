@@ -22,6 +22,7 @@ var (
 	ErrUserIsNotActivated             = errors.New("user is not activated")
 	ErrConfirmPasswordNotSame         = errors.New("password and confirm password are not same")
 	ErrInvalidVerificationCode        = errors.New("invalid verification code")
+	ErrInvalidVerificationCodeType    = errors.New("type should be verify-email or reset-password")
 	ErrEventNotRunning                = errors.New("event is not running")
 	ErrLatestEventRunning             = errors.New("another event is running")
 	ErrUnsupportedFileFormat          = errors.New("file format is not supported")
@@ -48,4 +49,66 @@ var (
 	ErrProjectStatusShouldBeDraft     = errors.New("project's status should be draft")
 	ErrProjectStatusShouldBeSubmitted = errors.New("project's status should be submitted")
 	ErrInvalidStatus                  = errors.New("invalid status")
+	ErrEmailNotFailed                 = errors.New("only failed email can be re-sent")
+	ErrOutsideTimelinePhase           = errors.New("this action is not available in the current event timeline")
+	ErrLeaderboardFrozen              = errors.New("leaderboard has been frozen as the official result")
+	ErrNoProjectToRank                = errors.New("there is no project to rank")
+	ErrInvalidAssessmentCriteria      = errors.New("criteria is not an active criteria of the project's event")
+	ErrDuplicateAssessmentCriteria    = errors.New("criteria can only be scored once")
+	ErrScoreOutOfRange                = errors.New("score is out of the criteria's range")
+	ErrIncompleteAssessment           = errors.New("every active criteria should be scored")
+	ErrNotEventJudge                  = errors.New("user is not a judge of the project's event")
+	ErrJudgeConflict                  = errors.New("judge has a conflict of interest with the project")
+	ErrJudgeAlreadyAssigned           = errors.New("judge is already assigned to the project")
+	ErrConflictAlreadyDeclared        = errors.New("conflict of interest has been declared")
+	ErrJudgeIDRequired                = errors.New("judge id is required")
+	ErrPaymentMethodNotAuto           = errors.New("payment method is not available for automatic payment")
+	ErrInvalidSignature               = errors.New("invalid signature")
+	ErrTransactionMismatch            = errors.New("transaction does not belong to the order")
+	ErrInvoiceNotPaid                 = errors.New("invoice is not paid yet")
+	ErrInvalidDocumentType            = errors.New("document type should be invoice or receipt")
+	ErrRefundExceedsPaid              = errors.New("refund amount exceeds the paid amount")
+	ErrRefundExceedsPayment           = errors.New("refund amount exceeds what is left of the payment")
+	ErrAdjustmentExceedsBalance       = errors.New("amount exceeds the outstanding balance")
+	ErrInvalidAmount                  = errors.New("amount should be greater than zero")
+	ErrVoucherInvalid                 = errors.New("voucher code is invalid or expired")
+	ErrVoucherUsedUp                  = errors.New("voucher has reached its usage limit")
+	ErrVoucherCodeAlreadyExists       = errors.New("voucher code already exist")
+	ErrInvalidDiscountValue           = errors.New("percentage discount should be between 1 and 100")
+	ErrInvalidDateRange               = errors.New("end date should not be before start date")
+	ErrPaymentStatusChanged           = errors.New("payment has been processed by someone else, please reload it")
+	ErrAutoPaymentNotSettled          = errors.New("automatic payment is settled by the payment provider")
+	ErrInvoiceExpired                 = errors.New("invoice has expired, please contact the committee")
+	ErrInvoiceNotExpired              = errors.New("only expired invoice can be reopened")
+	ErrInvalidGracePeriod             = errors.New("grace period should be a positive duration, e.g. 48h")
+	ErrInvalidRefreshToken            = errors.New("refresh token is invalid or expired")
+	ErrInvalidTwoFactorChallenge      = errors.New("two factor challenge is invalid or expired")
+	ErrInvalidTwoFactorCode           = errors.New("invalid two factor code")
+	ErrTwoFactorAlreadyEnabled        = errors.New("two factor authentication is already enabled")
+	ErrTwoFactorNotEnabled            = errors.New("two factor authentication is not enabled")
+	ErrTwoFactorNotSetUp              = errors.New("two factor authentication has not been set up")
+	ErrTwoFactorRequired              = errors.New("two factor authentication is mandatory for this role")
+	ErrTooManyTwoFactorAttempts       = errors.New("too many invalid two factor codes, please log in again")
+	ErrUnknownPermission              = errors.New("unknown permission")
+	ErrRoleManageLockout              = errors.New("role.manage can't be removed from your own role")
+	ErrNotEventStaff                  = errors.New("you are not a staff of this event")
+	ErrEventStaffExist                = errors.New("user already has this role in the event")
+	ErrNotEventMentor                 = errors.New("user is not a mentor of the event")
+	ErrInvalidTrashType               = errors.New("type should be events, teams, projects or users")
+	ErrUnknownOauthProvider           = errors.New("sign in provider should be google, github or linkedin")
+	ErrOauthEmailNotVerified          = errors.New("email of the account is not verified by the provider")
+	ErrVerificationCodeExpired        = errors.New("verification code has expired, please request a new one")
+	ErrPasswordTooShort               = errors.New("password is too short")
+	ErrPasswordTooLong                = errors.New("password should be at most 72 characters")
+	ErrPasswordBreached               = errors.New("password is too common, it appears in known data breaches")
+	ErrInvalidImportType              = errors.New("import type should be mentors, judges or participants")
+	ErrImportFileEmpty                = errors.New("the file has no rows to import")
+	ErrImportInvalidRows              = errors.New("some rows are invalid, nothing was imported")
+	ErrInvalidExportType              = errors.New("export type should be participants, teams, projects or payments")
+	ErrInvalidExportFormat            = errors.New("export format should be csv or xlsx")
+	ErrRestoreConflict                = errors.New("an active record with the same unique value already exist")
+	ErrEventNotFinished               = errors.New("certificates can only be generated once the event is finished")
+	ErrCertificateTemplateExist       = errors.New("the event already has a certificate template of this type")
+	ErrNoCertificateTemplate          = errors.New("the event has no certificate template")
+	ErrUnknownCertificatePlaceholder  = errors.New("certificate template uses an unknown placeholder")
 )
@@ -0,0 +1,233 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const midtransSandboxURL = "https://api.sandbox.midtrans.com"
+
+// MidtransProvider talks to the Midtrans Core API.
+type MidtransProvider struct {
+	BaseURL   string
+	ServerKey string
+	Client    *http.Client
+}
+
+func NewMidtransProvider() Provider {
+	baseURL := os.Getenv("MIDTRANS_BASE_URL")
+	if baseURL == "" {
+		baseURL = midtransSandboxURL
+	}
+	return &MidtransProvider{
+		BaseURL:   strings.TrimRight(baseURL, "/"),
+		ServerKey: os.Getenv("MIDTRANS_SERVER_KEY"),
+		Client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (provider *MidtransProvider) Name() string {
+	return ProviderMidtrans
+}
+
+type midtransChargeResponse struct {
+	StatusCode    string `json:"status_code"`
+	StatusMessage string `json:"status_message"`
+	TransactionID string `json:"transaction_id"`
+	OrderID       string `json:"order_id"`
+	ExpiryTime    string `json:"expiry_time"`
+	QRString      string `json:"qr_string"`
+	VANumbers     []struct {
+		Bank     string `json:"bank"`
+		VANumber string `json:"va_number"`
+	} `json:"va_numbers"`
+}
+
+func (provider *MidtransProvider) CreateCharge(request ChargeRequest) (charge Charge, err error) {
+	body := map[string]interface{}{
+		"transaction_details": map[string]interface{}{
+			"order_id":     request.OrderID,
+			"gross_amount": request.Amount,
+		},
+		"customer_details": map[string]interface{}{
+			"first_name": request.Customer.Name,
+			"email":      request.Customer.Email,
+			"phone":      request.Customer.Phone,
+		},
+	}
+
+	switch request.Type {
+	case ChargeVirtualAccount:
+		body["payment_type"] = "bank_transfer"
+		body["bank_transfer"] = map[string]interface{}{"bank": strings.ToLower(request.Bank)}
+	case ChargeQR:
+		body["payment_type"] = "qris"
+	default:
+		err = ErrUnsupportedCharge
+		return
+	}
+
+	if request.ExpireIn > 0 {
+		body["custom_expiry"] = map[string]interface{}{
+			"expiry_duration": int(request.ExpireIn.Minutes()),
+			"unit":            "minute",
+		}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, provider.BaseURL+"/v2/charge", bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(provider.ServerKey, "")
+
+	res, err := provider.Client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+
+	var response midtransChargeResponse
+	if err = json.Unmarshal(resBody, &response); err != nil {
+		return
+	}
+
+	// midtrans answers 201 in the body even though the http status is 200
+	if response.StatusCode != "201" {
+		err = fmt.Errorf("midtrans: %s %s", response.StatusCode, response.StatusMessage)
+		return
+	}
+
+	charge = Charge{
+		TransactionID: response.TransactionID,
+		OrderID:       response.OrderID,
+		QRString:      response.QRString,
+	}
+	if len(response.VANumbers) > 0 {
+		charge.VANumber = response.VANumbers[0].VANumber
+	}
+	if response.ExpiryTime != "" {
+		// expiry time is sent in Asia/Jakarta without the offset
+		if expiresAt, errParse := time.Parse("2006-01-02 15:04:05 -0700", response.ExpiryTime+" +0700"); errParse == nil {
+			charge.ExpiresAt = &expiresAt
+		}
+	}
+	return
+}
+
+type midtransNotification struct {
+	TransactionID     string `json:"transaction_id"`
+	OrderID           string `json:"order_id"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+}
+
+// ParseNotification checks signature_key from the body, which is
+// SHA512(order_id + status_code + gross_amount + server key). The signature argument is not used.
+// The signature doesn't cover transaction_id and transaction_status, so the notification is only
+// trusted for its order id and the state of the transaction is fetched from the status API.
+func (provider *MidtransProvider) ParseNotification(payload []byte, _ string) (notification Notification, err error) {
+	var body midtransNotification
+	if err = json.Unmarshal(payload, &body); err != nil {
+		return
+	}
+
+	hash := sha512.Sum512([]byte(body.OrderID + body.StatusCode + body.GrossAmount + provider.ServerKey))
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(hash[:])), []byte(body.SignatureKey)) != 1 {
+		err = ErrInvalidSignature
+		return
+	}
+
+	status, err := provider.fetchStatus(body.OrderID)
+	if err != nil {
+		return
+	}
+	if status.OrderID != body.OrderID {
+		err = fmt.Errorf("midtrans: status of order %s answered for order %s", body.OrderID, status.OrderID)
+		return
+	}
+
+	amount, err := strconv.ParseFloat(status.GrossAmount, 64)
+	if err != nil {
+		return
+	}
+
+	notification = Notification{
+		TransactionID: status.TransactionID,
+		OrderID:       status.OrderID,
+		Amount:        uint64(amount),
+	}
+
+	switch status.TransactionStatus {
+	case "settlement":
+		notification.Status = TransactionPaid
+	case "capture":
+		notification.Status = TransactionPaid
+		if status.FraudStatus == "challenge" {
+			notification.Status = TransactionPending
+		}
+	case "deny", "cancel", "expire", "failure":
+		notification.Status = TransactionFailed
+	default:
+		notification.Status = TransactionPending
+	}
+	return
+}
+
+// fetchStatus asks the status API for the current state of the order's transaction.
+func (provider *MidtransProvider) fetchStatus(orderID string) (status midtransNotification, err error) {
+	req, err := http.NewRequest(http.MethodGet, provider.BaseURL+"/v2/"+url.PathEscape(orderID)+"/status", nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(provider.ServerKey, "")
+
+	res, err := provider.Client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(resBody, &status); err != nil {
+		return
+	}
+
+	// 200, 201, 202 and 407 (expired) carry a transaction, anything else (e.g. 404) is an error
+	switch status.StatusCode {
+	case "200", "201", "202", "407":
+	default:
+		err = fmt.Errorf("midtrans: status of order %s answered %s", orderID, status.StatusCode)
+	}
+	return
+}
@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"errors"
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	ProviderMidtrans = "midtrans"
+	ProviderFake     = "fake"
+)
+
+const (
+	ChargeVirtualAccount = "va"
+	ChargeQR             = "qr"
+)
+
+const (
+	TransactionPending = "pending"
+	TransactionPaid    = "paid"
+	TransactionFailed  = "failed"
+)
+
+var (
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrUnsupportedCharge = errors.New("charge type is not supported")
+)
+
+// ChargeRequest asks the provider for a virtual account (Bank is required) or a QR code.
+type ChargeRequest struct {
+	OrderID  string
+	Type     string
+	Bank     string
+	Amount   uint64
+	Customer Customer
+	ExpireIn time.Duration
+}
+
+type Customer struct {
+	Name  string
+	Email string
+	Phone string
+}
+
+type Charge struct {
+	TransactionID string
+	OrderID       string
+	VANumber      string
+	QRString      string
+	ExpiresAt     *time.Time
+}
+
+// Notification is the provider's callback about the state of a transaction.
+type Notification struct {
+	TransactionID string
+	OrderID       string
+	Status        string
+	Amount        uint64
+}
+
+// Provider creates charges and checks the callbacks sent to the webhook.
+type Provider interface {
+	Name() string
+	CreateCharge(request ChargeRequest) (Charge, error)
+	// ParseNotification verifies the callback signature and returns ErrInvalidSignature when it doesn't match.
+	ParseNotification(payload []byte, signature string) (Notification, error)
+}
+
+// NewProvider picks the implementation from PAYMENT_PROVIDER (midtrans or fake). It defaults to midtrans.
+// The fake provider refuses to start without PAYMENT_FAKE_SECRET, a known secret would let anyone sign callbacks.
+func NewProvider() Provider {
+	switch os.Getenv("PAYMENT_PROVIDER") {
+	case ProviderFake:
+		secret := os.Getenv("PAYMENT_FAKE_SECRET")
+		if secret == "" {
+			log.Fatal("PAYMENT_FAKE_SECRET is required when PAYMENT_PROVIDER is fake")
+		}
+		return NewFakeProvider(secret)
+	default:
+		return NewMidtransProvider()
+	}
+}
@@ -0,0 +1,20 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatRupiah formats an amount with dot thousand separators, e.g. 1500000 becomes "Rp 1.500.000".
+func FormatRupiah(amount uint64) string {
+	digits := strconv.FormatUint(amount, 10)
+
+	var builder strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteRune(digit)
+	}
+	return "Rp " + builder.String()
+}
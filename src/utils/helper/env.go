@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func GetEnvInt(key string, defaultVal int) int {
+	val, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultVal
+	}
+	return val
+}
+
+func GetEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	val, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultVal
+	}
+	return val
+}
+
+// GetEnvDurations reads a comma separated list of durations, e.g. "72h,24h".
+func GetEnvDurations(key string, defaultVal []time.Duration) []time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultVal
+	}
+
+	var vals []time.Duration
+	for _, v := range strings.Split(raw, ",") {
+		val, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return defaultVal
+		}
+		vals = append(vals, val)
+	}
+	return vals
+}
@@ -17,3 +17,12 @@ func UintInSlice(val uint, s []uint) bool {
 	}
 	return false
 }
+
+// TruncateString cuts s to at most max characters
+func TruncateString(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -88,3 +88,16 @@ func DereferTime(s *time.Time) time.Time {
 
 	return time.Now()
 }
+
+// EndOfDate returns the moment date stops being valid. A date-only value is stored at midnight and covers the whole day.
+func EndOfDate(date time.Time) time.Time {
+	if date.Hour() == 0 && date.Minute() == 0 && date.Second() == 0 {
+		return date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// IsOpenAt reports whether t is between startDate and endDate, endDate is read like EndOfDate does
+func IsOpenAt(startDate, endDate, t time.Time) bool {
+	return !t.Before(startDate) && t.Before(EndOfDate(endDate))
+}
@@ -15,8 +15,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// GenerateToken Generate Token JWT
-func GenerateToken(user userEntity.User) (string, error) {
+// GenerateToken Generate a short-lived access token of a session, valid for ACCESS_TOKEN_TTL (15 minutes by default)
+func GenerateToken(user userEntity.User, sessionID uint) (string, int64, error) {
 	// Create Error Variable
 	var err error
 
@@ -31,9 +31,13 @@ func GenerateToken(user userEntity.User) (string, error) {
 	claims["user_id"] = user.ID
 	claims["email"] = user.Email
 	claims["role_id"] = user.UserRoleID
-	claims["role_name"] = user.UserRole.Name                   //nil
-	claims["expired"] = time.Now().Add(time.Hour * 720).Unix() // Valid for 30 days
-	if user.Participant == nil {                               //nil
+	claims["role_name"] = user.UserRole.Name //nil
+	issuedAt := time.Now()
+	expired := issuedAt.Add(helper.GetEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute)).Unix()
+	claims["iat"] = issuedAt.Unix()
+	claims["sid"] = sessionID
+	claims["expired"] = expired
+	if user.Participant == nil { //nil
 		claims["participant_id"] = nil
 	} else {
 		claims["participant_id"] = user.Participant.ID
@@ -47,11 +51,60 @@ func GenerateToken(user userEntity.User) (string, error) {
 
 	// Check if there is error when signing JWT
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 
 	// Return Token
-	return token, nil
+	return token, expired, nil
+}
+
+// GenerateChallengeToken Generate a short-lived token proving the password step of a two-factor login passed.
+// It is signed with its own key so it can't be used as an access token.
+func GenerateChallengeToken(userID uint, ttl time.Duration) (string, int64, error) {
+	expired := time.Now().Add(ttl).Unix()
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"purpose": "two-factor",
+		"issued":  time.Now().UnixMilli(),
+		"expired": expired,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(challengeKey())
+	if err != nil {
+		return "", 0, err
+	}
+	return token, expired, nil
+}
+
+// ParseChallengeToken returns the user of a valid, unexpired challenge token and when it was issued
+func ParseChallengeToken(tokenString string) (uint, time.Time, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if jwt.GetSigningMethod("HS256") != token.Method {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return challengeKey(), nil
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["purpose"] != "two-factor" {
+		return 0, time.Time{}, errors.New("invalid challenge token")
+	}
+
+	expired, _ := claims["expired"].(float64)
+	if time.Now().Unix() > int64(expired) {
+		return 0, time.Time{}, errors.New("challenge token expired")
+	}
+
+	userID, _ := claims["user_id"].(float64)
+	issued, _ := claims["issued"].(float64)
+	return uint(userID), time.UnixMilli(int64(issued)), nil
+}
+
+func challengeKey() []byte {
+	return []byte(os.Getenv("API_JWT_SECRET") + ":two-factor")
 }
 
 // ExtractToken Extract token
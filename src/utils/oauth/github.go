@@ -0,0 +1,71 @@
+package oauth
+
+import (
+	"be-sagara-hackathon/src/utils/constants"
+	"strconv"
+)
+
+// GithubProvider needs the read:user and user:email scopes
+type GithubProvider struct {
+	Config Config
+}
+
+func NewGithubProvider(config Config) Provider {
+	config.TokenURL = orDefault(config.TokenURL, "https://github.com/login/oauth/access_token")
+	config.APIURL = orDefault(config.APIURL, "https://api.github.com")
+	return &GithubProvider{Config: config}
+}
+
+func (provider *GithubProvider) Name() string {
+	return constants.AuthTypeGithub
+}
+
+func (provider *GithubProvider) Exchange(code string) (*Token, error) {
+	return exchangeCode(provider.Config.TokenURL, provider.Config, code)
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+	HtmlURL   string `json:"html_url"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// GetProfile takes the primary address from /user/emails, the one on the profile is empty when it is private
+func (provider *GithubProvider) GetProfile(token *Token) (*Profile, error) {
+	var user githubUser
+	if err := getJSON(provider.Config.APIURL+"/user", token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+
+	var emails []githubEmail
+	if err := getJSON(provider.Config.APIURL+"/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		ID:         strconv.FormatInt(user.ID, 10),
+		Name:       user.Name,
+		Picture:    user.AvatarURL,
+		ProfileURL: user.HtmlURL,
+	}
+	if profile.Name == "" {
+		profile.Name = user.Login
+	}
+
+	for _, email := range emails {
+		if email.Primary {
+			profile.Email = email.Email
+			profile.VerifiedEmail = email.Verified
+		}
+	}
+
+	return profile, nil
+}
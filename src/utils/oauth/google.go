@@ -1,127 +1,46 @@
 package oauth
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
-	"os"
-	"time"
+	"be-sagara-hackathon/src/utils/constants"
 )
 
-type GoogleOauthToken struct {
-	AccessToken string
-	IdToken     string
+type GoogleProvider struct {
+	Config Config
 }
 
-type GoogleUserResult struct {
-	Id            string
-	Email         string
-	VerifiedEmail bool
-	Name          string
-	GivenName     string
-	FamilyName    string
-	Picture       string
-	Locale        string
+func NewGoogleProvider(config Config) Provider {
+	config.TokenURL = orDefault(config.TokenURL, "https://oauth2.googleapis.com/token")
+	config.APIURL = orDefault(config.APIURL, "https://www.googleapis.com")
+	return &GoogleProvider{Config: config}
 }
 
-func GetGoogleOauthToken(code string) (*GoogleOauthToken, error) {
-	const rootURl = "https://oauth2.googleapis.com/token"
-
-	values := url.Values{}
-	values.Add("grant_type", "authorization_code")
-	values.Add("code", code)
-	values.Add("client_id", os.Getenv("GOOGLE_OAUTH_CLIENT_ID"))
-	values.Add("client_secret", os.Getenv("GOOGLE_OAUTH_CLIENT_SECRET"))
-	values.Add("redirect_uri", os.Getenv("GOOGLE_OAUTH_REDIRECT_URL"))
-
-	query := values.Encode()
-
-	req, err := http.NewRequest("POST", rootURl, bytes.NewBufferString(query))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	client := http.Client{
-		Timeout: time.Second * 30,
-	}
-
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, errors.New("could not retrieve token")
-	}
-
-	resBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var GoogleOauthTokenRes map[string]interface{}
-
-	if err := json.Unmarshal(resBody, &GoogleOauthTokenRes); err != nil {
-		return nil, err
-	}
-
-	tokenBody := &GoogleOauthToken{
-		AccessToken: GoogleOauthTokenRes["access_token"].(string),
-		IdToken:     GoogleOauthTokenRes["id_token"].(string),
-	}
-
-	return tokenBody, nil
+func (provider *GoogleProvider) Name() string {
+	return constants.AuthTypeGoogle
 }
 
-func GetGoogleUser(accessToken, idToken string) (*GoogleUserResult, error) {
-	rootUrl := fmt.Sprintf("https://www.googleapis.com/oauth2/v1/userinfo?alt=json&access_token=%s", accessToken)
-
-	req, err := http.NewRequest("GET", rootUrl, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", idToken))
-
-	client := http.Client{
-		Timeout: time.Second * 30,
-	}
-
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, errors.New("could not retrieve user")
-	}
-
-	resBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
+func (provider *GoogleProvider) Exchange(code string) (*Token, error) {
+	return exchangeCode(provider.Config.TokenURL, provider.Config, code)
+}
 
-	var GoogleUserRes map[string]interface{}
+type googleUserResult struct {
+	Id            string `json:"id"`
+	Email         string `json:"email"`
+	VerifiedEmail bool   `json:"verified_email"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
 
-	if err := json.Unmarshal(resBody, &GoogleUserRes); err != nil {
+func (provider *GoogleProvider) GetProfile(token *Token) (*Profile, error) {
+	var user googleUserResult
+	if err := getJSON(provider.Config.APIURL+"/oauth2/v1/userinfo?alt=json", token.AccessToken, &user); err != nil {
 		return nil, err
 	}
 
-	userBody := &GoogleUserResult{
-		Id:            GoogleUserRes["id"].(string),
-		Email:         GoogleUserRes["email"].(string),
-		VerifiedEmail: GoogleUserRes["verified_email"].(bool),
-		Name:          GoogleUserRes["name"].(string),
-		GivenName:     GoogleUserRes["given_name"].(string),
-		FamilyName:    GoogleUserRes["family_name"].(string),
-		Picture:       GoogleUserRes["picture"].(string),
-		Locale:        GoogleUserRes["locale"].(string),
-	}
-
-	return userBody, nil
+	return &Profile{
+		ID:            user.Id,
+		Email:         user.Email,
+		VerifiedEmail: user.VerifiedEmail,
+		Name:          user.Name,
+		Picture:       user.Picture,
+	}, nil
 }
@@ -0,0 +1,59 @@
+package oauth
+
+import (
+	"be-sagara-hackathon/src/utils/constants"
+)
+
+// LinkedinProvider uses Sign In with LinkedIn (OpenID Connect), with the openid, profile and email scopes
+type LinkedinProvider struct {
+	Config Config
+}
+
+func NewLinkedinProvider(config Config) Provider {
+	config.TokenURL = orDefault(config.TokenURL, "https://www.linkedin.com/oauth/v2/accessToken")
+	config.APIURL = orDefault(config.APIURL, "https://api.linkedin.com")
+	return &LinkedinProvider{Config: config}
+}
+
+func (provider *LinkedinProvider) Name() string {
+	return constants.AuthTypeLinkedin
+}
+
+func (provider *LinkedinProvider) Exchange(code string) (*Token, error) {
+	return exchangeCode(provider.Config.TokenURL, provider.Config, code)
+}
+
+type linkedinUserInfo struct {
+	Sub           string `json:"sub"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+type linkedinMe struct {
+	VanityName string `json:"vanityName"`
+}
+
+// GetProfile reads the public profile name from /v2/me when the app is allowed to, userinfo has no profile url
+func (provider *LinkedinProvider) GetProfile(token *Token) (*Profile, error) {
+	var user linkedinUserInfo
+	if err := getJSON(provider.Config.APIURL+"/v2/userinfo", token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		ID:            user.Sub,
+		Email:         user.Email,
+		VerifiedEmail: user.EmailVerified,
+		Name:          user.Name,
+		Picture:       user.Picture,
+	}
+
+	var me linkedinMe
+	if err := getJSON(provider.Config.APIURL+"/v2/me?projection=(vanityName)", token.AccessToken, &me); err == nil && me.VanityName != "" {
+		profile.ProfileURL = "https://www.linkedin.com/in/" + me.VanityName
+	}
+
+	return profile, nil
+}
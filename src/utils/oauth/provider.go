@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"be-sagara-hackathon/src/utils/constants"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRetrieveToken = errors.New("could not retrieve token")
+	ErrRetrieveUser  = errors.New("could not retrieve user")
+)
+
+// Token is what the provider gives back for an authorization code
+type Token struct {
+	AccessToken string
+	IdToken     string
+}
+
+// Profile is the account of the user at the provider. ProfileURL is the user's page on GitHub or LinkedIn.
+type Profile struct {
+	ID            string
+	Email         string
+	VerifiedEmail bool
+	Name          string
+	Picture       string
+	ProfileURL    string
+}
+
+// Provider signs users in with the authorization code flow. Name is the user's auth type.
+type Provider interface {
+	Name() string
+	Exchange(code string) (*Token, error)
+	GetProfile(token *Token) (*Profile, error)
+}
+
+// Config is read from <PROVIDER>_OAUTH_CLIENT_ID, _CLIENT_SECRET and _REDIRECT_URL.
+// TokenURL and APIURL default to the provider's own endpoints, they are only set to point at a stand-in.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	TokenURL     string
+	APIURL       string
+}
+
+func configFromEnv(prefix string) Config {
+	return Config{
+		ClientID:     os.Getenv(prefix + "_OAUTH_CLIENT_ID"),
+		ClientSecret: os.Getenv(prefix + "_OAUTH_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv(prefix + "_OAUTH_REDIRECT_URL"),
+		TokenURL:     os.Getenv(prefix + "_OAUTH_TOKEN_URL"),
+		APIURL:       os.Getenv(prefix + "_OAUTH_API_URL"),
+	}
+}
+
+// Providers are looked up by auth type
+type Providers map[string]Provider
+
+func NewProviders() Providers {
+	return Providers{
+		constants.AuthTypeGoogle:   NewGoogleProvider(configFromEnv("GOOGLE")),
+		constants.AuthTypeGithub:   NewGithubProvider(configFromEnv("GITHUB")),
+		constants.AuthTypeLinkedin: NewLinkedinProvider(configFromEnv("LINKEDIN")),
+	}
+}
+
+// Get returns false for an unknown provider
+func (providers Providers) Get(name string) (Provider, bool) {
+	provider, ok := providers[name]
+	return provider, ok
+}
+
+var client = http.Client{
+	Timeout: time.Second * 30,
+}
+
+// exchangeCode posts the authorization code as a form, the way every provider here expects it
+func exchangeCode(tokenURL string, config Config, code string) (*Token, error) {
+	values := url.Values{}
+	values.Add("grant_type", "authorization_code")
+	values.Add("code", code)
+	values.Add("client_id", config.ClientID)
+	values.Add("client_secret", config.ClientSecret)
+	values.Add("redirect_uri", config.RedirectURL)
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		IdToken     string `json:"id_token"`
+	}
+	if err = doJSON(req, &body, ErrRetrieveToken); err != nil {
+		return nil, err
+	}
+	if body.AccessToken == "" {
+		return nil, ErrRetrieveToken
+	}
+
+	return &Token{AccessToken: body.AccessToken, IdToken: body.IdToken}, nil
+}
+
+// getJSON calls a profile endpoint with the access token
+func getJSON(endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	return doJSON(req, out, ErrRetrieveUser)
+}
+
+func doJSON(req *http.Request, out interface{}, errStatus error) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errStatus
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resBody, out)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return strings.TrimRight(value, "/")
+}
@@ -0,0 +1,81 @@
+package oauth
+
+import (
+	"be-sagara-hackathon/src/utils/constants"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProviders(t *testing.T) {
+	cases := []struct {
+		provider string
+		profile  Profile
+		want     Profile
+	}{
+		{
+			provider: constants.AuthTypeGoogle,
+			profile:  Profile{ID: "g-1", Email: "jane@example.com", VerifiedEmail: true, Name: "Jane", Picture: "pic"},
+			want:     Profile{ID: "g-1", Email: "jane@example.com", VerifiedEmail: true, Name: "Jane", Picture: "pic"},
+		},
+		{
+			provider: constants.AuthTypeGithub,
+			profile:  Profile{Email: "jane@example.com", VerifiedEmail: true, Name: "Jane", Picture: "pic", ProfileURL: "https://github.com/jane"},
+			want:     Profile{ID: "42", Email: "jane@example.com", VerifiedEmail: true, Name: "Jane", Picture: "pic", ProfileURL: "https://github.com/jane"},
+		},
+		{
+			// without a name the login is used
+			provider: constants.AuthTypeGithub,
+			profile:  Profile{Email: "jane@example.com"},
+			want:     Profile{ID: "42", Email: "jane@example.com", Name: "jane"},
+		},
+		{
+			provider: constants.AuthTypeLinkedin,
+			profile:  Profile{ID: "l-1", Email: "jane@example.com", VerifiedEmail: true, Name: "Jane", ProfileURL: "https://www.linkedin.com/in/jane"},
+			want:     Profile{ID: "l-1", Email: "jane@example.com", VerifiedEmail: true, Name: "Jane", ProfileURL: "https://www.linkedin.com/in/jane"},
+		},
+	}
+
+	for _, c := range cases {
+		server := httptest.NewServer(stubHandler(c.profile))
+		provider, ok := stubProviders(server.URL).Get(c.provider)
+		if !ok {
+			t.Fatalf("provider %s is missing", c.provider)
+		}
+		if provider.Name() != c.provider {
+			t.Errorf("provider %s is named %s", c.provider, provider.Name())
+		}
+
+		token, err := provider.Exchange("code")
+		if err != nil {
+			t.Fatalf("%s: Exchange returned %v", c.provider, err)
+		}
+		profile, err := provider.GetProfile(token)
+		if err != nil {
+			t.Fatalf("%s: GetProfile returned %v", c.provider, err)
+		}
+		if *profile != c.want {
+			t.Errorf("%s: GetProfile = %+v, want %+v", c.provider, *profile, c.want)
+		}
+		server.Close()
+	}
+}
+
+func TestProvidersRejected(t *testing.T) {
+	server := httptest.NewServer(stubHandler(Profile{Email: "jane@example.com"}))
+	defer server.Close()
+
+	for name, provider := range stubProviders(server.URL) {
+		if _, err := provider.Exchange(stubInvalidCode); err != ErrRetrieveToken {
+			t.Errorf("%s: Exchange of an invalid code returned %v, want %v", name, err, ErrRetrieveToken)
+		}
+		if _, err := provider.GetProfile(&Token{AccessToken: "expired"}); err != ErrRetrieveUser {
+			t.Errorf("%s: GetProfile with an invalid token returned %v, want %v", name, err, ErrRetrieveUser)
+		}
+	}
+}
+
+func TestProvidersGet(t *testing.T) {
+	if _, ok := NewProviders().Get("myspace"); ok {
+		t.Error("an unknown provider should not be found")
+	}
+}
@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"be-sagara-hackathon/src/utils/constants"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// stubInvalidCode is the one authorization code the stand-in rejects
+const stubInvalidCode = "invalid"
+
+const stubAccessToken = "stub-access-token"
+
+var stubTokenPaths = map[string]string{
+	constants.AuthTypeGoogle:   "/token",
+	constants.AuthTypeGithub:   "/login/oauth/access_token",
+	constants.AuthTypeLinkedin: "/oauth/v2/accessToken",
+}
+
+// stubHandler stands in for the token and profile endpoints of every provider, answering the way each
+// of them does. Any code but stubInvalidCode is exchanged and every token belongs to profile.
+// Serve it with httptest.NewServer and hand stubProviders the server url.
+func stubHandler(profile Profile) http.Handler {
+	mux := http.NewServeMux()
+
+	for _, path := range stubTokenPaths {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.FormValue("code") == "" || r.FormValue("code") == stubInvalidCode {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			writeStub(w, map[string]string{"access_token": stubAccessToken, "id_token": "stub-id-token"})
+		})
+	}
+
+	mux.HandleFunc("/oauth2/v1/userinfo", stubProfile(func() interface{} {
+		return googleUserResult{
+			Id:            profile.ID,
+			Email:         profile.Email,
+			VerifiedEmail: profile.VerifiedEmail,
+			Name:          profile.Name,
+			Picture:       profile.Picture,
+		}
+	}))
+	mux.HandleFunc("/user", stubProfile(func() interface{} {
+		return map[string]interface{}{
+			"id":         42,
+			"login":      strings.Split(profile.Email, "@")[0],
+			"name":       profile.Name,
+			"avatar_url": profile.Picture,
+			"html_url":   profile.ProfileURL,
+		}
+	}))
+	mux.HandleFunc("/user/emails", stubProfile(func() interface{} {
+		return []githubEmail{{Email: profile.Email, Primary: true, Verified: profile.VerifiedEmail}}
+	}))
+	mux.HandleFunc("/v2/userinfo", stubProfile(func() interface{} {
+		return linkedinUserInfo{
+			Sub:           profile.ID,
+			Name:          profile.Name,
+			Picture:       profile.Picture,
+			Email:         profile.Email,
+			EmailVerified: profile.VerifiedEmail,
+		}
+	}))
+	mux.HandleFunc("/v2/me", stubProfile(func() interface{} {
+		return linkedinMe{VanityName: strings.TrimPrefix(profile.ProfileURL, "https://www.linkedin.com/in/")}
+	}))
+
+	return mux
+}
+
+// stubProviders returns every provider pointed at the stand-in served at baseURL
+func stubProviders(baseURL string) Providers {
+	config := func(name string) Config {
+		return Config{ClientID: "stub", ClientSecret: "stub", TokenURL: baseURL + stubTokenPaths[name], APIURL: baseURL}
+	}
+	return Providers{
+		constants.AuthTypeGoogle:   NewGoogleProvider(config(constants.AuthTypeGoogle)),
+		constants.AuthTypeGithub:   NewGithubProvider(config(constants.AuthTypeGithub)),
+		constants.AuthTypeLinkedin: NewLinkedinProvider(config(constants.AuthTypeLinkedin)),
+	}
+}
+
+func stubProfile(body func() interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+stubAccessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeStub(w, body())
+	}
+}
+
+func writeStub(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	e "be-sagara-hackathon/src/utils/errors"
+	"be-sagara-hackathon/src/utils/helper"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// bcrypt ignores everything after the first 72 bytes
+const passwordMaxLength = 72
+
+//go:embed data/breached_passwords.txt
+var breachedPasswordList string
+
+var (
+	breachedPasswords     map[string]struct{}
+	breachedPasswordsOnce sync.Once
+)
+
+// ValidatePassword checks a new password against the policy: PASSWORD_MIN_LENGTH characters at least
+// (8 by default), 72 bytes at most and not on the bundled list of breached passwords.
+func ValidatePassword(password string) error {
+	if len([]rune(password)) < helper.GetEnvInt("PASSWORD_MIN_LENGTH", 8) {
+		return e.ErrPasswordTooShort
+	}
+	if len(password) > passwordMaxLength {
+		return e.ErrPasswordTooLong
+	}
+
+	breachedPasswordsOnce.Do(func() {
+		breachedPasswords = make(map[string]struct{})
+		for _, line := range strings.Split(breachedPasswordList, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			breachedPasswords[strings.ToLower(line)] = struct{}{}
+		}
+	})
+
+	if _, ok := breachedPasswords[strings.ToLower(password)]; ok {
+		return e.ErrPasswordBreached
+	}
+	return nil
+}
+
+// IsPasswordPolicyError tells whether err came from ValidatePassword
+func IsPasswordPolicyError(err error) bool {
+	return err == e.ErrPasswordTooShort || err == e.ErrPasswordTooLong || err == e.ErrPasswordBreached
+}
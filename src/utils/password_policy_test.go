@@ -0,0 +1,39 @@
+package utils
+
+import (
+	e "be-sagara-hackathon/src/utils/errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name      string
+		minLength string
+		password  string
+		want      error
+	}{
+		{name: "too short", password: "kettle7", want: e.ErrPasswordTooShort},
+		{name: "minimum length", password: "kettle77", want: nil},
+		{name: "minimum length from env", minLength: "12", password: "violet-kettle", want: nil},
+		{name: "too short for the env minimum", minLength: "12", password: "kettle77", want: e.ErrPasswordTooShort},
+		{name: "length counted in characters", password: "kéttlé7", want: e.ErrPasswordTooShort},
+		{name: "maximum length", password: strings.Repeat("k", 72), want: nil},
+		{name: "too long", password: strings.Repeat("k", 73), want: e.ErrPasswordTooLong},
+		{name: "too long in bytes", password: strings.Repeat("é", 37), want: e.ErrPasswordTooLong},
+		{name: "breached", password: "password", want: e.ErrPasswordBreached},
+		{name: "breached in another case", password: "PassWord", want: e.ErrPasswordBreached},
+		{name: "breached digits", password: "12345678", want: e.ErrPasswordBreached},
+		{name: "breached password with a suffix", password: "password-of-violet", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PASSWORD_MIN_LENGTH", tt.minLength)
+
+			if got := ValidatePassword(tt.password); got != tt.want {
+				t.Errorf("ValidatePassword(%q) = %v, want %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
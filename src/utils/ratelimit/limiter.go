@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule allows Limit requests per Window
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRule reads a rule written as "<limit>/<window>", e.g. "5/15m"
+func ParseRule(raw string) (Rule, error) {
+	parts := strings.SplitN(raw, "/", 2)
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q", raw)
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q", raw)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q", raw)
+	}
+	return Rule{Limit: limit, Window: window}, nil
+}
+
+// RuleFromEnv reads a rule from the env variable key, falling back to defaultVal
+func RuleFromEnv(key string, defaultVal Rule) Rule {
+	rule, err := ParseRule(os.Getenv(key))
+	if err != nil {
+		return defaultVal
+	}
+	return rule
+}
+
+// Allow counts a request against the fixed window bucket of key. When the bucket is full
+// it returns false and how long until the window resets.
+func Allow(store Store, key string, rule Rule) (allowed bool, retryAfter time.Duration, err error) {
+	count, expiresAt, err := store.Incr(key, rule.Window)
+	if err != nil {
+		return
+	}
+	if count > rule.Limit {
+		return false, time.Until(expiresAt), nil
+	}
+	return true, 0, nil
+}
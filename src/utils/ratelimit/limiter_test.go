@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestStore returns a memory store whose clock is moved by hand
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(" 5 / 15m ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Limit != 5 || rule.Window != 15*time.Minute {
+		t.Errorf("ParseRule = %+v, want 5 per 15m", rule)
+	}
+
+	for _, raw := range []string{"", "5", "0/1m", "-1/1m", "5/0s", "five/1m", "5/soon"} {
+		if _, err = ParseRule(raw); err == nil {
+			t.Errorf("ParseRule(%q) should fail", raw)
+		}
+	}
+}
+
+func TestRuleFromEnv(t *testing.T) {
+	fallback := Rule{Limit: 1, Window: time.Second}
+
+	t.Setenv("RATE_LIMIT_TEST", "3/1h")
+	if rule := RuleFromEnv("RATE_LIMIT_TEST", fallback); rule != (Rule{Limit: 3, Window: time.Hour}) {
+		t.Errorf("RuleFromEnv = %+v, want 3 per 1h", rule)
+	}
+
+	t.Setenv("RATE_LIMIT_TEST", "broken")
+	if rule := RuleFromEnv("RATE_LIMIT_TEST", fallback); rule != fallback {
+		t.Errorf("RuleFromEnv of an invalid rule = %+v, want the default", rule)
+	}
+}
+
+func TestAllowWindow(t *testing.T) {
+	store, now := newTestStore()
+	rule := Rule{Limit: 2, Window: time.Minute}
+
+	for i := 1; i <= rule.Limit; i++ {
+		if allowed, _, err := Allow(store, "key", rule); err != nil || !allowed {
+			t.Fatalf("request %d = (%v, %v), want allowed", i, allowed, err)
+		}
+	}
+	if allowed, _, _ := Allow(store, "key", rule); allowed {
+		t.Fatal("a request past the limit should be refused")
+	}
+	if allowed, _, _ := Allow(store, "other", rule); !allowed {
+		t.Error("the limit of one key should not count for another")
+	}
+
+	// still refused at the last moment of the window, allowed again once it is over
+	*now = now.Add(rule.Window - time.Second)
+	if allowed, _, _ := Allow(store, "key", rule); allowed {
+		t.Error("a request inside the window should still be refused")
+	}
+	*now = now.Add(time.Second)
+	if allowed, _, _ := Allow(store, "key", rule); !allowed {
+		t.Error("a request after the window should be allowed")
+	}
+}
+
+func TestLockout(t *testing.T) {
+	store, now := newTestStore()
+	lockout := Lockout{Store: store, MaxFailures: 2, Duration: time.Minute, MaxDuration: 3 * time.Minute, Window: time.Hour}
+
+	_ = lockout.Fail("key")
+	if locked, _, _ := lockout.Check("key"); locked {
+		t.Fatal("a key under the failure limit should not be locked")
+	}
+	_ = lockout.Fail("key")
+	if locked, _, _ := lockout.Check("key"); !locked {
+		t.Fatal("a key at the failure limit should be locked")
+	}
+
+	*now = now.Add(time.Minute)
+	if locked, _, _ := lockout.Check("key"); locked {
+		t.Fatal("the lock should be over after its duration")
+	}
+
+	// every further failure doubles the lock up to the max duration
+	_ = lockout.Fail("key")
+	*now = now.Add(time.Minute)
+	if locked, _, _ := lockout.Check("key"); !locked {
+		t.Error("the third failure should lock for two minutes")
+	}
+	_ = lockout.Fail("key")
+	*now = now.Add(3*time.Minute - time.Second)
+	if locked, _, _ := lockout.Check("key"); !locked {
+		t.Error("the fourth failure should lock for the max duration")
+	}
+	*now = now.Add(time.Second)
+	if locked, _, _ := lockout.Check("key"); locked {
+		t.Error("the lock should never be longer than the max duration")
+	}
+}
+
+func TestLockoutReset(t *testing.T) {
+	store, _ := newTestStore()
+	lockout := Lockout{Store: store, MaxFailures: 2, Duration: time.Minute, MaxDuration: time.Hour, Window: time.Hour}
+
+	_ = lockout.Fail("key")
+	_ = lockout.Fail("key")
+	if err := lockout.Reset("key"); err != nil {
+		t.Fatal(err)
+	}
+	if locked, _, _ := lockout.Check("key"); locked {
+		t.Fatal("a reset key should not be locked")
+	}
+
+	// the failures before the reset are forgotten too
+	_ = lockout.Fail("key")
+	if locked, _, _ := lockout.Check("key"); locked {
+		t.Error("one failure after a reset should not lock")
+	}
+}
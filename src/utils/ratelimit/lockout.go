@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+// Lockout locks an account out after MaxFailures failed attempts. The lock starts at Duration
+// and doubles with every further failure up to MaxDuration. Failures are forgotten after Window
+// or on a successful attempt.
+type Lockout struct {
+	Store       Store
+	MaxFailures int
+	Duration    time.Duration
+	MaxDuration time.Duration
+	Window      time.Duration
+}
+
+// Check reports whether key is locked and for how long
+func (lockout Lockout) Check(key string) (locked bool, retryAfter time.Duration, err error) {
+	count, expiresAt, err := lockout.Store.Get("lock:" + key)
+	if err != nil || count == 0 {
+		return
+	}
+	return true, time.Until(expiresAt), nil
+}
+
+// Fail records a failed attempt of key and locks it once there are too many
+func (lockout Lockout) Fail(key string) error {
+	failures, _, err := lockout.Store.Incr("failures:"+key, lockout.Window)
+	if err != nil || failures < lockout.MaxFailures {
+		return err
+	}
+
+	duration := lockout.Duration
+	for i := lockout.MaxFailures; i < failures && duration < lockout.MaxDuration; i++ {
+		duration *= 2
+	}
+	if duration > lockout.MaxDuration {
+		duration = lockout.MaxDuration
+	}
+	return lockout.Store.Set("lock:"+key, 1, duration)
+}
+
+// Reset forgets the failed attempts of key
+func (lockout Lockout) Reset(key string) error {
+	if err := lockout.Store.Delete("failures:" + key); err != nil {
+		return err
+	}
+	return lockout.Store.Delete("lock:" + key)
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of writes between two sweeps of expired counters
+const sweepEvery = 1000
+
+type counter struct {
+	count     int
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the counters in the process memory
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]counter
+	writes   int
+	now      func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: map[string]counter{}, now: time.Now}
+}
+
+func (store *MemoryStore) Incr(key string, ttl time.Duration) (int, time.Time, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	c, ok := store.counters[key]
+	if !ok || !now.Before(c.expiresAt) {
+		c = counter{expiresAt: now.Add(ttl)}
+	}
+	c.count++
+	store.write(key, c, now)
+	return c.count, c.expiresAt, nil
+}
+
+func (store *MemoryStore) Get(key string) (int, time.Time, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	c, ok := store.counters[key]
+	if !ok || !store.now().Before(c.expiresAt) {
+		return 0, time.Time{}, nil
+	}
+	return c.count, c.expiresAt, nil
+}
+
+func (store *MemoryStore) Set(key string, count int, ttl time.Duration) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	store.write(key, counter{count: count, expiresAt: now.Add(ttl)}, now)
+	return nil
+}
+
+func (store *MemoryStore) Delete(key string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	delete(store.counters, key)
+	return nil
+}
+
+// write saves the counter and sweeps expired ones every sweepEvery writes so the map does not grow without bound
+func (store *MemoryStore) write(key string, c counter, now time.Time) {
+	store.counters[key] = c
+	store.writes++
+	if store.writes < sweepEvery {
+		return
+	}
+
+	store.writes = 0
+	for k, v := range store.counters {
+		if !now.Before(v.expiresAt) {
+			delete(store.counters, k)
+		}
+	}
+}
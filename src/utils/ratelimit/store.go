@@ -0,0 +1,22 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+// Store keeps expiring counters. Every instance of the API has to share the same store for
+// the limits to hold across nodes, the in-memory store only works for a single node.
+type Store interface {
+	// Incr adds one to the counter of key and returns the new count. A new counter expires after ttl.
+	Incr(key string, ttl time.Duration) (count int, expiresAt time.Time, err error)
+	// Get returns the counter of key, zero when it does not exist or has expired.
+	Get(key string) (count int, expiresAt time.Time, err error)
+	// Set replaces the counter of key, it expires after ttl.
+	Set(key string, count int, ttl time.Duration) error
+	Delete(key string) error
+}
+
+// NewStore returns the in-memory store, a shared store is plugged in by implementing Store
+func NewStore() Store {
+	return NewMemoryStore()
+}
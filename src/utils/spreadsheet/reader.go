@@ -0,0 +1,57 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+var ErrUnsupportedFormat = errors.New("only .csv and .xlsx files are supported")
+
+// Read returns the rows of a .csv file or of the first sheet of a .xlsx file, picked by the file extension.
+// Cells are trimmed and trailing empty rows are dropped.
+func Read(filename string, file io.ReaderAt, size int64) ([][]string, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		rows, err = readCSV(io.NewSectionReader(file, 0, size))
+	case ".xlsx":
+		rows, err = readXLSX(file, size)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		for j := range rows[i] {
+			rows[i][j] = strings.TrimSpace(rows[i][j])
+		}
+	}
+	for len(rows) > 0 && isEmpty(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader.ReadAll()
+}
+
+func isEmpty(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
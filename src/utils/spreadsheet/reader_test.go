@@ -0,0 +1,128 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testWorkbook = `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	testRels = `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`
+)
+
+// newXLSX zips a workbook with one sheet holding sheetData and the given shared strings
+func newXLSX(t *testing.T, sheetData string, shared ...string) *bytes.Reader {
+	var sharedXML strings.Builder
+	sharedXML.WriteString("<sst>")
+	for _, s := range shared {
+		sharedXML.WriteString("<si><t>" + s + "</t></si>")
+	}
+	sharedXML.WriteString("</sst>")
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRels,
+		"xl/sharedStrings.xml":       sharedXML.String(),
+		"xl/worksheets/sheet1.xml":   "<worksheet><sheetData>" + sheetData + "</sheetData></worksheet>",
+	}
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadXLSX(t *testing.T) {
+	file := newXLSX(t,
+		`<row><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>`+
+			`<row><c r="A2" t="inlineStr"><is><t> Jane </t></is></c><c r="B2"><v>42</v></c></row>`+
+			`<row><c r="B3"><v>7</v></c></row>`+
+			`<row></row>`,
+		"name", "age",
+	)
+
+	rows, err := Read("people.xlsx", file, file.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"name", "age"}, {"Jane", "42"}, {"", "7"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read = %q, want %q", rows, want)
+	}
+}
+
+func TestReadXLSXCapsColumnsAtHeader(t *testing.T) {
+	file := newXLSX(t,
+		`<row><c r="A1"><v>1</v></c><c r="B1"><v>2</v></c></row>`+
+			`<row><c r="A2"><v>3</v></c><c r="XFD2"><v>4</v></c><c r="ZZZZZZZZZZ2"><v>5</v></c></row>`,
+	)
+
+	rows, err := Read("people.xlsx", file, file.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"1", "2"}, {"3"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read = %q, want %q", rows, want)
+	}
+}
+
+func TestReadXLSXInvalid(t *testing.T) {
+	file := bytes.NewReader([]byte("not a zip"))
+	if _, err := Read("people.xlsx", file, file.Size()); err != ErrInvalidXLSX {
+		t.Errorf("Read of a file that isn't a zip returned %v, want %v", err, ErrInvalidXLSX)
+	}
+
+	file = newXLSX(t, `<row><c r="A1" t="s"><v>3</v></c></row>`)
+	if _, err := Read("people.xlsx", file, file.Size()); err != ErrInvalidXLSX {
+		t.Errorf("Read of a missing shared string returned %v, want %v", err, ErrInvalidXLSX)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	file := strings.NewReader("name, email\n Jane ,jane@example.com,extra\n\n")
+	rows, err := Read("people.CSV", file, file.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"name", "email"}, {"Jane", "jane@example.com", "extra"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read = %q, want %q", rows, want)
+	}
+
+	if _, err = Read("people.txt", file, file.Size()); err != ErrUnsupportedFormat {
+		t.Errorf("Read of a .txt returned %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	cases := map[string]int{
+		"A1":     0,
+		"C7":     2,
+		"Z3":     25,
+		"AA1":    26,
+		"XFD9":   maxColumns - 1,
+		"XFE9":   -1,
+		"ZZZZZZ": -1,
+		"12":     -1,
+	}
+	for ref, want := range cases {
+		if got := columnIndex(ref); got != want {
+			t.Errorf("columnIndex(%q) = %d, want %d", ref, got, want)
+		}
+	}
+}
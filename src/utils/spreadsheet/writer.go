@@ -0,0 +1,123 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // EXPORT_TIMEZONE must load on hosts without a zoneinfo database
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer writes one row at a time so an export never holds the whole result in memory.
+// Close must be called to finish the file.
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+// NewWriter returns a csv or xlsx writer, format is FormatCSV or FormatXLSX
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// ContentType is the media type of the format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+// Write quotes cells that a spreadsheet would run as a formula, names and titles come from participants
+func (w *csvWriter) Write(row []string) error {
+	escaped := make([]string, len(row))
+	for i, cell := range row {
+		escaped[i] = escapeFormula(cell)
+	}
+	return w.writer.Write(escaped)
+}
+
+// escapeFormula prefixes cells starting with =, +, -, @, a tab or a carriage return with ' so they are read as text
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+var (
+	location     *time.Location
+	locationOnce sync.Once
+)
+
+// Location is EXPORT_TIMEZONE, Asia/Jakarta by default
+func Location() *time.Location {
+	locationOnce.Do(func() {
+		name := os.Getenv("EXPORT_TIMEZONE")
+		if name == "" {
+			name = "Asia/Jakarta"
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			loc = time.UTC
+		}
+		location = loc
+	})
+	return location
+}
+
+// FormatTime writes every time of an export in Location, nil is an empty cell
+func FormatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.In(Location()).Format("2006-01-02 15:04:05")
+}
+
+// FormatDate is FormatTime without the time of day, for dates like a birthdate
+func FormatDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func FormatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+func FormatUint(n uint64) string {
+	return strconv.FormatUint(n, 10)
+}
+
+// FormatString is an empty cell for nil
+func FormatString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,35 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatCSV, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := []string{"=HYPERLINK(\"http://evil\")", "+1", "-2", "@SUM(A1)", "\t=1+1", "\r=1+1", "Team = Best", "", "42"}
+	if err = writer.Write(row); err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if row[0] != "=HYPERLINK(\"http://evil\")" {
+		t.Error("Write should not change the row it is given")
+	}
+
+	rows, err := Read("export.csv", bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"'=HYPERLINK(\"http://evil\")", "'+1", "'-2", "'@SUM(A1)", "'\t=1+1", "'\r=1+1", "Team = Best", "", "42"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("written rows = %q, want %q", rows, want)
+	}
+}
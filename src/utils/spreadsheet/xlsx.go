@@ -0,0 +1,198 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidXLSX = errors.New("the file is not a valid .xlsx workbook")
+
+const (
+	// maxColumns is the column limit of Excel, XFD
+	maxColumns = 16384
+	// maxXMLSize caps how much of a single part of the workbook is unpacked
+	maxXMLSize = 32 << 20
+)
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a shared or inline string, rich text keeps its pieces in runs
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (text xlsxText) String() string {
+	if len(text.Runs) == 0 {
+		return text.T
+	}
+	var b strings.Builder
+	for _, run := range text.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads the first sheet with only the standard library, formulas come back as their cached value
+func readXLSX(file io.ReaderAt, size int64) ([][]string, error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, ErrInvalidXLSX
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err = decodeXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, ErrInvalidXLSX
+	}
+	var sheet xlsxSheet
+	if err = decodeXML(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	// the first row is the header, cells right of it are dropped so a stray reference can't blow up every row
+	width := maxColumns
+	var rows [][]string
+	for _, r := range sheet.Rows {
+		var row []string
+		for i, c := range r.Cells {
+			column := i
+			if c.Ref != "" {
+				column = columnIndex(c.Ref)
+			}
+			if column < 0 || column >= width {
+				continue
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch c.Type {
+			case "s":
+				index, err := strconv.Atoi(c.Value)
+				if err != nil || index < 0 || index >= len(shared.Items) {
+					return nil, ErrInvalidXLSX
+				}
+				row[column] = shared.Items[index].String()
+			case "inlineStr":
+				row[column] = c.Inline.String()
+			default:
+				row[column] = c.Value
+			}
+		}
+		if len(rows) == 0 {
+			width = len(row)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var workbook xlsxWorkbook
+	var rels xlsxRelationships
+	wb, okWorkbook := files["xl/workbook.xml"]
+	rel, okRels := files["xl/_rels/workbook.xml.rels"]
+	if !okWorkbook || !okRels {
+		return "", ErrInvalidXLSX
+	}
+	if err := decodeXML(wb, &workbook); err != nil {
+		return "", err
+	}
+	if err := decodeXML(rel, &rels); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", ErrInvalidXLSX
+	}
+
+	for _, r := range rels.Relationships {
+		if r.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(r.Target, "/") {
+			return strings.TrimPrefix(r.Target, "/"), nil
+		}
+		return path.Join("xl", r.Target), nil
+	}
+	return "", ErrInvalidXLSX
+}
+
+// decodeXML unpacks at most maxXMLSize of a part, a larger one is cut off and fails to decode
+func decodeXML(f *zip.File, out interface{}) error {
+	r, err := f.Open()
+	if err != nil {
+		return ErrInvalidXLSX
+	}
+	defer r.Close()
+
+	if err = xml.NewDecoder(io.LimitReader(r, maxXMLSize)).Decode(out); err != nil {
+		return ErrInvalidXLSX
+	}
+	return nil
+}
+
+// columnIndex turns the letters of a cell reference into a zero based column, "C7" is 2.
+// It returns -1 when there are no letters or the column is past maxColumns.
+func columnIndex(ref string) int {
+	column := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		column = column*26 + int(ch-'A'+1)
+		if column > maxColumns {
+			return -1
+		}
+	}
+	return column - 1
+}
@@ -0,0 +1,107 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 secret of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// the RFC vectors are 8 digits, the codes here are their last 6
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		code, err := Code(rfcSecret, time.Unix(c.unix, 0))
+		if err != nil {
+			t.Fatalf("Code(%d) returned %v", c.unix, err)
+		}
+		if code != c.code {
+			t.Errorf("Code(%d) = %s, want %s", c.unix, code, c.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / int64(Period.Seconds())
+
+	code, _ := Code(rfcSecret, now)
+	got, ok := Validate(rfcSecret, code, now)
+	if !ok || got != step {
+		t.Fatalf("Validate of the current code = (%d, %v), want (%d, true)", got, ok, step)
+	}
+
+	if _, ok = Validate(rfcSecret, code, now.Add(time.Second)); !ok {
+		t.Error("a code should be accepted for the rest of its step")
+	}
+
+	if _, ok = Validate("not base32!", code, now); ok {
+		t.Error("an invalid secret should never validate")
+	}
+	if _, ok = Validate(rfcSecret, code[:Digits-1], now); ok {
+		t.Error("a code of the wrong length should be refused")
+	}
+	if _, ok = Validate(rfcSecret, "", now); ok {
+		t.Error("an empty code should be refused")
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / int64(Period.Seconds())
+
+	for offset := -Skew; offset <= Skew; offset++ {
+		code, _ := Code(rfcSecret, now.Add(time.Duration(offset)*Period))
+		got, ok := Validate(rfcSecret, code, now)
+		if !ok {
+			t.Errorf("code %d steps away should be accepted", offset)
+			continue
+		}
+		if got != step+int64(offset) {
+			t.Errorf("code %d steps away returned step %d, want %d", offset, got, step+int64(offset))
+		}
+	}
+
+	for _, offset := range []int{-Skew - 1, Skew + 1} {
+		code, _ := Code(rfcSecret, now.Add(time.Duration(offset)*Period))
+		if _, ok := Validate(rfcSecret, code, now); ok {
+			t.Errorf("code %d steps away should be refused", offset)
+		}
+	}
+}
+
+func TestValidateLowercaseSecret(t *testing.T) {
+	now := time.Unix(59, 0)
+	if _, ok := Validate("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", now); !ok {
+		t.Error("secrets should be accepted in lower case")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q isn't base32: %v", secret, err)
+	}
+	if len(key) != 20 {
+		t.Errorf("secret is %d bytes, want 20", len(key))
+	}
+
+	other, _ := GenerateSecret()
+	if other == secret {
+		t.Error("two secrets should not be the same")
+	}
+}